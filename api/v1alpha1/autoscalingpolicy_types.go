@@ -0,0 +1,342 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalingPolicySpec defines the autoscaling configuration of the models of
+// the VariantAutoscalings a policy selects. It replaces the per-model entries
+// of the saturation scaling, queueing model and scale-to-zero ConfigMaps.
+//
+// A policy applies to a model in a namespace when it selects at least one of
+// the model's VariantAutoscalings there. Each section applies independently:
+// for every section, the policy that takes precedence among those setting it
+// wins, and fields it leaves unset keep their ConfigMap value.
+// +kubebuilder:validation:XValidation:rule="has(self.saturation) || has(self.queueingModel) || has(self.scaleToZero)",message="at least one of saturation, queueingModel or scaleToZero must be set"
+type AutoscalingPolicySpec struct {
+	// Selector selects VariantAutoscalings by label.
+	// When unset, labels are not considered.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// ModelIDs selects VariantAutoscalings by spec.modelID.
+	// A VariantAutoscaling must match both the selector and the model IDs when both are set.
+	// A policy with neither selects all VariantAutoscalings in its scope.
+	// +kubebuilder:validation:MaxItems=64
+	// +listType=set
+	// +optional
+	ModelIDs []string `json:"modelIDs,omitempty"`
+
+	// Priority orders the policies setting the same section for a model: the highest wins.
+	// See the precedence rules in the documentation.
+	// +kubebuilder:default=0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// Saturation overrides the saturation scaling thresholds.
+	// +optional
+	Saturation *SaturationPolicy `json:"saturation,omitempty"`
+
+	// QueueingModel overrides the queueing model analyzer settings.
+	// +optional
+	QueueingModel *QueueingModelPolicy `json:"queueingModel,omitempty"`
+
+	// ScaleToZero overrides the scale-to-zero settings and scaling schedules.
+	// +optional
+	ScaleToZero *ScaleToZeroPolicy `json:"scaleToZero,omitempty"`
+}
+
+// SaturationPolicy holds the per-model settings of the saturation analyzers.
+// Decimal values are strings, like spec.variantCost of VariantAutoscalings.
+// +kubebuilder:validation:XValidation:rule="!has(self.scaleUpThreshold) || !has(self.scaleDownBoundary) || double(self.scaleUpThreshold) > double(self.scaleDownBoundary)",message="scaleUpThreshold must be greater than scaleDownBoundary"
+type SaturationPolicy struct {
+	// KvCacheThreshold: a replica is saturated when its KV cache utilization reaches this fraction.
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	KvCacheThreshold *string `json:"kvCacheThreshold,omitempty"`
+
+	// QueueLengthThreshold: a replica is saturated when its queue reaches this length.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	QueueLengthThreshold *string `json:"queueLengthThreshold,omitempty"`
+
+	// KvSpareTrigger: scale up when the average spare KV cache capacity falls below this fraction.
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	KvSpareTrigger *string `json:"kvSpareTrigger,omitempty"`
+
+	// QueueSpareTrigger: scale up when the average spare queue capacity falls below this value.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	QueueSpareTrigger *string `json:"queueSpareTrigger,omitempty"`
+
+	// ScaleUpThreshold is the utilization above which the V2 analyzer scales up, in (0, 1].
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	ScaleUpThreshold *string `json:"scaleUpThreshold,omitempty"`
+
+	// ScaleDownBoundary is the utilization below which the V2 analyzer scales down, in (0, 1].
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	ScaleDownBoundary *string `json:"scaleDownBoundary,omitempty"`
+
+	// Priority multiplies the model's scaling urgency in GPU fair-share allocation.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	Priority *string `json:"priority,omitempty"`
+
+	// ColdStartLookahead scales up ahead of an upward demand trend by the learned
+	// replica startup latency.
+	// +optional
+	ColdStartLookahead *bool `json:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction of the model's capacity kept on
+	// non-preemptible variants.
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	MinOnDemandFraction *string `json:"minOnDemandFraction,omitempty"`
+
+	// CompareAnalyzers lists analyzers to run alongside the primary analyzer for evaluation only.
+	// +kubebuilder:validation:MaxItems=2
+	// +kubebuilder:validation:items:Enum=saturation;queueing-model
+	// +listType=set
+	// +optional
+	CompareAnalyzers []string `json:"compareAnalyzers,omitempty"`
+}
+
+// QueueingModelPolicy holds the per-model settings of the queueing model analyzer.
+// They apply when the queueing model analyzer is selected.
+type QueueingModelPolicy struct {
+	// SLOMultiplier is the maximum tolerable ratio of the iteration time under
+	// load to the idle iteration time, greater than 1.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	SLOMultiplier *string `json:"sloMultiplier,omitempty"`
+
+	// TuningEnabled enables online learning of the queueing model parameters.
+	// +optional
+	TuningEnabled *bool `json:"tuningEnabled,omitempty"`
+
+	// TargetTTFT is the target time to first token in milliseconds.
+	// Used only together with targetITL.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	TargetTTFT *string `json:"targetTTFT,omitempty"`
+
+	// TargetITL is the target inter-token latency in milliseconds.
+	// Used only together with targetTTFT.
+	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
+	// +optional
+	TargetITL *string `json:"targetITL,omitempty"`
+
+	// ColdStartLookahead scales up ahead of an upward demand trend by the learned
+	// replica startup latency.
+	// +optional
+	ColdStartLookahead *bool `json:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction of the model's capacity kept on
+	// non-preemptible variants.
+	// +kubebuilder:validation:Pattern=`^(0(\.\d+)?|1(\.0+)?)$`
+	// +optional
+	MinOnDemandFraction *string `json:"minOnDemandFraction,omitempty"`
+
+	// RequestClasses is the number of ranges the token histograms are split into
+	// to size variants per class of requests. 0 or 1 sizes for the average request.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4
+	// +optional
+	RequestClasses *int32 `json:"requestClasses,omitempty"`
+}
+
+// ScaleToZeroPolicy holds the per-model scale-to-zero settings.
+type ScaleToZeroPolicy struct {
+	// Enabled allows the model to scale to zero replicas when idle.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// RetentionPeriod is how long the model must be idle before it is scaled to zero.
+	// +optional
+	RetentionPeriod *metav1.Duration `json:"retentionPeriod,omitempty"`
+
+	// Schedules override the replica bounds and scale-to-zero eligibility of the
+	// model's variants during recurring time windows. Schedules in the spec of a
+	// VariantAutoscaling take precedence.
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Schedules []ScalingSchedule `json:"schedules,omitempty"`
+}
+
+// ClusterAutoscalingPolicySpec defines a cluster-wide autoscaling policy.
+// Namespaced AutoscalingPolicies take precedence over it.
+type ClusterAutoscalingPolicySpec struct {
+	// NamespaceSelector selects the namespaces the policy applies to.
+	// When unset, the policy applies to all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// AutoscalingPolicySpec selects VariantAutoscalings and holds their configuration.
+	AutoscalingPolicySpec `json:",inline"`
+}
+
+// AutoscalingPolicyStatus reports which VariantAutoscalings a policy applies to.
+type AutoscalingPolicyStatus struct {
+	// ObservedGeneration is the generation of the spec the status was computed for.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// TargetCount is the number of VariantAutoscalings the policy selects.
+	// +optional
+	TargetCount int32 `json:"targetCount,omitempty"`
+
+	// Targets lists the VariantAutoscalings the policy selects, sorted by
+	// namespace and name, and the sections of the policy in effect for each.
+	// At most 100 are listed.
+	// +optional
+	Targets []PolicyTarget `json:"targets,omitempty"`
+
+	// Conditions represent the latest available observations of the policy's state.
+	// +kubebuilder:validation:Optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// PolicyTarget is a VariantAutoscaling selected by a policy.
+type PolicyTarget struct {
+	// Namespace of the VariantAutoscaling.
+	Namespace string `json:"namespace"`
+
+	// Name of the VariantAutoscaling.
+	Name string `json:"name"`
+
+	// ModelID of the VariantAutoscaling.
+	ModelID string `json:"modelID"`
+
+	// Sections are the sections of the policy in effect for the model of the
+	// VariantAutoscaling. Sections set by the policy but missing here are
+	// overridden by a policy that takes precedence.
+	// +optional
+	Sections []string `json:"sections,omitempty"`
+}
+
+// Sections of an autoscaling policy, as listed in status.targets[].sections.
+const (
+	PolicySectionSaturation    = "saturation"
+	PolicySectionQueueingModel = "queueingModel"
+	PolicySectionScaleToZero   = "scaleToZero"
+)
+
+// Condition types and reasons of autoscaling policies.
+const (
+	// TypePolicyValid indicates whether the policy's configuration is valid.
+	// Invalid policies are not applied.
+	TypePolicyValid = "Valid"
+	// TypePolicyApplied indicates whether the policy is in effect for at least
+	// one VariantAutoscaling.
+	TypePolicyApplied = "Applied"
+
+	// ReasonPolicyValid indicates the policy's configuration is valid.
+	ReasonPolicyValid = "Valid"
+	// ReasonPolicyInvalid indicates the policy's configuration is invalid.
+	ReasonPolicyInvalid = "InvalidConfiguration"
+	// ReasonPolicyApplied indicates the policy is in effect.
+	ReasonPolicyApplied = "Applied"
+	// ReasonPolicyNoTargets indicates the policy selects no VariantAutoscaling.
+	ReasonPolicyNoTargets = "NoTargets"
+	// ReasonPolicyOverridden indicates policies taking precedence override all
+	// sections of the policy for all its targets.
+	ReasonPolicyOverridden = "Overridden"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=ap
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Targets",type=integer,JSONPath=".status.targetCount"
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=".status.conditions[?(@.type=='Valid')].status"
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=".status.conditions[?(@.type=='Applied')].status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// AutoscalingPolicy is the Schema for the autoscalingpolicies API.
+// It configures the autoscaling of the models of the VariantAutoscalings it
+// selects in its namespace.
+type AutoscalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec selects VariantAutoscalings and holds their configuration.
+	Spec AutoscalingPolicySpec `json:"spec,omitempty"`
+
+	// Status reports which VariantAutoscalings the policy applies to.
+	Status AutoscalingPolicyStatus `json:"status,omitempty"`
+}
+
+// AutoscalingPolicyList contains a list of AutoscalingPolicy resources.
+// +kubebuilder:object:root=true
+type AutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of AutoscalingPolicy resources.
+	Items []AutoscalingPolicy `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=cap
+// +kubebuilder:printcolumn:name="Priority",type=integer,JSONPath=".spec.priority"
+// +kubebuilder:printcolumn:name="Targets",type=integer,JSONPath=".status.targetCount"
+// +kubebuilder:printcolumn:name="Valid",type=string,JSONPath=".status.conditions[?(@.type=='Valid')].status"
+// +kubebuilder:printcolumn:name="Applied",type=string,JSONPath=".status.conditions[?(@.type=='Applied')].status"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
+
+// ClusterAutoscalingPolicy is the Schema for the clusterautoscalingpolicies API.
+// It configures the autoscaling of the models of the VariantAutoscalings it
+// selects in all namespaces, or in the namespaces its namespace selector selects.
+type ClusterAutoscalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec selects namespaces and VariantAutoscalings and holds their configuration.
+	Spec ClusterAutoscalingPolicySpec `json:"spec,omitempty"`
+
+	// Status reports which VariantAutoscalings the policy applies to.
+	Status AutoscalingPolicyStatus `json:"status,omitempty"`
+}
+
+// ClusterAutoscalingPolicyList contains a list of ClusterAutoscalingPolicy resources.
+// +kubebuilder:object:root=true
+type ClusterAutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// Items is the list of ClusterAutoscalingPolicy resources.
+	Items []ClusterAutoscalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&AutoscalingPolicy{}, &AutoscalingPolicyList{},
+		&ClusterAutoscalingPolicy{}, &ClusterAutoscalingPolicyList{},
+	)
+}
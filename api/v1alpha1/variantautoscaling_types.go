@@ -10,10 +10,102 @@ import (
 // (e.g. KServe) can inline it without duplicating field definitions.
 type VariantAutoscalingConfigSpec struct {
 	// VariantCost specifies the cost per replica for this variant (used in saturation analysis).
+	// When set, it overrides the cost derived from the accelerator price catalog.
+	// When unset, the cost is GPUs per replica × the catalog's per-GPU price for the
+	// variant's accelerator, falling back to a default of 10.0 if the accelerator is not priced.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:Pattern=`^\d+(\.\d+)?$`
-	// +kubebuilder:default="10.0"
 	VariantCost string `json:"variantCost,omitempty"`
+
+	// PricingTier selects which accelerator price catalog tier applies to this variant.
+	// Tiers without a configured price fall back to on-demand.
+	// It also sets the variant's capacity tier: "spot" variants are preemptible, so the
+	// optimizer replaces their capacity on node interruptions and keeps the configured
+	// minimum fraction of capacity on non-spot variants.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=on-demand;spot;reserved
+	// +optional
+	PricingTier string `json:"pricingTier,omitempty"`
+
+	// Schedules temporarily override minReplicas, maxReplicas and scale-to-zero
+	// eligibility during recurring time windows. They take precedence over
+	// per-model schedules in the scale-to-zero ConfigMap.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=16
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Schedules []ScalingSchedule `json:"schedules,omitempty"`
+
+	// GracefulScaleDown picks the least-loaded replicas for removal on scale-down
+	// and holds the scale-down until their in-flight requests drain. Only
+	// Deployments are supported. Disabled when unset.
+	// +kubebuilder:validation:Optional
+	// +optional
+	GracefulScaleDown *GracefulScaleDown `json:"gracefulScaleDown,omitempty"`
+}
+
+// GracefulScaleDown configures how the replicas of a variant are removed on
+// scale-down. The replicas with the least in-flight work are given the lowest
+// controller.kubernetes.io/pod-deletion-cost, so that the ReplicaSet removes
+// them first, and the desired replicas are held at the current replicas until
+// they drain or DrainTimeout passes.
+type GracefulScaleDown struct {
+	// DrainTimeout is the longest a scale-down is held for the replicas picked
+	// for removal to finish their in-flight requests. 0 picks the replicas
+	// without holding the scale-down. Defaults to 5m.
+	// +optional
+	DrainTimeout *metav1.Duration `json:"drainTimeout,omitempty"`
+
+	// CordonLabel is a label, as key=value, set on the replicas picked for
+	// removal while they drain, e.g. "llm-d.ai/routable=false". When the
+	// InferencePool selects the pods on another value of the label, the endpoint
+	// picker stops routing new requests to them. The label must not be part of
+	// the Deployment's selector. When a scale-down is cancelled, the label of the
+	// pod template is restored.
+	// +kubebuilder:validation:Pattern=`^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?=([A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?)?$`
+	// +optional
+	CordonLabel string `json:"cordonLabel,omitempty"`
+}
+
+// ScalingSchedule is a recurring time window during which replica bounds and
+// scale-to-zero eligibility are overridden.
+type ScalingSchedule struct {
+	// Name identifies the schedule in status and logs.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Schedule is a five-field cron expression (minute hour day-of-month month day-of-week)
+	// or a macro such as @daily, giving the start time of each window.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in, e.g. "Europe/Berlin".
+	// Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Duration is how long each window lasts, e.g. "10h". At most 168h.
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// MinReplicas replaces spec.minReplicas while the window is active.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// MaxReplicas replaces spec.maxReplicas while the window is active.
+	// 0 keeps the variant at zero replicas regardless of load.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxReplicas *int32 `json:"maxReplicas,omitempty"`
+
+	// ScaleToZero overrides the model's scale-to-zero eligibility while the window is active.
+	// When active schedules disagree, false wins.
+	// +optional
+	ScaleToZero *bool `json:"scaleToZero,omitempty"`
 }
 
 // VariantAutoscalingSpec defines the desired state for autoscaling a model variant.
@@ -57,6 +149,19 @@ type VariantAutoscalingStatus struct {
 	// Actuation provides details about the actuation process and its current status.
 	Actuation ActuationStatus `json:"actuation,omitempty"`
 
+	// EffectiveCost is the per-replica cost used by the optimizer and where it came from.
+	// +optional
+	EffectiveCost *EffectiveCost `json:"effectiveCost,omitempty"`
+
+	// EffectiveConfig is the autoscaling configuration resolved for the variant's
+	// model, and the layers each section was resolved from.
+	// +optional
+	EffectiveConfig *EffectiveConfig `json:"effectiveConfig,omitempty"`
+
+	// ActiveSchedules lists the scaling schedules whose window is currently active.
+	// +optional
+	ActiveSchedules []ActiveSchedule `json:"activeSchedules,omitempty"`
+
 	// Conditions represent the latest available observations of the VariantAutoscaling's state
 	// +kubebuilder:validation:Optional
 	// +patchMergeKey=type
@@ -83,6 +188,180 @@ type OptimizedAlloc struct {
 	NumReplicas *int32 `json:"numReplicas,omitempty"`
 }
 
+// EffectiveCost describes the per-replica cost the autoscaler uses for a variant.
+type EffectiveCost struct {
+	// Cost is the per-replica cost, formatted like spec.variantCost.
+	Cost string `json:"cost"`
+
+	// Source is where the cost came from: VariantCost (explicit spec override),
+	// PriceCatalog (GPUs per replica × catalog price), or Default.
+	// +kubebuilder:validation:Enum=VariantCost;PriceCatalog;Default
+	Source string `json:"source"`
+
+	// Accelerator is the catalog accelerator type the price was looked up for.
+	// +optional
+	Accelerator string `json:"accelerator,omitempty"`
+
+	// PricingTier is the catalog tier the price was taken from.
+	// +optional
+	PricingTier string `json:"pricingTier,omitempty"`
+
+	// GPUsPerReplica is the number of GPUs the catalog price was multiplied by.
+	// +optional
+	GPUsPerReplica int32 `json:"gpusPerReplica,omitempty"`
+}
+
+// EffectiveConfig describes the autoscaling configuration the engine resolves
+// for the model of a variant. Decimal values are formatted like spec.variantCost.
+type EffectiveConfig struct {
+	// Hash identifies the effective configuration, values and sources. It
+	// changes whenever the configuration of the variant's model changes.
+	Hash string `json:"hash"`
+
+	// LastChangeTime is when Hash last changed.
+	LastChangeTime metav1.Time `json:"lastChangeTime"`
+
+	// Saturation is the saturation scaling configuration.
+	Saturation EffectiveSaturationConfig `json:"saturation"`
+
+	// QueueingModel is the queueing model configuration. Unset when the queueing
+	// model analyzer is not active.
+	// +optional
+	QueueingModel *EffectiveQueueingModelConfig `json:"queueingModel,omitempty"`
+
+	// ScaleToZero is the scale-to-zero configuration.
+	ScaleToZero EffectiveScaleToZeroConfig `json:"scaleToZero"`
+}
+
+// EffectiveSaturationConfig is the saturation scaling configuration of a model.
+type EffectiveSaturationConfig struct {
+	// KvCacheThreshold is the KV cache utilization at which a replica is saturated.
+	KvCacheThreshold string `json:"kvCacheThreshold"`
+
+	// QueueLengthThreshold is the queue length at which a replica is saturated.
+	QueueLengthThreshold string `json:"queueLengthThreshold"`
+
+	// KvSpareTrigger is the average spare KV cache capacity below which the model scales up.
+	KvSpareTrigger string `json:"kvSpareTrigger"`
+
+	// QueueSpareTrigger is the average spare queue capacity below which the model scales up.
+	QueueSpareTrigger string `json:"queueSpareTrigger"`
+
+	// ScaleUpThreshold is the utilization above which the V2 analyzer scales up.
+	// Set when the V2 analyzer is configured.
+	// +optional
+	ScaleUpThreshold string `json:"scaleUpThreshold,omitempty"`
+
+	// ScaleDownBoundary is the utilization below which the V2 analyzer scales down.
+	// Set when the V2 analyzer is configured.
+	// +optional
+	ScaleDownBoundary string `json:"scaleDownBoundary,omitempty"`
+
+	// Priority is the multiplier of the model's scaling urgency.
+	Priority string `json:"priority"`
+
+	// ColdStartLookahead reports whether the model scales up ahead of demand.
+	// +optional
+	ColdStartLookahead bool `json:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction of capacity on non-preemptible variants.
+	// +optional
+	MinOnDemandFraction string `json:"minOnDemandFraction,omitempty"`
+
+	// CompareAnalyzers lists the analyzers run alongside the primary analyzer.
+	// +optional
+	CompareAnalyzers []string `json:"compareAnalyzers,omitempty"`
+
+	// Sources lists the layers the section was resolved from.
+	Sources []ConfigSource `json:"sources"`
+}
+
+// EffectiveQueueingModelConfig is the queueing model configuration of a model.
+type EffectiveQueueingModelConfig struct {
+	// SLOMultiplier is the tolerated ratio of iteration time under load to the idle baseline.
+	SLOMultiplier string `json:"sloMultiplier"`
+
+	// TuningEnabled reports whether performance parameters are learned online.
+	TuningEnabled bool `json:"tuningEnabled"`
+
+	// TargetTTFT is the explicit time-to-first-token target in milliseconds.
+	// Unset when the targets are inferred by the queueing model.
+	// +optional
+	TargetTTFT string `json:"targetTTFT,omitempty"`
+
+	// TargetITL is the explicit inter-token latency target in milliseconds.
+	// Unset when the targets are inferred by the queueing model.
+	// +optional
+	TargetITL string `json:"targetITL,omitempty"`
+
+	// ColdStartLookahead reports whether the model scales up ahead of demand.
+	// +optional
+	ColdStartLookahead bool `json:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction of capacity on non-preemptible variants.
+	// +optional
+	MinOnDemandFraction string `json:"minOnDemandFraction,omitempty"`
+
+	// RequestClasses is the maximum number of token ranges variants are sized for.
+	// +optional
+	RequestClasses int32 `json:"requestClasses,omitempty"`
+
+	// Sources lists the layers the section was resolved from.
+	Sources []ConfigSource `json:"sources"`
+}
+
+// EffectiveScaleToZeroConfig is the scale-to-zero configuration of a model.
+type EffectiveScaleToZeroConfig struct {
+	// Enabled reports whether the model may scale to zero replicas.
+	Enabled bool `json:"enabled"`
+
+	// RetentionPeriod is how long the model waits after its last request before scaling to zero.
+	RetentionPeriod metav1.Duration `json:"retentionPeriod"`
+
+	// Schedules lists the names of the model's scaling schedules.
+	// +optional
+	Schedules []string `json:"schedules,omitempty"`
+
+	// Sources lists the layers the section was resolved from.
+	Sources []ConfigSource `json:"sources"`
+}
+
+// ConfigSource is a layer of the effective configuration. Sources are listed
+// lowest precedence first: fields a layer leaves unset keep the value of the
+// layers before it.
+type ConfigSource struct {
+	// Layer is BuiltIn (the controller's defaults), GlobalConfigMap (a ConfigMap
+	// in the controller's namespace), NamespaceConfigMap (a namespace-local
+	// ConfigMap), AutoscalingPolicy or ClusterAutoscalingPolicy.
+	// +kubebuilder:validation:Enum=BuiltIn;GlobalConfigMap;NamespaceConfigMap;AutoscalingPolicy;ClusterAutoscalingPolicy
+	Layer string `json:"layer"`
+
+	// Entry is the entry of a ConfigMap layer: Default (the "default" entry) or
+	// Model (the model's entry).
+	// +kubebuilder:validation:Enum=Default;Model
+	// +optional
+	Entry string `json:"entry,omitempty"`
+
+	// Name is the name of the policy of a policy layer.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// ActiveSchedule describes a scaling schedule that currently applies to a variant.
+type ActiveSchedule struct {
+	// Name is the name of the schedule.
+	Name string `json:"name"`
+
+	// Source is where the schedule is defined: VariantAutoscaling (spec.schedules),
+	// ConfigMap (per-model schedules in the scale-to-zero ConfigMap) or
+	// AutoscalingPolicy (per-model schedules of an autoscaling policy).
+	// +kubebuilder:validation:Enum=VariantAutoscaling;ConfigMap;AutoscalingPolicy
+	Source string `json:"source"`
+
+	// Until is when the current window ends.
+	Until metav1.Time `json:"until"`
+}
+
 // ActuationStatus provides details about the actuation process and its current status.
 type ActuationStatus struct {
 	// Applied indicates whether the actuation was successfully applied.
@@ -97,6 +376,8 @@ type ActuationStatus struct {
 // +kubebuilder:printcolumn:name="Min",type=integer,JSONPath=".spec.minReplicas"
 // +kubebuilder:printcolumn:name="Max",type=integer,JSONPath=".spec.maxReplicas"
 // +kubebuilder:printcolumn:name="Optimized",type=string,JSONPath=".status.desiredOptimizedAlloc.numReplicas"
+// +kubebuilder:printcolumn:name="Cost",type=string,JSONPath=".status.effectiveCost.cost",priority=1
+// +kubebuilder:printcolumn:name="Config",type=string,JSONPath=".status.effectiveConfig.hash",priority=1
 // +kubebuilder:printcolumn:name="MetricsReady",type=string,JSONPath=".status.conditions[?(@.type=='MetricsAvailable')].status"
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
@@ -176,6 +457,16 @@ const (
 	ReasonTargetNotFound = "TargetNotFound"
 )
 
+// Effective cost sources reported in status.effectiveCost.source
+const (
+	// CostSourceVariantCost indicates the cost was set explicitly in spec.variantCost
+	CostSourceVariantCost = "VariantCost"
+	// CostSourcePriceCatalog indicates the cost was derived from the accelerator price catalog
+	CostSourcePriceCatalog = "PriceCatalog"
+	// CostSourceDefault indicates neither spec.variantCost nor a catalog price was available
+	CostSourceDefault = "Default"
+)
+
 // GetScaleTargetAPI returns the API of the scale target resource.
 func (va *VariantAutoscaling) GetScaleTargetAPI() string {
 	return va.Spec.ScaleTargetRef.APIVersion
@@ -25,6 +25,22 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveSchedule) DeepCopyInto(out *ActiveSchedule) {
+	*out = *in
+	in.Until.DeepCopyInto(&out.Until)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveSchedule.
+func (in *ActiveSchedule) DeepCopy() *ActiveSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActuationStatus) DeepCopyInto(out *ActuationStatus) {
 	*out = *in
@@ -40,6 +56,358 @@ func (in *ActuationStatus) DeepCopy() *ActuationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
+func (in *AutoscalingPolicy) DeepCopy() *AutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoscalingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicyList) DeepCopyInto(out *AutoscalingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]AutoscalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyList.
+func (in *AutoscalingPolicyList) DeepCopy() *AutoscalingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *AutoscalingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicySpec) DeepCopyInto(out *AutoscalingPolicySpec) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelIDs != nil {
+		in, out := &in.ModelIDs, &out.ModelIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Saturation != nil {
+		in, out := &in.Saturation, &out.Saturation
+		*out = new(SaturationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueueingModel != nil {
+		in, out := &in.QueueingModel, &out.QueueingModel
+		*out = new(QueueingModelPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZeroPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicySpec.
+func (in *AutoscalingPolicySpec) DeepCopy() *AutoscalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingPolicyStatus) DeepCopyInto(out *AutoscalingPolicyStatus) {
+	*out = *in
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]PolicyTarget, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyStatus.
+func (in *AutoscalingPolicyStatus) DeepCopy() *AutoscalingPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalingPolicy) DeepCopyInto(out *ClusterAutoscalingPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalingPolicy.
+func (in *ClusterAutoscalingPolicy) DeepCopy() *ClusterAutoscalingPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalingPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAutoscalingPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalingPolicyList) DeepCopyInto(out *ClusterAutoscalingPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterAutoscalingPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalingPolicyList.
+func (in *ClusterAutoscalingPolicyList) DeepCopy() *ClusterAutoscalingPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalingPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterAutoscalingPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoscalingPolicySpec) DeepCopyInto(out *ClusterAutoscalingPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AutoscalingPolicySpec.DeepCopyInto(&out.AutoscalingPolicySpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoscalingPolicySpec.
+func (in *ClusterAutoscalingPolicySpec) DeepCopy() *ClusterAutoscalingPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoscalingPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigSource) DeepCopyInto(out *ConfigSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigSource.
+func (in *ConfigSource) DeepCopy() *ConfigSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveConfig) DeepCopyInto(out *EffectiveConfig) {
+	*out = *in
+	in.LastChangeTime.DeepCopyInto(&out.LastChangeTime)
+	in.Saturation.DeepCopyInto(&out.Saturation)
+	if in.QueueingModel != nil {
+		in, out := &in.QueueingModel, &out.QueueingModel
+		*out = new(EffectiveQueueingModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.ScaleToZero.DeepCopyInto(&out.ScaleToZero)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveConfig.
+func (in *EffectiveConfig) DeepCopy() *EffectiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveCost) DeepCopyInto(out *EffectiveCost) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveCost.
+func (in *EffectiveCost) DeepCopy() *EffectiveCost {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveCost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveQueueingModelConfig) DeepCopyInto(out *EffectiveQueueingModelConfig) {
+	*out = *in
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ConfigSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveQueueingModelConfig.
+func (in *EffectiveQueueingModelConfig) DeepCopy() *EffectiveQueueingModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveQueueingModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveSaturationConfig) DeepCopyInto(out *EffectiveSaturationConfig) {
+	*out = *in
+	if in.CompareAnalyzers != nil {
+		in, out := &in.CompareAnalyzers, &out.CompareAnalyzers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ConfigSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveSaturationConfig.
+func (in *EffectiveSaturationConfig) DeepCopy() *EffectiveSaturationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveSaturationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EffectiveScaleToZeroConfig) DeepCopyInto(out *EffectiveScaleToZeroConfig) {
+	*out = *in
+	out.RetentionPeriod = in.RetentionPeriod
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ConfigSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EffectiveScaleToZeroConfig.
+func (in *EffectiveScaleToZeroConfig) DeepCopy() *EffectiveScaleToZeroConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EffectiveScaleToZeroConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GracefulScaleDown) DeepCopyInto(out *GracefulScaleDown) {
+	*out = *in
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GracefulScaleDown.
+func (in *GracefulScaleDown) DeepCopy() *GracefulScaleDown {
+	if in == nil {
+		return nil
+	}
+	out := new(GracefulScaleDown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptimizedAlloc) DeepCopyInto(out *OptimizedAlloc) {
 	*out = *in
@@ -61,6 +429,204 @@ func (in *OptimizedAlloc) DeepCopy() *OptimizedAlloc {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyTarget) DeepCopyInto(out *PolicyTarget) {
+	*out = *in
+	if in.Sections != nil {
+		in, out := &in.Sections, &out.Sections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyTarget.
+func (in *PolicyTarget) DeepCopy() *PolicyTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueingModelPolicy) DeepCopyInto(out *QueueingModelPolicy) {
+	*out = *in
+	if in.SLOMultiplier != nil {
+		in, out := &in.SLOMultiplier, &out.SLOMultiplier
+		*out = new(string)
+		**out = **in
+	}
+	if in.TuningEnabled != nil {
+		in, out := &in.TuningEnabled, &out.TuningEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TargetTTFT != nil {
+		in, out := &in.TargetTTFT, &out.TargetTTFT
+		*out = new(string)
+		**out = **in
+	}
+	if in.TargetITL != nil {
+		in, out := &in.TargetITL, &out.TargetITL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ColdStartLookahead != nil {
+		in, out := &in.ColdStartLookahead, &out.ColdStartLookahead
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinOnDemandFraction != nil {
+		in, out := &in.MinOnDemandFraction, &out.MinOnDemandFraction
+		*out = new(string)
+		**out = **in
+	}
+	if in.RequestClasses != nil {
+		in, out := &in.RequestClasses, &out.RequestClasses
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueingModelPolicy.
+func (in *QueueingModelPolicy) DeepCopy() *QueueingModelPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueingModelPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SaturationPolicy) DeepCopyInto(out *SaturationPolicy) {
+	*out = *in
+	if in.KvCacheThreshold != nil {
+		in, out := &in.KvCacheThreshold, &out.KvCacheThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueueLengthThreshold != nil {
+		in, out := &in.QueueLengthThreshold, &out.QueueLengthThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.KvSpareTrigger != nil {
+		in, out := &in.KvSpareTrigger, &out.KvSpareTrigger
+		*out = new(string)
+		**out = **in
+	}
+	if in.QueueSpareTrigger != nil {
+		in, out := &in.QueueSpareTrigger, &out.QueueSpareTrigger
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleUpThreshold != nil {
+		in, out := &in.ScaleUpThreshold, &out.ScaleUpThreshold
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleDownBoundary != nil {
+		in, out := &in.ScaleDownBoundary, &out.ScaleDownBoundary
+		*out = new(string)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(string)
+		**out = **in
+	}
+	if in.ColdStartLookahead != nil {
+		in, out := &in.ColdStartLookahead, &out.ColdStartLookahead
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinOnDemandFraction != nil {
+		in, out := &in.MinOnDemandFraction, &out.MinOnDemandFraction
+		*out = new(string)
+		**out = **in
+	}
+	if in.CompareAnalyzers != nil {
+		in, out := &in.CompareAnalyzers, &out.CompareAnalyzers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SaturationPolicy.
+func (in *SaturationPolicy) DeepCopy() *SaturationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SaturationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleToZeroPolicy) DeepCopyInto(out *ScaleToZeroPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RetentionPeriod != nil {
+		in, out := &in.RetentionPeriod, &out.RetentionPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleToZeroPolicy.
+func (in *ScaleToZeroPolicy) DeepCopy() *ScaleToZeroPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleToZeroPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingSchedule) DeepCopyInto(out *ScalingSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxReplicas != nil {
+		in, out := &in.MaxReplicas, &out.MaxReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingSchedule.
+func (in *ScalingSchedule) DeepCopy() *ScalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VariantAutoscaling) DeepCopyInto(out *VariantAutoscaling) {
 	*out = *in
@@ -91,6 +657,18 @@ func (in *VariantAutoscaling) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VariantAutoscalingConfigSpec) DeepCopyInto(out *VariantAutoscalingConfigSpec) {
 	*out = *in
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]ScalingSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GracefulScaleDown != nil {
+		in, out := &in.GracefulScaleDown, &out.GracefulScaleDown
+		*out = new(GracefulScaleDown)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantAutoscalingConfigSpec.
@@ -144,7 +722,7 @@ func (in *VariantAutoscalingSpec) DeepCopyInto(out *VariantAutoscalingSpec) {
 		*out = new(int32)
 		**out = **in
 	}
-	out.VariantAutoscalingConfigSpec = in.VariantAutoscalingConfigSpec
+	in.VariantAutoscalingConfigSpec.DeepCopyInto(&out.VariantAutoscalingConfigSpec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VariantAutoscalingSpec.
@@ -162,6 +740,23 @@ func (in *VariantAutoscalingStatus) DeepCopyInto(out *VariantAutoscalingStatus)
 	*out = *in
 	in.DesiredOptimizedAlloc.DeepCopyInto(&out.DesiredOptimizedAlloc)
 	out.Actuation = in.Actuation
+	if in.EffectiveCost != nil {
+		in, out := &in.EffectiveCost, &out.EffectiveCost
+		*out = new(EffectiveCost)
+		**out = **in
+	}
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = new(EffectiveConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveSchedules != nil {
+		in, out := &in.ActiveSchedules, &out.ActiveSchedules
+		*out = make([]ActiveSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
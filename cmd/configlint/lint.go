@@ -0,0 +1,434 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/autoscalingpolicy"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/drain"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// defaultNamespace is the namespace of manifests without one when --namespace
+// is not set, as for kubectl.
+const defaultNamespace = "default"
+
+// manifest is a document of a manifest file.
+type manifest struct {
+	file string
+	meta metav1.PartialObjectMetadata
+	// raw is the document as JSON.
+	raw []byte
+}
+
+// readManifests reads the documents of manifest files and of the manifest
+// files of directories, recursively. Lists are expanded into their items.
+func readManifests(filenames []string, stdin io.Reader) ([]manifest, error) {
+	var manifests []manifest
+	for _, name := range filenames {
+		if name == "-" {
+			docs, err := readDocuments("<stdin>", stdin)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, docs...)
+			continue
+		}
+		err := filepath.WalkDir(name, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			// Files named explicitly are read whatever their extension
+			if path != name && !slices.Contains([]string{".yaml", ".yml", ".json"}, filepath.Ext(path)) {
+				return nil
+			}
+			f, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer f.Close() //nolint:errcheck
+			docs, err := readDocuments(path, f)
+			if err != nil {
+				return err
+			}
+			manifests = append(manifests, docs...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading manifests: %w", err)
+		}
+	}
+	return manifests, nil
+}
+
+// readDocuments reads the YAML or JSON documents of a manifest file.
+func readDocuments(file string, r io.Reader) ([]manifest, error) {
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(r))
+	var manifests []manifest
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return manifests, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", file, err)
+		}
+		raw, err := yaml.YAMLToJSON(doc)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", file, err)
+		}
+		if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+			continue // empty document
+		}
+		docs, err := decodeManifest(file, raw)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, docs...)
+	}
+}
+
+func decodeManifest(file string, raw []byte) ([]manifest, error) {
+	m := manifest{file: file, raw: raw}
+	if err := json.Unmarshal(raw, &m.meta); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	if m.meta.Kind != "List" {
+		return []manifest{m}, nil
+	}
+	var list struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	var manifests []manifest
+	for _, item := range list.Items {
+		docs, err := decodeManifest(file, item)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, docs...)
+	}
+	return manifests, nil
+}
+
+// finding is a problem found in a manifest.
+type finding struct {
+	Severity  string `json:"severity"`
+	File      string `json:"file"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Key is the ConfigMap entry of the finding, if any.
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
+// linter validates manifests the way the controller does, and builds the
+// configuration the controller would run with from them.
+type linter struct {
+	controllerNamespace string
+	// namespace is the namespace of manifests without one.
+	namespace string
+
+	cfg             *config.Config
+	vas             []wvav1alpha1.VariantAutoscaling
+	namespaceLabels map[string]labels.Set
+	findings        []finding
+}
+
+func newLinter(controllerNamespace, namespace string) *linter {
+	return &linter{
+		controllerNamespace: controllerNamespace,
+		namespace:           cmp.Or(namespace, defaultNamespace),
+		cfg:                 &config.Config{},
+		namespaceLabels:     make(map[string]labels.Set),
+	}
+}
+
+// lint validates manifests. ConfigMaps are read first: policies are validated
+// against the global saturation defaults.
+func (l *linter) lint(manifests []manifest) {
+	seen := make(map[string]string)
+	var policies []manifest
+	for _, m := range manifests {
+		clusterScoped := m.meta.Kind == "Namespace" || m.meta.Kind == "ClusterAutoscalingPolicy"
+		if m.meta.Namespace == "" && !clusterScoped {
+			m.meta.Namespace = l.namespace
+		}
+		key := m.meta.Kind + "/" + m.meta.Namespace + "/" + m.meta.Name
+		if file, ok := seen[key]; ok {
+			l.report(m, severityError, "", fmt.Sprintf("defined more than once, also in %s", file))
+			continue
+		}
+		seen[key] = m.file
+
+		switch m.meta.Kind {
+		case "ConfigMap":
+			l.lintConfigMap(m)
+		case "VariantAutoscaling":
+			l.lintVariantAutoscaling(m)
+		case "Namespace":
+			l.namespaceLabels[m.meta.Name] = m.meta.Labels
+		case "AutoscalingPolicy", "ClusterAutoscalingPolicy":
+			policies = append(policies, m)
+		}
+	}
+	l.lintPolicies(policies)
+}
+
+// lintConfigMap parses the saturation, queueing model and scale-to-zero
+// ConfigMaps, and reports their per-model entries. Other ConfigMaps are ignored.
+func (l *linter) lintConfigMap(m manifest) {
+	var cm corev1.ConfigMap
+	if !l.decode(m, &cm) {
+		return
+	}
+	namespace := m.meta.Namespace
+	if namespace == l.controllerNamespace {
+		namespace = "" // global
+	}
+
+	switch cm.Name {
+	case config.SaturationConfigMapName():
+		configs, errs := config.ParseSaturationConfigMap(cm.Data)
+		l.reportEntryErrors(m, errs)
+		// The engine looks per-model entries up by "{model_id}#{namespace}", which
+		// is not a valid ConfigMap key
+		for _, key := range slices.Sorted(maps.Keys(configs)) {
+			if key != config.GlobalDefaultsKey {
+				l.report(m, severityWarning, key,
+					"per-model saturation entries are never used, use AutoscalingPolicy resources instead")
+			}
+		}
+		l.cfg.UpdateSaturationConfigForNamespace(namespace, configs)
+		return
+	case config.QMAnalyzerConfigMapName():
+		configs, errs := config.ParseQMAnalyzerConfigMap(cm.Data)
+		l.reportEntryErrors(m, errs)
+		l.cfg.UpdateQMAnalyzerConfigForNamespace(namespace, configs)
+	case config.DefaultScaleToZeroConfigMapName:
+		configs, errs := config.ParseScaleToZeroConfigMap(cm.Data)
+		l.reportEntryErrors(m, errs)
+		l.cfg.UpdateScaleToZeroConfigForNamespace(namespace, configs)
+	default:
+		return
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(cm.Data)) {
+		if key != config.GlobalDefaultsKey {
+			l.report(m, severityWarning, key,
+				"per-model ConfigMap entries are deprecated, use AutoscalingPolicy resources instead")
+		}
+	}
+}
+
+// lintVariantAutoscaling validates what the CRD schema and the controller check
+// of a VariantAutoscaling.
+func (l *linter) lintVariantAutoscaling(m manifest) {
+	var va wvav1alpha1.VariantAutoscaling
+	if !l.decode(m, &va) {
+		return
+	}
+	va.Namespace = m.meta.Namespace
+	valid := true
+	if va.Spec.ModelID == "" {
+		l.report(m, severityError, "", "spec.modelID is required")
+		valid = false
+	}
+	if va.Spec.ScaleTargetRef.Name == "" {
+		l.report(m, severityError, "", "spec.scaleTargetRef.name is required")
+		valid = false
+	}
+	if va.Spec.MinReplicas != nil && va.Spec.MaxReplicas > 0 && *va.Spec.MinReplicas > va.Spec.MaxReplicas {
+		l.report(m, severityError, "", "minReplicas must be less than or equal to maxReplicas")
+	}
+	if _, err := utils.VariantScheduleRules(&va); err != nil {
+		for _, err := range unwrapJoined(err) {
+			l.report(m, severityError, "", "spec.schedules: "+err.Error())
+		}
+	}
+	if gsd := va.Spec.GracefulScaleDown; gsd != nil {
+		if _, err := drain.ParseLabel(gsd.CordonLabel); err != nil {
+			l.report(m, severityError, "", "spec.gracefulScaleDown: "+err.Error())
+		}
+		if kind := va.Spec.ScaleTargetRef.Kind; kind != "" && kind != constants.DeploymentKind {
+			l.report(m, severityWarning, "",
+				fmt.Sprintf("spec.gracefulScaleDown only supports Deployments, ignored for %s", kind))
+		}
+	}
+	if valid {
+		l.vas = append(l.vas, va)
+	}
+}
+
+// lintPolicies validates policies like the AutoscalingPolicy reconciler, and
+// applies the valid ones to the VariantAutoscalings.
+func (l *linter) lintPolicies(manifests []manifest) {
+	base := l.cfg.SaturationConfig()[config.GlobalDefaultsKey]
+	var (
+		policies []*autoscalingpolicy.Policy
+		valid    []manifest // in the order of policies
+	)
+	for _, m := range manifests {
+		var (
+			p   *autoscalingpolicy.Policy
+			err error
+		)
+		if m.meta.Kind == "AutoscalingPolicy" {
+			var obj wvav1alpha1.AutoscalingPolicy
+			if !l.decode(m, &obj) {
+				continue
+			}
+			obj.Namespace = m.meta.Namespace
+			p, err = autoscalingpolicy.FromAutoscalingPolicy(&obj, base)
+		} else {
+			var obj wvav1alpha1.ClusterAutoscalingPolicy
+			if !l.decode(m, &obj) {
+				continue
+			}
+			p, err = autoscalingpolicy.FromClusterAutoscalingPolicy(&obj, base)
+		}
+		if err != nil {
+			l.report(m, severityError, "", err.Error())
+			continue
+		}
+		policies = append(policies, p)
+		valid = append(valid, m)
+	}
+
+	result := autoscalingpolicy.Resolve(policies, l.vas, l.namespaceLabels, l.cfg.ValidateSaturationPolicy)
+	l.cfg.UpdatePolicyOverrides(result.Overrides)
+	for i, m := range valid {
+		if result.Errors[i] != nil {
+			l.report(m, severityError, "", result.Errors[i].Error())
+		}
+	}
+	if len(l.vas) == 0 {
+		return
+	}
+	for i, m := range valid {
+		if len(result.Targets[i]) == 0 {
+			l.report(m, severityWarning, "", "selects none of the VariantAutoscalings")
+		}
+	}
+}
+
+// effectiveConfigs returns the effective configuration of the models of the
+// VariantAutoscalings in namespace, or in all namespaces when namespace is
+// empty, and of models in namespace, sorted by namespace and model ID.
+func (l *linter) effectiveConfigs(namespace string, models []string) []modelConfig {
+	type modelKey struct{ namespace, modelID string }
+	var keys []modelKey
+	for _, va := range l.vas {
+		if namespace == "" || va.Namespace == namespace {
+			keys = append(keys, modelKey{va.Namespace, va.Spec.ModelID})
+		}
+	}
+	for _, modelID := range models {
+		keys = append(keys, modelKey{namespace, modelID})
+	}
+	slices.SortFunc(keys, func(a, b modelKey) int {
+		return cmp.Or(cmp.Compare(a.namespace, b.namespace), cmp.Compare(a.modelID, b.modelID))
+	})
+	keys = slices.Compact(keys)
+
+	configs := make([]modelConfig, 0, len(keys))
+	for _, key := range keys {
+		effective := utils.EffectiveConfig(l.cfg.ModelConfig(key.namespace, key.modelID), nil, time.Time{})
+		configs = append(configs, modelConfig{
+			Namespace:     key.namespace,
+			ModelID:       key.modelID,
+			Hash:          effective.Hash,
+			Saturation:    effective.Saturation,
+			QueueingModel: effective.QueueingModel,
+			ScaleToZero:   effective.ScaleToZero,
+		})
+	}
+	return configs
+}
+
+// decode decodes a manifest into obj, and reports it when it is malformed.
+func (l *linter) decode(m manifest, obj any) bool {
+	if err := json.Unmarshal(m.raw, obj); err != nil {
+		l.report(m, severityError, "", fmt.Sprintf("malformed %s: %v", m.meta.Kind, err))
+		return false
+	}
+	return true
+}
+
+func (l *linter) reportEntryErrors(m manifest, errs []error) {
+	for _, err := range errs {
+		var entryErr *config.EntryError
+		if errors.As(err, &entryErr) {
+			l.report(m, severityError, entryErr.Key, entryErr.Err.Error())
+			continue
+		}
+		l.report(m, severityError, "", err.Error())
+	}
+}
+
+func (l *linter) report(m manifest, severity, key, message string) {
+	l.findings = append(l.findings, finding{
+		Severity:  severity,
+		File:      m.file,
+		Kind:      m.meta.Kind,
+		Namespace: m.meta.Namespace,
+		Name:      m.meta.Name,
+		Key:       key,
+		Message:   message,
+	})
+}
+
+// unwrapJoined returns the errors joined into err by errors.Join.
+func unwrapJoined(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
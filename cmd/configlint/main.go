@@ -0,0 +1,120 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command configlint validates the autoscaler's ConfigMaps, VariantAutoscalings
+// and AutoscalingPolicies offline, with the parsing and validation the
+// controller runs, and prints the effective configuration of each model. It
+// exits with status 1 when a manifest is invalid, so platform teams can gate
+// configuration changes in CI.
+package main
+
+import (
+	"errors"
+	goflag "flag"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// errInvalid is returned by run when the manifests have findings that fail the
+// lint. The findings have been written to the output.
+var errInvalid = errors.New("invalid configuration")
+
+// options are the command line options of configlint.
+type options struct {
+	filenames           []string
+	namespace           string
+	models              []string
+	controllerNamespace string
+	format              string
+	failOnWarnings      bool
+}
+
+func main() {
+	var o options
+	flag.StringSliceVarP(&o.filenames, "filename", "f", nil,
+		"Manifest files or directories to lint, as for kubectl apply -f. \"-\" reads stdin. Repeatable.")
+	flag.StringVarP(&o.namespace, "namespace", "n", "",
+		"Namespace of manifests without metadata.namespace, and the namespace to resolve effective configuration for. "+
+			"Defaults to \"default\" for manifests; effective configuration is then resolved for all namespaces.")
+	flag.StringSliceVar(&o.models, "model", nil,
+		"Model IDs to resolve the effective configuration of in --namespace, in addition to those of the VariantAutoscalings. Repeatable.")
+	flag.StringVar(&o.controllerNamespace, "controller-namespace", config.SystemNamespace(),
+		"Namespace of the controller. ConfigMaps in it are global, ConfigMaps in other namespaces are namespace-local.")
+	flag.StringVar(&o.format, "format", formatText, "Output format: text or json.")
+	flag.BoolVar(&o.failOnWarnings, "fail-on-warnings", false, "Exit with status 1 on warnings too.")
+	loggerVerbosity := flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
+
+	opts := ctrlzap.Options{
+		Development: true,
+		DestWriter:  os.Stderr,
+	}
+	gfs := goflag.NewFlagSet("zap", goflag.ExitOnError)
+	opts.BindFlags(gfs)
+	flag.CommandLine.AddGoFlagSet(gfs)
+
+	flag.Parse()
+
+	logging.InitLogging(&opts, loggerVerbosity)
+	defer logging.Sync() // nolint:errcheck
+
+	if err := run(o, os.Stdin, os.Stdout); err != nil {
+		if !errors.Is(err, errInvalid) {
+			fmt.Fprintln(os.Stderr, "configlint:", err)
+		}
+		logging.Sync() //nolint:errcheck
+		os.Exit(1)     //nolint:gocritic // exitAfterDefer: Sync() called explicitly above
+	}
+}
+
+func run(o options, stdin io.Reader, out io.Writer) error {
+	if len(o.filenames) == 0 {
+		return errors.New("at least one --filename is required")
+	}
+	if o.format != formatJSON && o.format != formatText {
+		return fmt.Errorf("unknown output format %q, expected %s or %s", o.format, formatText, formatJSON)
+	}
+	if len(o.models) > 0 && o.namespace == "" {
+		return errors.New("--model requires --namespace")
+	}
+
+	manifests, err := readManifests(o.filenames, stdin)
+	if err != nil {
+		return err
+	}
+
+	l := newLinter(o.controllerNamespace, o.namespace)
+	l.lint(manifests)
+
+	r := report{
+		Findings:         l.findings,
+		EffectiveConfigs: l.effectiveConfigs(o.namespace, o.models),
+	}
+	r.Valid = !r.has(severityError) && (!o.failOnWarnings || !r.has(severityWarning))
+	if err := writeReport(out, o.format, r); err != nil {
+		return err
+	}
+	if !r.Valid {
+		return errInvalid
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testManifests = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wva-saturation-scaling-config
+  namespace: wva-system
+data:
+  default: |
+    kvCacheThreshold: 0.80
+    queueLengthThreshold: 5
+    kvSpareTrigger: 0.1
+    queueSpareTrigger: 3
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wva-queueing-model-config
+  namespace: wva-system
+data:
+  default: |
+    sloMultiplier: 4
+  llama: |
+    model_id: meta/llama
+    namespace: team-a
+    targetTTFT: 500
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wva-model-scale-to-zero-config
+data:
+  default: |
+    enable_scale_to_zero: true
+    retention_period: 5 minutes
+---
+apiVersion: llmd.ai/v1alpha1
+kind: VariantAutoscaling
+metadata:
+  name: llama-a100
+spec:
+  modelID: meta/llama
+  scaleTargetRef:
+    kind: Deployment
+    name: llama-a100
+  maxReplicas: 4
+---
+apiVersion: llmd.ai/v1alpha1
+kind: AutoscalingPolicy
+metadata:
+  name: llama
+spec:
+  modelIDs: [meta/llama]
+  saturation:
+    kvCacheThreshold: "0.9"
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func lint(t *testing.T, o options) (report, error) {
+	t.Helper()
+	var out strings.Builder
+	o.format = formatJSON
+	o.controllerNamespace = "wva-system"
+	err := run(o, strings.NewReader(""), &out)
+	var r report
+	if jsonErr := json.Unmarshal([]byte(out.String()), &r); jsonErr != nil {
+		t.Fatalf("invalid JSON output %q: %v", out.String(), jsonErr)
+	}
+	return r, err
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "manifests.yaml", testManifests)
+	writeFile(t, dir, "README.md", "not a manifest")
+
+	r, err := lint(t, options{filenames: []string{dir}, namespace: "team-a"})
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("run() error = %v, want errInvalid", err)
+	}
+	if r.Valid {
+		t.Error("report is valid, want invalid")
+	}
+
+	var errs []finding
+	for _, f := range r.Findings {
+		if f.Severity == severityError {
+			errs = append(errs, f)
+		}
+	}
+	if len(errs) != 2 {
+		t.Fatalf("errors = %+v, want 2", errs)
+	}
+	if errs[0].Name != "wva-queueing-model-config" || errs[0].Key != "llama" ||
+		!strings.Contains(errs[0].Message, "targetTTFT and targetITL must both be set") {
+		t.Errorf("first error = %+v, want the partial SLO targets", errs[0])
+	}
+	if errs[1].Namespace != "team-a" || errs[1].Key != "default" ||
+		!strings.Contains(errs[1].Message, "retention_period") {
+		t.Errorf("second error = %+v, want the retention period of the namespace-local ConfigMap", errs[1])
+	}
+
+	if len(r.EffectiveConfigs) != 1 {
+		t.Fatalf("effective configs = %+v, want 1", r.EffectiveConfigs)
+	}
+	c := r.EffectiveConfigs[0]
+	if c.Namespace != "team-a" || c.ModelID != "meta/llama" {
+		t.Errorf("effective config of %s/%s, want team-a/meta/llama", c.Namespace, c.ModelID)
+	}
+	if c.Saturation.KvCacheThreshold != "0.9" {
+		t.Errorf("kvCacheThreshold = %q, want the policy's 0.9", c.Saturation.KvCacheThreshold)
+	}
+	if c.QueueingModel == nil || c.QueueingModel.SLOMultiplier != "4" {
+		t.Errorf("queueing model = %+v, want the global default entry", c.QueueingModel)
+	}
+	if c.ScaleToZero.RetentionPeriod.Duration.String() != "10m0s" {
+		t.Errorf("retention period = %s, want the default for an invalid value", c.ScaleToZero.RetentionPeriod.Duration)
+	}
+}
+
+func TestLintWarnings(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "saturation.yaml", `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: wva-saturation-scaling-config
+  namespace: wva-system
+data:
+  default: |
+    kvCacheThreshold: 0.80
+    queueLengthThreshold: 5
+    kvSpareTrigger: 0.1
+    queueSpareTrigger: 3
+  llama: |
+    model_id: meta/llama
+    namespace: team-a
+    kvCacheThreshold: 0.9
+    queueLengthThreshold: 5
+`)
+
+	r, err := lint(t, options{filenames: []string{path}, namespace: "team-a", models: []string{"meta/llama"}})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !r.Valid || len(r.Findings) != 1 {
+		t.Fatalf("report = %+v, want valid with an unused entry", r)
+	}
+	if f := r.Findings[0]; f.Severity != severityWarning || f.Key != "llama" || !strings.Contains(f.Message, "never used") {
+		t.Errorf("finding = %+v, want the unused entry", f)
+	}
+	if got := r.EffectiveConfigs[0].Saturation.KvCacheThreshold; got != "0.8" {
+		t.Errorf("kvCacheThreshold = %q, want the default entry's", got)
+	}
+
+	_, err = lint(t, options{filenames: []string{path}, failOnWarnings: true})
+	if !errors.Is(err, errInvalid) {
+		t.Errorf("run() error = %v with --fail-on-warnings, want errInvalid", err)
+	}
+}
+
+func TestLintVariantAutoscaling(t *testing.T) {
+	manifests := `apiVersion: v1
+kind: List
+items:
+- apiVersion: llmd.ai/v1alpha1
+  kind: VariantAutoscaling
+  metadata:
+    name: no-model
+    namespace: team-a
+  spec:
+    scaleTargetRef: {kind: Deployment, name: llama}
+    maxReplicas: 2
+- apiVersion: llmd.ai/v1alpha1
+  kind: VariantAutoscaling
+  metadata:
+    name: bad-schedule
+    namespace: team-a
+  spec:
+    modelID: meta/llama
+    scaleTargetRef: {kind: Deployment, name: llama}
+    minReplicas: 3
+    maxReplicas: 2
+    schedules:
+    - name: nights
+      schedule: "0 25 * * *"
+      duration: 1h
+- apiVersion: llmd.ai/v1alpha1
+  kind: VariantAutoscaling
+  metadata:
+    name: bad-drain
+    namespace: team-a
+  spec:
+    modelID: meta/llama
+    scaleTargetRef: {kind: LeaderWorkerSet, name: llama}
+    gracefulScaleDown:
+      cordonLabel: routable
+`
+	var out strings.Builder
+	err := run(options{filenames: []string{"-"}, format: formatText}, strings.NewReader(manifests), &out)
+	if !errors.Is(err, errInvalid) {
+		t.Fatalf("run() error = %v, want errInvalid", err)
+	}
+	for _, want := range []string{
+		"error: <stdin>: VariantAutoscaling no-model (namespace team-a): spec.modelID is required",
+		"VariantAutoscaling bad-schedule (namespace team-a): minReplicas must be less than or equal to maxReplicas",
+		"VariantAutoscaling bad-schedule (namespace team-a): spec.schedules:",
+		"VariantAutoscaling bad-drain (namespace team-a): spec.gracefulScaleDown: cordon label \"routable\" is not key=value",
+		"warning: <stdin>: VariantAutoscaling bad-drain (namespace team-a): spec.gracefulScaleDown only supports Deployments",
+		"configuration is invalid",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output %q does not contain %q", out.String(), want)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	if err := run(options{format: formatJSON}, nil, nil); err == nil {
+		t.Error("run() without --filename: want an error")
+	}
+	if err := run(options{filenames: []string{"-"}, format: "csv"}, nil, nil); err == nil {
+		t.Error("run() with an unknown format: want an error")
+	}
+	if err := run(options{filenames: []string{"-"}, format: formatJSON, models: []string{"m"}}, nil, nil); err == nil {
+		t.Error("run() with --model and no --namespace: want an error")
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// report is the output of configlint.
+type report struct {
+	// Valid is false when the findings fail the lint.
+	Valid            bool          `json:"valid"`
+	Findings         []finding     `json:"findings"`
+	EffectiveConfigs []modelConfig `json:"effectiveConfigs"`
+}
+
+// modelConfig is the effective configuration of a model in a namespace, as
+// reported in the status.effectiveConfig of its VariantAutoscalings.
+type modelConfig struct {
+	Namespace     string                                    `json:"namespace"`
+	ModelID       string                                    `json:"modelID"`
+	Hash          string                                    `json:"hash"`
+	Saturation    wvav1alpha1.EffectiveSaturationConfig     `json:"saturation"`
+	QueueingModel *wvav1alpha1.EffectiveQueueingModelConfig `json:"queueingModel,omitempty"`
+	ScaleToZero   wvav1alpha1.EffectiveScaleToZeroConfig    `json:"scaleToZero"`
+}
+
+// has returns whether the report has findings of a severity.
+func (r report) has(severity string) bool {
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			return true
+		}
+	}
+	return false
+}
+
+func writeReport(w io.Writer, format string, r report) error {
+	if r.Findings == nil {
+		r.Findings = []finding{}
+	}
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	}
+
+	var b strings.Builder
+	for _, f := range r.Findings {
+		fmt.Fprintf(&b, "%s: %s: %s %s", f.Severity, f.File, f.Kind, f.Name)
+		if f.Namespace != "" {
+			fmt.Fprintf(&b, " (namespace %s)", f.Namespace)
+		}
+		if f.Key != "" {
+			fmt.Fprintf(&b, ", entry %q", f.Key)
+		}
+		fmt.Fprintf(&b, ": %s\n", f.Message)
+	}
+	if r.Valid {
+		b.WriteString("configuration is valid\n")
+	} else {
+		b.WriteString("configuration is invalid\n")
+	}
+	for _, c := range r.EffectiveConfigs {
+		out, err := yaml.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("encoding effective configuration: %w", err)
+		}
+		b.WriteString("---\n")
+		b.Write(out)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
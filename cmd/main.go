@@ -56,10 +56,15 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/controller"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/controller/indexers"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/debug"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/scalefromzero"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/externalmetrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/federation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/kedascaler"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
 	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -148,6 +153,9 @@ func main() {
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.String("watch-namespace", "",
 		"Namespace to watch for updates. If unspecified, all namespaces are watched.")
+	flag.Bool("keda-scaler-insecure", false,
+		"If set, the KEDA external scaler may be served over plaintext gRPC without client authentication "+
+			"when no certificate is configured.")
 
 	// Leader election timeout configuration flags
 	// These can be overridden in manager.yaml to tune for different environments
@@ -195,6 +203,16 @@ func main() {
 	}
 	setupLog.Info("Configuration loaded successfully")
 
+	// Export OpenTelemetry spans of the optimization cycle and reconciler, if enabled
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing())
+	if err != nil {
+		setupLog.Error(err, "failed to set up tracing")
+		os.Exit(1)
+	}
+	if shutdownTracing != nil {
+		setupLog.Info("Tracing enabled", "exporter", cfg.Tracing().Exporter)
+	}
+
 	// Conditionally add LeaderWorkerSet scheme if CRD exists
 	lwsEnabled := checkLeaderWorkerSetCRD(restConfig, setupLog)
 	if lwsEnabled {
@@ -305,6 +323,19 @@ func main() {
 	// --- Setup Datastore ---
 	ds := datastore.NewDatastore(cfg)
 
+	// The debug endpoint exposes the engines' state. It is served on the metrics server only
+	// when that is secured, so it is behind the same authn/authz; the RBAC is in
+	// 'config/rbac/debug_reader_role.yaml'. Engine sections are registered when the engines start.
+	debugHandler := debug.NewHandler()
+	debugHandler.Register(debug.SectionDecisionCache, debug.DecisionCacheSection())
+	debugHandler.Register(debug.SectionDatastorePools, debug.DatastorePoolsSection(ds))
+	debugHandler.Register(debug.SectionPoolSourceCache, debug.PoolSourceCacheSection(ds, cfg))
+	if cfg.SecureMetrics() {
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{debug.Path: debugHandler}
+	} else {
+		setupLog.Info("Debug endpoint disabled, it requires secure metrics serving", "path", debug.Path)
+	}
+
 	// Use configurable REST client timeout from Config (default 60s, can be overridden via --rest-client-timeout flag)
 	restConfig.Timeout = cfg.RestTimeout()
 
@@ -428,6 +459,59 @@ func main() {
 	}
 	setupLog.Info("Prometheus client and API wrapper initialized and validated successfully")
 
+	// Federation shares the supply and demand of models with the controllers of other
+	// clusters, and adjusts the scaling of each model to the share of this cluster.
+	var fed *federation.Federation
+	if federationCfg := cfg.Federation(); federationCfg.Enabled() {
+		fed, err = federation.New(federation.Options{
+			Cluster:      federationCfg.ClusterName,
+			Peers:        federationCfg.Peers,
+			Token:        federationCfg.Token,
+			SyncInterval: federationCfg.SyncInterval,
+			StaleAfter:   federationCfg.StaleAfter,
+			CACertPath:   federationCfg.CACertPath,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create federation")
+			os.Exit(1)
+		}
+		if err := mgr.Add(fed); err != nil {
+			setupLog.Error(err, "unable to add federation to manager")
+			os.Exit(1)
+		}
+		federationServerOpts := federation.ServerOptions{BindAddress: federationCfg.BindAddress, Token: federationCfg.Token}
+		if len(federationCfg.CertPath) > 0 {
+			federationCertWatcher, err := certwatcher.New(
+				filepath.Join(federationCfg.CertPath, federationCfg.CertName),
+				filepath.Join(federationCfg.CertPath, federationCfg.CertKey),
+			)
+			if err != nil {
+				setupLog.Error(err, "Failed to initialize federation certificate watcher")
+				os.Exit(1)
+			}
+			if err := mgr.Add(federationCertWatcher); err != nil {
+				setupLog.Error(err, "unable to add federation certificate watcher to manager")
+				os.Exit(1)
+			}
+			federationServerOpts.GetCertificate = federationCertWatcher.GetCertificate
+		} else {
+			setupLog.Info("Serving the federation summary over plain HTTP; set FEDERATION_CERT_PATH unless TLS is terminated in front of it")
+		}
+		if federationCfg.Token == "" {
+			setupLog.Info("Federation authentication is disabled by FEDERATION_INSECURE")
+		}
+		federationServer, err := federation.NewServer(fed, federationServerOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to create federation server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(federationServer); err != nil {
+			setupLog.Error(err, "unable to add federation server to manager")
+			os.Exit(1)
+		}
+		debugHandler.Register(debug.SectionFederation, debug.FederationSection(fed))
+	}
+
 	// Register optimization engine loops with the manager. Only start when leader.
 	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
 		sourceRegistry := source.NewSourceRegistry()
@@ -454,6 +538,9 @@ func main() {
 			sourceRegistry,
 			cfg, // Pass unified Config to engine
 		)
+		engine.Federation = fed
+		debugHandler.Register(debug.SectionSaturation, debug.SaturationEngineSection(engine))
+		debugHandler.Register(debug.SectionSourceCache, debug.SourceCacheSection(sourceRegistry, cfg))
 		go engine.StartOptimizeLoop(ctx)
 		return nil
 	}))
@@ -465,7 +552,8 @@ func main() {
 
 	// Register scale from zero engine loop with the manager. Only start when leader.
 	err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
-		engine, err := scalefromzero.NewEngine(mgr.GetClient(), mgr.GetRESTMapper(), restConfig, ds, cfg)
+		engine, err := scalefromzero.NewEngine(mgr.GetClient(), mgr.GetRESTMapper(), restConfig, ds,
+			mgr.GetEventRecorderFor("workload-variant-autoscaler-scale-from-zero-engine"), cfg)
 		if err != nil {
 			return err
 		}
@@ -518,6 +606,97 @@ func main() {
 		os.Exit(1)
 	}
 
+	// AutoscalingPolicies and ClusterAutoscalingPolicies override the per-model
+	// entries of the saturation, queueing model and scale-to-zero ConfigMaps.
+	autoscalingPolicyReconciler := &controller.AutoscalingPolicyReconciler{
+		Client: mgr.GetClient(),
+		Config: cfg,
+	}
+	if err = autoscalingPolicyReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create autoscaling policy controller")
+		os.Exit(1)
+	}
+
+	// Node interruptions trigger an immediate re-optimization so that capacity
+	// lost on spot variants is replaced on on-demand variants.
+	nodeInterruptionReconciler := &controller.NodeInterruptionReconciler{
+		Client: mgr.GetClient(),
+	}
+	if err = nodeInterruptionReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create node interruption controller")
+		os.Exit(1)
+	}
+
+	// The external metrics API lets HPAs read the desired replicas from WVA directly, without
+	// prometheus-adapter. It is registered by the APIService in 'config/external-metrics'.
+	if external := cfg.ExternalMetrics(); external.BindAddress != "" {
+		externalMetricsOpts := externalmetrics.Options{
+			BindAddress: external.BindAddress,
+			TLSOpts:     tlsOpts,
+		}
+		if len(external.CertPath) > 0 {
+			externalMetricsCertWatcher, err := certwatcher.New(
+				filepath.Join(external.CertPath, external.CertName),
+				filepath.Join(external.CertPath, external.CertKey),
+			)
+			if err != nil {
+				setupLog.Error(err, "Failed to initialize external metrics certificate watcher")
+				os.Exit(1)
+			}
+			if err := mgr.Add(externalMetricsCertWatcher); err != nil {
+				setupLog.Error(err, "unable to add external metrics certificate watcher to manager")
+				os.Exit(1)
+			}
+			externalMetricsOpts.GetCertificate = externalMetricsCertWatcher.GetCertificate
+		}
+		externalMetricsServer, err := externalmetrics.NewServer(restConfig,
+			externalmetrics.NewHandler(externalmetrics.NewProvider(mgr.GetClient())), externalMetricsOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to create external metrics server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(externalMetricsServer); err != nil {
+			setupLog.Error(err, "unable to add external metrics server to manager")
+			os.Exit(1)
+		}
+	}
+
+	// The KEDA external scaler lets ScaledObjects scale on the desired replicas without a
+	// Prometheus trigger, with activations from zero pushed by the scale-from-zero engine.
+	if kedaScaler := cfg.KedaScaler(); kedaScaler.BindAddress != "" {
+		kedaScalerOpts := kedascaler.Options{
+			BindAddress:      kedaScaler.BindAddress,
+			ClientCACertPath: kedaScaler.ClientCACertPath,
+			Insecure:         kedaScaler.Insecure,
+		}
+		if len(kedaScaler.CertPath) > 0 {
+			kedaScalerCertWatcher, err := certwatcher.New(
+				filepath.Join(kedaScaler.CertPath, kedaScaler.CertName),
+				filepath.Join(kedaScaler.CertPath, kedaScaler.CertKey),
+			)
+			if err != nil {
+				setupLog.Error(err, "Failed to initialize KEDA scaler certificate watcher")
+				os.Exit(1)
+			}
+			if err := mgr.Add(kedaScalerCertWatcher); err != nil {
+				setupLog.Error(err, "unable to add KEDA scaler certificate watcher to manager")
+				os.Exit(1)
+			}
+			kedaScalerOpts.GetCertificate = kedaScalerCertWatcher.GetCertificate
+		} else {
+			setupLog.Info("Serving the KEDA external scaler over plaintext gRPC without client authentication, as set by --keda-scaler-insecure")
+		}
+		kedaScalerServer, err := kedascaler.NewServer(kedascaler.NewScaler(mgr.GetClient()), kedaScalerOpts)
+		if err != nil {
+			setupLog.Error(err, "unable to create KEDA scaler server")
+			os.Exit(1)
+		}
+		if err := mgr.Add(kedaScalerServer); err != nil {
+			setupLog.Error(err, "unable to add KEDA scaler server to manager")
+			os.Exit(1)
+		}
+	}
+
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
 		if err := mgr.Add(metricsCertWatcher); err != nil {
@@ -568,7 +747,16 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	err = mgr.Start(ctrl.SetupSignalHandler())
+	if shutdownTracing != nil {
+		// Flush the spans of the last cycle
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			setupLog.Error(err, "Failed to flush traces")
+		}
+		cancel()
+	}
+	if err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
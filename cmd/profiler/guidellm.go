@@ -0,0 +1,85 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+)
+
+// guidellmReport is the part of a guidellm benchmark report (benchmarks.json)
+// the profiler reads. Each benchmark of a sweep is one request rate.
+type guidellmReport struct {
+	Benchmarks []guidellmBenchmark `json:"benchmarks"`
+}
+
+type guidellmBenchmark struct {
+	Metrics struct {
+		TimeToFirstTokenMs  guidellmMetric `json:"time_to_first_token_ms"`
+		InterTokenLatencyMs guidellmMetric `json:"inter_token_latency_ms"`
+		PromptTokenCount    guidellmMetric `json:"prompt_token_count"`
+		OutputTokenCount    guidellmMetric `json:"output_token_count"`
+		RequestsPerSecond   guidellmMetric `json:"requests_per_second"`
+	} `json:"metrics"`
+	Rate struct {
+		CompletedRate float64 `json:"completed_rate"`
+	} `json:"rate"`
+}
+
+// guidellmMetric holds the distributions of a metric over the requests of a
+// benchmark; only successful requests are used.
+type guidellmMetric struct {
+	Successful struct {
+		Mean float64 `json:"mean"`
+	} `json:"successful"`
+}
+
+// loadGuidellm reads the observations of the benchmarks of a guidellm report.
+// Benchmarks without successful requests are skipped.
+func loadGuidellm(path string) ([]*tuner.Environment, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading guidellm report: %w", err)
+	}
+	var report guidellmReport
+	if err := json.Unmarshal(raw, &report); err != nil {
+		return nil, fmt.Errorf("parsing guidellm report %s: %w", path, err)
+	}
+
+	envs := make([]*tuner.Environment, 0, len(report.Benchmarks))
+	for _, b := range report.Benchmarks {
+		rate := b.Metrics.RequestsPerSecond.Successful.Mean
+		if rate <= 0 {
+			rate = b.Rate.CompletedRate
+		}
+		env := &tuner.Environment{
+			Lambda:        float32(rate * 60), // reqs/sec to reqs/min
+			AvgInputToks:  float32(b.Metrics.PromptTokenCount.Successful.Mean),
+			AvgOutputToks: float32(b.Metrics.OutputTokenCount.Successful.Mean),
+			AvgTTFT:       float32(b.Metrics.TimeToFirstTokenMs.Successful.Mean),
+			AvgITL:        float32(b.Metrics.InterTokenLatencyMs.Successful.Mean),
+		}
+		if env.Lambda <= 0 || env.AvgInputToks <= 0 || env.AvgOutputToks <= 0 || env.AvgTTFT <= 0 || env.AvgITL <= 0 {
+			continue
+		}
+		envs = append(envs, env)
+	}
+	return envs, nil
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command profiler fits the queueing model parameters (alpha, beta, gamma) and
+// max batch size of a model on an accelerator to guidellm benchmark sweeps or
+// to replica metrics recorded by the controller's decision recorder, and writes
+// them as an entry of the model performance data ConfigMap. The queueing model
+// analyzer starts the variants it has not learned yet from these parameters
+// instead of guessing them.
+package main
+
+import (
+	"cmp"
+	"errors"
+	goflag "flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/yaml"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/decisionrecord"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// options are the command line options of the profiler.
+type options struct {
+	guidellmPaths   []string
+	recordsPath     string
+	model           string
+	accelerator     string
+	accCount        int
+	maxBatchSize    int
+	maxObservations int
+	name            string
+	namespace       string
+	outputPath      string
+}
+
+func main() {
+	var opts options
+	flag.StringSliceVar(&opts.guidellmPaths, "guidellm", nil,
+		"Paths to guidellm benchmark reports (JSON), each benchmark being one request rate of a sweep.")
+	flag.StringVar(&opts.recordsPath, "records", "",
+		"Path to the JSON lines file written by the controller's decision recorder, fitted instead of --guidellm. "+
+			"Its rotated files are read too.")
+	flag.StringVar(&opts.model, "model", "", "Model ID the parameters are profiled for.")
+	flag.StringVar(&opts.accelerator, "accelerator", "", "Accelerator the parameters are profiled for (e.g. H100).")
+	flag.IntVar(&opts.accCount, "acc-count", 1,
+		"Number of accelerator units per replica. 0 applies the parameters to any count.")
+	flag.IntVar(&opts.maxBatchSize, "max-batch-size", 0,
+		"Max batch size (vLLM --max-num-seqs) of the profiled server. "+
+			"When 0, it is taken from the records, or fitted to the benchmarks.")
+	flag.IntVar(&opts.maxObservations, "max-observations", 200,
+		"Maximum number of observations to fit, spread over the observed request rates.")
+	flag.StringVar(&opts.name, "name", config.DefaultPerfDataConfigMapName, "Name of the ConfigMap written.")
+	flag.StringVar(&opts.namespace, "namespace", "", "Namespace of the ConfigMap written.")
+	flag.StringVar(&opts.outputPath, "output", "", "Path to write the ConfigMap manifest to. Defaults to stdout.")
+	loggerVerbosity := flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
+
+	zapOpts := ctrlzap.Options{
+		Development: true,
+		DestWriter:  os.Stderr,
+	}
+	gfs := goflag.NewFlagSet("zap", goflag.ExitOnError)
+	zapOpts.BindFlags(gfs)
+	flag.CommandLine.AddGoFlagSet(gfs)
+
+	flag.Parse()
+
+	logging.InitLogging(&zapOpts, loggerVerbosity)
+	defer logging.Sync() // nolint:errcheck
+
+	if err := run(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "profiler:", err)
+		logging.Sync() //nolint:errcheck
+		os.Exit(1)     //nolint:gocritic // exitAfterDefer: Sync() called explicitly above
+	}
+}
+
+func run(opts options) error {
+	if (len(opts.guidellmPaths) == 0) == (opts.recordsPath == "") {
+		return errors.New("exactly one of --guidellm and --records is required")
+	}
+	if opts.model == "" || opts.accelerator == "" {
+		return errors.New("--model and --accelerator are required")
+	}
+	if opts.accCount < 0 || opts.maxBatchSize < 0 || opts.maxObservations <= 0 {
+		return errors.New("--acc-count and --max-batch-size must not be negative, --max-observations must be positive")
+	}
+
+	var envs []*tuner.Environment
+	var err error
+	if opts.recordsPath != "" {
+		envs, err = loadRecords(opts.recordsPath, opts.model, opts.accelerator, opts.accCount)
+	} else {
+		for _, path := range opts.guidellmPaths {
+			var benchmarkEnvs []*tuner.Environment
+			if benchmarkEnvs, err = loadGuidellm(path); err != nil {
+				break
+			}
+			envs = append(envs, benchmarkEnvs...)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	maxBatchSizes, envs, err := maxBatchSizeCandidates(envs, opts.maxBatchSize)
+	if err != nil {
+		return err
+	}
+	if len(envs) == 0 {
+		return fmt.Errorf("no observations of model %s on %s", opts.model, opts.accelerator)
+	}
+	envs = spreadObservations(envs, opts.maxObservations)
+
+	fitted, err := queueingmodel.FitParameters(envs, maxBatchSizes)
+	if err != nil {
+		return err
+	}
+	ctrl.Log.Info("Fitted queueing model parameters",
+		"model", opts.model,
+		"accelerator", opts.accelerator,
+		"accCount", opts.accCount,
+		"observations", len(envs),
+		"alpha", fitted.Alpha,
+		"beta", fitted.Beta,
+		"gamma", fitted.Gamma,
+		"maxBatchSize", fitted.MaxBatchSize,
+		"error", fitted.Error)
+
+	perfData := infernoConfig.ModelAcceleratorPerfData{
+		Name:         opts.model,
+		Acc:          opts.accelerator,
+		AccCount:     opts.accCount,
+		MaxBatchSize: fitted.MaxBatchSize,
+		AtTokens:     averageTokens(envs),
+		ServiceParms: infernoConfig.ServiceParms{
+			Alpha: fitted.Alpha,
+			Beta:  fitted.Beta,
+			Gamma: fitted.Gamma,
+		},
+	}
+
+	var out io.Writer = os.Stdout
+	if opts.outputPath != "" {
+		f, err := os.Create(opts.outputPath)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		out = f
+	}
+	return writeConfigMap(out, opts.name, opts.namespace, perfData)
+}
+
+// loadRecords reads the observations of the replicas of a model on an
+// accelerator from the cycles recorded by the decision recorder. Replicas
+// without traffic or latency metrics are skipped, as are replicas of variants
+// with another accelerator count when accCount is set.
+func loadRecords(path, model, accelerator string, accCount int) ([]*tuner.Environment, error) {
+	records, err := decisionrecord.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+
+	acceleratorKey := config.PriceCatalogKey(accelerator)
+	var envs []*tuner.Environment
+	for _, cycle := range records {
+		for _, m := range cycle.Models {
+			if m.ModelID != model || m.Input == nil {
+				continue
+			}
+			gpus := make(map[string]int, len(m.Input.VariantStates))
+			for _, state := range m.Input.VariantStates {
+				gpus[state.VariantName] = state.GPUsPerReplica
+			}
+			for _, rm := range m.Input.ReplicaMetrics {
+				if config.PriceCatalogKey(rm.AcceleratorName) != acceleratorKey {
+					continue
+				}
+				if accCount > 0 && gpus[rm.VariantName] != accCount {
+					continue
+				}
+				env := &tuner.Environment{
+					Lambda:             float32(rm.ArrivalRate * 60), // reqs/sec to reqs/min
+					AvgInputToks:       float32(rm.AvgInputTokens),
+					AvgOutputToks:      float32(rm.AvgOutputTokens),
+					PrefixCacheHitRate: float32(rm.PrefixCacheHitRate),
+					MaxBatchSize:       int(rm.MaxBatchSize),
+					AvgTTFT:            float32(rm.AvgTTFT * 1000), // secs to msecs
+					AvgITL:             float32(rm.AvgITL * 1000),  // secs to msecs
+				}
+				if env.Lambda <= 0 || env.AvgInputToks <= 0 || env.AvgOutputToks <= 0 || env.AvgTTFT <= 0 || env.AvgITL <= 0 {
+					continue
+				}
+				envs = append(envs, env)
+			}
+		}
+	}
+	return envs, nil
+}
+
+// maxBatchSizeCandidates returns the max batch sizes to fit the observations
+// for, and the observations to fit.
+//
+// A max batch size given on the command line is the only candidate, and only
+// the observations of servers with that max batch size, or an unknown one, are
+// fitted. Otherwise, observations that all report the same max batch size are
+// fitted for it, and the max batch size of observations without one, such as
+// benchmarks, is fitted among queueingmodel.DefaultMaxBatchSizeCandidates.
+func maxBatchSizeCandidates(envs []*tuner.Environment, maxBatchSize int) ([]int, []*tuner.Environment, error) {
+	if maxBatchSize > 0 {
+		envs = slices.DeleteFunc(envs, func(env *tuner.Environment) bool {
+			return env.MaxBatchSize != 0 && env.MaxBatchSize != maxBatchSize
+		})
+		return []int{maxBatchSize}, envs, nil
+	}
+
+	observed := make(map[int]bool)
+	for _, env := range envs {
+		observed[env.MaxBatchSize] = true
+	}
+	switch {
+	case len(observed) > 1:
+		return nil, nil, errors.New("observations with several max batch sizes, select one with --max-batch-size")
+	case len(observed) == 1 && !observed[0]:
+		return []int{envs[0].MaxBatchSize}, envs, nil
+	}
+	return queueingmodel.DefaultMaxBatchSizeCandidates, envs, nil
+}
+
+// spreadObservations returns at most n observations, evenly spread over the
+// observations sorted by request rate so that the whole range of rates is
+// kept.
+func spreadObservations(envs []*tuner.Environment, n int) []*tuner.Environment {
+	if len(envs) <= n {
+		return envs
+	}
+	sorted := slices.SortedStableFunc(slices.Values(envs), func(a, b *tuner.Environment) int {
+		return cmp.Compare(a.Lambda, b.Lambda)
+	})
+	spread := make([]*tuner.Environment, n)
+	for i := range spread {
+		spread[i] = sorted[i*(len(sorted)-1)/max(n-1, 1)]
+	}
+	return spread
+}
+
+// averageTokens returns the average number of tokens (input and output) per
+// request over the observations.
+func averageTokens(envs []*tuner.Environment) int {
+	var sum float64
+	for _, env := range envs {
+		sum += float64(env.AvgInputToks + env.AvgOutputToks)
+	}
+	return int(math.Round(sum / float64(len(envs))))
+}
+
+// configMapManifest is a ConfigMap manifest, without the fields the API
+// server sets.
+type configMapManifest struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace,omitempty"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+var invalidKeyChars = regexp.MustCompile(`[^a-z0-9.]+`)
+
+// perfDataEntryKey returns the ConfigMap key of the performance data of a
+// model on an accelerator, e.g. "llama-3.1-8b-instruct-h100-1".
+func perfDataEntryKey(perfData *infernoConfig.ModelAcceleratorPerfData) string {
+	model := perfData.Name
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	key := fmt.Sprintf("%s-%s-%d", model, config.PriceCatalogKey(perfData.Acc), perfData.AccCount)
+	return strings.Trim(invalidKeyChars.ReplaceAllString(strings.ToLower(key), "-"), "-.")
+}
+
+// writeConfigMap writes the manifest of a model performance data ConfigMap
+// holding the entry of the given performance data, to be merged into the
+// deployed ConfigMap.
+func writeConfigMap(w io.Writer, name, namespace string, perfData infernoConfig.ModelAcceleratorPerfData) error {
+	entry, err := yaml.Marshal(perfData)
+	if err != nil {
+		return fmt.Errorf("encoding performance data: %w", err)
+	}
+	manifest := configMapManifest{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Data:       map[string]string{perfDataEntryKey(&perfData): string(entry)},
+	}
+	manifest.Metadata.Name = name
+	manifest.Metadata.Namespace = namespace
+
+	out, err := yaml.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("encoding ConfigMap: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
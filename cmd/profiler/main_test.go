@@ -0,0 +1,157 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// writeGuidellmReport writes a guidellm report of a sweep of request rates
+// (req/sec) served by a server with the given parameters.
+func writeGuidellmReport(t *testing.T, dir string, parms *analyzer.ServiceParms, maxBatchSize int, rates ...float64) string {
+	t.Helper()
+	mean := func(v float64) map[string]any {
+		return map[string]any{"successful": map[string]any{"mean": v}}
+	}
+	var benchmarks []any
+	for _, rate := range rates {
+		env := &tuner.Environment{Lambda: float32(rate * 60), AvgInputToks: 1024, AvgOutputToks: 256, MaxBatchSize: maxBatchSize}
+		ttft, itl, err := tuner.Predict(env, parms)
+		if err != nil {
+			t.Fatalf("Predict at rate %v: %v", rate, err)
+		}
+		benchmarks = append(benchmarks, map[string]any{
+			"metrics": map[string]any{
+				"time_to_first_token_ms": mean(ttft),
+				"inter_token_latency_ms": mean(itl),
+				"prompt_token_count":     mean(1024),
+				"output_token_count":     mean(256),
+				"requests_per_second":    mean(rate),
+			},
+		})
+	}
+	raw, err := json.Marshal(map[string]any{"benchmarks": benchmarks})
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "benchmarks.json")
+	if err := os.WriteFile(path, raw, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProfileGuidellm(t *testing.T) {
+	dir := t.TempDir()
+	want := &analyzer.ServiceParms{Alpha: 6, Beta: 0.04, Gamma: 0.0002}
+	reportPath := writeGuidellmReport(t, dir, want, 64, 1, 2, 4, 6, 7)
+	outputPath := filepath.Join(dir, "perf-data.yaml")
+
+	err := run(options{
+		guidellmPaths:   []string{reportPath},
+		model:           "meta-llama/Llama-3.1-8B-Instruct",
+		accelerator:     "NVIDIA-H100-80GB-HBM3",
+		accCount:        1,
+		maxBatchSize:    64,
+		maxObservations: 200,
+		name:            config.DefaultPerfDataConfigMapName,
+		namespace:       "workload-variant-autoscaler-system",
+		outputPath:      outputPath,
+	})
+	if err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest configMapManifest
+	if err := yaml.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("Output is not a ConfigMap manifest: %v\n%s", err, raw)
+	}
+	if manifest.Kind != "ConfigMap" || manifest.Metadata.Name != config.DefaultPerfDataConfigMapName {
+		t.Errorf("Manifest %s %s, want ConfigMap %s", manifest.Kind, manifest.Metadata.Name, config.DefaultPerfDataConfigMapName)
+	}
+	if _, ok := manifest.Data["llama-3.1-8b-instruct-h100-1"]; !ok {
+		t.Errorf("Data keys %v, want llama-3.1-8b-instruct-h100-1", manifest.Data)
+	}
+
+	// the controller reads the entry back
+	perfData, ok := config.ParsePerfDataConfigMap(manifest.Data).Lookup("meta-llama/Llama-3.1-8B-Instruct", "H100", 1)
+	if !ok {
+		t.Fatalf("Entry not found in the catalog parsed from %v", manifest.Data)
+	}
+	if perfData.MaxBatchSize != 64 || perfData.AtTokens != 1280 {
+		t.Errorf("MaxBatchSize %d, AtTokens %d, want 64, 1280", perfData.MaxBatchSize, perfData.AtTokens)
+	}
+	parms := perfData.ServiceParms
+	for _, p := range []struct {
+		name      string
+		got, want float32
+	}{
+		{"alpha", parms.Alpha, want.Alpha},
+		{"beta", parms.Beta, want.Beta},
+		{"gamma", parms.Gamma, want.Gamma},
+	} {
+		if p.got < 0.8*p.want || p.got > 1.2*p.want {
+			t.Errorf("%s = %v, want about %v", p.name, p.got, p.want)
+		}
+	}
+}
+
+func TestRunInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts options
+	}{
+		{"no input", options{model: "m", accelerator: "H100", maxObservations: 1}},
+		{"both inputs", options{guidellmPaths: []string{"a"}, recordsPath: "b", model: "m", accelerator: "H100", maxObservations: 1}},
+		{"no model", options{guidellmPaths: []string{"a"}, accelerator: "H100", maxObservations: 1}},
+		{"negative max batch size", options{guidellmPaths: []string{"a"}, model: "m", accelerator: "H100", maxBatchSize: -1, maxObservations: 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.opts); err == nil {
+				t.Error("run() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestMaxBatchSizeCandidates(t *testing.T) {
+	envs := func(maxBatchSizes ...int) []*tuner.Environment {
+		out := make([]*tuner.Environment, len(maxBatchSizes))
+		for i, n := range maxBatchSizes {
+			out[i] = &tuner.Environment{MaxBatchSize: n}
+		}
+		return out
+	}
+
+	candidates, fitted, err := maxBatchSizeCandidates(envs(0, 0), 0)
+	if err != nil || !slices.Equal(candidates, queueingmodel.DefaultMaxBatchSizeCandidates) || len(fitted) != 2 {
+		t.Errorf("Benchmarks: candidates %v, %d observations, error %v, want the default candidates",
+			candidates, len(fitted), err)
+	}
+	candidates, _, err = maxBatchSizeCandidates(envs(128, 128), 0)
+	if err != nil || len(candidates) != 1 || candidates[0] != 128 {
+		t.Errorf("Recorded max batch size: candidates %v, error %v, want [128]", candidates, err)
+	}
+	if _, _, err = maxBatchSizeCandidates(envs(128, 256), 0); err == nil {
+		t.Error("Several recorded max batch sizes: want an error")
+	}
+	candidates, fitted, err = maxBatchSizeCandidates(envs(128, 256, 0), 256)
+	if err != nil || len(candidates) != 1 || candidates[0] != 256 || len(fitted) != 2 {
+		t.Errorf("Selected max batch size: candidates %v, %d observations, error %v, want [256] and 2 observations",
+			candidates, len(fitted), err)
+	}
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command replay runs a recorded trace of replica metrics and variant states
+// through the autoscaler's decision pipeline offline, and writes the decisions
+// of every cycle as JSON or CSV. Replaying the same trace with two sets of
+// ConfigMaps and diffing the output shows the effect of a config change before
+// it is rolled out.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	goflag "flag"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+	yaml "gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/decisionrecord"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+func main() {
+	tracePath := flag.String("trace", "", "Path to the recorded trace (JSON).")
+	recordsPath := flag.String("records", "",
+		"Path to the JSON lines file written by the controller's decision recorder, replayed instead of --trace. "+
+			"Its rotated files are read too.")
+	saturationConfigPath := flag.String("saturation-config", "",
+		"Path to the saturation scaling ConfigMap manifest (wva-saturation-scaling-config).")
+	qmConfigPath := flag.String("queueing-model-config", "",
+		"Path to the queueing model ConfigMap manifest. Selects the queueing model analyzer when it has a \"default\" entry.")
+	scaleToZeroConfigPath := flag.String("scale-to-zero-config", "",
+		"Path to the scale-to-zero ConfigMap manifest, including scaling schedules.")
+	outputPath := flag.String("output", "", "Path to write the decisions to. Defaults to stdout.")
+	format := flag.String("format", "json", "Output format: json or csv.")
+	loggerVerbosity := flag.Int("v", logging.DEFAULT, "number for the log level verbosity")
+
+	opts := ctrlzap.Options{
+		Development: true,
+		DestWriter:  os.Stderr,
+	}
+	gfs := goflag.NewFlagSet("zap", goflag.ExitOnError)
+	opts.BindFlags(gfs)
+	flag.CommandLine.AddGoFlagSet(gfs)
+
+	flag.Parse()
+
+	logging.InitLogging(&opts, loggerVerbosity)
+	defer logging.Sync() // nolint:errcheck
+
+	if err := run(*tracePath, *recordsPath, *saturationConfigPath, *qmConfigPath, *scaleToZeroConfigPath, *outputPath, *format); err != nil {
+		fmt.Fprintln(os.Stderr, "replay:", err)
+		logging.Sync() //nolint:errcheck
+		os.Exit(1)     //nolint:gocritic // exitAfterDefer: Sync() called explicitly above
+	}
+}
+
+func run(tracePath, recordsPath, saturationConfigPath, qmConfigPath, scaleToZeroConfigPath, outputPath, format string) error {
+	if (tracePath == "") == (recordsPath == "") {
+		return errors.New("exactly one of --trace and --records is required")
+	}
+	if format != formatJSON && format != formatCSV {
+		return fmt.Errorf("unknown output format %q, expected %s or %s", format, formatJSON, formatCSV)
+	}
+
+	cfg, err := loadConfig(saturationConfigPath, qmConfigPath, scaleToZeroConfigPath)
+	if err != nil {
+		return err
+	}
+
+	var trace *saturation.ReplayTrace
+	if recordsPath != "" {
+		trace, err = loadRecords(recordsPath)
+	} else {
+		trace, err = loadTrace(tracePath)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx := ctrl.LoggerInto(context.Background(), ctrl.Log.WithName("replay"))
+	results := saturation.NewReplayer(cfg).Replay(ctx, trace)
+
+	var out io.Writer = os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("creating output file: %w", err)
+		}
+		defer f.Close() //nolint:errcheck
+		out = f
+	}
+	return writeResults(out, format, results)
+}
+
+// loadTrace reads a recorded trace from a JSON file.
+func loadTrace(path string) (*saturation.ReplayTrace, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading trace: %w", err)
+	}
+	var trace saturation.ReplayTrace
+	if err := json.Unmarshal(data, &trace); err != nil {
+		return nil, fmt.Errorf("parsing trace %s: %w", path, err)
+	}
+	if len(trace.Cycles) == 0 {
+		return nil, fmt.Errorf("trace %s has no cycles", path)
+	}
+	return &trace, nil
+}
+
+// loadRecords reads the cycles recorded by the decision recorder as a trace.
+func loadRecords(path string) (*saturation.ReplayTrace, error) {
+	records, err := decisionrecord.LoadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("records %s have no cycles", path)
+	}
+	return saturation.ReplayTraceFromRecords(records), nil
+}
+
+// loadConfig builds the configuration to replay with from ConfigMap manifests.
+// Unlike the controller, which skips invalid entries, any invalid entry is an error.
+func loadConfig(saturationConfigPath, qmConfigPath, scaleToZeroConfigPath string) (*config.Config, error) {
+	cfg := &config.Config{}
+
+	if saturationConfigPath != "" {
+		data, err := readConfigMapData(saturationConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		configs, errs := config.ParseSaturationConfigMap(data)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("invalid saturation scaling config: %w", errors.Join(errs...))
+		}
+		cfg.UpdateSaturationConfig(configs)
+	}
+
+	if qmConfigPath != "" {
+		data, err := readConfigMapData(qmConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		configs, errs := config.ParseQMAnalyzerConfigMap(data)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("invalid queueing model config: %w", errors.Join(errs...))
+		}
+		cfg.UpdateQMAnalyzerConfig(configs)
+	}
+
+	if scaleToZeroConfigPath != "" {
+		data, err := readConfigMapData(scaleToZeroConfigPath)
+		if err != nil {
+			return nil, err
+		}
+		configs, errs := config.ParseScaleToZeroConfigMap(data)
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("invalid scale-to-zero config: %w", errors.Join(errs...))
+		}
+		cfg.UpdateScaleToZeroConfig(configs)
+	}
+
+	return cfg, nil
+}
+
+// readConfigMapData returns the data of a ConfigMap manifest, as written by
+// `kubectl get configmap -o yaml`.
+func readConfigMapData(path string) (map[string]string, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading ConfigMap: %w", err)
+	}
+	var cm struct {
+		Kind string            `yaml:"kind"`
+		Data map[string]string `yaml:"data"`
+	}
+	if err := yaml.Unmarshal(raw, &cm); err != nil {
+		return nil, fmt.Errorf("parsing ConfigMap %s: %w", path, err)
+	}
+	if cm.Kind != "ConfigMap" {
+		return nil, fmt.Errorf("%s is not a ConfigMap manifest (kind %q)", path, cm.Kind)
+	}
+	return cm.Data, nil
+}
@@ -0,0 +1,216 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testTrace = `{
+  "cycles": [
+    {
+      "time": "2025-06-04T12:00:00Z",
+      "gpuLimits": {"A100": 2},
+      "models": [
+        {
+          "modelID": "meta/llama",
+          "namespace": "llm",
+          "requestCount": 100,
+          "replicaMetrics": [
+            {"PodName": "llama-a100-0", "VariantName": "llama-a100", "ModelID": "meta/llama", "Namespace": "llm", "AcceleratorName": "A100", "KvCacheUsage": 0.95, "QueueLength": 8, "Cost": 10},
+            {"PodName": "llama-a100-1", "VariantName": "llama-a100", "ModelID": "meta/llama", "Namespace": "llm", "AcceleratorName": "A100", "KvCacheUsage": 0.92, "QueueLength": 7, "Cost": 10}
+          ],
+          "variantStates": [
+            {"VariantName": "llama-a100", "CurrentReplicas": 2, "DesiredReplicas": 2, "GPUsPerReplica": 1}
+          ]
+        }
+      ]
+    }
+  ]
+}`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func saturationConfigMap(extra string) string {
+	return `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: saturation-scaling-config
+data:
+  default: |
+    kvCacheThreshold: 0.80
+    queueLengthThreshold: 5
+    kvSpareTrigger: 0.1
+    queueSpareTrigger: 3
+    ` + extra + "\n"
+}
+
+func replay(t *testing.T, saturationConfig, format string) string {
+	t.Helper()
+	return replayTrace(t, testTrace, saturationConfig, format)
+}
+
+func replayTrace(t *testing.T, trace, saturationConfig, format string) string {
+	t.Helper()
+	dir := t.TempDir()
+	tracePath := writeFile(t, dir, "trace.json", trace)
+	configPath := writeFile(t, dir, "saturation.yaml", saturationConfig)
+	outputPath := filepath.Join(dir, "out")
+
+	if err := run(tracePath, "", configPath, "", "", outputPath, format); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	out, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestReplayJSON(t *testing.T) {
+	var cycles []cycleRecord
+	if err := json.Unmarshal([]byte(replay(t, saturationConfigMap("enableLimiter: false"), formatJSON)), &cycles); err != nil {
+		t.Fatal(err)
+	}
+	if len(cycles) != 1 || len(cycles[0].Decisions) != 1 {
+		t.Fatalf("Expected one cycle with one decision, got %+v", cycles)
+	}
+	if cycles[0].Analyzer != "v1-saturation" {
+		t.Errorf("Expected v1-saturation analyzer, got %q", cycles[0].Analyzer)
+	}
+	d := cycles[0].Decisions[0]
+	if d.Variant != "llama-a100" || d.CurrentReplicas != 2 || d.TargetReplicas != 3 {
+		t.Errorf("Expected saturated variant to scale from 2 to 3, got %+v", d)
+	}
+}
+
+func TestReplayV2(t *testing.T) {
+	var cycles []cycleRecord
+	if err := json.Unmarshal([]byte(replay(t, saturationConfigMap("analyzerName: saturation"), formatJSON)), &cycles); err != nil {
+		t.Fatal(err)
+	}
+	if len(cycles) != 1 || cycles[0].Analyzer != "saturation" {
+		t.Fatalf("Expected one cycle decided by the saturation analyzer, got %+v", cycles)
+	}
+	if len(cycles[0].Decisions) != 1 || cycles[0].Decisions[0].Action == "" {
+		t.Errorf("Expected one optimizer decision, got %+v", cycles[0].Decisions)
+	}
+}
+
+// testVariantAutoscaling is the VA of the variant of testTrace, in shadow mode
+// and capped to 2 replicas by a schedule active at the time of the cycle.
+const testVariantAutoscaling = `"variantAutoscalings": [{
+            "metadata": {"name": "llama-a100", "namespace": "llm", "annotations": {"wva.llmd.ai/shadow": "true"}},
+            "spec": {
+              "scaleTargetRef": {"kind": "Deployment", "name": "llama-a100"},
+              "modelID": "meta/llama",
+              "schedules": [{"name": "cap", "schedule": "0 11 * * *", "duration": "2h", "maxReplicas": 2}]
+            }
+          }],
+          "replicaMetrics"`
+
+func TestReplayVariantAutoscalings(t *testing.T) {
+	trace := strings.Replace(testTrace, `"replicaMetrics"`, testVariantAutoscaling, 1)
+	var cycles []cycleRecord
+	if err := json.Unmarshal([]byte(replayTrace(t, trace, saturationConfigMap("enableLimiter: false"), formatJSON)), &cycles); err != nil {
+		t.Fatal(err)
+	}
+	if len(cycles) != 1 || len(cycles[0].Decisions) != 1 {
+		t.Fatalf("Expected one cycle with one decision, got %+v", cycles)
+	}
+	d := cycles[0].Decisions[0]
+	if d.CurrentReplicas != 2 || d.TargetReplicas != 2 {
+		t.Errorf("Expected the scale-up to be capped by the VA schedule, got %+v", d)
+	}
+	if !d.Shadow {
+		t.Errorf("Expected the decision of the shadow VA to be marked shadow, got %+v", d)
+	}
+}
+
+func TestReplayCSVWithLimiter(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(replay(t, saturationConfigMap("enableLimiter: true"), formatCSV))).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected header and one decision, got %v", rows)
+	}
+	row := make(map[string]string, len(csvHeader))
+	for i, col := range csvHeader {
+		row[col] = rows[1][i]
+	}
+	if row["targetReplicas"] != "2" || row["limitedBy"] != "gpu-limiter" {
+		t.Errorf("Expected scale-up to be limited by the recorded GPU capacity, got %v", row)
+	}
+	if row["time"] != "2025-06-04T12:00:00Z" {
+		t.Errorf("Expected the recorded cycle time, got %q", row["time"])
+	}
+}
+
+// testRecord is the cycle of testTrace as written by the decision recorder.
+const testRecord = `{"schemaVersion": 1, "time": "2025-06-04T12:00:00Z", "analyzer": "v1-saturation", "models": [{"modelID": "meta/llama", "namespace": "llm", "requestCount": 100, "input": {` +
+	`"replicaMetrics": [` +
+	`{"PodName": "llama-a100-0", "VariantName": "llama-a100", "ModelID": "meta/llama", "Namespace": "llm", "AcceleratorName": "A100", "KvCacheUsage": 0.95, "QueueLength": 8, "Cost": 10},` +
+	`{"PodName": "llama-a100-1", "VariantName": "llama-a100", "ModelID": "meta/llama", "Namespace": "llm", "AcceleratorName": "A100", "KvCacheUsage": 0.92, "QueueLength": 7, "Cost": 10}],` +
+	`"variantStates": [{"VariantName": "llama-a100", "CurrentReplicas": 2, "DesiredReplicas": 2, "GPUsPerReplica": 1}]}}], "decisions": []}`
+
+func TestReplayRecords(t *testing.T) {
+	dir := t.TempDir()
+	recordsPath := writeFile(t, dir, "decisions.jsonl", testRecord+"\n")
+	configPath := writeFile(t, dir, "saturation.yaml", saturationConfigMap("enableLimiter: false"))
+	outputPath := filepath.Join(dir, "out")
+
+	if err := run("", recordsPath, configPath, "", "", outputPath, formatJSON); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	out, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cycles []cycleRecord
+	if err := json.Unmarshal(out, &cycles); err != nil {
+		t.Fatal(err)
+	}
+	if len(cycles) != 1 || len(cycles[0].Decisions) != 1 {
+		t.Fatalf("Expected one cycle with one decision, got %+v", cycles)
+	}
+	if d := cycles[0].Decisions[0]; d.CurrentReplicas != 2 || d.TargetReplicas != 3 {
+		t.Errorf("Expected the recorded saturated variant to scale from 2 to 3, got %+v", d)
+	}
+}
+
+func TestReplayErrors(t *testing.T) {
+	dir := t.TempDir()
+	tracePath := writeFile(t, dir, "trace.json", testTrace)
+	notConfigMap := writeFile(t, dir, "va.yaml", "kind: VariantAutoscaling\n")
+
+	tests := []struct {
+		name                      string
+		trace, records, satConfig string
+		format                    string
+	}{
+		{name: "missing trace", format: formatJSON},
+		{name: "trace and records", trace: tracePath, records: tracePath, format: formatJSON},
+		{name: "records of unknown schema", records: writeFile(t, dir, "v2.jsonl", `{"schemaVersion": 2}`), format: formatJSON},
+		{name: "unknown format", trace: tracePath, format: "xml"},
+		{name: "not a ConfigMap", trace: tracePath, satConfig: notConfigMap, format: formatJSON},
+		{name: "empty trace", trace: writeFile(t, dir, "empty.json", `{"cycles": []}`), format: formatJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := run(tt.trace, tt.records, tt.satConfig, "", "", filepath.Join(dir, "out"), tt.format); err == nil {
+				t.Error("Expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+)
+
+// cycleRecord is the output of one replayed cycle.
+type cycleRecord struct {
+	Time      time.Time        `json:"time"`
+	Analyzer  string           `json:"analyzer"`
+	Decisions []decisionRecord `json:"decisions"`
+}
+
+// decisionRecord is the output of one variant decision.
+type decisionRecord struct {
+	Namespace       string `json:"namespace"`
+	ModelID         string `json:"modelID"`
+	Variant         string `json:"variant"`
+	Accelerator     string `json:"accelerator,omitempty"`
+	Action          string `json:"action"`
+	CurrentReplicas int    `json:"currentReplicas"`
+	TargetReplicas  int    `json:"targetReplicas"`
+	LimitedBy       string `json:"limitedBy,omitempty"`
+	// ConstrainedBy are the pipeline stages that changed the target.
+	ConstrainedBy []string `json:"constrainedBy,omitempty"`
+	Reason        string   `json:"reason,omitempty"`
+	// Shadow is set for variants in shadow mode, whose decisions are not actuated.
+	Shadow bool `json:"shadow,omitempty"`
+}
+
+var csvHeader = []string{
+	"time", "analyzer", "namespace", "modelID", "variant", "accelerator", "action",
+	"currentReplicas", "targetReplicas", "limitedBy", "constrainedBy", "reason", "shadow",
+}
+
+func newDecisionRecord(d interfaces.VariantDecision) decisionRecord {
+	r := decisionRecord{
+		Namespace:       d.Namespace,
+		ModelID:         d.ModelID,
+		Variant:         d.VariantName,
+		Accelerator:     d.AcceleratorName,
+		Action:          string(d.Action),
+		CurrentReplicas: d.CurrentReplicas,
+		TargetReplicas:  d.TargetReplicas,
+		LimitedBy:       d.LimitedBy,
+		Reason:          d.Reason,
+		Shadow:          d.Shadow,
+	}
+	for _, step := range d.DecisionSteps {
+		if step.WasConstrained {
+			r.ConstrainedBy = append(r.ConstrainedBy, step.Name)
+		}
+	}
+	return r
+}
+
+// writeResults writes the replayed decisions in the given format: a JSON array
+// with one element per cycle, or CSV with one row per decision.
+func writeResults(w io.Writer, format string, results []saturation.ReplayResult) error {
+	cycles := make([]cycleRecord, 0, len(results))
+	for _, res := range results {
+		c := cycleRecord{Time: res.Time, Analyzer: res.Analyzer, Decisions: []decisionRecord{}}
+		for _, d := range res.Decisions {
+			c.Decisions = append(c.Decisions, newDecisionRecord(d))
+		}
+		cycles = append(cycles, c)
+	}
+
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(cycles)
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, c := range cycles {
+		for _, d := range c.Decisions {
+			if err := cw.Write([]string{
+				c.Time.Format(time.RFC3339), c.Analyzer, d.Namespace, d.ModelID, d.Variant, d.Accelerator, d.Action,
+				strconv.Itoa(d.CurrentReplicas), strconv.Itoa(d.TargetReplicas), d.LimitedBy,
+				strings.Join(d.ConstrainedBy, ";"), d.Reason, strconv.FormatBool(d.Shadow),
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -6,7 +6,9 @@ import (
 
 	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -72,6 +74,15 @@ func (a *Actuator) EmitMetrics(ctx context.Context, variantAutoscaling *llmdOptv
 		currentReplicas = 0 // Fallback to 0 since CurrentAlloc is removed
 	}
 
+	// In shadow mode, keep external autoscalers at the current replicas and
+	// report the optimization target through the shadow metrics instead
+	if utils.IsShadowMode(ctx, a.Client, variantAutoscaling) {
+		a.EmitShadowMetrics(ctx, variantAutoscaling, currentReplicas, desiredReplicas)
+		desiredReplicas = currentReplicas
+	} else {
+		a.MetricsEmitter.ClearShadowReplicas(variantAutoscaling.Name, variantAutoscaling.Namespace)
+	}
+
 	if err := a.MetricsEmitter.EmitReplicaMetrics(
 		ctx,
 		variantAutoscaling,
@@ -92,3 +103,33 @@ func (a *Actuator) EmitMetrics(ctx context.Context, variantAutoscaling *llmdOptv
 		"accelerator", variantAutoscaling.Status.DesiredOptimizedAlloc.Accelerator)
 	return nil
 }
+
+// EmitShadowMetrics emits the recommendation of a VA in shadow mode, compared
+// to the replicas chosen by the HPA scaling its target if any, or else to the
+// current replicas.
+func (a *Actuator) EmitShadowMetrics(ctx context.Context, va *llmdOptv1alpha1.VariantAutoscaling, currentReplicas, recommended int32) {
+	logger := log.FromContext(ctx)
+
+	baseline, baselineReplicas := metrics.ShadowBaselineCurrent, currentReplicas
+	hpaReplicas, hpaName, found, err := utils.HPADesiredReplicas(ctx, a.Client, va)
+	switch {
+	case err != nil:
+		logger.Error(err, "Could not list HPAs for shadow comparison, comparing to current replicas",
+			"variantName", va.Name)
+	case found:
+		baseline, baselineReplicas = metrics.ShadowBaselineHPA, hpaReplicas
+	}
+
+	if err := a.MetricsEmitter.EmitShadowReplicas(va.Name, va.Namespace, va.Status.DesiredOptimizedAlloc.Accelerator,
+		recommended, baseline, baselineReplicas); err != nil {
+		logger.Error(err, "Failed to emit shadow metrics", "variantName", va.Name)
+		return
+	}
+	logger.V(logging.DEBUG).Info("Shadow mode: not actuating recommendation",
+		"variantName", va.Name,
+		"currentReplicas", currentReplicas,
+		"recommendedReplicas", recommended,
+		"baseline", baseline,
+		"baselineReplicas", baselineReplicas,
+		"hpa", hpaName)
+}
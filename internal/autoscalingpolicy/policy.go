@@ -0,0 +1,296 @@
+// Package autoscalingpolicy converts AutoscalingPolicies and
+// ClusterAutoscalingPolicies into per-model configuration overrides and
+// resolves which policy's sections apply to each model.
+package autoscalingpolicy
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+// Policy is a valid AutoscalingPolicy or ClusterAutoscalingPolicy.
+type Policy struct {
+	// Namespace of an AutoscalingPolicy, empty for a ClusterAutoscalingPolicy.
+	Namespace string
+	Name      string
+	Priority  int32
+	Overrides config.PolicyOverrides
+
+	selector          labels.Selector // nil selects any labels
+	namespaceSelector labels.Selector // nil selects any namespace
+	modelIDs          []string
+}
+
+// Cluster reports whether the policy is a ClusterAutoscalingPolicy.
+func (p *Policy) Cluster() bool {
+	return p.Namespace == ""
+}
+
+// Source returns the configuration layer of the policy, for VA status.
+func (p *Policy) Source() config.ConfigSource {
+	if p.Cluster() {
+		return config.ConfigSource{Layer: config.ConfigLayerClusterAutoscalingPolicy, Name: p.Name}
+	}
+	return config.ConfigSource{Layer: config.ConfigLayerAutoscalingPolicy, Name: p.Name}
+}
+
+// Sections returns the sections the policy sets.
+func (p *Policy) Sections() []string {
+	var sections []string
+	if p.Overrides.Saturation != nil {
+		sections = append(sections, wvav1alpha1.PolicySectionSaturation)
+	}
+	if p.Overrides.QueueingModel != nil {
+		sections = append(sections, wvav1alpha1.PolicySectionQueueingModel)
+	}
+	if p.Overrides.ScaleToZero != nil {
+		sections = append(sections, wvav1alpha1.PolicySectionScaleToZero)
+	}
+	return sections
+}
+
+// Selects reports whether the policy selects a VA whose namespace has the
+// given labels.
+func (p *Policy) Selects(va *wvav1alpha1.VariantAutoscaling, namespaceLabels labels.Set) bool {
+	if p.Cluster() {
+		if p.namespaceSelector != nil && !p.namespaceSelector.Matches(namespaceLabels) {
+			return false
+		}
+	} else if va.Namespace != p.Namespace {
+		return false
+	}
+	if p.selector != nil && !p.selector.Matches(labels.Set(va.Labels)) {
+		return false
+	}
+	return len(p.modelIDs) == 0 || slices.Contains(p.modelIDs, va.Spec.ModelID)
+}
+
+// specificity ranks how narrowly a policy selects VAs: model IDs are more
+// specific than a label selector, which is more specific than selecting all.
+func (p *Policy) specificity() int {
+	s := 0
+	if len(p.modelIDs) > 0 {
+		s += 2
+	}
+	if p.selector != nil {
+		s++
+	}
+	return s
+}
+
+// FromAutoscalingPolicy validates an AutoscalingPolicy and converts it.
+// Saturation overrides are validated merged over base, the global "default"
+// entry of the saturation scaling ConfigMap.
+func FromAutoscalingPolicy(policy *wvav1alpha1.AutoscalingPolicy, base config.SaturationScalingConfig) (*Policy, error) {
+	p, err := fromSpec(&policy.Spec, base)
+	if err != nil {
+		return nil, err
+	}
+	p.Namespace = policy.Namespace
+	p.Name = policy.Name
+	return p, nil
+}
+
+// FromClusterAutoscalingPolicy validates a ClusterAutoscalingPolicy and converts it.
+// Saturation overrides are validated merged over base, the global "default"
+// entry of the saturation scaling ConfigMap.
+func FromClusterAutoscalingPolicy(policy *wvav1alpha1.ClusterAutoscalingPolicy, base config.SaturationScalingConfig) (*Policy, error) {
+	p, err := fromSpec(&policy.Spec.AutoscalingPolicySpec, base)
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+	namespaceSelector, err := labelSelector(policy.Spec.NamespaceSelector)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("namespaceSelector: %w", err))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	p.Name = policy.Name
+	p.namespaceSelector = namespaceSelector
+	return p, nil
+}
+
+func fromSpec(spec *wvav1alpha1.AutoscalingPolicySpec, base config.SaturationScalingConfig) (*Policy, error) {
+	p := &Policy{
+		Priority: spec.Priority,
+		modelIDs: spec.ModelIDs,
+	}
+	var errs []error
+	var err error
+	if p.selector, err = labelSelector(spec.Selector); err != nil {
+		errs = append(errs, fmt.Errorf("selector: %w", err))
+	}
+	if spec.Saturation != nil {
+		if p.Overrides.Saturation, err = saturationOverride(spec.Saturation, base); err != nil {
+			errs = append(errs, fmt.Errorf("saturation: %w", err))
+		}
+	}
+	if spec.QueueingModel != nil {
+		if p.Overrides.QueueingModel, err = queueingModelOverride(spec.QueueingModel); err != nil {
+			errs = append(errs, fmt.Errorf("queueingModel: %w", err))
+		}
+	}
+	if spec.ScaleToZero != nil {
+		if p.Overrides.ScaleToZero, err = scaleToZeroOverride(spec.ScaleToZero); err != nil {
+			errs = append(errs, fmt.Errorf("scaleToZero: %w", err))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return p, nil
+}
+
+// labelSelector converts a label selector; nil stays nil.
+func labelSelector(s *metav1.LabelSelector) (labels.Selector, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return metav1.LabelSelectorAsSelector(s)
+}
+
+func saturationOverride(s *wvav1alpha1.SaturationPolicy, base config.SaturationScalingConfig) (*config.SaturationScalingOverride, error) {
+	o := &config.SaturationScalingOverride{
+		ColdStartLookahead: s.ColdStartLookahead,
+		CompareAnalyzers:   s.CompareAnalyzers,
+	}
+	var errs []error
+	for _, f := range []struct {
+		name  string
+		value *string
+		dst   **float64
+	}{
+		{"kvCacheThreshold", s.KvCacheThreshold, &o.KvCacheThreshold},
+		{"queueLengthThreshold", s.QueueLengthThreshold, &o.QueueLengthThreshold},
+		{"kvSpareTrigger", s.KvSpareTrigger, &o.KvSpareTrigger},
+		{"queueSpareTrigger", s.QueueSpareTrigger, &o.QueueSpareTrigger},
+		{"scaleUpThreshold", s.ScaleUpThreshold, &o.ScaleUpThreshold},
+		{"scaleDownBoundary", s.ScaleDownBoundary, &o.ScaleDownBoundary},
+		{"priority", s.Priority, &o.Priority},
+		{"minOnDemandFraction", s.MinOnDemandFraction, &o.MinOnDemandFraction},
+	} {
+		v, err := parseDecimal(f.name, f.value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		*f.dst = v
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	merged := base
+	merged.ApplyDefaults()
+	o.ApplyTo(&merged)
+	if err := merged.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func queueingModelOverride(q *wvav1alpha1.QueueingModelPolicy) (*interfaces.QueueingModelScalingConfig, error) {
+	o := &interfaces.QueueingModelScalingConfig{
+		TuningEnabled:      q.TuningEnabled,
+		ColdStartLookahead: q.ColdStartLookahead,
+	}
+	var errs []error
+	if v, err := parseDecimal("sloMultiplier", q.SLOMultiplier); err != nil {
+		errs = append(errs, err)
+	} else if v != nil {
+		o.SLOMultiplier = *v
+	}
+	if v, err := parseDecimal("targetTTFT", q.TargetTTFT); err != nil {
+		errs = append(errs, err)
+	} else if v != nil {
+		o.TargetTTFT = float32(*v)
+	}
+	if v, err := parseDecimal("targetITL", q.TargetITL); err != nil {
+		errs = append(errs, err)
+	} else if v != nil {
+		o.TargetITL = float32(*v)
+	}
+	if v, err := parseDecimal("minOnDemandFraction", q.MinOnDemandFraction); err != nil {
+		errs = append(errs, err)
+	} else {
+		o.MinOnDemandFraction = v
+	}
+	if q.RequestClasses != nil {
+		n := int(*q.RequestClasses)
+		o.RequestClasses = &n
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if q.SLOMultiplier != nil && o.SLOMultiplier == 0 {
+		return nil, errors.New("sloMultiplier must be > 1.0, got 0")
+	}
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+func scaleToZeroOverride(s *wvav1alpha1.ScaleToZeroPolicy) (*config.ModelScaleToZeroConfig, error) {
+	o := &config.ModelScaleToZeroConfig{EnableScaleToZero: s.Enabled}
+	var errs []error
+	if s.RetentionPeriod != nil {
+		o.RetentionPeriod = s.RetentionPeriod.Duration.String()
+		if _, err := config.ValidateRetentionPeriod(o.RetentionPeriod); err != nil {
+			errs = append(errs, fmt.Errorf("retentionPeriod: %w", err))
+		}
+	}
+	for _, sched := range s.Schedules {
+		sc := config.ScheduleConfig{
+			Name:              sched.Name,
+			Schedule:          sched.Schedule,
+			TimeZone:          sched.TimeZone,
+			Duration:          sched.Duration.Duration.String(),
+			MinReplicas:       int32PtrToIntPtr(sched.MinReplicas),
+			MaxReplicas:       int32PtrToIntPtr(sched.MaxReplicas),
+			EnableScaleToZero: sched.ScaleToZero,
+			Source:            schedule.SourceAutoscalingPolicy,
+		}
+		if _, err := sc.Rule(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		o.Schedules = append(o.Schedules, sc)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return o, nil
+}
+
+// parseDecimal parses a decimal field of a policy; nil stays nil.
+func parseDecimal(name string, s *string) (*float64, error) {
+	if s == nil {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(*s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid decimal %q", name, *s)
+	}
+	return &v, nil
+}
+
+func int32PtrToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
@@ -0,0 +1,158 @@
+package autoscalingpolicy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+// globalDefault is a typical "default" entry of the saturation scaling ConfigMap.
+var globalDefault = config.SaturationScalingConfig{
+	KvCacheThreshold:     0.8,
+	QueueLengthThreshold: 5,
+	KvSpareTrigger:       0.1,
+	QueueSpareTrigger:    3,
+}
+
+func TestFromAutoscalingPolicy(t *testing.T) {
+	policy := &wvav1alpha1.AutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: "team-a"},
+		Spec: wvav1alpha1.AutoscalingPolicySpec{
+			ModelIDs: []string{"meta/llama"},
+			Priority: 10,
+			Saturation: &wvav1alpha1.SaturationPolicy{
+				KvCacheThreshold: ptr.To("0.9"),
+				Priority:         ptr.To("2"),
+				CompareAnalyzers: []string{"queueing-model"},
+			},
+			QueueingModel: &wvav1alpha1.QueueingModelPolicy{
+				SLOMultiplier:  ptr.To("4"),
+				TargetTTFT:     ptr.To("500"),
+				TargetITL:      ptr.To("20"),
+				RequestClasses: ptr.To(int32(2)),
+			},
+			ScaleToZero: &wvav1alpha1.ScaleToZeroPolicy{
+				Enabled:         ptr.To(true),
+				RetentionPeriod: &metav1.Duration{Duration: 15 * time.Minute},
+				Schedules: []wvav1alpha1.ScalingSchedule{{
+					Name:        "business-hours",
+					Schedule:    "0 8 * * 1-5",
+					Duration:    metav1.Duration{Duration: 10 * time.Hour},
+					MinReplicas: ptr.To(int32(2)),
+				}},
+			},
+		},
+	}
+
+	p, err := FromAutoscalingPolicy(policy, globalDefault)
+	require.NoError(t, err)
+	assert.Equal(t, "team-a", p.Namespace)
+	assert.False(t, p.Cluster())
+	assert.Equal(t, []string{"saturation", "queueingModel", "scaleToZero"}, p.Sections())
+
+	sat := p.Overrides.Saturation
+	require.NotNil(t, sat)
+	assert.Equal(t, 0.9, *sat.KvCacheThreshold)
+	assert.Equal(t, 2.0, *sat.Priority)
+	assert.Nil(t, sat.KvSpareTrigger, "unset fields stay unset")
+
+	qm := p.Overrides.QueueingModel
+	require.NotNil(t, qm)
+	assert.Equal(t, 4.0, qm.SLOMultiplier)
+	assert.InDelta(t, 500, qm.TargetTTFT, 1e-6)
+	assert.Equal(t, 2, *qm.RequestClasses)
+
+	stz := p.Overrides.ScaleToZero
+	require.NotNil(t, stz)
+	assert.Equal(t, "15m0s", stz.RetentionPeriod)
+	require.Len(t, stz.Schedules, 1)
+	rule, err := stz.Schedules[0].Rule()
+	require.NoError(t, err)
+	assert.Equal(t, schedule.SourceAutoscalingPolicy, rule.Source)
+	assert.Equal(t, 2, *rule.MinReplicas)
+}
+
+func TestFromAutoscalingPolicy_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec wvav1alpha1.AutoscalingPolicySpec
+	}{
+		{
+			name: "kv cache threshold below the global spare trigger",
+			spec: wvav1alpha1.AutoscalingPolicySpec{
+				Saturation: &wvav1alpha1.SaturationPolicy{KvCacheThreshold: ptr.To("0.05")},
+			},
+		},
+		{
+			name: "only one SLO target",
+			spec: wvav1alpha1.AutoscalingPolicySpec{
+				QueueingModel: &wvav1alpha1.QueueingModelPolicy{TargetTTFT: ptr.To("500")},
+			},
+		},
+		{
+			name: "SLO multiplier of 1",
+			spec: wvav1alpha1.AutoscalingPolicySpec{
+				QueueingModel: &wvav1alpha1.QueueingModelPolicy{SLOMultiplier: ptr.To("1")},
+			},
+		},
+		{
+			name: "invalid cron expression",
+			spec: wvav1alpha1.AutoscalingPolicySpec{
+				ScaleToZero: &wvav1alpha1.ScaleToZeroPolicy{
+					Schedules: []wvav1alpha1.ScalingSchedule{{
+						Name:     "bad",
+						Schedule: "every monday",
+						Duration: metav1.Duration{Duration: time.Hour},
+					}},
+				},
+			},
+		},
+		{
+			name: "invalid selector",
+			spec: wvav1alpha1.AutoscalingPolicySpec{
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tier", Operator: "Near"},
+				}},
+				ScaleToZero: &wvav1alpha1.ScaleToZeroPolicy{Enabled: ptr.To(true)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := &wvav1alpha1.AutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: "team-a"},
+				Spec:       tt.spec,
+			}
+			_, err := FromAutoscalingPolicy(policy, globalDefault)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFromClusterAutoscalingPolicy(t *testing.T) {
+	policy := &wvav1alpha1.ClusterAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "defaults"},
+		Spec: wvav1alpha1.ClusterAutoscalingPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			AutoscalingPolicySpec: wvav1alpha1.AutoscalingPolicySpec{
+				ScaleToZero: &wvav1alpha1.ScaleToZeroPolicy{Enabled: ptr.To(false)},
+			},
+		},
+	}
+
+	p, err := FromClusterAutoscalingPolicy(policy, globalDefault)
+	require.NoError(t, err)
+	assert.True(t, p.Cluster())
+
+	va := newVA("team-a", "llama-h100", "meta/llama", nil)
+	assert.True(t, p.Selects(va, map[string]string{"env": "prod"}))
+	assert.False(t, p.Selects(va, map[string]string{"env": "dev"}))
+}
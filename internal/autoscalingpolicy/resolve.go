@@ -0,0 +1,166 @@
+package autoscalingpolicy
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+// Result is the resolution of a set of policies over the VAs of the cluster.
+type Result struct {
+	// Overrides holds the sections in effect, keyed by namespace and model ID.
+	Overrides map[string]config.PolicyOverridesPerModel
+	// Targets holds the VAs each policy selects, in the order of the resolved
+	// policies, sorted by namespace and name.
+	Targets [][]wvav1alpha1.PolicyTarget
+	// Errors holds, in the order of the resolved policies, the error of the
+	// saturation sections that are invalid for a model they apply to, or nil.
+	Errors []error
+}
+
+// SaturationValidator validates a saturation override merged over the
+// configuration of a model in a namespace.
+type SaturationValidator func(namespace, modelID string, o *config.SaturationScalingOverride) error
+
+// Compare orders policies by precedence, highest first:
+//  1. AutoscalingPolicies before ClusterAutoscalingPolicies,
+//  2. higher spec.priority first,
+//  3. more specific selection first: modelIDs and a selector, modelIDs only,
+//     a selector only, then neither,
+//  4. namespace and name, alphabetically.
+func Compare(a, b *Policy) int {
+	if a.Cluster() != b.Cluster() {
+		if b.Cluster() {
+			return -1
+		}
+		return 1
+	}
+	return cmp.Or(
+		cmp.Compare(b.Priority, a.Priority),
+		cmp.Compare(b.specificity(), a.specificity()),
+		cmp.Compare(a.Namespace, b.Namespace),
+		cmp.Compare(a.Name, b.Name),
+	)
+}
+
+// Resolve applies policies to VAs. A policy applies to a model in a namespace
+// when it selects at least one of the model's VAs there. Each section of a
+// model's configuration comes from the policy with the highest precedence
+// (see Compare) among those applying to the model that set the section.
+// namespaceLabels holds the labels of the namespaces of the VAs.
+//
+// A saturation section is merged over the configuration of each model it
+// applies to, which can make it invalid although it is valid over the global
+// defaults. When validate is set, a saturation section it rejects for a model
+// is skipped for the model, in favor of the next policy setting the section,
+// and reported in Errors.
+func Resolve(
+	policies []*Policy,
+	vas []wvav1alpha1.VariantAutoscaling,
+	namespaceLabels map[string]labels.Set,
+	validate SaturationValidator,
+) Result {
+	type modelKey struct{ namespace, modelID string }
+
+	// policies selecting each VA, and applying to each model
+	selected := make([][]int, len(vas))
+	applying := make(map[modelKey][]int)
+	for i := range vas {
+		va := &vas[i]
+		key := modelKey{va.Namespace, va.Spec.ModelID}
+		for j, p := range policies {
+			if !p.Selects(va, namespaceLabels[va.Namespace]) {
+				continue
+			}
+			selected[i] = append(selected[i], j)
+			if !slices.Contains(applying[key], j) {
+				applying[key] = append(applying[key], j)
+			}
+		}
+	}
+
+	result := Result{
+		Overrides: make(map[string]config.PolicyOverridesPerModel),
+		Targets:   make([][]wvav1alpha1.PolicyTarget, len(policies)),
+		Errors:    make([]error, len(policies)),
+	}
+	// models in a stable order, for the order of the errors
+	keys := make([]modelKey, 0, len(applying))
+	for key := range applying {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b modelKey) int {
+		return cmp.Or(cmp.Compare(a.namespace, b.namespace), cmp.Compare(a.modelID, b.modelID))
+	})
+	errs := make([][]error, len(policies))
+	// winner of each section of each model, by policy index
+	winners := make(map[modelKey]map[string]int, len(applying))
+	for _, key := range keys {
+		indexes := applying[key]
+		slices.SortFunc(indexes, func(a, b int) int { return Compare(policies[a], policies[b]) })
+		var overrides config.PolicyOverrides
+		sectionWinners := make(map[string]int)
+		for _, j := range indexes {
+			o := policies[j].Overrides
+			if overrides.Saturation == nil && o.Saturation != nil && validate != nil {
+				if err := validate(key.namespace, key.modelID, o.Saturation); err != nil {
+					errs[j] = append(errs[j], fmt.Errorf("saturation of model %q in namespace %s: %w", key.modelID, key.namespace, err))
+					o.Saturation = nil
+				}
+			}
+			if overrides.Saturation == nil && o.Saturation != nil {
+				overrides.Saturation = o.Saturation
+				overrides.SaturationSource = policies[j].Source()
+				sectionWinners[wvav1alpha1.PolicySectionSaturation] = j
+			}
+			if overrides.QueueingModel == nil && o.QueueingModel != nil {
+				overrides.QueueingModel = o.QueueingModel
+				overrides.QueueingModelSource = policies[j].Source()
+				sectionWinners[wvav1alpha1.PolicySectionQueueingModel] = j
+			}
+			if overrides.ScaleToZero == nil && o.ScaleToZero != nil {
+				overrides.ScaleToZero = o.ScaleToZero
+				overrides.ScaleToZeroSource = policies[j].Source()
+				sectionWinners[wvav1alpha1.PolicySectionScaleToZero] = j
+			}
+		}
+		if result.Overrides[key.namespace] == nil {
+			result.Overrides[key.namespace] = make(config.PolicyOverridesPerModel)
+		}
+		result.Overrides[key.namespace][key.modelID] = overrides
+		winners[key] = sectionWinners
+	}
+	for j := range errs {
+		result.Errors[j] = errors.Join(errs[j]...)
+	}
+
+	for i := range vas {
+		va := &vas[i]
+		key := modelKey{va.Namespace, va.Spec.ModelID}
+		for _, j := range selected[i] {
+			target := wvav1alpha1.PolicyTarget{
+				Namespace: va.Namespace,
+				Name:      va.Name,
+				ModelID:   va.Spec.ModelID,
+			}
+			for _, section := range policies[j].Sections() {
+				if winners[key][section] == j {
+					target.Sections = append(target.Sections, section)
+				}
+			}
+			result.Targets[j] = append(result.Targets[j], target)
+		}
+	}
+	for _, targets := range result.Targets {
+		slices.SortFunc(targets, func(a, b wvav1alpha1.PolicyTarget) int {
+			return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+		})
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package autoscalingpolicy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+func newVA(namespace, name, modelID string, vaLabels map[string]string) *wvav1alpha1.VariantAutoscaling {
+	return &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: vaLabels},
+		Spec:       wvav1alpha1.VariantAutoscalingSpec{ModelID: modelID},
+	}
+}
+
+func mustPolicy(t *testing.T, namespace, name string, spec wvav1alpha1.AutoscalingPolicySpec) *Policy {
+	t.Helper()
+	var (
+		p   *Policy
+		err error
+	)
+	if namespace == "" {
+		p, err = FromClusterAutoscalingPolicy(&wvav1alpha1.ClusterAutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       wvav1alpha1.ClusterAutoscalingPolicySpec{AutoscalingPolicySpec: spec},
+		}, globalDefault)
+	} else {
+		p, err = FromAutoscalingPolicy(&wvav1alpha1.AutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       spec,
+		}, globalDefault)
+	}
+	require.NoError(t, err)
+	return p
+}
+
+func scaleToZero(enabled bool) *wvav1alpha1.ScaleToZeroPolicy {
+	return &wvav1alpha1.ScaleToZeroPolicy{Enabled: ptr.To(enabled)}
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	clusterDefault := mustPolicy(t, "", "cluster-default", wvav1alpha1.AutoscalingPolicySpec{
+		Priority:    100,
+		Saturation:  &wvav1alpha1.SaturationPolicy{KvCacheThreshold: ptr.To("0.7")},
+		ScaleToZero: scaleToZero(true),
+	})
+	namespaceDefault := mustPolicy(t, "team-a", "all", wvav1alpha1.AutoscalingPolicySpec{
+		ScaleToZero: scaleToZero(false),
+	})
+	byLabel := mustPolicy(t, "team-a", "batch", wvav1alpha1.AutoscalingPolicySpec{
+		Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "batch"}},
+		ScaleToZero: scaleToZero(true),
+	})
+	byModel := mustPolicy(t, "team-a", "llama", wvav1alpha1.AutoscalingPolicySpec{
+		ModelIDs:    []string{"meta/llama"},
+		ScaleToZero: scaleToZero(false),
+	})
+	policies := []*Policy{clusterDefault, namespaceDefault, byLabel, byModel}
+	vas := []wvav1alpha1.VariantAutoscaling{
+		*newVA("team-a", "llama-h100", "meta/llama", map[string]string{"tier": "batch"}),
+		*newVA("team-a", "mistral-l4", "mistral/mistral", map[string]string{"tier": "batch"}),
+		*newVA("team-a", "granite-l4", "ibm/granite", nil),
+		*newVA("team-b", "granite-l4", "ibm/granite", nil),
+	}
+
+	result := Resolve(policies, vas, nil, nil)
+
+	teamA := result.Overrides["team-a"]
+	assert.False(t, *teamA["meta/llama"].ScaleToZero.EnableScaleToZero, "model IDs are more specific than a selector")
+	assert.True(t, *teamA["mistral/mistral"].ScaleToZero.EnableScaleToZero, "a selector is more specific than selecting all")
+	assert.False(t, *teamA["ibm/granite"].ScaleToZero.EnableScaleToZero, "namespaced policies take precedence over cluster policies")
+	assert.True(t, *result.Overrides["team-b"]["ibm/granite"].ScaleToZero.EnableScaleToZero)
+	assert.Equal(t, 0.7, *teamA["ibm/granite"].Saturation.KvCacheThreshold, "sections are resolved independently")
+	assert.Equal(t, config.ConfigSource{Layer: config.ConfigLayerClusterAutoscalingPolicy, Name: "cluster-default"},
+		teamA["ibm/granite"].SaturationSource)
+	assert.Equal(t, config.ConfigSource{Layer: config.ConfigLayerAutoscalingPolicy, Name: "all"},
+		teamA["ibm/granite"].ScaleToZeroSource)
+
+	assert.Equal(t, []wvav1alpha1.PolicyTarget{
+		{Namespace: "team-a", Name: "granite-l4", ModelID: "ibm/granite", Sections: []string{"saturation"}},
+		{Namespace: "team-a", Name: "llama-h100", ModelID: "meta/llama", Sections: []string{"saturation"}},
+		{Namespace: "team-a", Name: "mistral-l4", ModelID: "mistral/mistral", Sections: []string{"saturation"}},
+		{Namespace: "team-b", Name: "granite-l4", ModelID: "ibm/granite", Sections: []string{"saturation", "scaleToZero"}},
+	}, result.Targets[0])
+	assert.Equal(t, []wvav1alpha1.PolicyTarget{
+		{Namespace: "team-a", Name: "llama-h100", ModelID: "meta/llama", Sections: []string{"scaleToZero"}},
+	}, result.Targets[3])
+	assert.Len(t, result.Targets[2], 2)
+	assert.Empty(t, result.Targets[2][0].Sections, "overridden for meta/llama")
+}
+
+func TestResolve_Priority(t *testing.T) {
+	low := mustPolicy(t, "team-a", "a-low", wvav1alpha1.AutoscalingPolicySpec{
+		ModelIDs:    []string{"meta/llama"},
+		ScaleToZero: scaleToZero(true),
+	})
+	high := mustPolicy(t, "team-a", "b-high", wvav1alpha1.AutoscalingPolicySpec{
+		Priority:    1,
+		ScaleToZero: scaleToZero(false),
+	})
+	vas := []wvav1alpha1.VariantAutoscaling{*newVA("team-a", "llama-h100", "meta/llama", nil)}
+
+	result := Resolve([]*Policy{low, high}, vas, nil, nil)
+	assert.False(t, *result.Overrides["team-a"]["meta/llama"].ScaleToZero.EnableScaleToZero,
+		"priority takes precedence over specificity")
+}
+
+func TestResolve_ModelSelectedByOneVariant(t *testing.T) {
+	spot := mustPolicy(t, "team-a", "spot", wvav1alpha1.AutoscalingPolicySpec{
+		Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"pricing": "spot"}},
+		ScaleToZero: scaleToZero(true),
+	})
+	vas := []wvav1alpha1.VariantAutoscaling{
+		*newVA("team-a", "llama-h100", "meta/llama", nil),
+		*newVA("team-a", "llama-l4-spot", "meta/llama", map[string]string{"pricing": "spot"}),
+	}
+
+	result := Resolve([]*Policy{spot}, vas, map[string]labels.Set{}, nil)
+	require.Contains(t, result.Overrides["team-a"], "meta/llama", "a policy applies to the model of the VAs it selects")
+	assert.Len(t, result.Targets[0], 1)
+	assert.Equal(t, "llama-l4-spot", result.Targets[0][0].Name)
+}
+
+func TestResolve_InvalidSaturationForModel(t *testing.T) {
+	cluster := mustPolicy(t, "", "cluster-default", wvav1alpha1.AutoscalingPolicySpec{
+		Saturation: &wvav1alpha1.SaturationPolicy{KvCacheThreshold: ptr.To("0.9")},
+	})
+	spare := mustPolicy(t, "team-a", "spare", wvav1alpha1.AutoscalingPolicySpec{
+		Saturation: &wvav1alpha1.SaturationPolicy{KvSpareTrigger: ptr.To("0.75")},
+	})
+	vas := []wvav1alpha1.VariantAutoscaling{
+		*newVA("team-a", "llama-h100", "meta/llama", nil),
+		*newVA("team-a", "granite-l4", "ibm/granite", nil),
+	}
+	// meta/llama has a ConfigMap entry with kvCacheThreshold 0.7
+	validate := func(namespace, modelID string, o *config.SaturationScalingOverride) error {
+		if modelID == "meta/llama" && o.KvSpareTrigger != nil && *o.KvSpareTrigger > 0.7 {
+			return errors.New("kvCacheThreshold (0.70) should be >= kvSpareTrigger (0.75)")
+		}
+		return nil
+	}
+
+	result := Resolve([]*Policy{spare, cluster}, vas, nil, validate)
+
+	teamA := result.Overrides["team-a"]
+	assert.Equal(t, 0.9, *teamA["meta/llama"].Saturation.KvCacheThreshold, "the next policy setting the section applies")
+	assert.Equal(t, 0.75, *teamA["ibm/granite"].Saturation.KvSpareTrigger)
+	require.Error(t, result.Errors[0])
+	assert.Contains(t, result.Errors[0].Error(), `model "meta/llama" in namespace team-a`)
+	assert.NoError(t, result.Errors[1])
+	assert.Equal(t, []wvav1alpha1.PolicyTarget{
+		{Namespace: "team-a", Name: "granite-l4", ModelID: "ibm/granite", Sections: []string{"saturation"}},
+		{Namespace: "team-a", Name: "llama-h100", ModelID: "meta/llama"},
+	}, result.Targets[0])
+}
@@ -20,6 +20,14 @@ const (
 	// QueryAvgITL is the query name for average inter-token latency per pod (in seconds).
 	// Source: vllm:time_per_output_token_seconds histogram
 	QueryAvgITL = "avg_itl"
+
+	// QueryInputTokensHistogram is the query name for the histogram of input tokens per request, per pod.
+	// Source: vllm:request_prompt_tokens histogram
+	QueryInputTokensHistogram = "input_tokens_histogram"
+
+	// QueryOutputTokensHistogram is the query name for the histogram of output tokens per request, per pod.
+	// Source: vllm:request_generation_tokens histogram
+	QueryOutputTokensHistogram = "output_tokens_histogram"
 )
 
 // RegisterQueueingModelQueries registers queries used by the queueing model analyzer.
@@ -69,6 +77,27 @@ func RegisterQueueingModelQueries(sourceRegistry *source.SourceRegistry) {
 			"used by queueing model tuner for parameter learning",
 	})
 
+	// Histograms of input and output tokens per request, per pod (requests/sec per bucket).
+	// Uses the same 5m window as the average input/output token queries, so the
+	// request classes split the same traffic that the averages summarize.
+	// Used by queueing model analyzer to split the workload into request classes.
+	registry.MustRegister(source.QueryTemplate{
+		Name:     QueryInputTokensHistogram,
+		Type:     source.QueryTypePromQL,
+		Template: `sum by (pod, le) (rate(vllm:request_prompt_tokens_bucket{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:   []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Histogram of input tokens per request per pod (5m rate), " +
+			"used by queueing model analyzer to form request classes",
+	})
+	registry.MustRegister(source.QueryTemplate{
+		Name:     QueryOutputTokensHistogram,
+		Type:     source.QueryTypePromQL,
+		Template: `sum by (pod, le) (rate(vllm:request_generation_tokens_bucket{namespace="{{.namespace}}",model_name="{{.modelID}}"}[5m]))`,
+		Params:   []string{source.ParamNamespace, source.ParamModelID},
+		Description: "Histogram of output tokens per request per pod (5m rate), " +
+			"used by queueing model analyzer to form request classes",
+	})
+
 	// Note: MaxBatchSize (max_num_seqs) is not available as a Prometheus metric from vLLM.
 	// It is sourced from the Deployment's container args using the deployment parser
 	// (see saturation_v2.ParseVLLMArgs). The collector populates ReplicaMetrics.MaxBatchSize
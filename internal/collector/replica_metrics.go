@@ -24,9 +24,11 @@ limitations under the License.
 package collector
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"time"
 
@@ -36,6 +38,7 @@ import (
 	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
@@ -50,14 +53,17 @@ type ReplicaMetricsCollector struct {
 	source      source.MetricsSource
 	k8sClient   client.Client
 	podVAMapper *source.PodVAMapper
+	freshness   config.FreshnessThresholds
 }
 
-// NewReplicaMetricsCollector creates a new replica metrics collector.
-func NewReplicaMetricsCollector(metricsSource source.MetricsSource, k8sClient client.Client) *ReplicaMetricsCollector {
+// NewReplicaMetricsCollector creates a new replica metrics collector. The
+// freshness of the collected metrics is determined by freshness.
+func NewReplicaMetricsCollector(metricsSource source.MetricsSource, k8sClient client.Client, freshness config.FreshnessThresholds) *ReplicaMetricsCollector {
 	return &ReplicaMetricsCollector{
 		source:      metricsSource,
 		k8sClient:   k8sClient,
 		podVAMapper: source.NewPodVAMapper(k8sClient),
+		freshness:   freshness,
 	}
 }
 
@@ -98,7 +104,7 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 	// Refresh all Prometheus-sourced queries:
 	// - Saturation: KV cache, queue length, cache config, prefix cache hit rate
 	// - Shared (saturation + queueing model): avg input tokens, avg output tokens
-	// - Queueing model: scheduler dispatch rate, avg TTFT, avg ITL
+	// - Queueing model: scheduler dispatch rate, avg TTFT, avg ITL, input/output token histograms
 	queries := []string{
 		registration.QueryKvCacheUsage,
 		registration.QueryQueueLength,
@@ -109,6 +115,8 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		registration.QuerySchedulerDispatchRate,
 		registration.QueryAvgTTFT,
 		registration.QueryAvgITL,
+		registration.QueryInputTokensHistogram,
+		registration.QueryOutputTokensHistogram,
 	}
 
 	results, err := c.source.Refresh(ctx, source.RefreshSpec{
@@ -139,6 +147,8 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		hasArrivalRate bool
 		avgTTFT        float64
 		avgITL         float64
+		inputTokens    []interfaces.HistogramBucket
+		outputTokens   []interfaces.HistogramBucket
 	}
 
 	// Extract per-pod metrics from results
@@ -391,14 +401,46 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 		}
 	}
 
-	// Pre-compute MaxBatchSize per scale target from container args.
-	// MaxBatchSize (--max-num-seqs) is not a Prometheus metric; it is parsed
+	// Process input token histogram results (one value per pod and bucket)
+	if result := results[registration.QueryInputTokensHistogram]; result != nil {
+		if !result.HasError() {
+			for _, value := range result.Values {
+				podName, bucket, ok := histogramBucket(value)
+				if !ok {
+					continue
+				}
+				if podData[podName] == nil {
+					podData[podName] = &podMetricData{}
+				}
+				podData[podName].inputTokens = append(podData[podName].inputTokens, bucket)
+			}
+		}
+	}
+
+	// Process output token histogram results (one value per pod and bucket)
+	if result := results[registration.QueryOutputTokensHistogram]; result != nil {
+		if !result.HasError() {
+			for _, value := range result.Values {
+				podName, bucket, ok := histogramBucket(value)
+				if !ok {
+					continue
+				}
+				if podData[podName] == nil {
+					podData[podName] = &podMetricData{}
+				}
+				podData[podName].outputTokens = append(podData[podName].outputTokens, bucket)
+			}
+		}
+	}
+
+	// Pre-compute vLLM engine parameters per scale target from container args.
+	// MaxBatchSize (--max-num-seqs) and the per-step token budget
+	// (--max-num-batched-tokens) are not Prometheus metrics; they are parsed
 	// from the Deployment/LWS spec using the vLLM argument parser.
 	// Map key is scale target key (namespace/name).
-	scaleTargetMaxBatchSize := make(map[string]int64, len(scaleTargets))
+	scaleTargetParams := make(map[string]saturation_v2.VLLMEngineParams, len(scaleTargets))
 	for key, scaleTarget := range scaleTargets {
-		params := saturation_v2.ParseVLLMArgs(scaleTarget)
-		scaleTargetMaxBatchSize[key] = params.MaxNumSeqs
+		scaleTargetParams[key] = saturation_v2.ParseVLLMArgs(scaleTarget)
 	}
 
 	// Build replica metrics from pod data
@@ -481,12 +523,13 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			tokensInUse = int64(rounded)
 		}
 
-		// Look up MaxBatchSize from the scale target's vLLM args via the VA's ScaleTargetRef
-		var maxBatchSize int64
+		// Look up MaxBatchSize and the token budget from the scale target's vLLM args via the VA's ScaleTargetRef
+		var maxBatchSize, maxNumBatchedTokens int64
 		if va, ok := variantAutoscalings[variantKey]; ok && va != nil {
 			key := utils.GetNamespacedKey(namespace, va.Spec.ScaleTargetRef.Name)
-			if mbs, ok := scaleTargetMaxBatchSize[key]; ok {
-				maxBatchSize = mbs
+			if params, ok := scaleTargetParams[key]; ok {
+				maxBatchSize = params.MaxNumSeqs
+				maxNumBatchedTokens = params.EffectiveMaxBatchedTokens
 			}
 		}
 
@@ -494,6 +537,15 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			logger.Info("Pod has vLLM metrics but no dispatch rate — possible pod/pod_name label mismatch", "pod", podName, "model", modelID, "namespace", namespace)
 		}
 
+		// The age of the metrics is that of the oldest sample of the KV cache
+		// and queue metrics; samples without a timestamp are considered fresh
+		var age time.Duration
+		for _, ts := range []time.Time{data.kvTimestamp, data.queueTimestamp} {
+			if !ts.IsZero() {
+				age = max(age, collectedAt.Sub(ts))
+			}
+		}
+
 		metric := interfaces.ReplicaMetrics{
 			PodName:               podName,
 			ModelID:               modelID,
@@ -512,12 +564,15 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 			PrefixCacheHitRate:    data.prefixCacheHitRate,
 			ArrivalRate:           data.arrivalRate,
 			MaxBatchSize:          maxBatchSize,
+			MaxNumBatchedTokens:   maxNumBatchedTokens,
 			AvgTTFT:               data.avgTTFT,
 			AvgITL:                data.avgITL,
+			InputTokensHistogram:  sortedBuckets(data.inputTokens),
+			OutputTokensHistogram: sortedBuckets(data.outputTokens),
 			Metadata: &interfaces.ReplicaMetricsMetadata{
 				CollectedAt:     collectedAt,
-				Age:             0, // Fresh
-				FreshnessStatus: "fresh",
+				Age:             age,
+				FreshnessStatus: c.freshness.DetermineStatus(age),
 			},
 		}
 
@@ -532,6 +587,29 @@ func (c *ReplicaMetricsCollector) CollectReplicaMetrics(
 	return replicaMetrics, nil
 }
 
+// histogramBucket returns the pod and the bucket of a histogram query value,
+// and false if the value has no pod, no valid "le" label or no valid rate.
+func histogramBucket(value source.MetricValue) (string, interfaces.HistogramBucket, bool) {
+	podName := value.Labels["pod"]
+	if podName == "" {
+		podName = value.Labels["pod_name"]
+	}
+	upperBound, err := strconv.ParseFloat(value.Labels["le"], 64)
+	// NaN check: rate can produce NaN with no samples
+	if podName == "" || err != nil || math.IsNaN(value.Value) || math.IsInf(value.Value, 0) || value.Value < 0 {
+		return "", interfaces.HistogramBucket{}, false
+	}
+	return podName, interfaces.HistogramBucket{UpperBound: upperBound, Rate: value.Value}, true
+}
+
+// sortedBuckets sorts histogram buckets by upper bound.
+func sortedBuckets(buckets []interfaces.HistogramBucket) []interfaces.HistogramBucket {
+	slices.SortFunc(buckets, func(a, b interfaces.HistogramBucket) int {
+		return cmp.Compare(a.UpperBound, b.UpperBound)
+	})
+	return buckets
+}
+
 // CollectSchedulerQueueMetrics collects model-level queue metrics from the
 // llm-d inference scheduler flow control layer. These metrics are not per-pod
 // but per-model, representing requests queued upstream before reaching vLLM.
@@ -2,6 +2,8 @@ package source
 
 import (
 	"context"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -72,6 +74,43 @@ func (c *Cache) Set(key CacheKey, data MetricResult, ttl time.Duration) {
 	c.cache[key] = cached
 }
 
+// CacheEntry describes the freshness of one cached query result.
+type CacheEntry struct {
+	Key      CacheKey
+	CachedAt time.Time
+	TTL      time.Duration
+	Expired  bool
+	// OldestSample is the timestamp of the oldest value in the result, zero
+	// for results without values.
+	OldestSample time.Time
+	Values       int
+	Error        string
+}
+
+// Entries returns the freshness of all cached results, including expired
+// ones not yet cleaned up, sorted by key.
+func (c *Cache) Entries() []CacheEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entries := make([]CacheEntry, 0, len(c.cache))
+	for key, value := range c.cache {
+		entry := CacheEntry{
+			Key:          key,
+			CachedAt:     value.CachedAt,
+			TTL:          value.TTL,
+			Expired:      value.IsExpired(),
+			OldestSample: value.Result.OldestTimestamp(),
+			Values:       len(value.Result.Values),
+		}
+		if value.Result.Error != nil {
+			entry.Error = value.Result.Error.Error()
+		}
+		entries = append(entries, entry)
+	}
+	slices.SortFunc(entries, func(a, b CacheEntry) int { return strings.Compare(string(a.Key), string(b.Key)) })
+	return entries
+}
+
 // startCleanup runs a background goroutine to periodically clean up expired entries
 func (c *Cache) startCleanup(ctx context.Context) {
 	ticker := time.NewTicker(c.cleanupInterval)
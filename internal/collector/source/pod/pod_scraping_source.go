@@ -24,6 +24,7 @@ import (
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 )
 
 // PodScrapingSource implements MetricsSource for direct pod scraping.
@@ -103,7 +104,10 @@ func (p *PodScrapingSource) QueryList() *source.QueryList {
 
 // Refresh executes queries and updates the cache.
 // Called by engine/reconciler on-demand.
-func (p *PodScrapingSource) Refresh(ctx context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+func (p *PodScrapingSource) Refresh(ctx context.Context, spec source.RefreshSpec) (_ map[string]*source.MetricResult, err error) {
+	ctx, span := tracing.Start(ctx, "PodScrapingSource.Refresh", spec.SpanAttributes()...)
+	defer func() { tracing.End(span, err) }()
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -160,6 +164,11 @@ func (p *PodScrapingSource) Get(queryName string, params map[string]string) *sou
 	return cached
 }
 
+// CacheEntries returns the freshness of all cached results.
+func (p *PodScrapingSource) CacheEntries() []source.CacheEntry {
+	return p.cache.Entries()
+}
+
 // discoverPods finds all Ready pods for the service.
 func (p *PodScrapingSource) discoverPods(ctx context.Context) ([]*corev1.Pod, error) {
 	// Get Service
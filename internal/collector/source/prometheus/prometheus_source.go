@@ -13,10 +13,13 @@ import (
 
 	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
 	"github.com/prometheus/common/model"
+	"go.opentelemetry.io/otel/attribute"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 )
 
@@ -65,6 +68,9 @@ func (p *PrometheusSource) QueryList() *source.QueryList {
 // Refresh executes queries and updates the cache.
 // If spec.Queries is empty, refreshes all registered queries for this source.
 func (p *PrometheusSource) Refresh(ctx context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	ctx, span := tracing.Start(ctx, "PrometheusSource.Refresh", spec.SpanAttributes()...)
+	defer span.End()
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -142,7 +148,13 @@ func (p *PrometheusSource) executeQuery(ctx context.Context, queryName string, p
 	}
 
 	// Execute query with backoff
+	queryCtx, span := tracing.Start(queryCtx, "PrometheusSource.query", attribute.String("wva.query", queryName))
+	queryStart := time.Now()
 	val, warnings, err := utils.QueryPrometheusWithBackoff(queryCtx, p.api, queryStr)
+	tracing.End(span, err)
+	if emitErr := metrics.NewMetricsEmitter().ObserveQueryDuration("prometheus", queryName, time.Since(queryStart)); emitErr != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit query duration metric", "error", emitErr)
+	}
 	if err != nil {
 		return &source.MetricResult{
 			QueryName:   queryName,
@@ -269,6 +281,11 @@ func (p *PrometheusSource) Get(queryName string, params map[string]string) *sour
 	return cached
 }
 
+// CacheEntries returns the freshness of all cached results.
+func (p *PrometheusSource) CacheEntries() []source.CacheEntry {
+	return p.cache.Entries()
+}
+
 // MustGet retrieves a cached result or refreshes if expired.
 // This is a convenience method for cases where you always want a result.
 func (p *PrometheusSource) MustGet(ctx context.Context, queryName string, params map[string]string) *source.MetricResult {
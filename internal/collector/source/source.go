@@ -7,6 +7,10 @@ package source
 import (
 	"context"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 )
 
 // MetricsSource defines the interface for a metrics collection source.
@@ -28,6 +32,13 @@ type MetricsSource interface {
 	Get(queryName string, params map[string]string) *CachedValue
 }
 
+// CacheInspector is implemented by sources that cache query results, to
+// report the freshness of their cache.
+type CacheInspector interface {
+	// CacheEntries returns the freshness of all cached results.
+	CacheEntries() []CacheEntry
+}
+
 // MetricValue represents a single metric value with its metadata.
 type MetricValue struct {
 	// Value is the metric value (scalar).
@@ -128,3 +139,16 @@ type RefreshSpec struct {
 	// Params are the parameters to use for query building.
 	Params map[string]string
 }
+
+// SpanAttributes returns the attributes of a refresh span: the model and
+// namespace parameters, if any, and the number of queries.
+func (s RefreshSpec) SpanAttributes() []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.Int("wva.queries", len(s.Queries))}
+	if namespace, ok := s.Params[ParamNamespace]; ok {
+		attrs = append(attrs, tracing.NamespaceKey.String(namespace))
+	}
+	if modelID, ok := s.Params[ParamModelID]; ok {
+		attrs = append(attrs, tracing.ModelIDKey.String(modelID))
+	}
+	return attrs
+}
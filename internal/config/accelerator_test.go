@@ -0,0 +1,30 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestNormalizeAcceleratorName(t *testing.T) {
+	tests := []struct {
+		name     string
+		fullName string
+		want     string
+	}{
+		{"NVIDIA A100", "NVIDIA-A100-PCIE-80GB", "A100"},
+		{"NVIDIA H100", "NVIDIA-H100-SXM5-80GB", "H100"},
+		{"NVIDIA L40S", "NVIDIA-L40S-48GB", "L40S"},
+		{"AMD MI300X", "AMD-MI300X-192G", "MI300X"},
+		{"Intel Gaudi 2", "Intel-Gaudi-2-96GB", "Gaudi-2"},
+		{"already short - A100", "A100", "A100"},
+		{"already short - H100", "H100", "H100"},
+		{"lowercase nvidia", "nvidia-A100-PCIE-80GB", "A100"},
+		{"unknown vendor fallback", "Unknown-GPU-Model-123", "GPU"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeAcceleratorName(tt.fullName); got != tt.want {
+				t.Errorf("NormalizeAcceleratorName(%q) = %q, want %q", tt.fullName, got, tt.want)
+			}
+		})
+	}
+}
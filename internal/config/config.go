@@ -2,6 +2,7 @@ package config
 
 import (
 	"maps"
+	"slices"
 	"sync"
 	"time"
 
@@ -21,9 +22,17 @@ type Config struct {
 	prometheus     prometheusConfig
 	// epp            eppConfig
 	features    featureFlagsConfig
-	saturation  saturationConfig  // namespace-aware
-	qmAnalyzer  qmAnalyzerConfig  // namespace-aware
-	scaleToZero scaleToZeroConfig // namespace-aware
+	recorder    DecisionRecorderConfig
+	tracing     TracingConfig
+	external    ExternalMetricsConfig
+	kedaScaler  KedaScalerConfig
+	federation  FederationConfig
+	saturation  saturationConfig   // namespace-aware
+	qmAnalyzer  qmAnalyzerConfig   // namespace-aware
+	scaleToZero scaleToZeroConfig  // namespace-aware
+	prices      priceCatalogConfig // namespace-aware
+	perfData    perfDataConfig     // namespace-aware
+	policies    policyConfig       // namespace-aware
 
 }
 
@@ -73,6 +82,124 @@ type featureFlagsConfig struct {
 	scaleFromZeroMaxConcurrency int
 }
 
+// Sinks of the decision recorder.
+const (
+	DecisionRecorderSinkFile      = "file"
+	DecisionRecorderSinkConfigMap = "configmap"
+)
+
+// DecisionRecorderConfig configures the recorder of the saturation engine's
+// optimization cycles (inputs, analyzer results, optimizer requests and
+// decisions). Recording is disabled when Sink is empty.
+type DecisionRecorderConfig struct {
+	// Sink is where records are written: "file", "configmap" or empty.
+	Sink string
+	// Path is the JSON lines file written by the file sink.
+	Path string
+	// MaxBytes is the size at which the file sink rotates the file.
+	MaxBytes int64
+	// MaxFiles is the number of rotated files the file sink keeps.
+	MaxFiles int
+	// ConfigMapName is the ConfigMap in the controller's namespace written by
+	// the configmap sink.
+	ConfigMapName string
+	// MaxRecords is the number of cycles the configmap sink keeps.
+	MaxRecords int
+}
+
+// Exporters of the OpenTelemetry traces.
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// TracingConfig configures the OpenTelemetry traces of the optimization cycle
+// and the reconciler. Tracing is disabled when Exporter is empty.
+type TracingConfig struct {
+	// Exporter is where spans are exported: "otlp", "stdout" or empty.
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector. When empty, the
+	// OTEL_EXPORTER_OTLP_* environment variables apply.
+	OTLPEndpoint string
+	// OTLPInsecure disables TLS to the OTLP collector.
+	OTLPInsecure bool
+	// SampleRatio is the fraction of traces sampled, in [0, 1].
+	SampleRatio float64
+}
+
+// ExternalMetricsConfig configures the server of the external.metrics.k8s.io
+// API, which answers HPA queries for the desired replicas without
+// prometheus-adapter. The server is disabled when BindAddress is empty.
+type ExternalMetricsConfig struct {
+	// BindAddress is the address the server listens on, e.g. ":6443".
+	BindAddress string
+	// CertPath is the directory of the serving certificate. When empty, a
+	// self-signed certificate is generated.
+	CertPath string
+	// CertName is the name of the certificate file in CertPath.
+	CertName string
+	// CertKey is the name of the key file in CertPath.
+	CertKey string
+}
+
+// KedaScalerConfig configures the KEDA external scaler gRPC server, which lets
+// ScaledObjects scale on the desired replicas without a Prometheus trigger.
+// The server is disabled when BindAddress is empty.
+type KedaScalerConfig struct {
+	// BindAddress is the address the server listens on, e.g. ":9000".
+	BindAddress string
+	// CertPath is the directory of the serving certificate. Required unless
+	// Insecure is set.
+	CertPath string
+	// CertName is the name of the certificate file in CertPath.
+	CertName string
+	// CertKey is the name of the key file in CertPath.
+	CertKey string
+	// ClientCACertPath is the CA bundle that verifies the client certificates
+	// of KEDA. Required unless Insecure is set.
+	ClientCACertPath string
+	// Insecure serves plaintext gRPC without client authentication when no
+	// certificate is configured.
+	Insecure bool
+}
+
+// FederationConfig configures the federation of the controller with the
+// controllers of other clusters serving the same models. Federation is
+// disabled when BindAddress is empty.
+type FederationConfig struct {
+	// ClusterName is the name of the cluster, unique among peers. Required
+	// when federation is enabled.
+	ClusterName string
+	// BindAddress is the address the summary server listens on, e.g. ":9443".
+	BindAddress string
+	// Peers are the base URLs of the summary servers of the other clusters.
+	Peers []string
+	// Token is the bearer token sent to peers and required from them. Required
+	// when federation is enabled or peers are set, unless Insecure is set.
+	Token string
+	// Insecure allows federation without a token, accepting any request.
+	Insecure bool
+	// SyncInterval is the interval peers are polled at.
+	SyncInterval time.Duration
+	// StaleAfter is the age after which the summary of a peer is ignored.
+	StaleAfter time.Duration
+	// CertPath is the directory of the serving certificate. When empty, the
+	// server serves plain HTTP.
+	CertPath string
+	// CertName is the name of the certificate file in CertPath.
+	CertName string
+	// CertKey is the name of the key file in CertPath.
+	CertKey string
+	// CACertPath is the CA bundle that verifies the certificates of peers.
+	// When empty, the system roots verify them.
+	CACertPath string
+}
+
+// Enabled returns whether federation is enabled.
+func (f FederationConfig) Enabled() bool {
+	return f.BindAddress != ""
+}
+
 // SaturationScalingConfigPerModel represents saturation scaling configuration
 // for all models. Maps model ID (or "default" key) to its configuration.
 type SaturationScalingConfigPerModel map[string]SaturationScalingConfig
@@ -81,6 +208,12 @@ type SaturationScalingConfigPerModel map[string]SaturationScalingConfig
 // for all models. Maps model ID (or "default" key) to its configuration.
 type QMAnalyzerConfigPerModel map[string]interfaces.QueueingModelScalingConfig
 
+// DefaultSLOMultiplier is the queueing delay multiplier for inferred SLOs.
+// The SLO allows iteration time (queueing delay) to inflate by k× the idle
+// baseline α, while keeping deterministic work components at their true cost.
+// k=3 corresponds to utilization ρ = 1 - 1/k = 0.67.
+const DefaultSLOMultiplier = 3.0
+
 // saturationConfig holds saturation scaling configuration (namespace-aware)
 type saturationConfig struct {
 	// Global default configuration
@@ -108,6 +241,26 @@ type scaleToZeroConfig struct {
 	namespaceConfigs map[string]ScaleToZeroConfigData
 }
 
+// priceCatalogConfig holds the accelerator price catalog (namespace-aware)
+type priceCatalogConfig struct {
+	// Global catalog
+	global PriceCatalog
+
+	// Namespace-local catalogs (keyed by namespace name). Entries override the
+	// global price of the same accelerator; other accelerators keep global prices.
+	namespaceConfigs map[string]PriceCatalog
+}
+
+// perfDataConfig holds the model performance data catalog (namespace-aware)
+type perfDataConfig struct {
+	// Global catalog
+	global PerfDataCatalog
+
+	// Namespace-local catalogs (keyed by namespace name). Entries override the
+	// global entry of the same model and accelerator; other entries are kept.
+	namespaceConfigs map[string]PerfDataCatalog
+}
+
 // // StaticConfig holds configuration that is immutable after startup.
 // // These settings are loaded once at startup and cannot be changed at runtime.
 // // EPPConfig holds EPP (Endpoint Pool) integration configuration.
@@ -307,6 +460,48 @@ func (c *Config) ScaleFromZeroMaxConcurrency() int {
 	return c.features.scaleFromZeroMaxConcurrency
 }
 
+// DecisionRecorder returns the decision recorder configuration.
+// Thread-safe.
+func (c *Config) DecisionRecorder() DecisionRecorderConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.recorder
+}
+
+// Tracing returns the tracing configuration.
+// Thread-safe.
+func (c *Config) Tracing() TracingConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tracing
+}
+
+// ExternalMetrics returns the configuration of the external metrics API server.
+// Thread-safe.
+func (c *Config) ExternalMetrics() ExternalMetricsConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.external
+}
+
+// KedaScaler returns the configuration of the KEDA external scaler server.
+// Thread-safe.
+func (c *Config) KedaScaler() KedaScalerConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.kedaScaler
+}
+
+// Federation returns the configuration of the federation with other clusters.
+// Thread-safe.
+func (c *Config) Federation() FederationConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	f := c.federation
+	f.Peers = slices.Clone(f.Peers)
+	return f
+}
+
 // SaturationConfig returns the current global saturation scaling configuration.
 // Thread-safe. Returns a copy to prevent external modifications.
 // For namespace-aware lookups, use SaturationConfigForNamespace instead.
@@ -355,14 +550,16 @@ func (c *Config) resolveScaleToZeroConfig(namespace string) ScaleToZeroConfigDat
 }
 
 // SaturationConfigForNamespace returns the saturation scaling configuration for the given namespace.
-// Resolution order: namespace-local > global
+// Resolution order: namespace-local > global, with the overrides of the
+// autoscaling policies of the namespace's models applied.
 // Thread-safe. Returns a copy to prevent external modifications.
 // If namespace is empty, returns global config.
 func (c *Config) SaturationConfigForNamespace(namespace string) map[string]SaturationScalingConfig {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	sourceConfig := c.resolveSaturationConfig(namespace)
-	return copySaturationConfig(sourceConfig)
+	result := copySaturationConfig(c.resolveSaturationConfig(namespace))
+	c.applySaturationPolicies(namespace, result)
+	return result
 }
 
 // copySaturationConfig creates a deep copy of the saturation config map.
@@ -385,14 +582,16 @@ func (c *Config) ScaleToZeroConfig() ScaleToZeroConfigData {
 }
 
 // ScaleToZeroConfigForNamespace returns the scale-to-zero configuration for the given namespace.
-// Resolution order: namespace-local > global
+// Resolution order: namespace-local > global, with the overrides of the
+// autoscaling policies of the namespace's models applied.
 // Thread-safe. Returns a copy to prevent external modifications.
 // If namespace is empty, returns global config.
 func (c *Config) ScaleToZeroConfigForNamespace(namespace string) ScaleToZeroConfigData {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	sourceConfig := c.resolveScaleToZeroConfig(namespace)
-	return copyScaleToZeroConfig(sourceConfig)
+	result := copyScaleToZeroConfig(c.resolveScaleToZeroConfig(namespace))
+	c.applyScaleToZeroPolicies(namespace, result)
+	return result
 }
 
 // copyScaleToZeroConfig creates a deep copy of the scale-to-zero config map.
@@ -501,14 +700,16 @@ func (c *Config) QMAnalyzerConfig() map[string]interfaces.QueueingModelScalingCo
 }
 
 // QMAnalyzerConfigForNamespace returns the queueing model scaling configuration for the given namespace.
-// Resolution order: namespace-local > global
+// Resolution order: namespace-local > global, with the overrides of the
+// autoscaling policies of the namespace's models applied.
 // Thread-safe. Returns a copy to prevent external modifications.
 // If namespace is empty, returns global config.
 func (c *Config) QMAnalyzerConfigForNamespace(namespace string) map[string]interfaces.QueueingModelScalingConfig {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-	sourceConfig := c.resolveQMAnalyzerConfig(namespace)
-	return copyQMAnalyzerConfig(sourceConfig)
+	result := copyQMAnalyzerConfig(c.resolveQMAnalyzerConfig(namespace))
+	c.applyQMAnalyzerPolicies(namespace, result)
+	return result
 }
 
 // resolveQMAnalyzerConfig resolves queueing model config for a namespace (namespace-local > global).
@@ -584,6 +785,113 @@ func (c *Config) UpdateQMAnalyzerConfigForNamespace(namespace string, config map
 	}
 }
 
+// PriceCatalog returns the current global accelerator price catalog.
+// Thread-safe. Returns a copy to prevent external modifications.
+// For namespace-aware lookups, use PriceCatalogForNamespace instead.
+func (c *Config) PriceCatalog() PriceCatalog {
+	return c.PriceCatalogForNamespace("")
+}
+
+// PriceCatalogForNamespace returns the accelerator price catalog for the given namespace.
+// Unlike other namespace-aware configs, the namespace-local catalog is merged over the
+// global one per accelerator, so a namespace only needs to list the prices it overrides.
+// Thread-safe. Returns a copy to prevent external modifications.
+// If namespace is empty, returns the global catalog.
+func (c *Config) PriceCatalogForNamespace(namespace string) PriceCatalog {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := copyPriceCatalog(c.prices.global)
+	if namespace != "" {
+		maps.Copy(result, c.prices.namespaceConfigs[namespace])
+	}
+	return result
+}
+
+// UpdatePriceCatalog updates the global accelerator price catalog.
+// Thread-safe. Takes a copy of the provided catalog to prevent external modifications.
+// For namespace-local updates, use UpdatePriceCatalogForNamespace instead.
+func (c *Config) UpdatePriceCatalog(catalog PriceCatalog) {
+	c.UpdatePriceCatalogForNamespace("", catalog)
+}
+
+// UpdatePriceCatalogForNamespace updates the accelerator price catalog for the given namespace.
+// If namespace is empty, updates the global catalog.
+// Thread-safe. Takes a copy of the provided catalog to prevent external modifications.
+func (c *Config) UpdatePriceCatalogForNamespace(namespace string, catalog PriceCatalog) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	newCatalog := copyPriceCatalog(catalog)
+
+	if namespace == "" {
+		oldCount := len(c.prices.global)
+		c.prices.global = newCatalog
+		if oldCount != len(newCatalog) {
+			ctrl.Log.Info("Updated global accelerator price catalog", "oldEntries", oldCount, "newEntries", len(newCatalog))
+		}
+		return
+	}
+
+	if c.prices.namespaceConfigs == nil {
+		c.prices.namespaceConfigs = make(map[string]PriceCatalog)
+	}
+	oldCount := len(c.prices.namespaceConfigs[namespace])
+	c.prices.namespaceConfigs[namespace] = newCatalog
+	if oldCount != len(newCatalog) {
+		ctrl.Log.Info("Updated namespace-local accelerator price catalog", "namespace", namespace, "oldEntries", oldCount, "newEntries", len(newCatalog))
+	}
+}
+
+// PerfDataForNamespace returns the model performance data catalog for the given namespace.
+// Like the price catalog, the namespace-local catalog is merged over the global one per
+// model and accelerator.
+// Thread-safe. Returns a copy to prevent external modifications.
+// If namespace is empty, returns the global catalog.
+func (c *Config) PerfDataForNamespace(namespace string) PerfDataCatalog {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := copyPerfDataCatalog(c.perfData.global)
+	if namespace != "" {
+		maps.Copy(result, c.perfData.namespaceConfigs[namespace])
+	}
+	return result
+}
+
+// UpdatePerfData updates the global model performance data catalog.
+// Thread-safe. Takes a copy of the provided catalog to prevent external modifications.
+// For namespace-local updates, use UpdatePerfDataForNamespace instead.
+func (c *Config) UpdatePerfData(catalog PerfDataCatalog) {
+	c.UpdatePerfDataForNamespace("", catalog)
+}
+
+// UpdatePerfDataForNamespace updates the model performance data catalog for the given namespace.
+// If namespace is empty, updates the global catalog.
+// Thread-safe. Takes a copy of the provided catalog to prevent external modifications.
+func (c *Config) UpdatePerfDataForNamespace(namespace string, catalog PerfDataCatalog) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	newCatalog := copyPerfDataCatalog(catalog)
+
+	if namespace == "" {
+		oldCount := len(c.perfData.global)
+		c.perfData.global = newCatalog
+		if oldCount != len(newCatalog) {
+			ctrl.Log.Info("Updated global model performance data", "oldEntries", oldCount, "newEntries", len(newCatalog))
+		}
+		return
+	}
+
+	if c.perfData.namespaceConfigs == nil {
+		c.perfData.namespaceConfigs = make(map[string]PerfDataCatalog)
+	}
+	oldCount := len(c.perfData.namespaceConfigs[namespace])
+	c.perfData.namespaceConfigs[namespace] = newCatalog
+	if oldCount != len(newCatalog) {
+		ctrl.Log.Info("Updated namespace-local model performance data", "namespace", namespace, "oldEntries", oldCount, "newEntries", len(newCatalog))
+	}
+}
+
 // RemoveNamespaceConfig removes the namespace-local configuration for the given namespace.
 // This is called when a namespace-local ConfigMap is deleted, allowing fallback to global config.
 // Thread-safe.
@@ -612,6 +920,18 @@ func (c *Config) RemoveNamespaceConfig(namespace string) {
 			removed = true
 		}
 	}
+	if c.prices.namespaceConfigs != nil {
+		if _, exists := c.prices.namespaceConfigs[namespace]; exists {
+			delete(c.prices.namespaceConfigs, namespace)
+			removed = true
+		}
+	}
+	if c.perfData.namespaceConfigs != nil {
+		if _, exists := c.perfData.namespaceConfigs[namespace]; exists {
+			delete(c.perfData.namespaceConfigs, namespace)
+			removed = true
+		}
+	}
 	if removed {
 		ctrl.Log.Info("Removed namespace-local config", "namespace", namespace)
 	}
@@ -676,6 +996,14 @@ func NewTestConfig() *Config {
 			global:           make(ScaleToZeroConfigData),
 			namespaceConfigs: make(map[string]ScaleToZeroConfigData),
 		},
+		prices: priceCatalogConfig{
+			global:           make(PriceCatalog),
+			namespaceConfigs: make(map[string]PriceCatalog),
+		},
+		perfData: perfDataConfig{
+			global:           make(PerfDataCatalog),
+			namespaceConfigs: make(map[string]PerfDataCatalog),
+		},
 	}
 	return cfg
 }
@@ -195,6 +195,14 @@ func TestDetectImmutableParameterChanges(t *testing.T) {
 			expectError: true,
 			errorMsg:    "HEALTH_PROBE_BIND_ADDRESS",
 		},
+		{
+			name: "Attempt to enable the external metrics API",
+			configMap: map[string]string{
+				"EXTERNAL_METRICS_BIND_ADDRESS": ":6443",
+			},
+			expectError: true,
+			errorMsg:    "EXTERNAL_METRICS_BIND_ADDRESS",
+		},
 		{
 			name: "Attempt to change LEADER_ELECTION_ID",
 			configMap: map[string]string{
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Layers the effective configuration of a model is resolved from.
+const (
+	// ConfigLayerBuiltIn is the controller's defaults for unset fields.
+	ConfigLayerBuiltIn = "BuiltIn"
+	// ConfigLayerGlobalConfigMap is a ConfigMap in the controller's namespace.
+	ConfigLayerGlobalConfigMap = "GlobalConfigMap"
+	// ConfigLayerNamespaceConfigMap is a namespace-local ConfigMap.
+	ConfigLayerNamespaceConfigMap = "NamespaceConfigMap"
+	// ConfigLayerAutoscalingPolicy is an AutoscalingPolicy.
+	ConfigLayerAutoscalingPolicy = "AutoscalingPolicy"
+	// ConfigLayerClusterAutoscalingPolicy is a ClusterAutoscalingPolicy.
+	ConfigLayerClusterAutoscalingPolicy = "ClusterAutoscalingPolicy"
+)
+
+// ConfigMap entries a ConfigMap layer is read from.
+const (
+	// ConfigEntryDefault is the "default" entry.
+	ConfigEntryDefault = "Default"
+	// ConfigEntryModel is the model's entry.
+	ConfigEntryModel = "Model"
+)
+
+// ConfigSource identifies a layer of the effective configuration of a model.
+type ConfigSource struct {
+	// Layer is one of the ConfigLayer* constants.
+	Layer string
+	// Entry is the ConfigMap entry of a ConfigMap layer, one of the ConfigEntry* constants.
+	Entry string
+	// Name is the name of the policy of a policy layer.
+	Name string
+}
+
+// ModelConfig is the configuration the engine resolves for a model in a
+// namespace. Each section lists the layers it was resolved from, lowest
+// precedence first: fields a layer leaves unset keep the value of the layers
+// before it.
+type ModelConfig struct {
+	// Saturation is the saturation scaling configuration, with defaults applied.
+	Saturation        SaturationScalingConfig
+	SaturationSources []ConfigSource
+
+	// QueueingModel merges the queueing model entries of the model the way the
+	// engine does; unset fields take the analyzer's defaults. Nil when the
+	// queueing model analyzer is not active.
+	QueueingModel        *interfaces.QueueingModelScalingConfig
+	QueueingModelSources []ConfigSource
+
+	// ScaleToZero has EnableScaleToZero and RetentionPeriod resolved, and the
+	// model's valid schedules.
+	ScaleToZero        ModelScaleToZeroConfig
+	ScaleToZeroSources []ConfigSource
+}
+
+// ModelConfig returns the effective configuration of a model in a namespace.
+// Thread-safe.
+func (c *Config) ModelConfig(namespace, modelID string) ModelConfig {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	overrides := c.policies.namespaces[namespace][modelID]
+	var result ModelConfig
+	result.Saturation, result.SaturationSources = c.modelSaturationConfig(namespace, modelID, overrides)
+	if _, ok := c.qmAnalyzer.global[GlobalDefaultsKey]; ok {
+		qm, sources := c.modelQMAnalyzerConfig(namespace, modelID, overrides)
+		result.QueueingModel, result.QueueingModelSources = &qm, sources
+	}
+	result.ScaleToZero, result.ScaleToZeroSources = c.modelScaleToZeroConfig(namespace, modelID, overrides)
+	return result
+}
+
+// modelSaturationConfig resolves the saturation entry of a model like the
+// engine: "{modelID}#{namespace}", then "default", then defaults only.
+// Must be called while holding at least a read lock.
+func (c *Config) modelSaturationConfig(namespace, modelID string, overrides PolicyOverrides) (SaturationScalingConfig, []ConfigSource) {
+	raw := c.resolveSaturationConfig(namespace)
+	layer := configMapLayer(c.saturation.namespaceConfigs, namespace)
+	entries := copySaturationConfig(raw)
+	dropped := c.applySaturationPolicies(namespace, entries)
+
+	sources := []ConfigSource{{Layer: ConfigLayerBuiltIn}}
+	key := modelID + "#" + namespace
+	if cfg, ok := entries[key]; ok {
+		entry := ConfigEntryModel
+		if _, fromConfigMap := raw[key]; !fromConfigMap {
+			entry = ConfigEntryDefault // created from the "default" entry by a policy
+		}
+		sources = append(sources, ConfigSource{Layer: layer, Entry: entry})
+		if overrides.Saturation != nil && dropped[modelID] == nil {
+			sources = append(sources, overrides.SaturationSource)
+		}
+		return withDefaults(cfg), sources
+	}
+	cfg, ok := entries[GlobalDefaultsKey]
+	if ok {
+		sources = append(sources, ConfigSource{Layer: layer, Entry: ConfigEntryDefault})
+	}
+	return withDefaults(cfg), sources
+}
+
+// withDefaults returns a copy of cfg with defaults applied.
+func withDefaults(cfg SaturationScalingConfig) SaturationScalingConfig {
+	cfg.Analyzers = slices.Clone(cfg.Analyzers)
+	cfg.ApplyDefaults()
+	return cfg
+}
+
+// modelQMAnalyzerConfig merges the queueing model entries of a model like the
+// engine: the "default" entry, then the model's entry.
+// Must be called while holding at least a read lock.
+func (c *Config) modelQMAnalyzerConfig(namespace, modelID string, overrides PolicyOverrides) (interfaces.QueueingModelScalingConfig, []ConfigSource) {
+	raw := c.resolveQMAnalyzerConfig(namespace)
+	layer := configMapLayer(c.qmAnalyzer.namespaceConfigs, namespace)
+	entries := copyQMAnalyzerConfig(raw)
+	c.applyQMAnalyzerPolicies(namespace, entries)
+
+	sources := []ConfigSource{{Layer: ConfigLayerBuiltIn}}
+	var cfg interfaces.QueueingModelScalingConfig
+	if defaults, ok := entries[GlobalDefaultsKey]; ok {
+		sources = append(sources, ConfigSource{Layer: layer, Entry: ConfigEntryDefault})
+		cfg = interfaces.QueueingModelScalingConfig{
+			SLOMultiplier:       defaults.SLOMultiplier,
+			TuningEnabled:       defaults.TuningEnabled,
+			ColdStartLookahead:  defaults.ColdStartLookahead,
+			MinOnDemandFraction: defaults.MinOnDemandFraction,
+			RequestClasses:      defaults.RequestClasses,
+		}
+	}
+	if _, ok := modelQMAnalyzerEntry(raw, namespace, modelID); ok {
+		sources = append(sources, ConfigSource{Layer: layer, Entry: ConfigEntryModel})
+	}
+	if overrides.QueueingModel != nil {
+		sources = append(sources, overrides.QueueingModelSource)
+	}
+	if key, ok := modelQMAnalyzerEntry(entries, namespace, modelID); ok {
+		entry := entries[key]
+		if entry.SLOMultiplier > 1.0 {
+			cfg.SLOMultiplier = entry.SLOMultiplier
+		}
+		if entry.TuningEnabled != nil {
+			cfg.TuningEnabled = entry.TuningEnabled
+		}
+		if entry.ColdStartLookahead != nil {
+			cfg.ColdStartLookahead = entry.ColdStartLookahead
+		}
+		if entry.MinOnDemandFraction != nil {
+			cfg.MinOnDemandFraction = entry.MinOnDemandFraction
+		}
+		if entry.RequestClasses != nil {
+			cfg.RequestClasses = entry.RequestClasses
+		}
+		if entry.TargetTTFT > 0 && entry.TargetITL > 0 {
+			cfg.TargetTTFT = entry.TargetTTFT
+			cfg.TargetITL = entry.TargetITL
+		}
+	}
+	return cfg, sources
+}
+
+// modelQMAnalyzerEntry returns the key of the lexicographically first
+// queueing model entry of a model.
+func modelQMAnalyzerEntry(entries map[string]interfaces.QueueingModelScalingConfig, namespace, modelID string) (string, bool) {
+	var keys []string
+	for key, entry := range entries {
+		if key != GlobalDefaultsKey && entry.ModelID == modelID && entry.Namespace == namespace {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "", false
+	}
+	return slices.Min(keys), true
+}
+
+// modelScaleToZeroConfig resolves the scale-to-zero settings of a model: its
+// entry, then the "default" entry, then the built-in defaults.
+// Must be called while holding at least a read lock.
+func (c *Config) modelScaleToZeroConfig(namespace, modelID string, overrides PolicyOverrides) (ModelScaleToZeroConfig, []ConfigSource) {
+	raw := c.resolveScaleToZeroConfig(namespace)
+	layer := configMapLayer(c.scaleToZero.namespaceConfigs, namespace)
+	entries := copyScaleToZeroConfig(raw)
+	c.applyScaleToZeroPolicies(namespace, entries)
+
+	sources := []ConfigSource{{Layer: ConfigLayerBuiltIn}}
+	if _, ok := raw[GlobalDefaultsKey]; ok {
+		sources = append(sources, ConfigSource{Layer: layer, Entry: ConfigEntryDefault})
+	}
+	if _, ok := raw[modelID]; ok {
+		sources = append(sources, ConfigSource{Layer: layer, Entry: ConfigEntryModel})
+	}
+	if overrides.ScaleToZero != nil {
+		sources = append(sources, overrides.ScaleToZeroSource)
+	}
+
+	enabled := IsScaleToZeroEnabled(entries, modelID)
+	cfg := ModelScaleToZeroConfig{
+		EnableScaleToZero: &enabled,
+		RetentionPeriod:   ScaleToZeroRetentionPeriod(entries, modelID).String(),
+	}
+	schedules := entries[GlobalDefaultsKey].Schedules
+	if entry, ok := entries[modelID]; ok && len(entry.Schedules) > 0 {
+		schedules = entry.Schedules
+	}
+	for _, s := range schedules {
+		if _, err := s.Rule(); err == nil {
+			cfg.Schedules = append(cfg.Schedules, s)
+		}
+	}
+	return cfg, sources
+}
+
+// configMapLayer returns the layer the resolve*Config functions read a
+// namespace's configuration from: namespace-local when it has entries,
+// global otherwise.
+func configMapLayer[M ~map[string]V, V any](namespaceConfigs map[string]M, namespace string) string {
+	if namespace != "" && len(namespaceConfigs[namespace]) > 0 {
+		return ConfigLayerNamespaceConfigMap
+	}
+	return ConfigLayerGlobalConfigMap
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestConfig_ModelConfig(t *testing.T) {
+	t.Setenv("WVA_SCALE_TO_ZERO", "")
+	cfg := NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]SaturationScalingConfig{
+		"default":           {KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+		"meta/llama#team-a": {ModelID: "meta/llama", Namespace: "team-a", KvCacheThreshold: 0.9, QueueLengthThreshold: 8, Priority: 2},
+	})
+	cfg.UpdateQMAnalyzerConfig(map[string]interfaces.QueueingModelScalingConfig{
+		"default": {SLOMultiplier: 4, TuningEnabled: ptr.To(false)},
+	})
+	cfg.UpdateScaleToZeroConfig(ScaleToZeroConfigData{
+		"default":    {EnableScaleToZero: ptr.To(true)},
+		"meta/llama": {ModelID: "meta/llama", RetentionPeriod: "5m"},
+	})
+	cfg.UpdateScaleToZeroConfigForNamespace("team-b", ScaleToZeroConfigData{
+		"default": {EnableScaleToZero: ptr.To(false)},
+	})
+
+	t.Run("model entries of the global ConfigMaps", func(t *testing.T) {
+		mc := cfg.ModelConfig("team-a", "meta/llama")
+		assert.Equal(t, 0.9, mc.Saturation.KvCacheThreshold)
+		assert.Equal(t, 2.0, mc.Saturation.Priority)
+		assert.Equal(t, []ConfigSource{
+			{Layer: ConfigLayerBuiltIn},
+			{Layer: ConfigLayerGlobalConfigMap, Entry: ConfigEntryModel},
+		}, mc.SaturationSources, "a saturation model entry replaces the default entry")
+
+		require.NotNil(t, mc.QueueingModel)
+		assert.Equal(t, 4.0, mc.QueueingModel.SLOMultiplier)
+		assert.Equal(t, []ConfigSource{
+			{Layer: ConfigLayerBuiltIn},
+			{Layer: ConfigLayerGlobalConfigMap, Entry: ConfigEntryDefault},
+		}, mc.QueueingModelSources)
+
+		assert.True(t, *mc.ScaleToZero.EnableScaleToZero)
+		assert.Equal(t, "5m0s", mc.ScaleToZero.RetentionPeriod)
+		assert.Equal(t, []ConfigSource{
+			{Layer: ConfigLayerBuiltIn},
+			{Layer: ConfigLayerGlobalConfigMap, Entry: ConfigEntryDefault},
+			{Layer: ConfigLayerGlobalConfigMap, Entry: ConfigEntryModel},
+		}, mc.ScaleToZeroSources, "scale-to-zero entries are merged field by field")
+	})
+
+	t.Run("namespace-local ConfigMap", func(t *testing.T) {
+		mc := cfg.ModelConfig("team-b", "meta/llama")
+		assert.Equal(t, 0.8, mc.Saturation.KvCacheThreshold, "the model entry is for team-a")
+		assert.Equal(t, DefaultPriority, mc.Saturation.Priority)
+		assert.False(t, *mc.ScaleToZero.EnableScaleToZero)
+		assert.Equal(t, DefaultScaleToZeroRetentionPeriod.String(), mc.ScaleToZero.RetentionPeriod)
+		assert.Equal(t, []ConfigSource{
+			{Layer: ConfigLayerBuiltIn},
+			{Layer: ConfigLayerNamespaceConfigMap, Entry: ConfigEntryDefault},
+		}, mc.ScaleToZeroSources)
+	})
+
+	t.Run("policies", func(t *testing.T) {
+		policy := ConfigSource{Layer: ConfigLayerAutoscalingPolicy, Name: "granite"}
+		cfg.UpdatePolicyOverrides(map[string]PolicyOverridesPerModel{
+			"team-a": {"ibm/granite": {
+				Saturation:       &SaturationScalingOverride{KvCacheThreshold: ptr.To(0.95)},
+				SaturationSource: policy,
+				QueueingModel: &interfaces.QueueingModelScalingConfig{
+					TargetTTFT: 500, TargetITL: 25,
+				},
+				QueueingModelSource: policy,
+			}},
+		})
+		defer cfg.UpdatePolicyOverrides(nil)
+
+		mc := cfg.ModelConfig("team-a", "ibm/granite")
+		assert.Equal(t, 0.95, mc.Saturation.KvCacheThreshold)
+		assert.Equal(t, 5.0, mc.Saturation.QueueLengthThreshold)
+		assert.Equal(t, []ConfigSource{
+			{Layer: ConfigLayerBuiltIn},
+			{Layer: ConfigLayerGlobalConfigMap, Entry: ConfigEntryDefault},
+			policy,
+		}, mc.SaturationSources)
+		assert.Equal(t, float32(500), mc.QueueingModel.TargetTTFT)
+		assert.False(t, *mc.QueueingModel.TuningEnabled, "unset fields keep the default entry's value")
+		assert.Equal(t, policy, mc.QueueingModelSources[len(mc.QueueingModelSources)-1])
+	})
+
+	t.Run("queueing model analyzer inactive", func(t *testing.T) {
+		cfg.UpdateQMAnalyzerConfig(nil)
+		mc := cfg.ModelConfig("team-a", "meta/llama")
+		assert.Nil(t, mc.QueueingModel)
+		assert.Empty(t, mc.QueueingModelSources)
+	})
+}
@@ -15,6 +15,10 @@ const (
 	DefaultSaturationConfigMapName = "wva-saturation-scaling-config"
 	// DefaultQMAnalyzerConfigMapName is the default name of the ConfigMap for queueing model based scaling
 	DefaultQMAnalyzerConfigMapName = "wva-queueing-model-config"
+	// DefaultPriceCatalogConfigMapName is the default name of the ConfigMap for the accelerator price catalog
+	DefaultPriceCatalogConfigMapName = "wva-accelerator-price-catalog"
+	// DefaultPerfDataConfigMapName is the default name of the ConfigMap for the model performance data
+	DefaultPerfDataConfigMapName = "wva-model-perf-data"
 	// DefaultNamespace is the default namespace for the controller
 	DefaultNamespace = "workload-variant-autoscaler-system"
 )
@@ -105,3 +109,19 @@ func QMAnalyzerConfigMapName() string {
 	}
 	return DefaultQMAnalyzerConfigMapName
 }
+
+// PriceCatalogConfigMapName returns the accelerator price catalog ConfigMap name from environment variable or default.
+func PriceCatalogConfigMapName() string {
+	if name := os.Getenv("PRICE_CATALOG_CONFIG_MAP_NAME"); name != "" {
+		return name
+	}
+	return DefaultPriceCatalogConfigMapName
+}
+
+// PerfDataConfigMapName returns the model performance data ConfigMap name from environment variable or default.
+func PerfDataConfigMapName() string {
+	if name := os.Getenv("PERF_DATA_CONFIG_MAP_NAME"); name != "" {
+		return name
+	}
+	return DefaultPerfDataConfigMapName
+}
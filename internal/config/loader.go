@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	flag "github.com/spf13/pflag"
@@ -29,6 +30,7 @@ var flagBindings = map[string]string{
 	"METRICS_CERT_PATH":              "metrics-cert-path",
 	"METRICS_CERT_NAME":              "metrics-cert-name",
 	"METRICS_CERT_KEY":               "metrics-cert-key",
+	"KEDA_SCALER_INSECURE":           "keda-scaler-insecure",
 }
 
 // Load loads and validates the unified configuration.
@@ -85,6 +87,37 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 	v.SetDefault("WVA_LIMITED_MODE", false)
 	v.SetDefault("SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY", 10)
 	v.SetDefault("GLOBAL_OPT_INTERVAL", "60s")
+	v.SetDefault("DECISION_RECORDER", "")
+	v.SetDefault("DECISION_RECORDER_PATH", "/tmp/wva-decisions/decisions.jsonl")
+	v.SetDefault("DECISION_RECORDER_MAX_BYTES", 10*1024*1024)
+	v.SetDefault("DECISION_RECORDER_MAX_FILES", 3)
+	v.SetDefault("DECISION_RECORDER_CONFIGMAP", "wva-decision-records")
+	v.SetDefault("DECISION_RECORDER_MAX_RECORDS", 20)
+	v.SetDefault("TRACING_EXPORTER", "")
+	v.SetDefault("TRACING_OTLP_ENDPOINT", "")
+	v.SetDefault("TRACING_OTLP_INSECURE", false)
+	v.SetDefault("TRACING_SAMPLE_RATIO", 1.0)
+	v.SetDefault("EXTERNAL_METRICS_BIND_ADDRESS", "")
+	v.SetDefault("EXTERNAL_METRICS_CERT_PATH", "")
+	v.SetDefault("EXTERNAL_METRICS_CERT_NAME", "tls.crt")
+	v.SetDefault("EXTERNAL_METRICS_CERT_KEY", "tls.key")
+	v.SetDefault("KEDA_SCALER_BIND_ADDRESS", "")
+	v.SetDefault("KEDA_SCALER_CERT_PATH", "")
+	v.SetDefault("KEDA_SCALER_CERT_NAME", "tls.crt")
+	v.SetDefault("KEDA_SCALER_CERT_KEY", "tls.key")
+	v.SetDefault("KEDA_SCALER_CLIENT_CA_CERT_PATH", "")
+	v.SetDefault("KEDA_SCALER_INSECURE", false)
+	v.SetDefault("FEDERATION_CLUSTER_NAME", "")
+	v.SetDefault("FEDERATION_BIND_ADDRESS", "")
+	v.SetDefault("FEDERATION_PEERS", "")
+	v.SetDefault("FEDERATION_TOKEN", "")
+	v.SetDefault("FEDERATION_INSECURE", false)
+	v.SetDefault("FEDERATION_SYNC_INTERVAL", "30s")
+	v.SetDefault("FEDERATION_STALE_AFTER", "2m")
+	v.SetDefault("FEDERATION_CERT_PATH", "")
+	v.SetDefault("FEDERATION_CERT_NAME", "tls.crt")
+	v.SetDefault("FEDERATION_CERT_KEY", "tls.key")
+	v.SetDefault("FEDERATION_CA_CERT_PATH", "")
 
 	// Load from config file (mounted in the container) — sits between env and defaults in precedence
 	if configFilePath != "" {
@@ -139,6 +172,67 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 		scaleFromZeroMaxConcurrency: v.GetInt("SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY"),
 	}
 
+	cfg.recorder = DecisionRecorderConfig{
+		Sink:          v.GetString("DECISION_RECORDER"),
+		Path:          v.GetString("DECISION_RECORDER_PATH"),
+		MaxBytes:      v.GetInt64("DECISION_RECORDER_MAX_BYTES"),
+		MaxFiles:      v.GetInt("DECISION_RECORDER_MAX_FILES"),
+		ConfigMapName: v.GetString("DECISION_RECORDER_CONFIGMAP"),
+		MaxRecords:    v.GetInt("DECISION_RECORDER_MAX_RECORDS"),
+	}
+
+	cfg.tracing = TracingConfig{
+		Exporter:     v.GetString("TRACING_EXPORTER"),
+		OTLPEndpoint: v.GetString("TRACING_OTLP_ENDPOINT"),
+		OTLPInsecure: v.GetBool("TRACING_OTLP_INSECURE"),
+		SampleRatio:  v.GetFloat64("TRACING_SAMPLE_RATIO"),
+	}
+
+	cfg.external = ExternalMetricsConfig{
+		BindAddress: v.GetString("EXTERNAL_METRICS_BIND_ADDRESS"),
+		CertPath:    v.GetString("EXTERNAL_METRICS_CERT_PATH"),
+		CertName:    v.GetString("EXTERNAL_METRICS_CERT_NAME"),
+		CertKey:     v.GetString("EXTERNAL_METRICS_CERT_KEY"),
+	}
+	cfg.kedaScaler = KedaScalerConfig{
+		BindAddress: v.GetString("KEDA_SCALER_BIND_ADDRESS"),
+		CertPath:    v.GetString("KEDA_SCALER_CERT_PATH"),
+		CertName:    v.GetString("KEDA_SCALER_CERT_NAME"),
+		CertKey:     v.GetString("KEDA_SCALER_CERT_KEY"),
+
+		ClientCACertPath: v.GetString("KEDA_SCALER_CLIENT_CA_CERT_PATH"),
+		Insecure:         v.GetBool("KEDA_SCALER_INSECURE"),
+	}
+	if k := cfg.kedaScaler; k.BindAddress != "" && !k.Insecure && (k.CertPath == "" || k.ClientCACertPath == "") {
+		return errors.New("KEDA_SCALER_CERT_PATH and KEDA_SCALER_CLIENT_CA_CERT_PATH are required when KEDA_SCALER_BIND_ADDRESS is set; " +
+			"set --keda-scaler-insecure to serve the KEDA scaler without TLS and client authentication")
+	}
+	cfg.federation = FederationConfig{
+		ClusterName:  v.GetString("FEDERATION_CLUSTER_NAME"),
+		BindAddress:  v.GetString("FEDERATION_BIND_ADDRESS"),
+		Peers:        splitList(v.GetStringSlice("FEDERATION_PEERS")),
+		Token:        v.GetString("FEDERATION_TOKEN"),
+		Insecure:     v.GetBool("FEDERATION_INSECURE"),
+		SyncInterval: v.GetDuration("FEDERATION_SYNC_INTERVAL"),
+		StaleAfter:   v.GetDuration("FEDERATION_STALE_AFTER"),
+		CertPath:     v.GetString("FEDERATION_CERT_PATH"),
+		CertName:     v.GetString("FEDERATION_CERT_NAME"),
+		CertKey:      v.GetString("FEDERATION_CERT_KEY"),
+		CACertPath:   v.GetString("FEDERATION_CA_CERT_PATH"),
+	}
+	if cfg.federation.Enabled() {
+		if cfg.federation.ClusterName == "" {
+			return errors.New("FEDERATION_CLUSTER_NAME is required when FEDERATION_BIND_ADDRESS is set")
+		}
+		if cfg.federation.SyncInterval <= 0 || cfg.federation.StaleAfter <= 0 {
+			return errors.New("FEDERATION_SYNC_INTERVAL and FEDERATION_STALE_AFTER must be positive")
+		}
+	}
+	if (cfg.federation.Enabled() || len(cfg.federation.Peers) > 0) && cfg.federation.Token == "" && !cfg.federation.Insecure {
+		return errors.New("FEDERATION_TOKEN is required when FEDERATION_BIND_ADDRESS or FEDERATION_PEERS is set; " +
+			"set FEDERATION_INSECURE to federate without authentication")
+	}
+
 	cfg.saturation = saturationConfig{
 		global:           make(SaturationScalingConfigPerModel),
 		namespaceConfigs: make(map[string]SaturationScalingConfigPerModel),
@@ -149,6 +243,16 @@ func loadConfig(cfg *Config, flagSet *flag.FlagSet, configFilePath string) error
 		namespaceConfigs: make(map[string]ScaleToZeroConfigData),
 	}
 
+	cfg.prices = priceCatalogConfig{
+		global:           make(PriceCatalog),
+		namespaceConfigs: make(map[string]PriceCatalog),
+	}
+
+	cfg.perfData = perfDataConfig{
+		global:           make(PerfDataCatalog),
+		namespaceConfigs: make(map[string]PerfDataCatalog),
+	}
+
 	// Prometheus cache config from config file / env / defaults
 	cfg.prometheus.cache = parsePrometheusCacheConfigFromViper(v)
 
@@ -194,6 +298,20 @@ func parsePrometheusCacheConfigFromViper(v *viper.Viper) *CacheConfig {
 	return config
 }
 
+// splitList splits comma-separated elements of a list, as environment
+// variables hold lists, and drops empty elements.
+func splitList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for elem := range strings.SplitSeq(value, ",") {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				list = append(list, elem)
+			}
+		}
+	}
+	return list
+}
+
 // parseDurationOrDefault parses a duration string and returns the default if parsing fails.
 func parseDurationOrDefault(s string, def time.Duration) time.Duration {
 	if s == "" {
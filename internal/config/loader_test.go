@@ -197,6 +197,301 @@ SCALE_FROM_ZERO_ENGINE_MAX_CONCURRENCY: "5"
 	}
 }
 
+func TestLoad_DecisionRecorderFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+DECISION_RECORDER: "configmap"
+DECISION_RECORDER_MAX_RECORDS: "50"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	recorder := cfg.DecisionRecorder()
+	if recorder.Sink != DecisionRecorderSinkConfigMap {
+		t.Errorf("Expected decision recorder sink %q, got %q", DecisionRecorderSinkConfigMap, recorder.Sink)
+	}
+	if recorder.MaxRecords != 50 {
+		t.Errorf("Expected MaxRecords 50, got %d", recorder.MaxRecords)
+	}
+	if recorder.ConfigMapName != "wva-decision-records" {
+		t.Errorf("Expected default ConfigMap name, got %q", recorder.ConfigMapName)
+	}
+}
+
+func TestLoad_DecisionRecorderInvalidSink(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+DECISION_RECORDER: "s3"
+`)
+
+	if _, err := Load(nil, configFile); err == nil {
+		t.Fatal("Expected Load() to fail for an unknown decision recorder sink")
+	}
+}
+
+func TestLoad_TracingFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+TRACING_EXPORTER: "otlp"
+TRACING_OTLP_ENDPOINT: "otel-collector.monitoring:4317"
+TRACING_SAMPLE_RATIO: "0.25"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	tracing := cfg.Tracing()
+	if tracing.Exporter != TracingExporterOTLP {
+		t.Errorf("Expected tracing exporter %q, got %q", TracingExporterOTLP, tracing.Exporter)
+	}
+	if tracing.OTLPEndpoint != "otel-collector.monitoring:4317" {
+		t.Errorf("Expected OTLP endpoint from file, got %q", tracing.OTLPEndpoint)
+	}
+	if tracing.OTLPInsecure {
+		t.Error("Expected OTLP over TLS by default")
+	}
+	if tracing.SampleRatio != 0.25 {
+		t.Errorf("Expected sample ratio 0.25, got %v", tracing.SampleRatio)
+	}
+}
+
+func TestLoad_TracingInvalid(t *testing.T) {
+	for name, content := range map[string]string{
+		"unknown exporter": `TRACING_EXPORTER: "zipkin"`,
+		"sample ratio":     `TRACING_SAMPLE_RATIO: "2"`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			configFile := writeTestConfigFile(t, "PROMETHEUS_BASE_URL: \"https://prometheus:9090\"\n"+content+"\n")
+			if _, err := Load(nil, configFile); err == nil {
+				t.Fatal("Expected Load() to fail")
+			}
+		})
+	}
+}
+
+func TestLoad_ExternalMetricsFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+EXTERNAL_METRICS_BIND_ADDRESS: ":6443"
+EXTERNAL_METRICS_CERT_PATH: "/tmp/k8s-external-metrics-server/serving-certs"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	external := cfg.ExternalMetrics()
+	if external.BindAddress != ":6443" {
+		t.Errorf("Expected bind address from file, got %q", external.BindAddress)
+	}
+	if external.CertPath != "/tmp/k8s-external-metrics-server/serving-certs" {
+		t.Errorf("Expected cert path from file, got %q", external.CertPath)
+	}
+	if external.CertName != "tls.crt" || external.CertKey != "tls.key" {
+		t.Errorf("Expected default cert file names, got %q and %q", external.CertName, external.CertKey)
+	}
+}
+
+func TestLoad_KedaScalerFromFile(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+KEDA_SCALER_CERT_PATH: "/etc/wva/keda-scaler/tls"
+KEDA_SCALER_CLIENT_CA_CERT_PATH: "/etc/wva/keda-scaler/ca/ca.crt"
+`)
+
+	cfg, err := Load(nil, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	scaler := cfg.KedaScaler()
+	if scaler.BindAddress != ":9000" {
+		t.Errorf("Expected bind address from file, got %q", scaler.BindAddress)
+	}
+	if scaler.CertPath != "/etc/wva/keda-scaler/tls" || scaler.ClientCACertPath != "/etc/wva/keda-scaler/ca/ca.crt" {
+		t.Errorf("Expected cert and client CA paths from file, got %q and %q", scaler.CertPath, scaler.ClientCACertPath)
+	}
+	if scaler.Insecure {
+		t.Error("Expected the KEDA scaler to be secure by default")
+	}
+	if scaler.CertName != "tls.crt" || scaler.CertKey != "tls.key" {
+		t.Errorf("Expected default cert file names, got %q and %q", scaler.CertName, scaler.CertKey)
+	}
+}
+
+func TestLoad_FederationFromEnv(t *testing.T) {
+	t.Setenv("PROMETHEUS_BASE_URL", "https://prometheus:9090")
+	t.Setenv("FEDERATION_BIND_ADDRESS", ":9443")
+	t.Setenv("FEDERATION_CLUSTER_NAME", "us-east")
+	t.Setenv("FEDERATION_PEERS", "https://wva.us-west:9443, https://wva.eu-west:9443,")
+	t.Setenv("FEDERATION_TOKEN", "secret")
+
+	cfg, err := Load(nil, "")
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+
+	federation := cfg.Federation()
+	if !federation.Enabled() || federation.ClusterName != "us-east" {
+		t.Errorf("Expected federation enabled for us-east, got %+v", federation)
+	}
+	if len(federation.Peers) != 2 || federation.Peers[0] != "https://wva.us-west:9443" ||
+		federation.Peers[1] != "https://wva.eu-west:9443" {
+		t.Errorf("Expected two peers, got %q", federation.Peers)
+	}
+	if federation.SyncInterval != 30*time.Second || federation.StaleAfter != 2*time.Minute {
+		t.Errorf("Expected default intervals, got %v and %v", federation.SyncInterval, federation.StaleAfter)
+	}
+}
+
+func TestLoad_FederationRequiresClusterName(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+FEDERATION_BIND_ADDRESS: ":9443"
+FEDERATION_PEERS: ["https://wva.us-west:9443"]
+`)
+
+	if _, err := Load(nil, configFile); err == nil {
+		t.Error("Expected an error without FEDERATION_CLUSTER_NAME")
+	}
+}
+
+func TestLoad_FederationRequiresToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "server without token",
+			config: `
+FEDERATION_BIND_ADDRESS: ":9443"
+FEDERATION_CLUSTER_NAME: "us-east"
+`,
+			wantErr: true,
+		},
+		{
+			name: "peers without token",
+			config: `
+FEDERATION_PEERS: ["https://wva.us-west:9443"]
+`,
+			wantErr: true,
+		},
+		{
+			name: "explicitly insecure",
+			config: `
+FEDERATION_BIND_ADDRESS: ":9443"
+FEDERATION_CLUSTER_NAME: "us-east"
+FEDERATION_INSECURE: true
+`,
+		},
+		{
+			name: "with token",
+			config: `
+FEDERATION_BIND_ADDRESS: ":9443"
+FEDERATION_CLUSTER_NAME: "us-east"
+FEDERATION_TOKEN: "secret"
+`,
+		},
+		{
+			name:   "disabled",
+			config: ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+`+tt.config)
+			_, err := Load(nil, configFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_KedaScalerRequiresMTLS(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name: "without certificate",
+			config: `
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+`,
+			wantErr: true,
+		},
+		{
+			name: "without client CA",
+			config: `
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+KEDA_SCALER_CERT_PATH: "/etc/wva/keda-scaler/tls"
+`,
+			wantErr: true,
+		},
+		{
+			name: "explicitly insecure",
+			config: `
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+KEDA_SCALER_INSECURE: true
+`,
+		},
+		{
+			name: "mTLS",
+			config: `
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+KEDA_SCALER_CERT_PATH: "/etc/wva/keda-scaler/tls"
+KEDA_SCALER_CLIENT_CA_CERT_PATH: "/etc/wva/keda-scaler/ca/ca.crt"
+`,
+		},
+		{
+			name:   "disabled",
+			config: ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+`+tt.config)
+			_, err := Load(nil, configFile)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad_KedaScalerInsecureFlag(t *testing.T) {
+	configFile := writeTestConfigFile(t, `
+PROMETHEUS_BASE_URL: "https://prometheus:9090"
+KEDA_SCALER_BIND_ADDRESS: ":9000"
+`)
+	flagSet := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagSet.Bool("keda-scaler-insecure", false, "")
+	if err := flagSet.Parse([]string{"--keda-scaler-insecure"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(flagSet, configFile)
+	if err != nil {
+		t.Fatalf("Load() failed: %v", err)
+	}
+	if !cfg.KedaScaler().Insecure {
+		t.Error("KedaScaler().Insecure = false, want true from --keda-scaler-insecure")
+	}
+}
+
 func TestLoad_PrometheusCacheConfigFromFile(t *testing.T) {
 	configFile := writeTestConfigFile(t, `
 PROMETHEUS_BASE_URL: "https://prometheus:9090"
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// EntryError is an invalid entry, or an invalid value of an entry, of a
+// configuration ConfigMap.
+type EntryError struct {
+	// Key is the key of the entry in the ConfigMap data.
+	Key string
+	Err error
+}
+
+func (e *EntryError) Error() string {
+	return fmt.Sprintf("entry %q: %v", e.Key, e.Err)
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// ParseSaturationConfigMap parses the data of a saturation scaling ConfigMap.
+// Entries have defaults applied. Invalid entries are skipped; their errors are
+// returned as *EntryError, sorted by key.
+func ParseSaturationConfigMap(data map[string]string) (SaturationScalingConfigPerModel, []error) {
+	configs := make(SaturationScalingConfigPerModel, len(data))
+	var errs []error
+	for _, key := range sortedKeys(data) {
+		var satConfig SaturationScalingConfig
+		if err := yaml.Unmarshal([]byte(data[key]), &satConfig); err != nil {
+			errs = append(errs, &EntryError{Key: key, Err: err})
+			continue
+		}
+		// Apply defaults before validation (handles omitempty zero-values like scaleUpThreshold)
+		satConfig.ApplyDefaults()
+		if err := satConfig.Validate(); err != nil {
+			errs = append(errs, &EntryError{Key: key, Err: err})
+			continue
+		}
+		configs[key] = satConfig
+	}
+	return configs, errs
+}
+
+// ParseQMAnalyzerConfigMap parses the data of a queueing model ConfigMap.
+// Invalid entries are skipped; their errors are returned as *EntryError,
+// sorted by key.
+func ParseQMAnalyzerConfigMap(data map[string]string) (QMAnalyzerConfigPerModel, []error) {
+	configs := make(QMAnalyzerConfigPerModel, len(data))
+	var errs []error
+	for _, key := range sortedKeys(data) {
+		var qmConfig interfaces.QueueingModelScalingConfig
+		if err := yaml.Unmarshal([]byte(data[key]), &qmConfig); err != nil {
+			errs = append(errs, &EntryError{Key: key, Err: err})
+			continue
+		}
+		if err := qmConfig.Validate(); err != nil {
+			errs = append(errs, &EntryError{Key: key, Err: err})
+			continue
+		}
+		configs[key] = qmConfig
+	}
+	return configs, errs
+}
+
+func sortedKeys(data map[string]string) []string {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSaturationConfigMap(t *testing.T) {
+	configs, errs := ParseSaturationConfigMap(map[string]string{
+		GlobalDefaultsKey: `
+kvCacheThreshold: 0.8
+queueLengthThreshold: 5
+kvSpareTrigger: 0.1
+queueSpareTrigger: 3
+`,
+		"meta/llama#team-a": `
+model_id: meta/llama
+namespace: team-a
+kvCacheThreshold: 1.5
+`,
+		"mistral#team-b": "kvCacheThreshold: [",
+	})
+
+	require.Len(t, errs, 2)
+	keys := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var entryErr *EntryError
+		require.ErrorAs(t, err, &entryErr)
+		keys = append(keys, entryErr.Key)
+	}
+	assert.Equal(t, []string{"meta/llama#team-a", "mistral#team-b"}, keys, "errors are sorted by key")
+	assert.ErrorContains(t, errs[0], "kvCacheThreshold must be between 0 and 1")
+
+	require.Len(t, configs, 1, "invalid entries are skipped")
+	assert.Equal(t, 0.8, configs[GlobalDefaultsKey].KvCacheThreshold)
+	assert.Equal(t, DefaultPriority, configs[GlobalDefaultsKey].Priority, "defaults are applied")
+}
+
+func TestParseQMAnalyzerConfigMap(t *testing.T) {
+	configs, errs := ParseQMAnalyzerConfigMap(map[string]string{
+		GlobalDefaultsKey: "sloMultiplier: 4\n",
+		"llama": `
+model_id: meta/llama
+namespace: team-a
+targetTTFT: 500
+targetITL: 25
+`,
+		"mistral": "model_id: mistral\n",
+	})
+
+	require.Len(t, errs, 1)
+	var entryErr *EntryError
+	require.ErrorAs(t, errs[0], &entryErr)
+	assert.Equal(t, "mistral", entryErr.Key)
+	assert.ErrorContains(t, errs[0], "must have both model_id and namespace")
+
+	require.Len(t, configs, 2)
+	assert.Equal(t, 4.0, configs[GlobalDefaultsKey].SLOMultiplier)
+	assert.Equal(t, float32(500), configs["llama"].TargetTTFT)
+}
+
+func TestParseScaleToZeroConfigMap_Errors(t *testing.T) {
+	data, errs := ParseScaleToZeroConfigMap(map[string]string{
+		GlobalDefaultsKey: "enable_scale_to_zero: true\nretention_period: soon\n",
+		"a-llama":         "model_id: meta/llama\nretention_period: 5m\n",
+		"b-llama":         "model_id: meta/llama\nretention_period: 10m\n",
+		"no-model":        "enable_scale_to_zero: true\n",
+		"unparseable":     "enable_scale_to_zero: [",
+	})
+
+	keys := make([]string, 0, len(errs))
+	for _, err := range errs {
+		var entryErr *EntryError
+		require.ErrorAs(t, err, &entryErr)
+		keys = append(keys, entryErr.Key)
+	}
+	assert.Equal(t, []string{"b-llama", GlobalDefaultsKey, "no-model", "unparseable"}, keys)
+
+	require.Contains(t, data, GlobalDefaultsKey)
+	assert.True(t, *data[GlobalDefaultsKey].EnableScaleToZero, "an invalid retention period does not drop the entry")
+	assert.Empty(t, data[GlobalDefaultsKey].RetentionPeriod)
+	assert.Equal(t, "5m", data["meta/llama"].RetentionPeriod, "the first key wins")
+	assert.Len(t, data, 2)
+}
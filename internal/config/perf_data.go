@@ -0,0 +1,135 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	infernoConfig "github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/config"
+)
+
+// PerfDataCatalog maps models on accelerators to their performance data,
+// profiled offline from benchmarks or recorded metrics (see cmd/profiler).
+// The queueing model analyzer uses the profiled parameters as priors of the
+// variants that have not learned theirs yet.
+// Keys are produced by PerfDataKey; use Lookup rather than indexing directly.
+//
+// Example ConfigMap entry (the key only identifies the entry):
+//
+//	llama-3-8b-h100: |
+//	  name: meta-llama/Llama-3.1-8B-Instruct
+//	  acc: H100
+//	  accCount: 1
+//	  maxBatchSize: 256
+//	  atTokens: 1280
+//	  serviceParms:
+//	    alpha: 6.2
+//	    beta: 0.041
+//	    gamma: 0.0002
+type PerfDataCatalog map[string]infernoConfig.ModelAcceleratorPerfData
+
+// PerfDataKey returns the catalog key of a model on accCount units of an
+// accelerator. Full product names (e.g. "NVIDIA-H100-80GB-HBM3") and short
+// names (e.g. "h100") map to the same key. An accCount of 0 matches any count.
+func PerfDataKey(modelName, acceleratorName string, accCount int) string {
+	return fmt.Sprintf("%s|%s|%d", modelName, PriceCatalogKey(acceleratorName), accCount)
+}
+
+// Lookup returns the performance data of a model on accCount units of an
+// accelerator, normalizing the accelerator name first. Data profiled for the
+// exact count is preferred over data that does not specify one.
+func (c PerfDataCatalog) Lookup(modelName, acceleratorName string, accCount int) (infernoConfig.ModelAcceleratorPerfData, bool) {
+	if modelName == "" || acceleratorName == "" {
+		return infernoConfig.ModelAcceleratorPerfData{}, false
+	}
+	if data, ok := c[PerfDataKey(modelName, acceleratorName, accCount)]; ok {
+		return data, true
+	}
+	data, ok := c[PerfDataKey(modelName, acceleratorName, 0)]
+	return data, ok
+}
+
+// validatePerfData reports why a catalog entry is unusable, or "" if it is valid.
+func validatePerfData(data *infernoConfig.ModelAcceleratorPerfData) string {
+	switch {
+	case data.Name == "":
+		return "name must be set"
+	case data.Acc == "":
+		return "acc must be set"
+	case data.AccCount < 0:
+		return "accCount must not be negative"
+	case data.MaxBatchSize < 0:
+		return "maxBatchSize must not be negative"
+	case data.ServiceParms.Alpha <= 0 || data.ServiceParms.Beta <= 0 || data.ServiceParms.Gamma <= 0:
+		return "serviceParms alpha, beta and gamma must be positive"
+	}
+	return ""
+}
+
+// ParsePerfDataConfigMap parses the model performance data catalog from a
+// ConfigMap's data. Each value is a YAML ModelAcceleratorPerfData; keys only
+// identify the entries. Invalid entries are logged and skipped; when two
+// entries are for the same model, accelerator and count, the lexicographically
+// first key wins.
+//
+// Returns an empty catalog if the data is nil or empty.
+func ParsePerfDataConfigMap(data map[string]string) PerfDataCatalog {
+	out := make(PerfDataCatalog)
+	if len(data) == 0 {
+		return out
+	}
+
+	// Sort keys so duplicate resolution is deterministic.
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	winningKeys := make(map[string]string)
+	for _, key := range keys {
+		var perfData infernoConfig.ModelAcceleratorPerfData
+		if err := yaml.UnmarshalStrict([]byte(data[key]), &perfData); err != nil {
+			ctrl.Log.Info("Failed to parse model performance data entry, skipping",
+				"key", key,
+				"error", err)
+			continue
+		}
+		if reason := validatePerfData(&perfData); reason != "" {
+			ctrl.Log.Info("Invalid model performance data entry, skipping",
+				"key", key,
+				"reason", reason)
+			continue
+		}
+
+		catalogKey := PerfDataKey(perfData.Name, perfData.Acc, perfData.AccCount)
+		if winner, exists := winningKeys[catalogKey]; exists {
+			ctrl.Log.Info("Duplicate model performance data - first key wins",
+				"model", perfData.Name,
+				"accelerator", perfData.Acc,
+				"accCount", perfData.AccCount,
+				"winningKey", winner,
+				"duplicateKey", key)
+			continue
+		}
+		winningKeys[catalogKey] = key
+		out[catalogKey] = perfData
+	}
+
+	ctrl.Log.V(logging.DEBUG).Info("Parsed model performance data catalog",
+		"entryCount", len(out))
+
+	return out
+}
+
+// copyPerfDataCatalog creates a copy of the catalog. Entries hold no pointers
+// and are copied by value.
+func copyPerfDataCatalog(src PerfDataCatalog) PerfDataCatalog {
+	result := make(PerfDataCatalog, len(src))
+	maps.Copy(result, src)
+	return result
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const llamaH100 = `name: meta/llama
+acc: NVIDIA-H100-80GB-HBM3
+accCount: 2
+maxBatchSize: 256
+atTokens: 1280
+serviceParms:
+  alpha: 6.2
+  beta: 0.041
+  gamma: 0.0002
+`
+
+func TestParsePerfDataConfigMap(t *testing.T) {
+	catalog := ParsePerfDataConfigMap(map[string]string{
+		"llama-h100":     llamaH100,
+		"llama-a100":     "name: meta/llama\nacc: A100\nserviceParms: {alpha: 8, beta: 0.05, gamma: 0.0003}\n",
+		"llama-l4":       "name: meta/llama\nacc: L4\nserviceParms: {alpha: 8, beta: 0, gamma: 0.0003}\n", // zero beta
+		"llama-mi300x":   "name: meta/llama\nacc: MI300X\nserviceParms: [bad",                             // invalid YAML
+		"llama-unknown":  "name: meta/llama\nacc: H200\nalpha: 8\n",                                       // unknown field
+		"no-accelerator": "name: meta/llama\nserviceParms: {alpha: 8, beta: 0.05, gamma: 0.0003}\n",
+	})
+
+	require.Len(t, catalog, 2)
+
+	h100, ok := catalog.Lookup("meta/llama", "H100", 2)
+	require.True(t, ok, "short names should resolve to entries with full product names")
+	assert.Equal(t, 256, h100.MaxBatchSize)
+	assert.InDelta(t, 6.2, h100.ServiceParms.Alpha, 1e-6)
+	assert.InDelta(t, 0.041, h100.ServiceParms.Beta, 1e-6)
+	assert.InDelta(t, 0.0002, h100.ServiceParms.Gamma, 1e-9)
+
+	_, ok = catalog.Lookup("meta/llama", "H100", 1)
+	assert.False(t, ok, "entries for another accelerator count should not match")
+
+	a100, ok := catalog.Lookup("meta/llama", "NVIDIA-A100-SXM4-80GB", 4)
+	require.True(t, ok, "entries without an accelerator count should match any count")
+	assert.InDelta(t, 8.0, a100.ServiceParms.Alpha, 1e-6)
+
+	_, ok = catalog.Lookup("meta/llama", "L4", 1)
+	assert.False(t, ok)
+	_, ok = catalog.Lookup("", "H100", 2)
+	assert.False(t, ok)
+}
+
+func TestParsePerfDataConfigMap_Duplicate(t *testing.T) {
+	catalog := ParsePerfDataConfigMap(map[string]string{
+		"a": llamaH100,
+		"b": "name: meta/llama\nacc: H100\naccCount: 2\nserviceParms: {alpha: 9, beta: 0.05, gamma: 0.0003}\n",
+	})
+
+	require.Len(t, catalog, 1)
+	data, _ := catalog.Lookup("meta/llama", "H100", 2)
+	assert.InDelta(t, 6.2, data.ServiceParms.Alpha, 1e-6, "lexicographically first key wins")
+}
+
+func TestParsePerfDataConfigMap_Empty(t *testing.T) {
+	assert.Empty(t, ParsePerfDataConfigMap(nil))
+}
+
+func TestConfig_PerfDataForNamespace(t *testing.T) {
+	global := ParsePerfDataConfigMap(map[string]string{"llama-h100": llamaH100})
+	local := ParsePerfDataConfigMap(map[string]string{
+		"llama-h100": "name: meta/llama\nacc: H100\naccCount: 2\nserviceParms: {alpha: 5, beta: 0.03, gamma: 0.0001}\n",
+	})
+
+	cfg := NewTestConfig()
+	cfg.UpdatePerfData(global)
+	cfg.UpdatePerfDataForNamespace("team-a", local)
+
+	data, ok := cfg.PerfDataForNamespace("team-a").Lookup("meta/llama", "H100", 2)
+	require.True(t, ok)
+	assert.InDelta(t, 5.0, data.ServiceParms.Alpha, 1e-6, "namespace entry overrides global")
+
+	data, ok = cfg.PerfDataForNamespace("team-b").Lookup("meta/llama", "H100", 2)
+	require.True(t, ok)
+	assert.InDelta(t, 6.2, data.ServiceParms.Alpha, 1e-6)
+
+	cfg.RemoveNamespaceConfig("team-a")
+	data, _ = cfg.PerfDataForNamespace("team-a").Lookup("meta/llama", "H100", 2)
+	assert.InDelta(t, 6.2, data.ServiceParms.Alpha, 1e-6)
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// SaturationScalingOverride holds the saturation scaling fields set by an
+// autoscaling policy for a model. Nil fields keep the ConfigMap value.
+type SaturationScalingOverride struct {
+	KvCacheThreshold     *float64
+	QueueLengthThreshold *float64
+	KvSpareTrigger       *float64
+	QueueSpareTrigger    *float64
+	ScaleUpThreshold     *float64
+	ScaleDownBoundary    *float64
+	Priority             *float64
+	ColdStartLookahead   *bool
+	MinOnDemandFraction  *float64
+	CompareAnalyzers     []string
+}
+
+// ApplyTo sets the fields of cfg the override sets.
+func (o *SaturationScalingOverride) ApplyTo(cfg *SaturationScalingConfig) {
+	setIfNotNil(&cfg.KvCacheThreshold, o.KvCacheThreshold)
+	setIfNotNil(&cfg.QueueLengthThreshold, o.QueueLengthThreshold)
+	setIfNotNil(&cfg.KvSpareTrigger, o.KvSpareTrigger)
+	setIfNotNil(&cfg.QueueSpareTrigger, o.QueueSpareTrigger)
+	setIfNotNil(&cfg.ScaleUpThreshold, o.ScaleUpThreshold)
+	setIfNotNil(&cfg.ScaleDownBoundary, o.ScaleDownBoundary)
+	setIfNotNil(&cfg.Priority, o.Priority)
+	setIfNotNil(&cfg.ColdStartLookahead, o.ColdStartLookahead)
+	setIfNotNil(&cfg.MinOnDemandFraction, o.MinOnDemandFraction)
+	if o.CompareAnalyzers != nil {
+		cfg.CompareAnalyzers = slices.Clone(o.CompareAnalyzers)
+	}
+}
+
+func setIfNotNil[T any](dst *T, src *T) {
+	if src != nil {
+		*dst = *src
+	}
+}
+
+// PolicyOverrides holds the configuration autoscaling policies set for a model
+// in a namespace, one section per ConfigMap it replaces. A nil section leaves
+// the ConfigMap configuration unchanged. Unset fields of the queueing model and
+// scale-to-zero sections follow the conventions of the ConfigMap entries
+// (zero values and nil pointers are unset) and keep the ConfigMap value.
+type PolicyOverrides struct {
+	Saturation    *SaturationScalingOverride
+	QueueingModel *interfaces.QueueingModelScalingConfig
+	ScaleToZero   *ModelScaleToZeroConfig
+
+	// The policies the sections come from, reported in VA status.
+	SaturationSource    ConfigSource
+	QueueingModelSource ConfigSource
+	ScaleToZeroSource   ConfigSource
+}
+
+// PolicyOverridesPerModel maps model ID to the policy overrides of the model.
+type PolicyOverridesPerModel map[string]PolicyOverrides
+
+// policyConfig holds the configuration resolved from autoscaling policies.
+type policyConfig struct {
+	// Overrides keyed by namespace name.
+	namespaces map[string]PolicyOverridesPerModel
+}
+
+// PolicyOverrides returns the policy overrides of the models of a namespace.
+// Thread-safe. Returns a copy to prevent external modifications.
+func (c *Config) PolicyOverrides(namespace string) PolicyOverridesPerModel {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	result := make(PolicyOverridesPerModel, len(c.policies.namespaces[namespace]))
+	for modelID, overrides := range c.policies.namespaces[namespace] {
+		result[modelID] = overrides
+	}
+	return result
+}
+
+// UpdatePolicyOverrides replaces the policy overrides of all namespaces.
+// Thread-safe. Takes a copy of the provided map to prevent external modifications.
+func (c *Config) UpdatePolicyOverrides(overrides map[string]PolicyOverridesPerModel) {
+	newOverrides := make(map[string]PolicyOverridesPerModel, len(overrides))
+	for namespace, perModel := range overrides {
+		if len(perModel) == 0 {
+			continue
+		}
+		newPerModel := make(PolicyOverridesPerModel, len(perModel))
+		for modelID, o := range perModel {
+			newPerModel[modelID] = o
+		}
+		newOverrides[namespace] = newPerModel
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.policies.namespaces = newOverrides
+}
+
+// applySaturationPolicies sets the per-model entries of the models of a namespace
+// with saturation overrides. An entry is keyed "{modelID}#{namespace}" and
+// starts from the model's ConfigMap entry, or the "default" entry; overrides
+// are ignored until the ConfigMap is loaded. An override that makes the entry
+// invalid is dropped, keeping the ConfigMap entry; the dropped overrides are
+// returned by model ID. The AutoscalingPolicy reconciler reports them in the
+// status of the policy.
+// Must be called while holding at least a read lock.
+func (c *Config) applySaturationPolicies(namespace string, cfg map[string]SaturationScalingConfig) map[string]error {
+	var dropped map[string]error
+	for modelID, o := range c.policies.namespaces[namespace] {
+		if o.Saturation == nil {
+			continue
+		}
+		entry, ok := mergeSaturationPolicy(cfg, namespace, modelID, o.Saturation)
+		if !ok {
+			continue // the ConfigMap is not loaded yet
+		}
+		if err := validateSaturationEntry(entry); err != nil {
+			if dropped == nil {
+				dropped = make(map[string]error)
+			}
+			dropped[modelID] = err
+			continue
+		}
+		cfg[modelID+"#"+namespace] = entry
+	}
+	return dropped
+}
+
+// ValidateSaturationPolicy validates the saturation entry of a model in a
+// namespace with a policy override merged over the model's ConfigMap entry,
+// or the "default" entry, like the override is applied. It returns nil until
+// the ConfigMap is loaded.
+// Thread-safe.
+func (c *Config) ValidateSaturationPolicy(namespace, modelID string, o *SaturationScalingOverride) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := mergeSaturationPolicy(c.resolveSaturationConfig(namespace), namespace, modelID, o)
+	if !ok {
+		return nil
+	}
+	return validateSaturationEntry(entry)
+}
+
+// mergeSaturationPolicy returns the entry of a model with a saturation
+// override merged over the model's entry in cfg, or the "default" entry.
+// It returns false when cfg has neither.
+func mergeSaturationPolicy(cfg map[string]SaturationScalingConfig, namespace, modelID string, o *SaturationScalingOverride) (SaturationScalingConfig, bool) {
+	entry, ok := cfg[modelID+"#"+namespace]
+	if !ok {
+		if entry, ok = cfg[GlobalDefaultsKey]; !ok {
+			return SaturationScalingConfig{}, false
+		}
+	}
+	entry.Analyzers = slices.Clone(entry.Analyzers)
+	entry.ModelID = modelID
+	entry.Namespace = namespace
+	o.ApplyTo(&entry)
+	return entry, true
+}
+
+// validateSaturationEntry validates a saturation entry with defaults applied,
+// like the entry is used.
+func validateSaturationEntry(entry SaturationScalingConfig) error {
+	cfg := withDefaults(entry)
+	return cfg.Validate()
+}
+
+// applyQMAnalyzerPolicies replaces the per-model entries of the models of a
+// namespace with queueing model overrides by an entry merging the override over
+// the model's ConfigMap entry.
+// Must be called while holding at least a read lock.
+func (c *Config) applyQMAnalyzerPolicies(namespace string, cfg map[string]interfaces.QueueingModelScalingConfig) {
+	for modelID, o := range c.policies.namespaces[namespace] {
+		if o.QueueingModel == nil {
+			continue
+		}
+		// The lexicographically first matching ConfigMap entry is the base.
+		var keys []string
+		for key, entry := range cfg {
+			if key != GlobalDefaultsKey && entry.ModelID == modelID && entry.Namespace == namespace {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		var entry interfaces.QueueingModelScalingConfig
+		if len(keys) > 0 {
+			entry = cfg[keys[0]]
+		}
+		for _, key := range keys {
+			delete(cfg, key)
+		}
+		entry.ModelID = modelID
+		entry.Namespace = namespace
+		mergeQMAnalyzerConfig(&entry, o.QueueingModel)
+		cfg[modelID+"#"+namespace] = entry
+	}
+}
+
+// mergeQMAnalyzerConfig sets the fields of cfg set in o.
+func mergeQMAnalyzerConfig(cfg *interfaces.QueueingModelScalingConfig, o *interfaces.QueueingModelScalingConfig) {
+	if o.SLOMultiplier != 0 {
+		cfg.SLOMultiplier = o.SLOMultiplier
+	}
+	if o.TargetTTFT != 0 && o.TargetITL != 0 {
+		cfg.TargetTTFT = o.TargetTTFT
+		cfg.TargetITL = o.TargetITL
+	}
+	if o.TuningEnabled != nil {
+		cfg.TuningEnabled = o.TuningEnabled
+	}
+	if o.ColdStartLookahead != nil {
+		cfg.ColdStartLookahead = o.ColdStartLookahead
+	}
+	if o.MinOnDemandFraction != nil {
+		cfg.MinOnDemandFraction = o.MinOnDemandFraction
+	}
+	if o.RequestClasses != nil {
+		cfg.RequestClasses = o.RequestClasses
+	}
+}
+
+// applyScaleToZeroPolicies merges the scale-to-zero overrides of the models of
+// a namespace over their ConfigMap entries.
+// Must be called while holding at least a read lock.
+func (c *Config) applyScaleToZeroPolicies(namespace string, cfg ScaleToZeroConfigData) {
+	for modelID, o := range c.policies.namespaces[namespace] {
+		if o.ScaleToZero == nil {
+			continue
+		}
+		entry := cfg[modelID]
+		entry.ModelID = modelID
+		if o.ScaleToZero.EnableScaleToZero != nil {
+			entry.EnableScaleToZero = o.ScaleToZero.EnableScaleToZero
+		}
+		if o.ScaleToZero.RetentionPeriod != "" {
+			entry.RetentionPeriod = o.ScaleToZero.RetentionPeriod
+		}
+		if len(o.ScaleToZero.Schedules) > 0 {
+			entry.Schedules = slices.Clone(o.ScaleToZero.Schedules)
+		}
+		cfg[modelID] = entry
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestConfig_PolicyOverrides(t *testing.T) {
+	cfg := NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]SaturationScalingConfig{
+		"default": {KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3, Priority: 1},
+	})
+	cfg.UpdateQMAnalyzerConfig(map[string]interfaces.QueueingModelScalingConfig{
+		"default":      {SLOMultiplier: 3},
+		"llama-team-a": {ModelID: "meta/llama", Namespace: "team-a", SLOMultiplier: 2, TuningEnabled: ptr.To(false)},
+	})
+	cfg.UpdateScaleToZeroConfig(ScaleToZeroConfigData{
+		"meta/llama": {ModelID: "meta/llama", RetentionPeriod: "5m"},
+	})
+
+	cfg.UpdatePolicyOverrides(map[string]PolicyOverridesPerModel{
+		"team-a": {
+			"meta/llama": {
+				Saturation:    &SaturationScalingOverride{KvCacheThreshold: ptr.To(0.9)},
+				QueueingModel: &interfaces.QueueingModelScalingConfig{SLOMultiplier: 4},
+				ScaleToZero:   &ModelScaleToZeroConfig{EnableScaleToZero: ptr.To(true)},
+			},
+		},
+	})
+
+	sat := cfg.SaturationConfigForNamespace("team-a")
+	entry, ok := sat["meta/llama#team-a"]
+	require.True(t, ok)
+	assert.Equal(t, 0.9, entry.KvCacheThreshold)
+	assert.Equal(t, 5.0, entry.QueueLengthThreshold, "unset fields keep the default entry's value")
+	assert.Equal(t, 0.8, sat["default"].KvCacheThreshold)
+
+	qm := cfg.QMAnalyzerConfigForNamespace("team-a")
+	assert.NotContains(t, qm, "llama-team-a", "the ConfigMap entry is replaced by the merged entry")
+	merged := qm["meta/llama#team-a"]
+	assert.Equal(t, 4.0, merged.SLOMultiplier)
+	assert.False(t, *merged.TuningEnabled, "unset fields keep the ConfigMap entry's value")
+
+	stz := cfg.ScaleToZeroConfigForNamespace("team-a")
+	assert.True(t, IsScaleToZeroEnabled(stz, "meta/llama"))
+	assert.Equal(t, "5m", stz["meta/llama"].RetentionPeriod)
+
+	// other namespaces and the global configuration are unchanged
+	assert.NotContains(t, cfg.SaturationConfigForNamespace("team-b"), "meta/llama#team-b")
+	assert.Nil(t, cfg.ScaleToZeroConfig()["meta/llama"].EnableScaleToZero)
+	assert.Contains(t, cfg.QMAnalyzerConfig(), "llama-team-a")
+
+	cfg.UpdatePolicyOverrides(nil)
+	assert.NotContains(t, cfg.SaturationConfigForNamespace("team-a"), "meta/llama#team-a")
+}
+
+func TestConfig_InvalidSaturationPolicyDropped(t *testing.T) {
+	cfg := NewTestConfig()
+	cfg.UpdateSaturationConfig(map[string]SaturationScalingConfig{
+		"default":           {KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+		"meta/llama#team-a": {ModelID: "meta/llama", Namespace: "team-a", KvCacheThreshold: 0.7, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+	})
+	// valid over the "default" entry, but not over the model's entry
+	override := &SaturationScalingOverride{KvSpareTrigger: ptr.To(0.75)}
+	cfg.UpdatePolicyOverrides(map[string]PolicyOverridesPerModel{
+		"team-a": {"meta/llama": {Saturation: override}},
+		"team-b": {"meta/llama": {Saturation: override}},
+	})
+
+	require.Error(t, cfg.ValidateSaturationPolicy("team-a", "meta/llama", override))
+	require.NoError(t, cfg.ValidateSaturationPolicy("team-b", "meta/llama", override))
+
+	entry := cfg.SaturationConfigForNamespace("team-a")["meta/llama#team-a"]
+	assert.Equal(t, 0.1, entry.KvSpareTrigger, "the invalid override is dropped, keeping the ConfigMap entry")
+	assert.Equal(t, 0.75, cfg.SaturationConfigForNamespace("team-b")["meta/llama#team-b"].KvSpareTrigger)
+}
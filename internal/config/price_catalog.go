@@ -0,0 +1,152 @@
+package config
+
+import (
+	"maps"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// Pricing tiers supported by the accelerator price catalog.
+const (
+	// PricingTierOnDemand is the default tier, used when a VA does not request one.
+	PricingTierOnDemand = "on-demand"
+	// PricingTierSpot prices capacity that may be reclaimed by the provider.
+	PricingTierSpot = "spot"
+	// PricingTierReserved prices capacity bought under a commitment.
+	PricingTierReserved = "reserved"
+)
+
+// AcceleratorPrice is the per-GPU hourly price of an accelerator type.
+// Prices use the same unit as VariantAutoscaling spec.variantCost so that
+// catalog-derived and explicit costs can be compared by the optimizer.
+//
+// Example ConfigMap entry:
+//
+//	H100: |
+//	  onDemand: 4.5
+//	  spot: 1.8
+//	  reserved: 3.1
+type AcceleratorPrice struct {
+	// OnDemand is the hourly price of one GPU. Required.
+	OnDemand float64 `yaml:"onDemand" json:"onDemand"`
+	// Spot is the optional hourly price of one spot GPU.
+	Spot *float64 `yaml:"spot,omitempty" json:"spot,omitempty"`
+	// Reserved is the optional hourly price of one reserved GPU.
+	Reserved *float64 `yaml:"reserved,omitempty" json:"reserved,omitempty"`
+}
+
+// PriceForTier returns the hourly GPU price for tier.
+// Unknown tiers, and tiers without a configured price, fall back to on-demand.
+func (p AcceleratorPrice) PriceForTier(tier string) float64 {
+	switch tier {
+	case PricingTierSpot:
+		if p.Spot != nil {
+			return *p.Spot
+		}
+	case PricingTierReserved:
+		if p.Reserved != nil {
+			return *p.Reserved
+		}
+	}
+	return p.OnDemand
+}
+
+// PriceCatalog maps normalized accelerator types to their prices.
+// Keys are produced by PriceCatalogKey; use Lookup rather than indexing directly.
+type PriceCatalog map[string]AcceleratorPrice
+
+// PriceCatalogKey returns the catalog key for an accelerator name. Full product
+// names (e.g. "NVIDIA-H100-80GB-HBM3") and short names (e.g. "h100") map to the
+// same key.
+func PriceCatalogKey(acceleratorName string) string {
+	return strings.ToUpper(NormalizeAcceleratorName(acceleratorName))
+}
+
+// Lookup returns the price for an accelerator name, normalizing it first.
+func (c PriceCatalog) Lookup(acceleratorName string) (AcceleratorPrice, bool) {
+	if acceleratorName == "" {
+		return AcceleratorPrice{}, false
+	}
+	price, ok := c[PriceCatalogKey(acceleratorName)]
+	return price, ok
+}
+
+// validate reports why a catalog entry is unusable, or "" if it is valid.
+func (p AcceleratorPrice) validate() string {
+	if p.OnDemand <= 0 {
+		return "onDemand must be positive"
+	}
+	if p.Spot != nil && *p.Spot <= 0 {
+		return "spot must be positive when set"
+	}
+	if p.Reserved != nil && *p.Reserved <= 0 {
+		return "reserved must be positive when set"
+	}
+	return ""
+}
+
+// ParsePriceCatalogConfigMap parses the accelerator price catalog from a ConfigMap's data.
+// Each key is an accelerator type (full or short name) and each value is a YAML
+// AcceleratorPrice. Invalid entries are logged and skipped; when two keys normalize
+// to the same accelerator, the lexicographically first key wins.
+//
+// Returns an empty catalog if the data is nil or empty.
+func ParsePriceCatalogConfigMap(data map[string]string) PriceCatalog {
+	out := make(PriceCatalog)
+	if len(data) == 0 {
+		return out
+	}
+
+	// Sort keys so duplicate resolution is deterministic.
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	winningKeys := make(map[string]string)
+	for _, key := range keys {
+		var price AcceleratorPrice
+		if err := yaml.Unmarshal([]byte(data[key]), &price); err != nil {
+			ctrl.Log.Info("Failed to parse accelerator price entry, skipping",
+				"key", key,
+				"error", err)
+			continue
+		}
+		if reason := price.validate(); reason != "" {
+			ctrl.Log.Info("Invalid accelerator price entry, skipping",
+				"key", key,
+				"reason", reason)
+			continue
+		}
+
+		catalogKey := PriceCatalogKey(key)
+		if winner, exists := winningKeys[catalogKey]; exists {
+			ctrl.Log.Info("Duplicate accelerator in price catalog - first key wins",
+				"accelerator", catalogKey,
+				"winningKey", winner,
+				"duplicateKey", key)
+			continue
+		}
+		winningKeys[catalogKey] = key
+		out[catalogKey] = price
+	}
+
+	ctrl.Log.V(logging.DEBUG).Info("Parsed accelerator price catalog",
+		"acceleratorCount", len(out))
+
+	return out
+}
+
+// copyPriceCatalog creates a copy of the catalog. Price values are copied by
+// value; their optional tier pointers are never mutated after parsing.
+func copyPriceCatalog(src PriceCatalog) PriceCatalog {
+	result := make(PriceCatalog, len(src))
+	maps.Copy(result, src)
+	return result
+}
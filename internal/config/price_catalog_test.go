@@ -0,0 +1,78 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePriceCatalogConfigMap(t *testing.T) {
+	catalog := ParsePriceCatalogConfigMap(map[string]string{
+		"NVIDIA-H100-80GB-HBM3": "onDemand: 4.5\nspot: 1.8\nreserved: 3.1\n",
+		"a100":                  "onDemand: 2.0\n",
+		"L4":                    "spot: 0.3\n",    // missing onDemand
+		"MI300X":                "onDemand: [bad", // invalid YAML
+		"T4":                    "onDemand: 0.4\nspot: -1\n",
+	})
+
+	require.Len(t, catalog, 2)
+
+	h100, ok := catalog.Lookup("H100")
+	require.True(t, ok)
+	assert.InDelta(t, 4.5, h100.PriceForTier(PricingTierOnDemand), 1e-9)
+	assert.InDelta(t, 1.8, h100.PriceForTier(PricingTierSpot), 1e-9)
+	assert.InDelta(t, 3.1, h100.PriceForTier(PricingTierReserved), 1e-9)
+
+	a100, ok := catalog.Lookup("NVIDIA-A100-SXM4-80GB")
+	require.True(t, ok, "full product names should resolve to the short catalog key")
+	assert.InDelta(t, 2.0, a100.PriceForTier(PricingTierSpot), 1e-9, "unpriced tiers fall back to on-demand")
+
+	_, ok = catalog.Lookup("L4")
+	assert.False(t, ok)
+	_, ok = catalog.Lookup("")
+	assert.False(t, ok)
+}
+
+func TestParsePriceCatalogConfigMap_DuplicateAccelerator(t *testing.T) {
+	catalog := ParsePriceCatalogConfigMap(map[string]string{
+		"H100":                  "onDemand: 4.0\n",
+		"NVIDIA-H100-80GB-HBM3": "onDemand: 5.0\n",
+	})
+
+	require.Len(t, catalog, 1)
+	price, _ := catalog.Lookup("H100")
+	assert.InDelta(t, 4.0, price.OnDemand, 1e-9, "lexicographically first key wins")
+}
+
+func TestParsePriceCatalogConfigMap_Empty(t *testing.T) {
+	assert.Empty(t, ParsePriceCatalogConfigMap(nil))
+}
+
+func TestConfig_PriceCatalogForNamespace(t *testing.T) {
+	cfg := NewTestConfig()
+	cfg.UpdatePriceCatalog(PriceCatalog{
+		"H100": {OnDemand: 4.0},
+		"A100": {OnDemand: 2.0},
+	})
+	cfg.UpdatePriceCatalogForNamespace("team-a", PriceCatalog{
+		"H100": {OnDemand: 3.0},
+	})
+
+	global := cfg.PriceCatalog()
+	assert.InDelta(t, 4.0, global["H100"].OnDemand, 1e-9)
+
+	local := cfg.PriceCatalogForNamespace("team-a")
+	assert.InDelta(t, 3.0, local["H100"].OnDemand, 1e-9, "namespace entry overrides global")
+	assert.InDelta(t, 2.0, local["A100"].OnDemand, 1e-9, "accelerators not overridden keep global price")
+
+	other := cfg.PriceCatalogForNamespace("team-b")
+	assert.InDelta(t, 4.0, other["H100"].OnDemand, 1e-9)
+
+	// Returned catalogs are copies
+	local["A100"] = AcceleratorPrice{OnDemand: 99}
+	assert.InDelta(t, 2.0, cfg.PriceCatalogForNamespace("team-a")["A100"].OnDemand, 1e-9)
+
+	cfg.RemoveNamespaceConfig("team-a")
+	assert.InDelta(t, 4.0, cfg.PriceCatalogForNamespace("team-a")["H100"].OnDemand, 1e-9)
+}
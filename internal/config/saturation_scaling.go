@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 // DefaultPriority is the default model priority multiplier.
 // Higher priority → preferential GPU allocation in fair-share.
@@ -58,8 +61,35 @@ type SaturationScalingConfig struct {
 	// When empty and AnalyzerName is "saturation", defaults to
 	// [{Name: "saturation", Score: 1.0, Enabled: true}].
 	Analyzers []AnalyzerScoreConfig `yaml:"analyzers,omitempty"`
+
+	// ColdStartLookahead: When true, the V2 path projects model demand forward
+	// by the learned replica startup latency (pod creation → first request) and
+	// scales up early when demand is trending upward, so that new capacity is
+	// serving before the current replicas saturate.
+	// Default is false (scale up on observed demand only).
+	ColdStartLookahead bool `yaml:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction (0.0-1.0) of a model's capacity
+	// that must stay on non-preemptible variants (VA spec.pricingTier other than
+	// "spot"). When spot variants would carry more than their share, the
+	// optimizer moves capacity to the most cost-efficient on-demand variant.
+	// Applied by the cost-aware optimizer (enableLimiter: false).
+	// Default is 0 (no floor).
+	MinOnDemandFraction float64 `yaml:"minOnDemandFraction,omitempty"`
+
+	// CompareAnalyzers lists analyzers ("saturation", "queueing-model") to run
+	// alongside the primary analyzer on the same collected metrics, for
+	// evaluation only: their recommended replicas and divergence from the
+	// primary are reported as metrics, but only the primary's decisions are
+	// actuated. Entries naming the primary analyzer are ignored. Applies when
+	// the primary is the V2 or the queueing model analyzer.
+	// Default is empty (primary analyzer only).
+	CompareAnalyzers []string `yaml:"compareAnalyzers,omitempty"`
 }
 
+// ComparableAnalyzers are the analyzers that can be listed in CompareAnalyzers.
+var ComparableAnalyzers = []string{"saturation", "queueing-model"}
+
 // AnalyzerScoreConfig configures an individual analyzer's weight in the
 // composite scoring function. Per-analyzer threshold overrides are optional;
 // when nil, the global top-level thresholds are used.
@@ -164,6 +194,15 @@ func (c *SaturationScalingConfig) Validate() error {
 	if c.Priority < 0 {
 		return fmt.Errorf("priority must be >= 0, got %.2f", c.Priority)
 	}
+	if c.MinOnDemandFraction < 0 || c.MinOnDemandFraction > 1 {
+		return fmt.Errorf("minOnDemandFraction must be between 0 and 1, got %.2f", c.MinOnDemandFraction)
+	}
+
+	for _, name := range c.CompareAnalyzers {
+		if !slices.Contains(ComparableAnalyzers, name) {
+			return fmt.Errorf("compareAnalyzers: unknown analyzer %q, must be one of %v", name, ComparableAnalyzers)
+		}
+	}
 
 	// KV cache threshold should be greater than spare trigger (otherwise contradictory)
 	if c.KvCacheThreshold < c.KvSpareTrigger {
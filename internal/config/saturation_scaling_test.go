@@ -32,6 +32,20 @@ var _ = Describe("SaturationScalingConfig", func() {
 				KvSpareTrigger:       0.15,
 				QueueSpareTrigger:    5,
 			}, false),
+			Entry("valid compareAnalyzers", SaturationScalingConfig{
+				KvCacheThreshold:     0.80,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.10,
+				QueueSpareTrigger:    3,
+				CompareAnalyzers:     []string{"saturation", "queueing-model"},
+			}, false),
+			Entry("invalid compareAnalyzers entry", SaturationScalingConfig{
+				KvCacheThreshold:     0.80,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.10,
+				QueueSpareTrigger:    3,
+				CompareAnalyzers:     []string{"throughput"},
+			}, true),
 			Entry("invalid KvCacheThreshold too high", SaturationScalingConfig{
 				KvCacheThreshold:     1.5,
 				QueueLengthThreshold: 5,
@@ -62,6 +76,20 @@ var _ = Describe("SaturationScalingConfig", func() {
 				KvSpareTrigger:       -0.1,
 				QueueSpareTrigger:    3,
 			}, true),
+			Entry("valid MinOnDemandFraction", SaturationScalingConfig{
+				KvCacheThreshold:     0.8,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.1,
+				QueueSpareTrigger:    3,
+				MinOnDemandFraction:  0.3,
+			}, false),
+			Entry("invalid MinOnDemandFraction too high", SaturationScalingConfig{
+				KvCacheThreshold:     0.8,
+				QueueLengthThreshold: 5,
+				KvSpareTrigger:       0.1,
+				QueueSpareTrigger:    3,
+				MinOnDemandFraction:  1.5,
+			}, true),
 			Entry("invalid QueueSpareTrigger negative", SaturationScalingConfig{
 				KvCacheThreshold:     0.8,
 				QueueLengthThreshold: 5,
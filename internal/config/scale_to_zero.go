@@ -1,10 +1,10 @@
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
-	"sort"
 	"strings"
 	"time"
 
@@ -12,6 +12,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
 )
 
 // Scale-to-zero configuration constants
@@ -49,6 +50,50 @@ type ModelScaleToZeroConfig struct {
 	// This is stored as a string duration (e.g., "5m", "1h", "30s").
 	// Empty string = not set (inherit from defaults)
 	RetentionPeriod string `yaml:"retention_period,omitempty" json:"retention_period,omitempty"`
+	// Schedules temporarily override replica bounds and scale-to-zero eligibility of
+	// the model's variants during recurring time windows.
+	// Empty = not set (inherit from defaults)
+	Schedules []ScheduleConfig `yaml:"schedules,omitempty" json:"schedules,omitempty"`
+}
+
+// ScheduleConfig is a per-model scaling schedule in the scale-to-zero ConfigMap.
+// While a window is active, MinReplicas and MaxReplicas replace the bounds of
+// every variant of the model (VA spec.schedules take precedence), and
+// EnableScaleToZero replaces the model's scale-to-zero setting.
+type ScheduleConfig struct {
+	// Name identifies the schedule in status and logs.
+	Name string `yaml:"name" json:"name"`
+	// Schedule is a five-field cron expression for the window start times.
+	Schedule string `yaml:"schedule" json:"schedule"`
+	// TimeZone is the IANA time zone the schedule is evaluated in (default UTC).
+	TimeZone string `yaml:"time_zone,omitempty" json:"time_zone,omitempty"`
+	// Duration is how long each window lasts (e.g. "10h").
+	Duration string `yaml:"duration" json:"duration"`
+	// MinReplicas overrides each variant's minimum replicas while active.
+	MinReplicas *int `yaml:"min_replicas,omitempty" json:"min_replicas,omitempty"`
+	// MaxReplicas overrides each variant's maximum replicas while active.
+	MaxReplicas *int `yaml:"max_replicas,omitempty" json:"max_replicas,omitempty"`
+	// EnableScaleToZero overrides the model's scale-to-zero setting while active.
+	EnableScaleToZero *bool `yaml:"enable_scale_to_zero,omitempty" json:"enable_scale_to_zero,omitempty"`
+	// Source is the rule source reported in VA status (default ConfigMap).
+	Source string `yaml:"-" json:"-"`
+}
+
+// Rule validates the schedule and converts it for evaluation.
+func (s ScheduleConfig) Rule() (schedule.Rule, error) {
+	duration, err := time.ParseDuration(s.Duration)
+	if err != nil {
+		return schedule.Rule{}, fmt.Errorf("schedule %q: invalid duration %q: %w", s.Name, s.Duration, err)
+	}
+	return schedule.NewRule(schedule.RuleSpec{
+		Name:        s.Name,
+		Schedule:    s.Schedule,
+		TimeZone:    s.TimeZone,
+		Duration:    duration,
+		MinReplicas: s.MinReplicas,
+		MaxReplicas: s.MaxReplicas,
+		ScaleToZero: s.EnableScaleToZero,
+	}, cmp.Or(s.Source, schedule.SourceConfigMap))
 }
 
 // ScaleToZeroConfigData holds pre-read scale-to-zero configuration data for all models.
@@ -148,6 +193,25 @@ func ScaleToZeroRetentionPeriod(configData ScaleToZeroConfigData, modelID string
 	return DefaultScaleToZeroRetentionPeriod
 }
 
+// ModelScheduleRules returns the scaling schedules for a specific model.
+// Configuration priority (highest to lowest):
+// 1. Per-model schedules in ConfigMap
+// 2. Global defaults schedules in ConfigMap
+// Invalid schedules are skipped; they are logged when the ConfigMap is parsed.
+func ModelScheduleRules(configData ScaleToZeroConfigData, modelID string) []schedule.Rule {
+	schedules := configData[GlobalDefaultsKey].Schedules
+	if config, exists := configData[modelID]; exists && len(config.Schedules) > 0 {
+		schedules = config.Schedules
+	}
+	rules := make([]schedule.Rule, 0, len(schedules))
+	for _, s := range schedules {
+		if rule, err := s.Rule(); err == nil {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
 // MinNumReplicas returns the minimum number of replicas for a specific model based on
 // scale-to-zero configuration. Returns 0 if scale-to-zero is enabled, otherwise returns 1.
 func MinNumReplicas(configData ScaleToZeroConfigData, modelID string) int {
@@ -162,36 +226,33 @@ func MinNumReplicas(configData ScaleToZeroConfigData, modelID string) int {
 //   - "default": global defaults for all models
 //   - "<override-name>": per-model configuration with model_id field
 //
-// Returns an empty map if the data is nil or empty.
-func ParseScaleToZeroConfigMap(data map[string]string) ScaleToZeroConfigData {
-	if data == nil {
-		return make(ScaleToZeroConfigData)
-	}
-
+// Invalid entries are skipped, and invalid retention periods and schedules are
+// dropped from their entry; their errors are returned as *EntryError, sorted
+// by key. Returns an empty map if the data is nil or empty.
+func ParseScaleToZeroConfigMap(data map[string]string) (ScaleToZeroConfigData, []error) {
 	out := make(ScaleToZeroConfigData)
-	// Track which keys define which modelIDs to detect duplicates
-	modelIDToKeys := make(map[string][]string)
-
-	// Sort keys to ensure deterministic processing order
-	// This is critical because map iteration in Go is non-deterministic.
-	// If there are duplicate modelIDs, the lexicographically first key will win.
-	keys := make([]string, 0, len(data))
-	for k := range data {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	for _, key := range keys {
-		configStr := data[key]
+	var errs []error
+	// Track which key defines each modelID to detect duplicates
+	modelIDToKey := make(map[string]string)
 
+	// Keys are processed in sorted order: this is critical because map iteration
+	// in Go is non-deterministic. If there are duplicate modelIDs, the
+	// lexicographically first key wins.
+	for _, key := range sortedKeys(data) {
 		var config ModelScaleToZeroConfig
-		if err := yaml.Unmarshal([]byte(configStr), &config); err != nil {
-			ctrl.Log.Info("Failed to parse scale-to-zero config entry, skipping",
-				"key", key,
-				"error", err)
+		if err := yaml.Unmarshal([]byte(data[key]), &config); err != nil {
+			errs = append(errs, &EntryError{Key: key, Err: err})
 			continue
 		}
 
+		if config.RetentionPeriod != "" {
+			if _, err := ValidateRetentionPeriod(config.RetentionPeriod); err != nil {
+				errs = append(errs, &EntryError{Key: key, Err: fmt.Errorf("retention_period: %w", err)})
+				config.RetentionPeriod = ""
+			}
+		}
+		config.Schedules = validSchedules(key, config.Schedules, &errs)
+
 		// Handle global defaults (special key)
 		if key == GlobalDefaultsKey {
 			out[GlobalDefaultsKey] = config
@@ -200,21 +261,17 @@ func ParseScaleToZeroConfigMap(data map[string]string) ScaleToZeroConfigData {
 
 		// Handle per-model overrides (must include model_id)
 		if config.ModelID == "" {
-			ctrl.Log.Info("Skipping scale-to-zero config without model_id field",
-				"key", key)
+			errs = append(errs, &EntryError{Key: key, Err: errors.New("model_id is required")})
 			continue
 		}
 
-		// Check for duplicate modelID
-		if existingKeys, exists := modelIDToKeys[config.ModelID]; exists {
-			ctrl.Log.Info("Duplicate model_id found in scale-to-zero ConfigMap - first key wins",
-				"model_id", config.ModelID,
-				"winningKey", existingKeys[0],
-				"duplicateKey", key)
-			// Skip this duplicate - first key already processed wins
+		// Skip duplicate modelIDs - the first key already processed wins
+		if winningKey, exists := modelIDToKey[config.ModelID]; exists {
+			errs = append(errs, &EntryError{Key: key,
+				Err: fmt.Errorf("duplicate model_id %q, entry %q takes precedence", config.ModelID, winningKey)})
 			continue
 		}
-		modelIDToKeys[config.ModelID] = append(modelIDToKeys[config.ModelID], key)
+		modelIDToKey[config.ModelID] = key
 
 		out[config.ModelID] = config
 	}
@@ -222,5 +279,22 @@ func ParseScaleToZeroConfigMap(data map[string]string) ScaleToZeroConfigData {
 	ctrl.Log.V(logging.DEBUG).Info("Parsed scale-to-zero config",
 		"modelCount", len(out))
 
-	return out
+	return out, errs
+}
+
+// validSchedules drops the invalid schedules of a ConfigMap entry and appends
+// their errors to errs.
+func validSchedules(key string, schedules []ScheduleConfig, errs *[]error) []ScheduleConfig {
+	if len(schedules) == 0 {
+		return schedules
+	}
+	valid := make([]ScheduleConfig, 0, len(schedules))
+	for _, s := range schedules {
+		if _, err := s.Rule(); err != nil {
+			*errs = append(*errs, &EntryError{Key: key, Err: err})
+			continue
+		}
+		valid = append(valid, s)
+	}
+	return valid
 }
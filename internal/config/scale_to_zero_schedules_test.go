@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScaleToZeroConfigMap_Schedules(t *testing.T) {
+	data, errs := ParseScaleToZeroConfigMap(map[string]string{
+		GlobalDefaultsKey: `
+enable_scale_to_zero: true
+schedules:
+  - name: nights
+    schedule: "0 22 * * *"
+    duration: 10h
+    enable_scale_to_zero: true
+`,
+		"llama": `
+model_id: meta/llama
+schedules:
+  - name: business-hours
+    schedule: "0 8 * * mon-fri"
+    time_zone: Europe/Berlin
+    duration: 10h
+    min_replicas: 2
+  - name: broken
+    schedule: "0 25 * * *"
+    duration: 1h
+    min_replicas: 1
+  - name: no-duration
+    schedule: "@daily"
+    max_replicas: 1
+`,
+		"mistral": "model_id: mistral\n",
+	})
+
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		var entryErr *EntryError
+		require.ErrorAs(t, err, &entryErr)
+		assert.Equal(t, "llama", entryErr.Key)
+	}
+	require.Len(t, data["meta/llama"].Schedules, 1, "invalid schedules are dropped")
+	assert.Equal(t, "business-hours", data["meta/llama"].Schedules[0].Name)
+
+	rules := ModelScheduleRules(data, "meta/llama")
+	require.Len(t, rules, 1)
+	assert.Equal(t, "business-hours", rules[0].Name)
+	assert.Equal(t, 2, *rules[0].MinReplicas)
+
+	rules = ModelScheduleRules(data, "mistral")
+	require.Len(t, rules, 1, "models without schedules inherit the defaults")
+	assert.Equal(t, "nights", rules[0].Name)
+
+	assert.Empty(t, ModelScheduleRules(ScaleToZeroConfigData{}, "mistral"))
+}
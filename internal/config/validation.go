@@ -26,6 +26,37 @@ func Validate(cfg *Config) error {
 		return fmt.Errorf("scale-from-zero max concurrency must be positive, got %d", cfg.ScaleFromZeroMaxConcurrency())
 	}
 
+	// Decision recorder sink must be known and its bounds positive
+	recorder := cfg.DecisionRecorder()
+	switch recorder.Sink {
+	case "":
+	case DecisionRecorderSinkFile:
+		if recorder.Path == "" || recorder.MaxBytes <= 0 || recorder.MaxFiles < 0 {
+			return fmt.Errorf("decision recorder file sink needs a path, positive max bytes and non-negative max files, got %q, %d, %d",
+				recorder.Path, recorder.MaxBytes, recorder.MaxFiles)
+		}
+	case DecisionRecorderSinkConfigMap:
+		if recorder.ConfigMapName == "" || recorder.MaxRecords <= 0 {
+			return fmt.Errorf("decision recorder configmap sink needs a name and positive max records, got %q, %d",
+				recorder.ConfigMapName, recorder.MaxRecords)
+		}
+	default:
+		return fmt.Errorf("unknown decision recorder sink %q, expected %s or %s",
+			recorder.Sink, DecisionRecorderSinkFile, DecisionRecorderSinkConfigMap)
+	}
+
+	// Tracing exporter must be known and the sample ratio a fraction
+	tracing := cfg.Tracing()
+	switch tracing.Exporter {
+	case "", TracingExporterOTLP, TracingExporterStdout:
+	default:
+		return fmt.Errorf("unknown tracing exporter %q, expected %s or %s",
+			tracing.Exporter, TracingExporterOTLP, TracingExporterStdout)
+	}
+	if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+		return fmt.Errorf("tracing sample ratio must be in [0, 1], got %v", tracing.SampleRatio)
+	}
+
 	return nil
 }
 
@@ -44,6 +75,9 @@ type ImmutableParameterChange struct {
 // - PROMETHEUS_BASE_URL (connection endpoint)
 // - METRICS_BIND_ADDRESS (infrastructure)
 // - HEALTH_PROBE_BIND_ADDRESS (infrastructure)
+// - EXTERNAL_METRICS_BIND_ADDRESS (infrastructure)
+// - KEDA_SCALER_BIND_ADDRESS (infrastructure)
+// - FEDERATION_BIND_ADDRESS and FEDERATION_CLUSTER_NAME (infrastructure)
 // - LEADER_ELECTION_ID (coordination)
 // - TLS certificate paths (security-sensitive)
 //
@@ -95,6 +129,52 @@ func DetectImmutableParameterChanges(cfg *Config, configMapData map[string]strin
 		}
 	}
 
+	// Check EXTERNAL_METRICS_BIND_ADDRESS
+	if newAddr, ok := configMapData["EXTERNAL_METRICS_BIND_ADDRESS"]; ok {
+		currentAddr := cfg.ExternalMetrics().BindAddress
+		if newAddr != currentAddr {
+			changes = append(changes, ImmutableParameterChange{
+				Key:       "EXTERNAL_METRICS_BIND_ADDRESS",
+				OldValue:  currentAddr,
+				NewValue:  newAddr,
+				Parameter: "External metrics bind address",
+			})
+		}
+	}
+
+	// Check KEDA_SCALER_BIND_ADDRESS
+	if newAddr, ok := configMapData["KEDA_SCALER_BIND_ADDRESS"]; ok {
+		currentAddr := cfg.KedaScaler().BindAddress
+		if newAddr != currentAddr {
+			changes = append(changes, ImmutableParameterChange{
+				Key:       "KEDA_SCALER_BIND_ADDRESS",
+				OldValue:  currentAddr,
+				NewValue:  newAddr,
+				Parameter: "KEDA scaler bind address",
+			})
+		}
+	}
+
+	// Check FEDERATION_BIND_ADDRESS and FEDERATION_CLUSTER_NAME
+	federationKeys := []struct {
+		key       string
+		current   string
+		paramName string
+	}{
+		{"FEDERATION_BIND_ADDRESS", cfg.Federation().BindAddress, "Federation bind address"},
+		{"FEDERATION_CLUSTER_NAME", cfg.Federation().ClusterName, "Federation cluster name"},
+	}
+	for _, federationKey := range federationKeys {
+		if newValue, ok := configMapData[federationKey.key]; ok && newValue != federationKey.current {
+			changes = append(changes, ImmutableParameterChange{
+				Key:       federationKey.key,
+				OldValue:  federationKey.current,
+				NewValue:  newValue,
+				Parameter: federationKey.paramName,
+			})
+		}
+	}
+
 	// Check LEADER_ELECTION_ID
 	if newID, ok := configMapData["LEADER_ELECTION_ID"]; ok {
 		currentID := cfg.LeaderElectionID()
@@ -121,6 +201,17 @@ func DetectImmutableParameterChanges(cfg *Config, configMapData map[string]strin
 		{"METRICS_CERT_PATH", cfg.MetricsCertPath, "Metrics certificate path"},
 		{"METRICS_CERT_NAME", cfg.MetricsCertName, "Metrics certificate name"},
 		{"METRICS_CERT_KEY", cfg.MetricsCertKey, "Metrics certificate key"},
+		{"EXTERNAL_METRICS_CERT_PATH", func() string { return cfg.ExternalMetrics().CertPath }, "External metrics certificate path"},
+		{"EXTERNAL_METRICS_CERT_NAME", func() string { return cfg.ExternalMetrics().CertName }, "External metrics certificate name"},
+		{"EXTERNAL_METRICS_CERT_KEY", func() string { return cfg.ExternalMetrics().CertKey }, "External metrics certificate key"},
+		{"KEDA_SCALER_CERT_PATH", func() string { return cfg.KedaScaler().CertPath }, "KEDA scaler certificate path"},
+		{"KEDA_SCALER_CERT_NAME", func() string { return cfg.KedaScaler().CertName }, "KEDA scaler certificate name"},
+		{"KEDA_SCALER_CERT_KEY", func() string { return cfg.KedaScaler().CertKey }, "KEDA scaler certificate key"},
+		{"KEDA_SCALER_CLIENT_CA_CERT_PATH", func() string { return cfg.KedaScaler().ClientCACertPath }, "KEDA scaler client CA certificate path"},
+		{"FEDERATION_CERT_PATH", func() string { return cfg.Federation().CertPath }, "Federation certificate path"},
+		{"FEDERATION_CERT_NAME", func() string { return cfg.Federation().CertName }, "Federation certificate name"},
+		{"FEDERATION_CERT_KEY", func() string { return cfg.Federation().CertKey }, "Federation certificate key"},
+		{"FEDERATION_CA_CERT_PATH", func() string { return cfg.Federation().CACertPath }, "Federation CA certificate path"},
 	}
 
 	for _, tlsKey := range tlsKeys {
@@ -25,6 +25,23 @@ const (
 	// even if the namespace has VAs or opt-in labels.
 	// This provides explicit control to exclude namespaces from WVA management.
 	NamespaceExcludeAnnotationKey = "wva.llmd.ai/exclude"
+
+	// ShadowModeAnnotationKey is the annotation key used to run VariantAutoscalings in shadow mode.
+	// It can be set on a VariantAutoscaling or on its namespace; the VA annotation takes precedence,
+	// so a VA can opt out of a shadowed namespace with "false". In shadow mode the full pipeline runs
+	// and decisions are recorded, but wva_desired_replicas reports the current replicas and the
+	// scale target is never scaled directly.
+	ShadowModeAnnotationKey = "wva.llmd.ai/shadow"
+
+	// DrainStartedAnnotationKey is the annotation key set on the pods picked for removal by a graceful
+	// scale-down, holding the RFC 3339 time the pod started draining. It marks the pods being drained,
+	// so that the drain survives controller restarts, and is removed when the scale-down is cancelled.
+	DrainStartedAnnotationKey = "wva.llmd.ai/drain-started"
+
+	// DrainCordonLabelAnnotationKey is the annotation key holding the key of the cordon label set on a
+	// draining pod, so that the label of the pod template can be restored when the scale-down is
+	// cancelled, even after the cordon label of the VariantAutoscaling changed.
+	DrainCordonLabelAnnotationKey = "wva.llmd.ai/drain-cordon-label"
 )
 
 // AnnotationValueTrue is the canonical string value for boolean annotations and labels.
@@ -108,6 +108,86 @@ const (
 	// WVADesiredRatio is a gauge that tracks the ratio of desired to current replicas.
 	// Labels: variant_name, namespace, accelerator_type
 	WVADesiredRatio = "wva_desired_ratio"
+
+	// WVAReplicaStartupLatencySeconds is a histogram of observed replica startup latency.
+	// Phase "ready" covers pod creation → Ready; phase "first_request" covers Ready → first served request.
+	// Labels: variant_name, namespace, phase
+	WVAReplicaStartupLatencySeconds = "wva_replica_startup_latency_seconds"
+
+	// WVAExpectedStartupSeconds is a gauge of the learned startup lead time (creation → first request)
+	// used for cold-start-aware scale-up.
+	// Labels: variant_name, namespace
+	WVAExpectedStartupSeconds = "wva_expected_startup_seconds"
+
+	// WVAColdStartLookaheadCapacity is a gauge of the capacity added on top of the analyzer's
+	// required capacity to cover demand growth expected while new replicas start.
+	// Labels: model_name, namespace
+	WVAColdStartLookaheadCapacity = "wva_cold_start_lookahead_capacity"
+
+	// WVAAnalyzerSupply, WVAAnalyzerDemand, WVAAnalyzerUtilization, WVAAnalyzerRequiredCapacity and
+	// WVAAnalyzerSpareCapacity are gauges of the V2 and queueing model analyzer results per model, in
+	// analyzer units. Role is "all" for the model totals, or "prefill"/"decode" for P/D disaggregated models.
+	// Labels: model_name, namespace, analyzer, role
+	WVAAnalyzerSupply           = "wva_analyzer_supply"
+	WVAAnalyzerDemand           = "wva_analyzer_demand"
+	WVAAnalyzerUtilization      = "wva_analyzer_utilization"
+	WVAAnalyzerRequiredCapacity = "wva_analyzer_required_capacity"
+	WVAAnalyzerSpareCapacity    = "wva_analyzer_spare_capacity"
+
+	// WVAAnalyzerRecommendedReplicas is a gauge of the replicas recommended for a model by the primary
+	// analyzer and by each analyzer run alongside it for comparison (compareAnalyzers), as sized by the
+	// optimizer without GPU constraints and before the enforcer.
+	// Labels: model_name, namespace, analyzer
+	WVAAnalyzerRecommendedReplicas = "wva_analyzer_recommended_replicas"
+
+	// WVAAnalyzerReplicaDivergence is a gauge of the replicas recommended for a model by a comparison
+	// analyzer minus the replicas recommended by the primary analyzer.
+	// Labels: model_name, namespace, analyzer, primary
+	WVAAnalyzerReplicaDivergence = "wva_analyzer_replica_divergence"
+
+	// WVAOptimizationCycleDurationSeconds is a histogram of the duration of the saturation engine's
+	// optimization cycles, from listing the VariantAutoscalings to applying the decisions.
+	// Labels: analyzer
+	WVAOptimizationCycleDurationSeconds = "wva_optimization_cycle_duration_seconds"
+
+	// WVAMetricsQueryDurationSeconds is a histogram of the latency of metrics queries, including retries.
+	// Labels: source, query
+	WVAMetricsQueryDurationSeconds = "wva_metrics_query_duration_seconds"
+
+	// WVALimiterCutsTotal is a counter of decisions whose target the limiter reduced.
+	// Labels: variant_name, namespace, limited_by
+	WVALimiterCutsTotal = "wva_limiter_cuts_total"
+
+	// WVASafetyOverridesTotal is a counter of decisions overridden for safety: "saturation_veto" when
+	// saturation vetoed a model-based decision, "safety_net" when analysis failed and the current
+	// replicas were kept.
+	// Labels: variant_name, namespace, reason
+	WVASafetyOverridesTotal = "wva_safety_overrides_total"
+
+	// WVAScaleToZeroTotal is a counter of decisions scaling a variant to zero replicas.
+	// Labels: variant_name, namespace
+	WVAScaleToZeroTotal = "wva_scale_to_zero_total"
+
+	// WVAScaleFromZeroTotal is a counter of scale-from-zero actuations.
+	// Labels: variant_name, namespace
+	WVAScaleFromZeroTotal = "wva_scale_from_zero_total"
+
+	// WVAModelSkipsTotal is a counter of models skipped in an optimization cycle, by reason:
+	// "no_metrics" when no fresh replica metrics were available, "collection_failed",
+	// "analysis_failed" or "config_missing".
+	// Labels: model_name, namespace, reason
+	WVAModelSkipsTotal = "wva_model_skips_total"
+
+	// WVAShadowDesiredReplicas is a gauge of the replicas WVA recommends for a variant in shadow mode,
+	// while wva_desired_replicas reports its current replicas.
+	// Labels: variant_name, namespace, accelerator_type
+	WVAShadowDesiredReplicas = "wva_shadow_desired_replicas"
+
+	// WVAShadowReplicaDifference is a gauge of the replicas WVA recommends for a variant in shadow mode
+	// minus the replicas of the baseline: "hpa" when an HPA scales the variant (its desired replicas),
+	// "current" otherwise (the current replicas).
+	// Labels: variant_name, namespace, baseline
+	WVAShadowReplicaDifference = "wva_shadow_replica_difference"
 )
 
 // Metric Label Names
@@ -120,4 +200,12 @@ const (
 	LabelReason             = "reason"
 	LabelAcceleratorType    = "accelerator_type"
 	LabelControllerInstance = "controller_instance"
+	LabelPhase              = "phase"
+	LabelAnalyzer           = "analyzer"
+	LabelRole               = "role"
+	LabelSource             = "source"
+	LabelQuery              = "query"
+	LabelLimitedBy          = "limited_by"
+	LabelBaseline           = "baseline"
+	LabelPrimary            = "primary"
 )
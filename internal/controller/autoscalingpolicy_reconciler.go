@@ -0,0 +1,346 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/autoscalingpolicy"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// maxPolicyStatusTargets is the maximum number of VAs listed in the status of a policy.
+const maxPolicyStatusTargets = 100
+
+// autoscalingPolicyRequest is the single request the AutoscalingPolicyReconciler
+// resolves all policies on: any policy or VA change can change which policy
+// applies to a model.
+var autoscalingPolicyRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: "autoscaling-policies"}}
+
+// AutoscalingPolicyReconciler resolves AutoscalingPolicies and
+// ClusterAutoscalingPolicies over the VariantAutoscalings of the cluster,
+// applies the resulting per-model overrides to the unified Config and reports
+// the VAs each policy applies to in its status.
+type AutoscalingPolicyReconciler struct {
+	client.Client
+	Config *config.Config
+}
+
+// +kubebuilder:rbac:groups=llmd.ai,resources=autoscalingpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=llmd.ai,resources=autoscalingpolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=llmd.ai,resources=clusterautoscalingpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=llmd.ai,resources=clusterautoscalingpolicies/status,verbs=get;update;patch
+
+// Reconcile resolves all policies. Invalid policies are reported in their
+// status and not applied.
+func (r *AutoscalingPolicyReconciler) Reconcile(ctx context.Context, _ ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var policyList llmdVariantAutoscalingV1alpha1.AutoscalingPolicyList
+	if err := r.List(ctx, &policyList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing autoscaling policies: %w", err)
+	}
+	var clusterPolicyList llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicyList
+	if err := r.List(ctx, &clusterPolicyList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing cluster autoscaling policies: %w", err)
+	}
+	var vaList llmdVariantAutoscalingV1alpha1.VariantAutoscalingList
+	if err := r.List(ctx, &vaList); err != nil {
+		return ctrl.Result{}, fmt.Errorf("listing variant autoscalings: %w", err)
+	}
+
+	// Saturation overrides are validated against the global defaults.
+	base := r.Config.SaturationConfig()[config.GlobalDefaultsKey]
+
+	var (
+		policies []*autoscalingpolicy.Policy
+		valid    []client.Object // in the order of policies
+		invalid  []invalidPolicy
+	)
+	needNamespaceLabels := false
+	for i := range policyList.Items {
+		obj := &policyList.Items[i]
+		p, err := autoscalingpolicy.FromAutoscalingPolicy(obj, base)
+		if err != nil {
+			invalid = append(invalid, invalidPolicy{obj, err})
+			continue
+		}
+		policies = append(policies, p)
+		valid = append(valid, obj)
+	}
+	for i := range clusterPolicyList.Items {
+		obj := &clusterPolicyList.Items[i]
+		p, err := autoscalingpolicy.FromClusterAutoscalingPolicy(obj, base)
+		if err != nil {
+			invalid = append(invalid, invalidPolicy{obj, err})
+			continue
+		}
+		needNamespaceLabels = needNamespaceLabels || obj.Spec.NamespaceSelector != nil
+		policies = append(policies, p)
+		valid = append(valid, obj)
+	}
+
+	vas := make([]llmdVariantAutoscalingV1alpha1.VariantAutoscaling, 0, len(vaList.Items))
+	for _, va := range vaList.Items {
+		if va.DeletionTimestamp.IsZero() {
+			vas = append(vas, va)
+		}
+	}
+	namespaceLabels, err := r.namespaceLabels(ctx, vas, needNamespaceLabels)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result := autoscalingpolicy.Resolve(policies, vas, namespaceLabels, r.Config.ValidateSaturationPolicy)
+	r.Config.UpdatePolicyOverrides(result.Overrides)
+	logger.V(logging.DEBUG).Info("Resolved autoscaling policies",
+		"policies", len(policies),
+		"invalidPolicies", len(invalid),
+		"namespaces", len(result.Overrides))
+
+	var errs []error
+	for i, obj := range valid {
+		if result.Errors[i] != nil {
+			logger.Info("Autoscaling policy is invalid for some models, not applied to them",
+				"kind", policyKind(obj),
+				"namespace", obj.GetNamespace(),
+				"name", obj.GetName(),
+				"error", result.Errors[i].Error())
+		}
+		setPolicyStatus(policyStatus(obj), obj.GetGeneration(), result.Targets[i], result.Errors[i])
+		if err := r.patchPolicyStatus(ctx, obj); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, p := range invalid {
+		logger.Info("Invalid autoscaling policy, not applied",
+			"kind", policyKind(p.obj),
+			"namespace", p.obj.GetNamespace(),
+			"name", p.obj.GetName(),
+			"error", p.err.Error())
+		setPolicyStatus(policyStatus(p.obj), p.obj.GetGeneration(), nil, p.err)
+		if err := r.patchPolicyStatus(ctx, p.obj); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return ctrl.Result{}, errors.Join(errs...)
+}
+
+// invalidPolicy is a policy that failed validation.
+type invalidPolicy struct {
+	obj client.Object
+	err error
+}
+
+// namespaceLabels returns the labels of the namespaces of the VAs, when a
+// cluster policy selects namespaces.
+func (r *AutoscalingPolicyReconciler) namespaceLabels(
+	ctx context.Context,
+	vas []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	needed bool,
+) (map[string]labels.Set, error) {
+	if !needed {
+		return nil, nil
+	}
+	result := make(map[string]labels.Set)
+	for _, va := range vas {
+		if _, ok := result[va.Namespace]; ok {
+			continue
+		}
+		ns := &corev1.Namespace{}
+		if err := r.Get(ctx, client.ObjectKey{Name: va.Namespace}, ns); err != nil {
+			if apierrors.IsNotFound(err) {
+				result[va.Namespace] = nil
+				continue
+			}
+			return nil, fmt.Errorf("getting namespace %s: %w", va.Namespace, err)
+		}
+		result[va.Namespace] = ns.Labels
+	}
+	return result, nil
+}
+
+// setPolicyStatus sets the status of a policy from its targets and validation
+// error. A policy failing validation has no targets; a policy whose saturation
+// section is invalid for some models still applies to the other models.
+func setPolicyStatus(
+	status *llmdVariantAutoscalingV1alpha1.AutoscalingPolicyStatus,
+	generation int64,
+	targets []llmdVariantAutoscalingV1alpha1.PolicyTarget,
+	validationErr error,
+) {
+	status.ObservedGeneration = generation
+	status.TargetCount = int32(len(targets))
+	if len(targets) > maxPolicyStatusTargets {
+		targets = targets[:maxPolicyStatusTargets]
+	}
+	status.Targets = targets
+
+	valid := metav1.Condition{
+		Type:               llmdVariantAutoscalingV1alpha1.TypePolicyValid,
+		Status:             metav1.ConditionTrue,
+		Reason:             llmdVariantAutoscalingV1alpha1.ReasonPolicyValid,
+		Message:            "The policy configuration is valid",
+		ObservedGeneration: generation,
+	}
+	if validationErr != nil {
+		valid.Status = metav1.ConditionFalse
+		valid.Reason = llmdVariantAutoscalingV1alpha1.ReasonPolicyInvalid
+		valid.Message = strings.ReplaceAll(validationErr.Error(), "\n", "; ")
+	}
+	meta.SetStatusCondition(&status.Conditions, valid)
+
+	applied := metav1.Condition{
+		Type:               llmdVariantAutoscalingV1alpha1.TypePolicyApplied,
+		Status:             metav1.ConditionFalse,
+		Reason:             llmdVariantAutoscalingV1alpha1.ReasonPolicyNoTargets,
+		Message:            "The policy selects no VariantAutoscaling",
+		ObservedGeneration: generation,
+	}
+	if len(targets) > 0 {
+		applied.Reason = llmdVariantAutoscalingV1alpha1.ReasonPolicyOverridden
+		applied.Message = "Policies with higher precedence override all sections of the policy"
+		for _, t := range targets {
+			if len(t.Sections) > 0 {
+				applied.Status = metav1.ConditionTrue
+				applied.Reason = llmdVariantAutoscalingV1alpha1.ReasonPolicyApplied
+				applied.Message = fmt.Sprintf("The policy applies to %d VariantAutoscalings", status.TargetCount)
+				break
+			}
+		}
+	}
+	if validationErr != nil && applied.Status == metav1.ConditionFalse {
+		applied.Reason = llmdVariantAutoscalingV1alpha1.ReasonPolicyInvalid
+		applied.Message = "The policy is not applied until its configuration is fixed"
+	}
+	meta.SetStatusCondition(&status.Conditions, applied)
+}
+
+// patchPolicyStatus patches the status of a policy, if it changed.
+func (r *AutoscalingPolicyReconciler) patchPolicyStatus(ctx context.Context, obj client.Object) error {
+	var original client.Object
+	switch o := obj.(type) {
+	case *llmdVariantAutoscalingV1alpha1.AutoscalingPolicy:
+		current := &llmdVariantAutoscalingV1alpha1.AutoscalingPolicy{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(o), current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if equality.Semantic.DeepEqual(current.Status, o.Status) {
+			return nil
+		}
+		original = current
+	case *llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicy:
+		current := &llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicy{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(o), current); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		if equality.Semantic.DeepEqual(current.Status, o.Status) {
+			return nil
+		}
+		original = current
+	}
+	if err := r.Status().Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
+
+func policyStatus(obj client.Object) *llmdVariantAutoscalingV1alpha1.AutoscalingPolicyStatus {
+	switch o := obj.(type) {
+	case *llmdVariantAutoscalingV1alpha1.AutoscalingPolicy:
+		return &o.Status
+	case *llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicy:
+		return &o.Status
+	}
+	return nil
+}
+
+func policyKind(obj client.Object) string {
+	if _, ok := obj.(*llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicy); ok {
+		return "ClusterAutoscalingPolicy"
+	}
+	return "AutoscalingPolicy"
+}
+
+// configMapResolveDelay is how long after a saturation or queueing model
+// ConfigMap change policies are resolved, for the ConfigMapReconciler to load
+// the ConfigMap first.
+const configMapResolveDelay = 2 * time.Second
+
+// SetupWithManager sets up the controller with the Manager.
+// Policy spec changes, VA creation, deletion, label and model ID changes,
+// namespace label changes, and saturation and queueing model ConfigMap changes
+// trigger a resolution.
+func (r *AutoscalingPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{autoscalingPolicyRequest}
+	})
+	enqueueAfterLoad := func(q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		q.AddAfter(autoscalingPolicyRequest, configMapResolveDelay)
+	}
+	configMapChanged := handler.Funcs{
+		CreateFunc: func(_ context.Context, _ event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueAfterLoad(q)
+		},
+		UpdateFunc: func(_ context.Context, _ event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueAfterLoad(q)
+		},
+		DeleteFunc: func(_ context.Context, _ event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			enqueueAfterLoad(q)
+		},
+	}
+	policyConfigMaps := predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		name := obj.GetName()
+		return name == config.SaturationConfigMapName() || name == config.QMAnalyzerConfigMapName()
+	})
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("autoscalingpolicy").
+		Watches(&llmdVariantAutoscalingV1alpha1.AutoscalingPolicy{}, enqueue,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&llmdVariantAutoscalingV1alpha1.ClusterAutoscalingPolicy{}, enqueue,
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}, enqueue,
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Watches(&corev1.Namespace{}, enqueue,
+			builder.WithPredicates(predicate.LabelChangedPredicate{})).
+		Watches(&corev1.ConfigMap{}, configMapChanged,
+			builder.WithPredicates(policyConfigMaps)).
+		Complete(r)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+var _ = Describe("AutoscalingPolicyReconciler", func() {
+	const (
+		namespace = "autoscaling-policy-test"
+		modelID   = "meta/llama"
+	)
+
+	var (
+		ctx        context.Context
+		cfg        *config.Config
+		reconciler *AutoscalingPolicyReconciler
+		objects    []client.Object
+		low, high  *llmdVariantAutoscalingV1alpha1.AutoscalingPolicy
+	)
+
+	create := func(obj client.Object) {
+		Expect(k8sClient.Create(ctx, obj)).To(Succeed())
+		objects = append(objects, obj)
+	}
+
+	newVA := func(name, modelID string, labels map[string]string) *llmdVariantAutoscalingV1alpha1.VariantAutoscaling {
+		return &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{Kind: "Deployment", Name: name},
+				ModelID:        modelID,
+				MaxReplicas:    2,
+			},
+		}
+	}
+
+	newPolicy := func(name string, priority int32, kvCacheThreshold string) *llmdVariantAutoscalingV1alpha1.AutoscalingPolicy {
+		return &llmdVariantAutoscalingV1alpha1.AutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: llmdVariantAutoscalingV1alpha1.AutoscalingPolicySpec{
+				Priority:   priority,
+				Saturation: &llmdVariantAutoscalingV1alpha1.SaturationPolicy{KvCacheThreshold: ptr.To(kvCacheThreshold)},
+			},
+		}
+	}
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(ctx, autoscalingPolicyRequest)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getStatus := func(name string) llmdVariantAutoscalingV1alpha1.AutoscalingPolicyStatus {
+		policy := &llmdVariantAutoscalingV1alpha1.AutoscalingPolicy{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, policy)).To(Succeed())
+		return policy.Status
+	}
+
+	kvCacheThreshold := func() float64 {
+		return cfg.ModelConfig(namespace, modelID).Saturation.KvCacheThreshold
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		logging.NewTestLogger()
+		objects = nil
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(ctx, ns))).To(Succeed())
+
+		cfg = config.NewTestConfig()
+		cfg.UpdateSaturationConfig(map[string]config.SaturationScalingConfig{
+			"default": {KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3},
+		})
+		reconciler = &AutoscalingPolicyReconciler{Client: k8sClient, Config: cfg}
+
+		create(newVA("llama-a100", modelID, map[string]string{"team": "a"}))
+		create(newVA("llama-h100", modelID, nil))
+		create(newVA("granite-a100", "ibm/granite", nil))
+
+		// both policies set the saturation section of the model
+		low = newPolicy("low", 1, "0.7")
+		low.Spec.ModelIDs = []string{modelID}
+		create(low)
+		high = newPolicy("high", 10, "0.9")
+		high.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}}
+		create(high)
+	})
+
+	AfterEach(func() {
+		for _, obj := range objects {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, obj))).To(Succeed())
+		}
+	})
+
+	It("should apply the policy with the highest priority", func() {
+		reconcile()
+
+		Expect(kvCacheThreshold()).To(BeNumerically("~", 0.9, 1e-9))
+		Expect(cfg.ModelConfig(namespace, "ibm/granite").Saturation.KvCacheThreshold).To(BeNumerically("~", 0.8, 1e-9))
+
+		lowStatus := getStatus("low")
+		Expect(meta.IsStatusConditionTrue(lowStatus.Conditions, llmdVariantAutoscalingV1alpha1.TypePolicyValid)).To(BeTrue())
+		applied := meta.FindStatusCondition(lowStatus.Conditions, llmdVariantAutoscalingV1alpha1.TypePolicyApplied)
+		Expect(applied).NotTo(BeNil())
+		Expect(applied.Status).To(Equal(metav1.ConditionFalse))
+		Expect(applied.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonPolicyOverridden))
+
+		highStatus := getStatus("high")
+		applied = meta.FindStatusCondition(highStatus.Conditions, llmdVariantAutoscalingV1alpha1.TypePolicyApplied)
+		Expect(applied).NotTo(BeNil())
+		Expect(applied.Status).To(Equal(metav1.ConditionTrue))
+		Expect(applied.Reason).To(Equal(llmdVariantAutoscalingV1alpha1.ReasonPolicyApplied))
+	})
+
+	It("should list the VAs each policy applies to in its status", func() {
+		reconcile()
+
+		lowStatus := getStatus("low")
+		Expect(lowStatus.TargetCount).To(Equal(int32(2)))
+		Expect(lowStatus.Targets).To(Equal([]llmdVariantAutoscalingV1alpha1.PolicyTarget{
+			{Namespace: namespace, Name: "llama-a100", ModelID: modelID},
+			{Namespace: namespace, Name: "llama-h100", ModelID: modelID},
+		}))
+
+		highStatus := getStatus("high")
+		Expect(highStatus.TargetCount).To(Equal(int32(1)))
+		Expect(highStatus.Targets).To(Equal([]llmdVariantAutoscalingV1alpha1.PolicyTarget{
+			{Namespace: namespace, Name: "llama-a100", ModelID: modelID,
+				Sections: []string{llmdVariantAutoscalingV1alpha1.PolicySectionSaturation}},
+		}))
+	})
+
+	It("should fall back to the next policy, then to the ConfigMap, when policies are deleted", func() {
+		reconcile()
+		Expect(kvCacheThreshold()).To(BeNumerically("~", 0.9, 1e-9))
+
+		By("Deleting the policy with the highest priority")
+		Expect(k8sClient.Delete(ctx, high)).To(Succeed())
+		reconcile()
+		Expect(kvCacheThreshold()).To(BeNumerically("~", 0.7, 1e-9))
+		lowStatus := getStatus("low")
+		Expect(meta.IsStatusConditionTrue(lowStatus.Conditions, llmdVariantAutoscalingV1alpha1.TypePolicyApplied)).To(BeTrue())
+		Expect(lowStatus.Targets[0].Sections).To(ConsistOf(llmdVariantAutoscalingV1alpha1.PolicySectionSaturation))
+
+		By("Deleting the last policy")
+		Expect(k8sClient.Delete(ctx, low)).To(Succeed())
+		reconcile()
+		Expect(kvCacheThreshold()).To(BeNumerically("~", 0.8, 1e-9))
+		Expect(cfg.SaturationConfigForNamespace(namespace)).NotTo(HaveKey(modelID + "#" + namespace))
+	})
+})
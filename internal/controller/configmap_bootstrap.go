@@ -36,6 +36,8 @@ func (r *ConfigMapReconciler) BootstrapInitialConfigMaps(ctx context.Context) er
 		{name: config.SaturationConfigMapName(), namespace: systemNamespace, isGlobal: true},
 		{name: config.DefaultScaleToZeroConfigMapName, namespace: systemNamespace, isGlobal: true},
 		{name: config.QMAnalyzerConfigMapName(), namespace: systemNamespace, isGlobal: true},
+		{name: config.PriceCatalogConfigMapName(), namespace: systemNamespace, isGlobal: true},
+		{name: config.PerfDataConfigMapName(), namespace: systemNamespace, isGlobal: true},
 	}
 
 	// Determine which namespaces to scan for namespace-local ConfigMaps
@@ -97,6 +99,16 @@ func (r *ConfigMapReconciler) BootstrapInitialConfigMaps(ctx context.Context) er
 				namespace string
 				isGlobal  bool
 			}{name: config.QMAnalyzerConfigMapName(), namespace: ns, isGlobal: false},
+			struct {
+				name      string
+				namespace string
+				isGlobal  bool
+			}{name: config.PriceCatalogConfigMapName(), namespace: ns, isGlobal: false},
+			struct {
+				name      string
+				namespace string
+				isGlobal  bool
+			}{name: config.PerfDataConfigMapName(), namespace: ns, isGlobal: false},
 		)
 	}
 
@@ -131,6 +143,10 @@ func (r *ConfigMapReconciler) bootstrapConfigMap(ctx context.Context, name, name
 		r.handleScaleToZeroConfigMap(ctx, cm, namespace, isGlobal)
 	case config.QMAnalyzerConfigMapName():
 		r.handleQMAnalyzerConfigMap(ctx, cm, namespace, isGlobal)
+	case config.PriceCatalogConfigMapName():
+		r.handlePriceCatalogConfigMap(ctx, cm, namespace, isGlobal)
+	case config.PerfDataConfigMapName():
+		r.handlePerfDataConfigMap(ctx, cm, namespace, isGlobal)
 	default:
 		logger.V(1).Info("Ignoring unrecognized bootstrap ConfigMap", "name", name, "namespace", namespace)
 	}
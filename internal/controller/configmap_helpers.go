@@ -18,60 +18,56 @@ package controller
 
 import (
 	"context"
+	"slices"
 
 	"github.com/go-logr/logr"
-	yaml "gopkg.in/yaml.v3"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
-	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 )
 
 // parseSaturationConfig parses saturation scaling configuration from ConfigMap data.
 // Returns the parsed configs and count of successfully parsed entries.
+// Invalid or unparseable entries are skipped with an error log.
 func parseSaturationConfig(cmData map[string]string, logger logr.Logger) (config.SaturationScalingConfigPerModel, int) {
-	configs := make(config.SaturationScalingConfigPerModel)
-	count := 0
-	for key, yamlStr := range cmData {
-		var satConfig config.SaturationScalingConfig
-		if err := yaml.Unmarshal([]byte(yamlStr), &satConfig); err != nil {
-			logger.Error(err, "Failed to parse saturation scaling config entry", "key", key)
-			continue
-		}
-		// Apply defaults before validation (handles omitempty zero-values like scaleUpThreshold)
-		satConfig.ApplyDefaults()
-		if err := satConfig.Validate(); err != nil {
-			logger.Error(err, "Invalid saturation scaling config entry", "key", key)
-			continue
-		}
-		configs[key] = satConfig
-		count++
+	configs, errs := config.ParseSaturationConfigMap(cmData)
+	for _, err := range errs {
+		logger.Error(err, "Invalid saturation scaling config entry, skipping")
 	}
-	return configs, count
+	return configs, len(configs)
 }
 
 // parseQMAnalyzerConfig parses queueing model configuration from ConfigMap data.
 // Returns the parsed configs and count of successfully parsed entries.
 // Invalid or unparseable entries are skipped with an error log.
 func parseQMAnalyzerConfig(cmData map[string]string, logger logr.Logger) (config.QMAnalyzerConfigPerModel, int) {
-	configs := make(config.QMAnalyzerConfigPerModel)
-	count := 0
-	for key, yamlStr := range cmData {
-		var qmConfig interfaces.QueueingModelScalingConfig
-		if err := yaml.Unmarshal([]byte(yamlStr), &qmConfig); err != nil {
-			logger.Error(err, "Failed to parse queueing model config entry", "key", key)
-			continue
-		}
-		if err := qmConfig.Validate(); err != nil {
-			logger.Error(err, "Invalid queueing model config entry", "key", key)
-			continue
+	configs, errs := config.ParseQMAnalyzerConfigMap(cmData)
+	for _, err := range errs {
+		logger.Error(err, "Invalid queueing model config entry, skipping")
+	}
+	return configs, len(configs)
+}
+
+// logDeprecatedPerModelEntries logs the per-model entries of a saturation,
+// queueing model or scale-to-zero ConfigMap. They keep working during the
+// deprecation period; AutoscalingPolicies replace them.
+func logDeprecatedPerModelEntries(logger logr.Logger, cm *corev1.ConfigMap) {
+	var keys []string
+	for key := range cm.Data {
+		if key != config.GlobalDefaultsKey {
+			keys = append(keys, key)
 		}
-		configs[key] = qmConfig
-		count++
 	}
-	return configs, count
+	if len(keys) == 0 {
+		return
+	}
+	slices.Sort(keys)
+	logger.Info("Per-model ConfigMap entries are deprecated, use AutoscalingPolicy resources instead",
+		"configMap", cm.Name,
+		"namespace", cm.Namespace,
+		"keys", keys)
 }
 
 // isNamespaceConfigEnabled checks if a namespace has the opt-in label for namespace-local ConfigMaps.
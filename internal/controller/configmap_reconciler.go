@@ -84,6 +84,10 @@ func (r *ConfigMapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		r.handleScaleToZeroConfigMap(ctx, cm, namespace, isGlobal)
 	case config.QMAnalyzerConfigMapName():
 		r.handleQMAnalyzerConfigMap(ctx, cm, namespace, isGlobal)
+	case config.PriceCatalogConfigMapName():
+		r.handlePriceCatalogConfigMap(ctx, cm, namespace, isGlobal)
+	case config.PerfDataConfigMapName():
+		r.handlePerfDataConfigMap(ctx, cm, namespace, isGlobal)
 	default:
 		logger.V(1).Info("Ignoring unrecognized ConfigMap", "name", name, "namespace", namespace)
 	}
@@ -125,6 +129,12 @@ func (r *ConfigMapReconciler) handleConfigMapDeletion(ctx context.Context, name,
 	case config.QMAnalyzerConfigMapName():
 		r.Config.RemoveNamespaceConfig(namespace)
 		logger.Info("Removed namespace-local queueing model config on ConfigMap deletion", "namespace", namespace)
+	case config.PriceCatalogConfigMapName():
+		r.Config.RemoveNamespaceConfig(namespace)
+		logger.Info("Removed namespace-local accelerator price catalog on ConfigMap deletion", "namespace", namespace)
+	case config.PerfDataConfigMapName():
+		r.Config.RemoveNamespaceConfig(namespace)
+		logger.Info("Removed namespace-local model performance data on ConfigMap deletion", "namespace", namespace)
 	}
 }
 
@@ -162,6 +172,7 @@ func (r *ConfigMapReconciler) handleSaturationConfigMap(ctx context.Context, cm
 
 	// Parse saturation scaling config entries
 	configs, count := parseSaturationConfig(cm.Data, logger)
+	logDeprecatedPerModelEntries(logger, cm)
 
 	// Update global or namespace-local config
 	if isGlobal {
@@ -179,7 +190,11 @@ func (r *ConfigMapReconciler) handleScaleToZeroConfigMap(ctx context.Context, cm
 	logger := log.FromContext(ctx)
 
 	// Parse scale-to-zero config
-	scaleToZeroConfig := config.ParseScaleToZeroConfigMap(cm.Data)
+	scaleToZeroConfig, errs := config.ParseScaleToZeroConfigMap(cm.Data)
+	for _, err := range errs {
+		logger.Info("Invalid scale-to-zero config, ignoring", "error", err.Error())
+	}
+	logDeprecatedPerModelEntries(logger, cm)
 
 	// Log parsed config for debugging
 	logger.Info("Processing scale-to-zero ConfigMap",
@@ -206,6 +221,7 @@ func (r *ConfigMapReconciler) handleQMAnalyzerConfigMap(ctx context.Context, cm
 
 	// Parse queue model based scaling config entries
 	configs, count := parseQMAnalyzerConfig(cm.Data, logger)
+	logDeprecatedPerModelEntries(logger, cm)
 
 	// Update global or namespace-local config
 	if isGlobal {
@@ -216,3 +232,37 @@ func (r *ConfigMapReconciler) handleQMAnalyzerConfigMap(ctx context.Context, cm
 		logger.Info("Updated namespace-local queueing model config from ConfigMap", "namespace", namespace, "entries", count)
 	}
 }
+
+// handlePriceCatalogConfigMap handles updates to the accelerator price catalog ConfigMap.
+// Supports both global and namespace-local ConfigMaps.
+func (r *ConfigMapReconciler) handlePriceCatalogConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	catalog := config.ParsePriceCatalogConfigMap(cm.Data)
+
+	// Update global or namespace-local catalog
+	if isGlobal {
+		r.Config.UpdatePriceCatalog(catalog)
+		logger.Info("Updated global accelerator price catalog from ConfigMap", "accelerators", len(catalog))
+	} else {
+		r.Config.UpdatePriceCatalogForNamespace(namespace, catalog)
+		logger.Info("Updated namespace-local accelerator price catalog from ConfigMap", "namespace", namespace, "accelerators", len(catalog))
+	}
+}
+
+// handlePerfDataConfigMap handles updates to the model performance data ConfigMap.
+// Supports both global and namespace-local ConfigMaps.
+func (r *ConfigMapReconciler) handlePerfDataConfigMap(ctx context.Context, cm *corev1.ConfigMap, namespace string, isGlobal bool) {
+	logger := log.FromContext(ctx)
+
+	catalog := config.ParsePerfDataConfigMap(cm.Data)
+
+	// Update global or namespace-local catalog
+	if isGlobal {
+		r.Config.UpdatePerfData(catalog)
+		logger.Info("Updated global model performance data from ConfigMap", "entries", len(catalog))
+	} else {
+		r.Config.UpdatePerfDataForNamespace(namespace, catalog)
+		logger.Info("Updated namespace-local model performance data from ConfigMap", "namespace", namespace, "entries", len(catalog))
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// nodeInterruptionTaintKeys are taints placed on a node shortly before the
+// provider or a node lifecycle controller reclaims it.
+var nodeInterruptionTaintKeys = []string{
+	// AWS Node Termination Handler, on a spot interruption notice.
+	"aws-node-termination-handler/spot-itn",
+	// GKE, on preemption of Spot VMs with graceful node shutdown.
+	"cloud.google.com/impending-node-termination",
+	// Karpenter v1, while a node is being disrupted (including spot interruption).
+	"karpenter.sh/disrupted",
+	// Karpenter before v1.
+	"karpenter.sh/disruption",
+}
+
+// preemptibleNodeLabels are labels marking a node as spot or preemptible
+// capacity, with their value on such nodes (compared case-insensitively).
+var preemptibleNodeLabels = map[string]string{
+	// Karpenter.
+	"karpenter.sh/capacity-type": "spot",
+	// EKS managed node groups.
+	"eks.amazonaws.com/capacityType": "spot",
+	// GKE Spot VMs and preemptible VMs.
+	"cloud.google.com/gke-spot":        "true",
+	"cloud.google.com/gke-preemptible": "true",
+	// AKS spot node pools.
+	"kubernetes.azure.com/scalesetpriority": "spot",
+}
+
+// NodeInterruptionReconciler watches Nodes for signs that they are being reclaimed
+// (an interruption taint, or deletion), records them in common.Interruptions and
+// requests an immediate optimization cycle, so that capacity lost on preemptible
+// variants is replaced on other variants without waiting for the polling interval.
+type NodeInterruptionReconciler struct {
+	client.Client
+}
+
+// Reconcile records or clears the interruption state of a Node.
+func (r *NodeInterruptionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, req.NamespacedName, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			r.markInterrupted(ctx, req.Name, "deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !node.DeletionTimestamp.IsZero() {
+		r.markInterrupted(ctx, node.Name, "deleting")
+		return ctrl.Result{}, nil
+	}
+	if taint := nodeInterruptionTaint(node); taint != "" {
+		r.markInterrupted(ctx, node.Name, taint)
+		return ctrl.Result{}, nil
+	}
+
+	common.Interruptions.ClearNode(node.Name)
+	logger.V(logging.DEBUG).Info("Node is no longer interrupted", "node", node.Name)
+	return ctrl.Result{}, nil
+}
+
+// markInterrupted records a node interruption and, the first time it is seen,
+// asks the Engine to re-optimize.
+func (r *NodeInterruptionReconciler) markInterrupted(ctx context.Context, node, reason string) {
+	if !common.Interruptions.MarkNode(node, time.Now()) {
+		return
+	}
+	triggered := common.RequestOptimization()
+	log.FromContext(ctx).Info("Node interruption detected",
+		"node", node,
+		"reason", reason,
+		"optimizationTriggered", triggered)
+}
+
+// nodeInterruptionTaint returns the key of the first interruption taint on the
+// node, or "" if there is none.
+func nodeInterruptionTaint(node *corev1.Node) string {
+	for _, taint := range node.Spec.Taints {
+		for _, key := range nodeInterruptionTaintKeys {
+			if taint.Key == key {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// nodePreemptible reports whether the node is labeled as spot or preemptible
+// capacity.
+func nodePreemptible(node *corev1.Node) bool {
+	for key, value := range preemptibleNodeLabels {
+		if strings.EqualFold(node.Labels[key], value) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *NodeInterruptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("node-interruption").
+		For(&corev1.Node{}).
+		WithEventFilter(NodeInterruptionPredicate()).
+		Complete(r)
+}
@@ -40,6 +40,8 @@ func ConfigMapPredicate(ds datastore.Datastore, cfg *config.Config) predicate.Pr
 			config.SaturationConfigMapName():       true,
 			config.DefaultScaleToZeroConfigMapName: true,
 			config.QMAnalyzerConfigMapName():       true,
+			config.PriceCatalogConfigMapName():     true,
+			config.PerfDataConfigMapName():         true,
 		}
 
 		// Check if this is a well-known ConfigMap name
@@ -167,6 +169,43 @@ func ScaleTargetPredicate() predicate.Predicate {
 	}
 }
 
+// NodeInterruptionPredicate returns a predicate that filters Node events to those
+// that can change a node's interruption state:
+//   - Create events for nodes that already carry an interruption taint
+//   - Update events that add or remove an interruption taint, or start deletion
+//   - Delete events for nodes that carried an interruption taint or were
+//     labeled as spot or preemptible capacity
+//
+// It blocks Generic events, all other Node updates (status heartbeats etc.) and
+// the deletion of on-demand nodes, e.g. by a scale-down of the cluster, which
+// the reconciler would otherwise record as an interruption.
+func NodeInterruptionPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			node, ok := e.Object.(*corev1.Node)
+			return ok && nodeInterruptionTaint(node) != ""
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, okOld := e.ObjectOld.(*corev1.Node)
+			newNode, okNew := e.ObjectNew.(*corev1.Node)
+			if !okOld || !okNew {
+				return false
+			}
+			if oldNode.DeletionTimestamp.IsZero() && !newNode.DeletionTimestamp.IsZero() {
+				return true
+			}
+			return nodeInterruptionTaint(oldNode) != nodeInterruptionTaint(newNode)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			node, ok := e.Object.(*corev1.Node)
+			return ok && (nodeInterruptionTaint(node) != "" || nodePreemptible(node))
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 // VariantAutoscalingPredicate returns a predicate that filters VariantAutoscaling events
 // based on the controller instance label and namespace exclusion annotation.
 // This enables multi-controller isolation and namespace exclusion.
@@ -438,3 +438,25 @@ var _ = Describe("VariantAutoscalingPredicate", func() {
 		})
 	})
 })
+
+var _ = Describe("NodeInterruptionPredicate", func() {
+	newNode := func(labels map[string]string, taints ...corev1.Taint) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: labels},
+			Spec:       corev1.NodeSpec{Taints: taints},
+		}
+	}
+
+	DescribeTable("Delete events",
+		func(node *corev1.Node, expected bool) {
+			Expect(NodeInterruptionPredicate().Delete(event.DeleteEvent{Object: node})).To(Equal(expected))
+		},
+		Entry("on-demand node", newNode(map[string]string{"karpenter.sh/capacity-type": "on-demand"}), false),
+		Entry("unlabeled node", newNode(nil), false),
+		Entry("node with an interruption taint",
+			newNode(nil, corev1.Taint{Key: "karpenter.sh/disrupted", Effect: corev1.TaintEffectNoSchedule}), true),
+		Entry("Karpenter spot node", newNode(map[string]string{"karpenter.sh/capacity-type": "spot"}), true),
+		Entry("EKS spot node", newNode(map[string]string{"eks.amazonaws.com/capacityType": "SPOT"}), true),
+		Entry("GKE preemptible node", newNode(map[string]string{"cloud.google.com/gke-preemptible": "true"}), true),
+	)
+})
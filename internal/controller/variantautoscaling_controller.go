@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	promoperator "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -41,6 +42,8 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 )
@@ -84,7 +87,7 @@ func NewVariantAutoscalingReconciler(
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=leaderworkerset.x-k8s.io,resources=leaderworkersets,verbs=get;list;watch;update;patch
 // +kubebuilder:rbac:groups="apps",resources=replicasets,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;update;list;watch
@@ -97,10 +100,15 @@ func NewVariantAutoscalingReconciler(
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups=inference.networking.x-k8s.io;inference.networking.k8s.io,resources=inferencepools,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments/scale,verbs=get;update
+// +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch
 
 const (
 	// ServiceMonitor constants for watching controller's own metrics ServiceMonitor
 	defaultServiceMonitorName = "workload-variant-autoscaler-controller-manager-metrics-monitor"
+
+	// EventReasonLegacyVariantCostIgnored is the reason of the Warning Event
+	// emitted when the price catalog overrides the legacy CRD default variantCost.
+	EventReasonLegacyVariantCostIgnored = "LegacyVariantCostIgnored"
 )
 
 var (
@@ -112,7 +120,10 @@ var (
 	}
 )
 
-func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "VariantAutoscalingReconciler.Reconcile", tracing.Variant(req.Namespace, req.Name)...)
+	defer func() { tracing.End(span, err) }()
+
 	// NOTE: The reconciliation loop is being incrementally refactored so things may look a bit messy.
 	// Changes in progress:
 	// - reconcile loop will process one VA at a time. During the refactoring it does both, one and all
@@ -152,6 +163,7 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 	// Moved after deletion check to avoid tracking deleted VAs
 	// Idempotent: tracking the same VA multiple times (e.g., on retry) has no effect
 	r.Datastore.NamespaceTrack("VariantAutoscaling", va.Name, va.Namespace)
+	span.SetAttributes(tracing.ModelIDKey.String(va.Spec.ModelID))
 	logger.Info("Reconciling VariantAutoscaling",
 		"name", va.Name,
 		"namespace", va.Namespace,
@@ -159,7 +171,8 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	// Attempts to resolve the target model variant using scaleTargetRef
 	scaleTargetName := va.GetScaleTargetName()
-	if _, err := scaletarget.FetchScaleTarget(ctx, r.Client, va.Name, va.Spec.ScaleTargetRef.Kind, scaleTargetName, va.Namespace); err != nil {
+	scaleTarget, err := scaletarget.FetchScaleTarget(ctx, r.Client, va.Name, va.Spec.ScaleTargetRef.Kind, scaleTargetName, va.Namespace)
+	if err != nil {
 		if apierrors.IsNotFound(err) {
 			logger.Info(fmt.Sprintf("Scale target %s not found, waiting for %s watch", va.Spec.ScaleTargetRef.Kind, va.Spec.ScaleTargetRef.Kind),
 				"name", scaleTargetName,
@@ -172,7 +185,7 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 				llmdVariantAutoscalingV1alpha1.ReasonTargetNotFound,
 				fmt.Sprintf("Scale target %s %s not found", va.Spec.ScaleTargetRef.Kind, scaleTargetName))
 
-			if err := r.Status().Patch(ctx, &va, client.MergeFrom(fullDesiredAllocPatchBase(originalVA, &va))); err != nil {
+			if err := r.patchStatus(ctx, &va, originalVA); err != nil {
 				logger.Error(err, "Failed to update VariantAutoscaling status")
 				return ctrl.Result{}, err
 			}
@@ -198,6 +211,34 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 		fmt.Sprintf("Scale target %s found: name=%s, namespace=%s", va.Spec.ScaleTargetRef.Kind, scaleTargetName, va.Namespace),
 	)
 
+	// Report the per-replica cost the optimizer uses for this variant
+	var priceCatalog config.PriceCatalog
+	var scaleToZeroConfig config.ScaleToZeroConfigData
+	if r.Config != nil {
+		priceCatalog = r.Config.PriceCatalogForNamespace(va.Namespace)
+		scaleToZeroConfig = r.Config.ScaleToZeroConfigForNamespace(va.Namespace)
+	}
+	cost := utils.ResolveVariantCost(&va, scaleTarget, priceCatalog)
+	va.Status.EffectiveCost = cost.ToStatus()
+	// Warn once when the catalog starts to override the legacy CRD default,
+	// which may have been set deliberately
+	if cost.LegacyDefaultIgnored && r.Recorder != nil &&
+		(originalVA.Status.EffectiveCost == nil || originalVA.Status.EffectiveCost.Source != llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog) {
+		r.Recorder.Eventf(&va, corev1.EventTypeWarning, EventReasonLegacyVariantCostIgnored,
+			"spec.variantCost %q is the legacy CRD default and is ignored in favor of the price catalog (%s per replica); set it to \"10\" to pin the cost",
+			va.Spec.VariantCost, va.Status.EffectiveCost.Cost)
+	}
+
+	// Report the configuration the engine resolves for this variant's model
+	if r.Config != nil {
+		va.Status.EffectiveConfig = utils.EffectiveConfig(r.Config.ModelConfig(va.Namespace, va.Spec.ModelID),
+			originalVA.Status.EffectiveConfig, time.Now())
+	}
+
+	// Report the scaling schedules currently overriding this variant's bounds
+	va.Status.ActiveSchedules = utils.ActiveSchedules(&va,
+		config.ModelScheduleRules(scaleToZeroConfig, va.Spec.ModelID), time.Now())
+
 	// Process Engine Decisions from Shared Cache
 	// This mechanism allows the Engine to trigger updates without touching the API server directly.
 	if decision, ok := common.DecisionCache.Get(va.Name, va.Namespace); ok {
@@ -209,7 +250,8 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 			"metricsAvailable", decision.MetricsAvailable,
 			"metricsReason", decision.MetricsReason,
 			"metricsMessage", decision.MetricsMessage,
-			"reason", decision.Reason)
+			"reason", decision.Reason,
+			"effectiveConfigHash", effectiveConfigHash(&va))
 		// Only apply if the decision is fresher than the last one applied or if we haven't applied it
 		// Note: We blindly apply for now, assuming the Engine acts as the source of truth for "Desired" state
 		numReplicas, accelerator, lastRunTime := common.DecisionToOptimizedAlloc(decision)
@@ -252,7 +294,7 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 	// Without this, MergeFrom only includes changed fields within the struct,
 	// and the CRD validates the partial patch — rejecting it when required
 	// fields (numReplicas, accelerator) are absent. See: #731
-	if err := r.Status().Patch(ctx, &va, client.MergeFrom(fullDesiredAllocPatchBase(originalVA, &va))); err != nil {
+	if err := r.patchStatus(ctx, &va, originalVA); err != nil {
 		logger.Error(err, "Failed to update VariantAutoscaling status",
 			"name", va.Name)
 		return ctrl.Result{}, err
@@ -263,6 +305,22 @@ func (r *VariantAutoscalingReconciler) Reconcile(ctx context.Context, req ctrl.R
 	return ctrl.Result{}, nil
 }
 
+// effectiveConfigHash returns the hash of the effective configuration in the
+// status of va, for correlating decisions with configuration changes in logs.
+func effectiveConfigHash(va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) string {
+	if va.Status.EffectiveConfig == nil {
+		return ""
+	}
+	return va.Status.EffectiveConfig.Hash
+}
+
+// patchStatus patches the status of va with its changes from originalVA.
+func (r *VariantAutoscalingReconciler) patchStatus(ctx context.Context, va, originalVA *llmdVariantAutoscalingV1alpha1.VariantAutoscaling) (err error) {
+	ctx, span := tracing.Start(ctx, "VariantAutoscalingReconciler.patchStatus", tracing.Variant(va.Namespace, va.Name)...)
+	defer func() { tracing.End(span, err) }()
+	return r.Status().Patch(ctx, va, client.MergeFrom(fullDesiredAllocPatchBase(originalVA, va)))
+}
+
 // fullDesiredAllocPatchBase returns a patch base that forces the full
 // desiredOptimizedAlloc object into the JSON merge patch. Without this,
 // MergeFrom only includes changed fields within nested structs, and the
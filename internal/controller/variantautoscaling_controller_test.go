@@ -39,6 +39,7 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	testutils "github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/test/utils/resources"
 )
@@ -366,6 +367,69 @@ var _ = Describe("VariantAutoscalings Controller", func() {
 		})
 	})
 
+	Context("Legacy variantCost", func() {
+		const resourceName = "legacy-variant-cost-test"
+
+		It("should warn once when the price catalog overrides the legacy default", func() {
+			deployment := resources.CreateLlmdSimDeployment("default", resourceName, "legacy-model", "default", "8000", 0, 0, 1)
+			Expect(k8sClient.Create(ctx, deployment)).To(Succeed())
+			resource := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      resourceName,
+					Namespace: "default",
+					Labels:    map[string]string{utils.AcceleratorNameLabel: "H100"},
+				},
+				Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+					ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+						Kind: "Deployment",
+						Name: resourceName,
+					},
+					ModelID:     "legacy-model",
+					MaxReplicas: 2,
+					VariantAutoscalingConfigSpec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{
+						VariantCost: "10.0",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8sClient.Delete(ctx, resource)).To(Succeed())
+				Expect(k8sClient.Delete(ctx, deployment)).To(Succeed())
+			})
+
+			cfg := config.NewTestConfig()
+			cfg.UpdatePriceCatalog(config.PriceCatalog{"H100": {OnDemand: 4.0}})
+			fakeRecorder := record.NewFakeRecorder(10)
+			controllerReconciler := &VariantAutoscalingReconciler{
+				Client:    k8sClient,
+				Scheme:    k8sClient.Scheme(),
+				Recorder:  fakeRecorder,
+				Config:    cfg,
+				Datastore: datastore.NewDatastore(cfg),
+			}
+
+			By("Reconciling twice")
+			for range 2 {
+				_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+					NamespacedName: types.NamespacedName{Name: resourceName, Namespace: "default"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			fetchedResource := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName, Namespace: "default"}, fetchedResource)).To(Succeed())
+			Expect(fetchedResource.Status.EffectiveCost).NotTo(BeNil())
+			Expect(fetchedResource.Status.EffectiveCost.Source).To(Equal(llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog))
+
+			By("Verifying a single Warning event was emitted")
+			Expect(fakeRecorder.Events).To(Receive(And(
+				ContainSubstring(v1.EventTypeWarning),
+				ContainSubstring(EventReasonLegacyVariantCostIgnored),
+			)))
+			Consistently(fakeRecorder.Events).ShouldNot(Receive(ContainSubstring(EventReasonLegacyVariantCostIgnored)))
+		})
+	})
+
 	Context("When handling partial decisions from cache", func() {
 		const resourceName = "test-partial-decision"
 
@@ -0,0 +1,108 @@
+// Package debug serves a read-only JSON view of the controller's live state:
+// what the engines currently believe, without raising log verbosity.
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Path is where the handler is served on the metrics server.
+const Path = "/debug/engine"
+
+// Section returns a snapshot of one part of the controller's state. The
+// snapshot is encoded as JSON.
+type Section func(ctx context.Context) (any, error)
+
+// Handler serves the registered sections as one JSON document:
+//
+//	{"generatedAt": "...", "sections": {"<name>": <snapshot>, ...}}
+//
+// A section that fails is reported as {"error": "..."}. The "section" query
+// parameter selects a comma-separated subset of the sections.
+type Handler struct {
+	mu       sync.RWMutex
+	sections map[string]Section
+	now      func() time.Time
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates a Handler without sections.
+func NewHandler() *Handler {
+	return &Handler{
+		sections: make(map[string]Section),
+		now:      time.Now,
+	}
+}
+
+// Register adds a section, replacing any section of the same name. Sections
+// can be registered after the handler is served, e.g. by engines started on
+// leader election.
+func (h *Handler) Register(name string, section Section) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sections[name] = section
+}
+
+// response is the document served by the handler.
+type response struct {
+	GeneratedAt time.Time      `json:"generatedAt"`
+	Sections    map[string]any `json:"sections"`
+}
+
+// sectionError reports a section that failed.
+type sectionError struct {
+	Error string `json:"error"`
+}
+
+// ServeHTTP serves the sections. Only GET is allowed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.mu.RLock()
+	sections := make(map[string]Section, len(h.sections))
+	for name, section := range h.sections {
+		sections[name] = section
+	}
+	h.mu.RUnlock()
+
+	if selected := r.URL.Query().Get("section"); selected != "" {
+		names := strings.Split(selected, ",")
+		for name := range sections {
+			if !slices.Contains(names, name) {
+				delete(sections, name)
+			}
+		}
+	}
+
+	resp := response{GeneratedAt: h.now(), Sections: make(map[string]any, len(sections))}
+	for name, section := range sections {
+		snapshot, err := section(r.Context())
+		if err != nil {
+			resp.Sections[name] = sectionError{Error: err.Error()}
+			continue
+		}
+		resp.Sections[name] = snapshot
+	}
+
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		ctrl.LoggerFrom(r.Context()).Error(err, "Failed to encode debug state")
+		http.Error(w, "encoding debug state: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
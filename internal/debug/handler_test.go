@@ -0,0 +1,80 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func serve(t *testing.T, h *Handler, method, target string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	if rec.Code != http.StatusOK {
+		return rec, nil
+	}
+	var resp struct {
+		GeneratedAt time.Time                  `json:"generatedAt"`
+		Sections    map[string]json.RawMessage `json:"sections"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	if resp.GeneratedAt.IsZero() {
+		t.Error("Expected generatedAt to be set")
+	}
+	for name, section := range resp.Sections {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, section); err != nil {
+			t.Fatal(err)
+		}
+		resp.Sections[name] = compact.Bytes()
+	}
+	return rec, resp.Sections
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+	h.Register("pools", func(context.Context) (any, error) {
+		return map[string]int{"A100": 8}, nil
+	})
+	h.Register("broken", func(context.Context) (any, error) {
+		return nil, errors.New("not ready")
+	})
+
+	rec, sections := serve(t, h, http.MethodGet, Path)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", ct)
+	}
+	if got := string(sections["pools"]); got != `{"A100":8}` {
+		t.Errorf("Expected pools section, got %s", got)
+	}
+	if got := string(sections["broken"]); got != `{"error":"not ready"}` {
+		t.Errorf("Expected failed section to report its error, got %s", got)
+	}
+
+	// Sections registered later are served.
+	h.Register("engine", func(context.Context) (any, error) { return "running", nil })
+	_, sections = serve(t, h, http.MethodGet, Path+"?section=engine,pools,unknown")
+	if len(sections) != 2 || sections["engine"] == nil || sections["pools"] == nil {
+		t.Errorf("Expected the selected sections engine and pools, got %v", sections)
+	}
+}
+
+func TestHandlerReadOnly(t *testing.T) {
+	h := NewHandler()
+	for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodDelete} {
+		rec, _ := serve(t, h, method, Path)
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("%s: expected status 405, got %d", method, rec.Code)
+		}
+	}
+}
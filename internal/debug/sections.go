@@ -0,0 +1,122 @@
+package debug
+
+import (
+	"context"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/datastore"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/federation"
+)
+
+// Names of the sections registered by the controller.
+const (
+	SectionDecisionCache   = "decisionCache"
+	SectionSaturation      = "saturationEngine"
+	SectionDatastorePools  = "datastorePools"
+	SectionSourceCache     = "sourceCache"
+	SectionPoolSourceCache = "poolSourceCache"
+	SectionFederation      = "federation"
+)
+
+// DecisionCacheSection reports the decisions cached for the controller,
+// keyed by VariantAutoscaling namespace/name.
+func DecisionCacheSection() Section {
+	return func(context.Context) (any, error) {
+		return common.DecisionCache.Snapshot(), nil
+	}
+}
+
+// SaturationEngineSection reports the saturation engine's state: last
+// analyzer results, capacity knowledge, learned queueing model parameters
+// and GPU limiter pools.
+func SaturationEngineSection(engine *saturation.Engine) Section {
+	return func(context.Context) (any, error) {
+		return engine.DebugState(), nil
+	}
+}
+
+// federationState is the state of the federation with other clusters.
+type federationState struct {
+	Cluster string                     `json:"cluster"`
+	Summary *federation.ClusterSummary `json:"summary"`
+	Peers   []federation.PeerStatus    `json:"peers"`
+}
+
+// FederationSection reports the summary the local cluster serves to its peers
+// and the last sync of each peer.
+func FederationSection(f *federation.Federation) Section {
+	return func(context.Context) (any, error) {
+		return federationState{Cluster: f.Cluster(), Summary: f.Summary(), Peers: f.Peers()}, nil
+	}
+}
+
+// DatastorePoolsSection reports the InferencePools known to the datastore.
+func DatastorePoolsSection(ds datastore.Datastore) Section {
+	return func(context.Context) (any, error) {
+		return ds.PoolList(), nil
+	}
+}
+
+// cacheEntry is a cached result with its freshness status.
+type cacheEntry struct {
+	source.CacheEntry
+	// Freshness is "fresh", "stale" or "unavailable" by the age of the oldest
+	// sample, see config.FreshnessThresholds.
+	Freshness string
+}
+
+// SourceCacheSection reports the freshness of the cached results of the
+// metrics sources in registry, keyed by source name.
+func SourceCacheSection(registry *source.SourceRegistry, cfg *config.Config) Section {
+	return func(context.Context) (any, error) {
+		caches := make(map[string][]cacheEntry)
+		for _, name := range registry.List() {
+			if entries, ok := sourceCacheEntries(registry.Get(name), cfg); ok {
+				caches[name] = entries
+			}
+		}
+		return caches, nil
+	}
+}
+
+// PoolSourceCacheSection reports the freshness of the cached results of the
+// endpoint picker metrics sources of the datastore's pools, keyed by pool
+// namespace/name.
+func PoolSourceCacheSection(ds datastore.Datastore, cfg *config.Config) Section {
+	return func(context.Context) (any, error) {
+		caches := make(map[string][]cacheEntry)
+		for _, pool := range ds.PoolList() {
+			name := pool.Namespace + "/" + pool.Name
+			if entries, ok := sourceCacheEntries(ds.PoolGetMetricsSource(name), cfg); ok {
+				caches[name] = entries
+			}
+		}
+		return caches, nil
+	}
+}
+
+func sourceCacheEntries(src source.MetricsSource, cfg *config.Config) ([]cacheEntry, bool) {
+	inspector, ok := src.(source.CacheInspector)
+	if !ok {
+		return nil, false
+	}
+	thresholds := config.DefaultFreshnessThresholds()
+	if cacheConfig := cfg.PrometheusCacheConfig(); cacheConfig != nil {
+		thresholds = cacheConfig.FreshnessThresholds
+	}
+
+	cached := inspector.CacheEntries()
+	entries := make([]cacheEntry, 0, len(cached))
+	for _, e := range cached {
+		freshness := "unavailable"
+		if !e.OldestSample.IsZero() {
+			freshness = thresholds.DetermineStatus(time.Since(e.OldestSample))
+		}
+		entries = append(entries, cacheEntry{CacheEntry: e, Freshness: freshness})
+	}
+	return entries, true
+}
@@ -7,8 +7,10 @@ import (
 	"math"
 	"time"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
@@ -58,6 +60,18 @@ func (a *QueueingModelAnalyzer) Update(currentModels map[string]bool) {
 	}
 }
 
+// LearnedParameters returns a copy of the learned parameters of all variants,
+// keyed by model key (namespace/modelID), then variant key
+// (namespace/variantName). Like Analyze and Update, it must not be called
+// concurrently with them.
+func (a *QueueingModelAnalyzer) LearnedParameters() map[string]map[string]*LearnedParameters {
+	params := make(map[string]map[string]*LearnedParameters, len(a.modelsParameterStore))
+	for modelKey, pStore := range a.modelsParameterStore {
+		params[modelKey] = pStore.snapshot()
+	}
+	return params
+}
+
 // get parameters for a given model, namespace, and variant (nil if does not exist)
 func (a *QueueingModelAnalyzer) getParams(modelID, namespace, variantName string) (params *LearnedParameters) {
 	modelKey := MakeModelKey(namespace, modelID)
@@ -79,6 +93,16 @@ func (a *QueueingModelAnalyzer) setParams(modelID, namespace, variantName string
 	pStore.Set(namespace, variantName, params)
 }
 
+// ResetParameters drops the learned parameters of a variant, so that they are
+// learned again from its observations, starting from an initial guess. Used
+// when the serving configuration of the variant changed. Like Analyze and
+// Update, it must not be called concurrently with them.
+func (a *QueueingModelAnalyzer) ResetParameters(modelID, namespace, variantName string) {
+	if pStore, exists := a.modelsParameterStore[MakeModelKey(namespace, modelID)]; exists {
+		pStore.Delete(namespace, variantName)
+	}
+}
+
 // Analyze implements interfaces.Analyzer.
 // Called for each model.
 //
@@ -109,7 +133,9 @@ func (a *QueueingModelAnalyzer) Analyze(
 
 	// Update parameters (tuner) for all variants associated with the model
 	if qConfig.TuningEnabled {
-		a.updateVariantParameters(ctx, namespace, modelID, variantNames, variantMetrics, qConfig)
+		tuneCtx, span := tracing.Start(ctx, "QueueingModelAnalyzer.tune", tracing.Model(namespace, modelID)...)
+		a.updateVariantParameters(tuneCtx, namespace, modelID, variantNames, variantMetrics, qConfig)
+		tracing.End(span, nil)
 	}
 
 	// Get SLO targets
@@ -120,9 +146,13 @@ func (a *QueueingModelAnalyzer) Analyze(
 		return nil, fmt.Errorf("failed to analyze variants due to lack of SLO targets for model %q", modelID)
 	}
 
-	// Compute capacities
+	// Compute capacities, with the SLO targets of each request class if the workload is split
+	classSLOTarget := func(requestSize *analyzer.RequestSize) *SLOTarget {
+		return a.getClassSLOTarget(namespace, modelID, qConfig, variantNames, requestSize, sloTarget)
+	}
 	variantCapacities := a.computeAllVariantCapacities(
 		ctx, namespace, modelID, variantMetrics, input.VariantStates, sloTarget,
+		qConfig, classSLOTarget,
 	)
 	if len(variantCapacities) == 0 {
 		return nil, fmt.Errorf("could not compute variant capacities for model %q", modelID)
@@ -169,7 +199,7 @@ func (a *QueueingModelAnalyzer) updateVariantParameters(
 			continue
 		}
 		// Build environment from replica metrics
-		envs, err := buildEnvironmentsFromMetrics(variantName, variantReplicaMetrics)
+		envs, err := buildEnvironmentsFromMetrics(variantName, variantReplicaMetrics, config.defaultMaxBatchSize(variantName))
 		if len(envs) == 0 || err != nil {
 			logger.V(1).Info("Failed to build environment for variant",
 				"variant", variantName,
@@ -179,7 +209,7 @@ func (a *QueueingModelAnalyzer) updateVariantParameters(
 		}
 
 		// Create a variantTuner for this variant
-		variantTuner, err := a.createTunerForVariant(ctx, namespace, modelID, variantName, envs[0], config.FilterConfig)
+		variantTuner, err := a.createTunerForVariant(ctx, namespace, modelID, variantName, envs[0], config)
 		if err != nil {
 			logger.V(1).Info("Failed to get/create tuner for variant",
 				"variant", variantName,
@@ -262,6 +292,8 @@ func (a *QueueingModelAnalyzer) computeAllVariantCapacities(
 	variantMetrics map[string][]interfaces.ReplicaMetrics,
 	variantStates []interfaces.VariantReplicaState,
 	sloTarget *SLOTarget,
+	config *QMConfig,
+	classSLOTarget func(*analyzer.RequestSize) *SLOTarget,
 ) []interfaces.VariantCapacity {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -319,19 +351,36 @@ func (a *QueueingModelAnalyzer) computeAllVariantCapacities(
 			continue
 		}
 
-		// get max batch size
-		maxBatchSize := int64(DefaultMaxBatchSize)
+		// get max batch size, token budget and KV cache capacity
+		maxBatchSize := config.defaultMaxBatchSize(variantName)
 		for _, rm := range replicaMetrics {
 			if rm.MaxBatchSize > 0 {
 				maxBatchSize = rm.MaxBatchSize
 				break
 			}
 		}
+		var maxNumTokens int64
+		for _, rm := range replicaMetrics {
+			if rm.MaxNumBatchedTokens > 0 {
+				maxNumTokens = rm.MaxNumBatchedTokens
+				break
+			}
+		}
+		var numGPUBlocks, blockSize int64
+		for _, rm := range replicaMetrics {
+			if rm.NumGpuBlocks > 0 && rm.BlockSize > 0 {
+				numGPUBlocks, blockSize = rm.NumGpuBlocks, rm.BlockSize
+				break
+			}
+		}
 
 		// Create queue analyzer
-		config := &analyzer.Configuration{
+		qaConfig := &analyzer.Configuration{
 			MaxBatchSize: int(maxBatchSize),
+			MaxNumTokens: int(maxNumTokens),
 			MaxQueueSize: DefaultMaxQueueSize,
+			NumGPUBlocks: int(numGPUBlocks),
+			BlockSize:    int(blockSize),
 			ServiceParms: &analyzer.ServiceParms{
 				Alpha: params.Alpha,
 				Beta:  params.Beta,
@@ -340,8 +389,9 @@ func (a *QueueingModelAnalyzer) computeAllVariantCapacities(
 		}
 
 		requestSize := &analyzer.RequestSize{
-			AvgInputTokens:  float32(wm.avgInputTokens),
-			AvgOutputTokens: float32(wm.avgOutputTokens),
+			AvgInputTokens:     float32(wm.avgInputTokens),
+			AvgOutputTokens:    float32(wm.avgOutputTokens),
+			PrefixCacheHitRate: float32(wm.prefixCacheHitRate),
 		}
 
 		targetPerf := &analyzer.TargetPerf{
@@ -349,22 +399,49 @@ func (a *QueueingModelAnalyzer) computeAllVariantCapacities(
 			TargetITL:  sloTarget.TargetITL,
 		}
 
-		queueAnalyzer, err := analyzer.NewQueueAnalyzer(config, requestSize)
-		if err != nil {
-			logger.Info("Failed to create queue analyzer for variant", "variant", variantName, "error", err)
-			vr := errorVariantCapacity
-			variantCapacities = append(variantCapacities, vr)
-			continue
-		}
-
-		// find max request rate to achieve target SLOs
+		// find max request rate to achieve target SLOs, for every request class if the workload is split
 		var maxRequestRate float64
-		if _, metrics, _, err := queueAnalyzer.Size(targetPerf); err != nil {
-			logger.Info("Failed to calculate max request rate for variant", "variant", variantName, "error", err)
-			vr := errorVariantCapacity
-			variantCapacities = append(variantCapacities, vr)
-			continue
+		if classes := requestClasses(replicaMetrics, wm, config.RequestClasses); len(classes) > 0 {
+			for _, class := range classes {
+				classTarget := classSLOTarget(class.RequestSize)
+				class.TargetPerf = &analyzer.TargetPerf{
+					TargetTTFT: classTarget.TargetTTFT,
+					TargetITL:  classTarget.TargetITL,
+				}
+			}
+			multiClassAnalyzer, err := analyzer.NewMultiClassAnalyzer(qaConfig, classes)
+			if err != nil {
+				logger.Info("Failed to create multi-class queue analyzer for variant", "variant", variantName, "error", err)
+				vr := errorVariantCapacity
+				variantCapacities = append(variantCapacities, vr)
+				continue
+			}
+			_, metrics, err := multiClassAnalyzer.Size()
+			if err != nil {
+				logger.Info("Failed to calculate max request rate for variant", "variant", variantName,
+					"classes", len(classes), "error", err)
+				vr := errorVariantCapacity
+				variantCapacities = append(variantCapacities, vr)
+				continue
+			}
+			logger.V(1).Info("Sized variant for request classes", "variant", variantName, "classes", classes,
+				"maxRequestRate", metrics.Total.Throughput)
+			maxRequestRate = float64(metrics.Total.Throughput)
 		} else {
+			queueAnalyzer, err := analyzer.NewQueueAnalyzer(qaConfig, requestSize)
+			if err != nil {
+				logger.Info("Failed to create queue analyzer for variant", "variant", variantName, "error", err)
+				vr := errorVariantCapacity
+				variantCapacities = append(variantCapacities, vr)
+				continue
+			}
+			_, metrics, _, err := queueAnalyzer.Size(targetPerf)
+			if err != nil {
+				logger.Info("Failed to calculate max request rate for variant", "variant", variantName, "error", err)
+				vr := errorVariantCapacity
+				variantCapacities = append(variantCapacities, vr)
+				continue
+			}
 			maxRequestRate = float64(metrics.Throughput)
 		}
 
@@ -402,14 +479,15 @@ func (a *QueueingModelAnalyzer) computeAllVariantCapacities(
 
 // createTunerForVariant creates a new tuner instance for a variant.
 // If parameters exist in the store, uses the stored state and covariance.
-// Otherwise, attempts to guess initial state from environment metrics.
+// Otherwise, starts from the parameters profiled offline for the variant if
+// any, else attempts to guess initial state from environment metrics.
 func (a *QueueingModelAnalyzer) createTunerForVariant(
 	ctx context.Context,
 	namespace string,
 	modelID string,
 	variantName string,
 	env *tuner.Environment,
-	filterConfig *tuner.FilterData,
+	config *QMConfig,
 ) (*tuner.Tuner, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
@@ -417,7 +495,7 @@ func (a *QueueingModelAnalyzer) createTunerForVariant(
 	existingParams := a.getParams(modelID, namespace, variantName)
 
 	// Get base tuner config (uses user config or defaults)
-	tunerConfig := tuner.CreateTunerConfigFromData(filterConfig, env)
+	tunerConfig := tuner.CreateTunerConfigFromData(config.FilterConfig, env)
 
 	if existingParams != nil {
 		// Restore state and covariance from previous tuning cycle
@@ -437,6 +515,18 @@ func (a *QueueingModelAnalyzer) createTunerForVariant(
 		if flatCov != nil {
 			tunerConfig.ModelData.InitCovarianceMatrix = flatCov
 		}
+	} else if prior := config.GetPrior(variantName); prior != nil {
+		// No existing parameters - start from the profiled parameters
+		logger.V(1).Info("No existing parameters found, using profiled parameters",
+			"variant", variantName,
+			"namespace", namespace,
+			"alpha", prior.Alpha,
+			"beta", prior.Beta,
+			"gamma", prior.Gamma)
+		state := ParamsToStateVector(float64(prior.Alpha), float64(prior.Beta), float64(prior.Gamma))
+		tunerConfig.ModelData.InitState = state
+		tunerConfig.ModelData.MinState = tuner.GetFactoredSlice(state, tuner.DefaultMinStateFactor)
+		tunerConfig.ModelData.MaxState = tuner.GetFactoredSlice(state, tuner.DefaultMaxStateFactor)
 	} else {
 		// No existing parameters - attempt to guess initial state from metrics
 		logger.V(1).Info("No existing parameters found, attempting to guess initial state",
@@ -495,7 +585,7 @@ func (a *QueueingModelAnalyzer) guessSLOFromMetrics(
 	ctx context.Context,
 	namespace string,
 	modelID string,
-	config *QMConfig,
+	cfg *QMConfig,
 	variantNames []string,
 	modelReplicaMetrics []interfaces.ReplicaMetrics,
 ) *SLOTarget {
@@ -508,9 +598,9 @@ func (a *QueueingModelAnalyzer) guessSLOFromMetrics(
 	}
 
 	// Get the SLO multiplier (default if not configured)
-	k := config.SLOMultiplier
+	k := cfg.SLOMultiplier
 	if k <= 1.0 {
-		k = DefaultSLOMultiplier
+		k = config.DefaultSLOMultiplier
 	}
 
 	// Try theory-based SLO: take the max of SLO targets over variants with learned parameters
@@ -521,35 +611,19 @@ func (a *QueueingModelAnalyzer) guessSLOFromMetrics(
 			continue
 		}
 
-		alpha := float64(params.Alpha)
-		beta := float64(params.Beta)
-		gamma := float64(params.Gamma)
-
-		// T_iter at SLO utilization: k × α = α/(1-ρ) where ρ = 1-1/k
-		tIterSLO := k * alpha
-
-		// Deterministic work — NOT inflated by k
-		prefillWork := (beta + gamma) * wm.avgInputTokens
-		decodeWork := beta + gamma*(wm.avgInputTokens+(wm.avgOutputTokens+1.0)/2.0)
-
-		ttftSLO := tIterSLO + prefillWork
-		itlSLO := tIterSLO + decodeWork
+		SLOTargetForVariant := inferSLOTarget(k, params, wm.avgInputTokens, wm.avgOutputTokens, wm.prefixCacheHitRate)
 
 		logger.V(1).Info("Inferred SLO from queueing model",
 			"variant", variantName,
 			"k", k,
-			"alpha", alpha, "beta", beta, "gamma", gamma,
+			"alpha", params.Alpha, "beta", params.Beta, "gamma", params.Gamma,
 			"avgInputTokens", wm.avgInputTokens,
 			"avgOutputTokens", wm.avgOutputTokens,
-			"TargetTTFT_ms", ttftSLO,
-			"TargetITL_ms", itlSLO,
+			"prefixCacheHitRate", wm.prefixCacheHitRate,
+			"TargetTTFT_ms", SLOTargetForVariant.TargetTTFT,
+			"TargetITL_ms", SLOTargetForVariant.TargetITL,
 		)
 
-		SLOTargetForVariant := &SLOTarget{
-			TargetTTFT: float32(ttftSLO),
-			TargetITL:  float32(itlSLO),
-		}
-
 		if SLOTargetForModel == nil {
 			SLOTargetForModel = SLOTargetForVariant
 		} else {
@@ -565,6 +639,67 @@ func (a *QueueingModelAnalyzer) guessSLOFromMetrics(
 	return SLOTargetForModel
 }
 
+// inferSLOTarget infers SLO targets for requests of the given average size
+// from the learned parameters of a variant and the SLO multiplier k. Input
+// tokens served from the prefix cache are not computed in prefill, but are
+// read from the KV cache.
+func inferSLOTarget(k float64, params *LearnedParameters, avgInputTokens, avgOutputTokens, prefixCacheHitRate float64) *SLOTarget {
+	alpha := float64(params.Alpha)
+	beta := float64(params.Beta)
+	gamma := float64(params.Gamma)
+
+	// T_iter at SLO utilization: k × α = α/(1-ρ) where ρ = 1-1/k
+	tIterSLO := k * alpha
+
+	// Deterministic work — NOT inflated by k
+	prefillWork := beta*avgInputTokens*(1-prefixCacheHitRate) + gamma*avgInputTokens
+	decodeWork := beta + gamma*(avgInputTokens+(avgOutputTokens+1.0)/2.0)
+
+	return &SLOTarget{
+		TargetTTFT: float32(tIterSLO + prefillWork),
+		TargetITL:  float32(tIterSLO + decodeWork),
+	}
+}
+
+// getClassSLOTarget returns the SLO targets of a request class: the explicit
+// targets of the model if configured, else the targets inferred from the
+// queueing model for the size of the class (max over variants with learned
+// parameters), else the targets of the model.
+func (a *QueueingModelAnalyzer) getClassSLOTarget(
+	namespace string,
+	modelID string,
+	cfg *QMConfig,
+	variantNames []string,
+	requestSize *analyzer.RequestSize,
+	modelSLOTarget *SLOTarget,
+) *SLOTarget {
+	if slo := cfg.GetSLOForModel(namespace, modelID); slo != nil {
+		return slo
+	}
+	k := cfg.SLOMultiplier
+	if k <= 1.0 {
+		k = config.DefaultSLOMultiplier
+	}
+	var SLOTargetForClass *SLOTarget
+	for _, variantName := range variantNames {
+		params := a.getParams(modelID, namespace, variantName)
+		if params == nil || params.Alpha <= 0 || params.Beta <= 0 || params.Gamma <= 0 {
+			continue
+		}
+		SLOTargetForVariant := inferSLOTarget(k, params, float64(requestSize.AvgInputTokens),
+			float64(requestSize.AvgOutputTokens), float64(requestSize.PrefixCacheHitRate))
+		if SLOTargetForClass == nil {
+			SLOTargetForClass = SLOTargetForVariant
+		} else {
+			SLOTargetForClass.Max(SLOTargetForVariant)
+		}
+	}
+	if SLOTargetForClass == nil {
+		return modelSLOTarget
+	}
+	return SLOTargetForClass
+}
+
 // fallbackSLOFromObservations creates SLO targets from observed TTFT/ITL
 // with a headroom multiplier and reasonable caps. Used during cold start
 // before the Kalman filter has learned hardware parameters.
@@ -620,10 +755,12 @@ func (a *QueueingModelAnalyzer) storeParametersFromResults(
 // true: single environment from aggregating all pods, representing the variant's
 // current operating state.
 // false: multiple environments, one per pod
+// defaultMaxBatchSize is used when no replica reports its max batch size.
 // Returns error if required metrics are unavailable.
 func buildEnvironmentsFromMetrics(
 	variantName string,
 	variantReplicaMetrics []interfaces.ReplicaMetrics,
+	defaultMaxBatchSize int64,
 ) ([]*tuner.Environment, error) {
 	if len(variantReplicaMetrics) == 0 {
 		return nil, fmt.Errorf("no replica metrics for variant %s", variantName)
@@ -631,7 +768,7 @@ func buildEnvironmentsFromMetrics(
 
 	// MaxBatchSize is per-deployment (same for all replicas of a variant),
 	// so we extract it once from the first replica that has it.
-	maxBatchSize := int64(DefaultMaxBatchSize)
+	maxBatchSize := defaultMaxBatchSize
 	for _, rm := range variantReplicaMetrics {
 		if rm.MaxBatchSize > 0 {
 			maxBatchSize = rm.MaxBatchSize
@@ -648,12 +785,13 @@ func buildEnvironmentsFromMetrics(
 		}
 
 		env := &tuner.Environment{
-			Lambda:        float32(wm.avgArrivalRate * 60), // Convert reqs/sec to reqs/min for tuner
-			AvgInputToks:  float32(wm.avgInputTokens),
-			AvgOutputToks: float32(wm.avgOutputTokens),
-			MaxBatchSize:  int(maxBatchSize),
-			AvgTTFT:       float32(wm.avgTTFT * 1000.0), // convert secs to msecs for tuner
-			AvgITL:        float32(wm.avgITL * 1000.0),  // convert secs to msecs for tuner
+			Lambda:             float32(wm.avgArrivalRate * 60), // Convert reqs/sec to reqs/min for tuner
+			AvgInputToks:       float32(wm.avgInputTokens),
+			AvgOutputToks:      float32(wm.avgOutputTokens),
+			PrefixCacheHitRate: float32(wm.prefixCacheHitRate),
+			MaxBatchSize:       int(maxBatchSize),
+			AvgTTFT:            float32(wm.avgTTFT * 1000.0), // convert secs to msecs for tuner
+			AvgITL:             float32(wm.avgITL * 1000.0),  // convert secs to msecs for tuner
 		}
 
 		if !env.Valid() {
@@ -667,12 +805,13 @@ func buildEnvironmentsFromMetrics(
 				continue
 			}
 			env := &tuner.Environment{
-				Lambda:        float32(rm.ArrivalRate * 60), // Convert reqs/sec to reqs/min for tuner
-				MaxBatchSize:  int(maxBatchSize),
-				AvgInputToks:  float32(rm.AvgInputTokens),
-				AvgOutputToks: float32(rm.AvgOutputTokens),
-				AvgTTFT:       float32(rm.AvgTTFT * 1000), // Convert from microseconds to milliseconds for tuner
-				AvgITL:        float32(rm.AvgITL * 1000),  // Convert from microseconds to milliseconds for tuner
+				Lambda:             float32(rm.ArrivalRate * 60), // Convert reqs/sec to reqs/min for tuner
+				MaxBatchSize:       int(maxBatchSize),
+				AvgInputToks:       float32(rm.AvgInputTokens),
+				AvgOutputToks:      float32(rm.AvgOutputTokens),
+				PrefixCacheHitRate: float32(min(max(rm.PrefixCacheHitRate, 0), 1)),
+				AvgTTFT:            float32(rm.AvgTTFT * 1000), // Convert from microseconds to milliseconds for tuner
+				AvgITL:             float32(rm.AvgITL * 1000),  // Convert from microseconds to milliseconds for tuner
 			}
 			if env.Valid() {
 				envs = append(envs, env)
@@ -690,7 +829,7 @@ func buildEnvironmentsFromMetrics(
 //
 // From the queueing model:
 //
-//	T_p (TTFT) = T_iter + (beta + gamma) × i_l                    ... (eq 12)
+//	T_p (TTFT) = T_iter + (beta × (1 - h) + gamma) × i_l          ... (eq 12)
 //	T_g (ITL)  = T_iter + beta + gamma × (i_l + (o_l + 1)/2)     ... (eq 13)
 //
 // Where:
@@ -699,6 +838,7 @@ func buildEnvironmentsFromMetrics(
 //   - gamma: KV cache memory access time per token
 //   - i_l: average input tokens
 //   - o_l: average output tokens
+//   - h: prefix cache hit rate (input tokens not computed in prefill)
 func guessInitState(env *tuner.Environment) ([]float64, error) {
 	// Validate environment
 	if env == nil || !env.Valid() {
@@ -710,6 +850,7 @@ func guessInitState(env *tuner.Environment) ([]float64, error) {
 	itl := float64(env.AvgITL)               // T_g in paper
 	inputToks := float64(env.AvgInputToks)   // i_l in paper
 	outputToks := float64(env.AvgOutputToks) // o_l in paper
+	computed := 1 - float64(env.PrefixCacheHitRate)
 
 	// Validate inputs
 	if ttft <= 0 || itl <= 0 || inputToks <= 0 || outputToks <= 0 {
@@ -723,14 +864,14 @@ func guessInitState(env *tuner.Environment) ([]float64, error) {
 	// We use ITL as a proxy since it includes T_iter plus minimal decode work
 	alpha := tuner.BaseFactor * itl // BaseFactor ≈ 0.9
 
-	// Step 2: From TTFT equation (eq 12), solve for (beta + gamma)
-	// TTFT = T_iter + (beta + gamma) × i_l
+	// Step 2: From TTFT equation (eq 12), solve for the prefill time per input token
+	// TTFT = T_iter + (beta × (1 - h) + gamma) × i_l
 	// Assuming T_iter ≈ α at the observed load:
-	// (beta + gamma) = (TTFT - alpha) / i_l
-	sumBetaGamma := (ttft - alpha) / inputToks
+	// beta × (1 - h) + gamma = (TTFT - alpha) / i_l
+	prefillPerToken := (ttft - alpha) / inputToks
 
-	if sumBetaGamma < 0 {
-		return nil, fmt.Errorf("invalid derived sum(beta+gamma)=%.6f < 0, check BaseFactor or metrics", sumBetaGamma)
+	if prefillPerToken < 0 {
+		return nil, fmt.Errorf("invalid derived prefill time per token=%.6f < 0, check BaseFactor or metrics", prefillPerToken)
 	}
 
 	// Step 3: From ITL equation (eq 13), solve for the beta and gamma relationship
@@ -738,20 +879,20 @@ func guessInitState(env *tuner.Environment) ([]float64, error) {
 	// Assuming T_iter is approximately alpha:
 	// beta + gamma × (i_l + (o_l + 1)/2) = ITL - alpha
 	//
-	// Substitute beta = sumBetaGamma - gamma:
-	// (sumBetaGamma - gamma) + gamma × (i_l + (o_l + 1)/2) = ITL - alpha
-	// sumBetaGamma + gamma × (i_l + (o_l + 1)/2 - 1) = ITL - alpha
+	// Substitute beta = (prefillPerToken - gamma) / (1 - h):
+	// (prefillPerToken - gamma) + gamma × (1 - h) × (i_l + (o_l + 1)/2) = (1 - h) × (ITL - alpha)
+	// prefillPerToken + gamma × ((1 - h) × (i_l + (o_l + 1)/2) - 1) = (1 - h) × (ITL - alpha)
 	//
-	// Solve for gamma:
-	denominator := inputToks + (outputToks+1)/2 - 1
+	// Solve for gamma (with h = 0, beta + gamma = prefillPerToken):
+	denominator := computed*(inputToks+(outputToks+1)/2) - 1
 	if denominator <= 0 {
 		return nil, fmt.Errorf("invalid denominator=%.6f for gamma calculation", denominator)
 	}
 
-	gamma := ((itl - alpha) - sumBetaGamma) / denominator
+	gamma := (computed*(itl-alpha) - prefillPerToken) / denominator
 
-	// Step 4: Solve for beta
-	beta := sumBetaGamma - gamma
+	// Step 4: Solve for beta from the ITL equation
+	beta := (itl - alpha) - gamma*(inputToks+(outputToks+1)/2)
 
 	// Validate results: all parameters must be positive
 	if alpha <= 0 {
@@ -805,19 +946,21 @@ func getVariantNames(replicaMetrics []interfaces.ReplicaMetrics) []string {
 
 // workloadMetrics holds workload characteristics for a server.
 type workloadMetrics struct {
-	avgArrivalRate  float64 // req/sec
-	avgInputTokens  float64
-	avgOutputTokens float64
-	avgTTFT         float64 // seconds
-	avgITL          float64 // seconds
-	busyPods        int
+	avgArrivalRate     float64 // req/sec
+	avgInputTokens     float64
+	avgOutputTokens    float64
+	prefixCacheHitRate float64 // share of the input tokens served from the prefix cache
+	avgTTFT            float64 // seconds
+	avgITL             float64 // seconds
+	busyPods           int
 }
 
 // aggregateWorkloadMetrics averages token sizes and latencies across replicas
 // that have active traffic. Returns zero metrics if no replicas have traffic.
+// The prefix cache hit rate is weighted by the input tokens of the replicas.
 func aggregateWorkloadMetrics(replicaMetrics []interfaces.ReplicaMetrics) *workloadMetrics {
 	var totalArrivalRate float64
-	var totalInputToks, totalOutputToks float64
+	var totalInputToks, totalOutputToks, totalCachedInputToks float64
 	var totalTTFT, totalITL float64
 
 	// Aggregate per-pod traffic metrics across replicas
@@ -833,6 +976,7 @@ func aggregateWorkloadMetrics(replicaMetrics []interfaces.ReplicaMetrics) *workl
 		totalArrivalRate += rm.ArrivalRate
 		totalInputToks += rm.ArrivalRate * rm.AvgInputTokens
 		totalOutputToks += rm.ArrivalRate * rm.AvgOutputTokens
+		totalCachedInputToks += rm.ArrivalRate * rm.AvgInputTokens * min(max(rm.PrefixCacheHitRate, 0), 1)
 		totalTTFT += rm.ArrivalRate * rm.AvgTTFT
 		totalITL += rm.ArrivalRate * rm.AvgITL
 		busyPods++
@@ -842,12 +986,18 @@ func aggregateWorkloadMetrics(replicaMetrics []interfaces.ReplicaMetrics) *workl
 		return &workloadMetrics{}
 	}
 
+	var prefixCacheHitRate float64
+	if totalInputToks > 0 {
+		prefixCacheHitRate = totalCachedInputToks / totalInputToks
+	}
+
 	return &workloadMetrics{
-		avgArrivalRate:  totalArrivalRate / float64(busyPods),
-		avgInputTokens:  totalInputToks / totalArrivalRate,
-		avgOutputTokens: totalOutputToks / totalArrivalRate,
-		avgTTFT:         totalTTFT / totalArrivalRate,
-		avgITL:          totalITL / totalArrivalRate,
-		busyPods:        busyPods,
+		avgArrivalRate:     totalArrivalRate / float64(busyPods),
+		avgInputTokens:     totalInputToks / totalArrivalRate,
+		avgOutputTokens:    totalOutputToks / totalArrivalRate,
+		prefixCacheHitRate: prefixCacheHitRate,
+		avgTTFT:            totalTTFT / totalArrivalRate,
+		avgITL:             totalITL / totalArrivalRate,
+		busyPods:           busyPods,
 	}
 }
@@ -0,0 +1,109 @@
+package queueingmodel
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestAggregateWorkloadMetrics_PrefixCacheHitRate(t *testing.T) {
+	wm := aggregateWorkloadMetrics([]interfaces.ReplicaMetrics{
+		{ArrivalRate: 1, AvgInputTokens: 1000, AvgOutputTokens: 100, PrefixCacheHitRate: 0.8},
+		{ArrivalRate: 1, AvgInputTokens: 3000, AvgOutputTokens: 100},
+		// idle replica, ignored
+		{AvgInputTokens: 1000, PrefixCacheHitRate: 1},
+	})
+	// 800 of 4000 input tokens are cached
+	if math.Abs(wm.prefixCacheHitRate-0.2) > 1e-9 {
+		t.Errorf("prefixCacheHitRate = %v, want 0.2", wm.prefixCacheHitRate)
+	}
+}
+
+func TestInferSLOTarget_PrefixCacheHitRate(t *testing.T) {
+	params := &LearnedParameters{Alpha: 10, Beta: 0.01, Gamma: 0.001}
+	uncached := inferSLOTarget(2, params, 1000, 100, 0)
+	cached := inferSLOTarget(2, params, 1000, 100, 0.5)
+
+	// only the compute of the cached tokens is saved: 0.5 × 1000 × beta
+	if diff := uncached.TargetTTFT - cached.TargetTTFT; math.Abs(float64(diff-5)) > 1e-4 {
+		t.Errorf("TTFT difference = %v, want 5", diff)
+	}
+	if cached.TargetITL != uncached.TargetITL {
+		t.Errorf("TargetITL = %v, want %v", cached.TargetITL, uncached.TargetITL)
+	}
+}
+
+// The initial guess solves the TTFT and ITL equations of the queueing model
+// at the observed load, for prefill computing only the uncached input tokens.
+func TestGuessInitState(t *testing.T) {
+	for _, hitRate := range []float32{0, 0.5} {
+		env := &tuner.Environment{
+			Lambda:             60,
+			AvgInputToks:       1000,
+			AvgOutputToks:      200,
+			PrefixCacheHitRate: hitRate,
+			MaxBatchSize:       64,
+			AvgTTFT:            118,
+			AvgITL:             20,
+		}
+		state, err := guessInitState(env)
+		if err != nil {
+			t.Fatalf("guessInitState(hitRate=%v) failed: %v", hitRate, err)
+		}
+		alpha := state[tuner.StateIndexAlpha]
+		beta := state[tuner.StateIndexBeta]
+		gamma := state[tuner.StateIndexGamma]
+		in, out, h := float64(env.AvgInputToks), float64(env.AvgOutputToks), float64(hitRate)
+
+		ttft := alpha + (beta*(1-h)+gamma)*in
+		itl := alpha + beta + gamma*(in+(out+1)/2)
+		if math.Abs(ttft-float64(env.AvgTTFT)) > 1e-6 || math.Abs(itl-float64(env.AvgITL)) > 1e-6 {
+			t.Errorf("hitRate=%v: guess (%v, %v, %v) gives TTFT=%v ITL=%v, want %v and %v",
+				hitRate, alpha, beta, gamma, ttft, itl, env.AvgTTFT, env.AvgITL)
+		}
+	}
+}
+
+func TestCreateTunerForVariant_Prior(t *testing.T) {
+	a := NewQueueingModelAnalyzer()
+	env := &tuner.Environment{
+		Lambda:        60,
+		AvgInputToks:  1000,
+		AvgOutputToks: 200,
+		MaxBatchSize:  64,
+		AvgTTFT:       118,
+		AvgITL:        20,
+	}
+	config := &QMConfig{Priors: map[string]*ParameterPrior{
+		"variant-a": {Alpha: 8, Beta: 0.02, Gamma: 0.0003, MaxBatchSize: 128},
+	}}
+
+	// a variant with a prior and no learned parameters starts from its prior
+	tnr, err := a.createTunerForVariant(context.Background(), "ns", "model", "variant-a", env, config)
+	if err != nil {
+		t.Fatalf("createTunerForVariant() error: %v", err)
+	}
+	alpha, beta, gamma := StateVectorToParams(tnr.X().RawVector().Data)
+	if float32(alpha) != 8 || float32(beta) != 0.02 || float32(gamma) != 0.0003 {
+		t.Errorf("Initial state = (%v, %v, %v), want the prior", alpha, beta, gamma)
+	}
+	if got := config.defaultMaxBatchSize("variant-a"); got != 128 {
+		t.Errorf("defaultMaxBatchSize() = %d, want the profiled 128", got)
+	}
+
+	// other variants start from a guess
+	tnr, err = a.createTunerForVariant(context.Background(), "ns", "model", "variant-b", env, config)
+	if err != nil {
+		t.Fatalf("createTunerForVariant() error: %v", err)
+	}
+	guess, _ := guessInitState(env)
+	if got := tnr.X().RawVector().Data; got[tuner.StateIndexAlpha] != guess[tuner.StateIndexAlpha] {
+		t.Errorf("Initial state = %v, want the guess %v", got, guess)
+	}
+	if got := config.defaultMaxBatchSize("variant-b"); got != DefaultMaxBatchSize {
+		t.Errorf("defaultMaxBatchSize() = %d, want %d", got, DefaultMaxBatchSize)
+	}
+}
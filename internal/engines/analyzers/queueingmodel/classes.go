@@ -0,0 +1,137 @@
+package queueingmodel
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// tokenRange is a range of request lengths, with its share of the requests
+// and the average number of tokens of its requests.
+type tokenRange struct {
+	weight    float64
+	avgTokens float64
+}
+
+// requestClasses splits the workload of the replicas of a variant into request
+// classes, from their histograms of input and output tokens per request.
+//
+// Each histogram is split into at most maxRanges ranges of request lengths
+// holding similar shares of the tokens, so a few long requests get a range of
+// their own. The classes are the combinations of an input and an output range,
+// assuming input and output lengths are independent. The average lengths of
+// the ranges are rescaled to match the observed averages of the workload, and
+// every class gets the prefix cache hit rate of the workload.
+//
+// Returns nil when the histograms are unavailable or give a single class: the
+// workload is then described by its average request size.
+func requestClasses(replicaMetrics []interfaces.ReplicaMetrics, wm *workloadMetrics, maxRanges int) []*analyzer.RequestClass {
+	if maxRanges < 2 {
+		return nil
+	}
+	var inputHistograms, outputHistograms [][]interfaces.HistogramBucket
+	for _, rm := range replicaMetrics {
+		if rm.ArrivalRate <= 0 {
+			continue
+		}
+		inputHistograms = append(inputHistograms, rm.InputTokensHistogram)
+		outputHistograms = append(outputHistograms, rm.OutputTokensHistogram)
+	}
+	inputRanges := splitTokenRanges(mergeHistograms(inputHistograms), wm.avgInputTokens, maxRanges)
+	outputRanges := splitTokenRanges(mergeHistograms(outputHistograms), wm.avgOutputTokens, maxRanges)
+	if len(inputRanges) == 0 || len(outputRanges) == 0 || len(inputRanges)*len(outputRanges) < 2 {
+		return nil
+	}
+
+	classes := make([]*analyzer.RequestClass, 0, len(inputRanges)*len(outputRanges))
+	for _, in := range inputRanges {
+		for _, out := range outputRanges {
+			classes = append(classes, &analyzer.RequestClass{
+				Weight: float32(in.weight * out.weight),
+				RequestSize: &analyzer.RequestSize{
+					AvgInputTokens:     float32(in.avgTokens),
+					AvgOutputTokens:    float32(max(out.avgTokens, 1)),
+					PrefixCacheHitRate: float32(wm.prefixCacheHitRate),
+				},
+			})
+		}
+	}
+	return classes
+}
+
+// mergeHistograms adds cumulative histograms bucket by bucket. Buckets are
+// matched on their upper bound.
+func mergeHistograms(histograms [][]interfaces.HistogramBucket) []interfaces.HistogramBucket {
+	rates := make(map[float64]float64)
+	for _, histogram := range histograms {
+		for _, bucket := range histogram {
+			rates[bucket.UpperBound] += bucket.Rate
+		}
+	}
+	merged := make([]interfaces.HistogramBucket, 0, len(rates))
+	for upperBound, rate := range rates {
+		merged = append(merged, interfaces.HistogramBucket{UpperBound: upperBound, Rate: rate})
+	}
+	slices.SortFunc(merged, func(a, b interfaces.HistogramBucket) int {
+		return cmp.Compare(a.UpperBound, b.UpperBound)
+	})
+	return merged
+}
+
+// splitTokenRanges splits a cumulative histogram of tokens per request into at
+// most maxRanges ranges of adjacent buckets holding similar shares of the
+// tokens. The requests of a bucket are assumed to have the midpoint length of
+// the bucket, or its lower bound for the last (+Inf) bucket. The average
+// lengths are then rescaled so that the overall average is avgTokens, when
+// known.
+func splitTokenRanges(histogram []interfaces.HistogramBucket, avgTokens float64, maxRanges int) []tokenRange {
+	type bucket struct {
+		requests, tokens float64
+	}
+	buckets := make([]bucket, 0, len(histogram))
+	var totalRequests, totalTokens, lowerBound, cumulative float64
+	for _, b := range histogram {
+		requests := max(b.Rate-cumulative, 0)
+		cumulative = max(cumulative, b.Rate)
+		length := lowerBound
+		if !math.IsInf(b.UpperBound, 1) {
+			length = (lowerBound + b.UpperBound) / 2
+			lowerBound = b.UpperBound
+		}
+		buckets = append(buckets, bucket{requests: requests, tokens: requests * length})
+		totalRequests += requests
+		totalTokens += requests * length
+	}
+	if totalRequests <= 0 || totalTokens <= 0 {
+		return nil
+	}
+
+	// assign each bucket to a range by the middle of its share of the tokens
+	ranges := make([]bucket, maxRanges)
+	var tokensBefore float64
+	for _, b := range buckets {
+		i := min(int((tokensBefore+b.tokens/2)/totalTokens*float64(maxRanges)), maxRanges-1)
+		ranges[i].requests += b.requests
+		ranges[i].tokens += b.tokens
+		tokensBefore += b.tokens
+	}
+
+	scale := 1.0
+	if avgTokens > 0 {
+		scale = avgTokens / (totalTokens / totalRequests)
+	}
+	tokenRanges := make([]tokenRange, 0, maxRanges)
+	for _, r := range ranges {
+		if r.requests <= 0 {
+			continue
+		}
+		tokenRanges = append(tokenRanges, tokenRange{
+			weight:    r.requests / totalRequests,
+			avgTokens: r.tokens / r.requests * scale,
+		})
+	}
+	return tokenRanges
+}
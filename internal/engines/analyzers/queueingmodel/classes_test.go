@@ -0,0 +1,139 @@
+package queueingmodel
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// chatAndRAGHistogram has 0.9 req/s of 100-200 input tokens and 0.1 req/s of
+// 20k-50k input tokens.
+var chatAndRAGHistogram = []interfaces.HistogramBucket{
+	{UpperBound: 100, Rate: 0},
+	{UpperBound: 200, Rate: 0.9},
+	{UpperBound: 20000, Rate: 0.9},
+	{UpperBound: 50000, Rate: 1.0},
+	{UpperBound: math.Inf(1), Rate: 1.0},
+}
+
+func TestSplitTokenRanges(t *testing.T) {
+	tests := []struct {
+		name      string
+		histogram []interfaces.HistogramBucket
+		avgTokens float64
+		maxRanges int
+		want      []tokenRange
+	}{
+		{
+			name:      "no histogram",
+			maxRanges: 2,
+			want:      nil,
+		},
+		{
+			name:      "no requests",
+			histogram: []interfaces.HistogramBucket{{UpperBound: 100, Rate: 0}, {UpperBound: math.Inf(1), Rate: 0}},
+			maxRanges: 2,
+			want:      nil,
+		},
+		{
+			name:      "short and long requests",
+			histogram: chatAndRAGHistogram,
+			maxRanges: 2,
+			want:      []tokenRange{{weight: 0.9, avgTokens: 150}, {weight: 0.1, avgTokens: 35000}},
+		},
+		{
+			name:      "single range",
+			histogram: chatAndRAGHistogram,
+			maxRanges: 1,
+			want:      []tokenRange{{weight: 1, avgTokens: 3635}},
+		},
+		{
+			name:      "rescaled to the observed average",
+			histogram: chatAndRAGHistogram,
+			avgTokens: 7270,
+			maxRanges: 2,
+			want:      []tokenRange{{weight: 0.9, avgTokens: 300}, {weight: 0.1, avgTokens: 70000}},
+		},
+		{
+			name: "requests above the last finite bucket",
+			histogram: []interfaces.HistogramBucket{
+				{UpperBound: 1000, Rate: 1},
+				{UpperBound: math.Inf(1), Rate: 2},
+			},
+			maxRanges: 2,
+			want:      []tokenRange{{weight: 0.5, avgTokens: 500}, {weight: 0.5, avgTokens: 1000}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := splitTokenRanges(tt.histogram, tt.avgTokens, tt.maxRanges)
+			if len(got) != len(tt.want) {
+				t.Fatalf("splitTokenRanges() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if math.Abs(got[i].weight-tt.want[i].weight) > 1e-9 ||
+					math.Abs(got[i].avgTokens-tt.want[i].avgTokens) > 1e-6 {
+					t.Errorf("splitTokenRanges()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeHistograms(t *testing.T) {
+	got := mergeHistograms([][]interfaces.HistogramBucket{
+		{{UpperBound: 100, Rate: 1}, {UpperBound: math.Inf(1), Rate: 2}},
+		{{UpperBound: math.Inf(1), Rate: 3}, {UpperBound: 100, Rate: 0.5}},
+		nil,
+	})
+	want := []interfaces.HistogramBucket{{UpperBound: 100, Rate: 1.5}, {UpperBound: math.Inf(1), Rate: 5}}
+	if len(got) != len(want) {
+		t.Fatalf("mergeHistograms() = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("mergeHistograms()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRequestClasses(t *testing.T) {
+	outputHistogram := []interfaces.HistogramBucket{
+		{UpperBound: 100, Rate: 0},
+		{UpperBound: 300, Rate: 1.0},
+		{UpperBound: math.Inf(1), Rate: 1.0},
+	}
+	replicaMetrics := []interfaces.ReplicaMetrics{
+		{ArrivalRate: 1, InputTokensHistogram: chatAndRAGHistogram, OutputTokensHistogram: outputHistogram},
+		{ArrivalRate: 1, InputTokensHistogram: chatAndRAGHistogram, OutputTokensHistogram: outputHistogram},
+		// idle replica, ignored
+		{InputTokensHistogram: []interfaces.HistogramBucket{{UpperBound: 100, Rate: 5}}},
+	}
+	wm := aggregateWorkloadMetrics(replicaMetrics)
+
+	if classes := requestClasses(replicaMetrics, wm, 1); classes != nil {
+		t.Errorf("Expected no classes for a single range, got %v", classes)
+	}
+	if classes := requestClasses([]interfaces.ReplicaMetrics{{ArrivalRate: 1}}, wm, 2); classes != nil {
+		t.Errorf("Expected no classes without histograms, got %v", classes)
+	}
+
+	// the output lengths are in a single range, so there are two classes
+	classes := requestClasses(replicaMetrics, wm, 2)
+	if len(classes) != 2 {
+		t.Fatalf("Expected 2 classes, got %v", classes)
+	}
+	for i, want := range []struct{ weight, input, output float32 }{
+		{weight: 0.9, input: 150, output: 200},
+		{weight: 0.1, input: 35000, output: 200},
+	} {
+		got := classes[i]
+		if math.Abs(float64(got.Weight-want.weight)) > 1e-6 ||
+			math.Abs(float64(got.RequestSize.AvgInputTokens-want.input)) > 1e-3 ||
+			math.Abs(float64(got.RequestSize.AvgOutputTokens-want.output)) > 1e-3 {
+			t.Errorf("Class %d = %s, want %+v", i, got, want)
+		}
+	}
+}
@@ -17,7 +17,7 @@ type QMConfig struct {
 	//   TargetTTFT = k×alpha + (beta+gamma)×input_len
 	//   TargetITL  = k×alpha + beta + gamma×(input_len + (output_len+1)/2)
 	// The utilization correspondence is rho = 1 - 1/k.
-	// Zero value means use DefaultSLOMultiplier (3.0, rho=0.67).
+	// Zero value means use config.DefaultSLOMultiplier (3.0, rho=0.67).
 	SLOMultiplier float64
 
 	// Tuning configuration
@@ -28,6 +28,40 @@ type QMConfig struct {
 	// noise model and are hardware-independent, so they are shared across all variants.
 	// If nil, default filter configuration will be used.
 	FilterConfig *tuner.FilterData
+
+	// ColdStartLookahead enables scaling up ahead of an upward demand trend
+	// by the learned replica startup latency. Applied by the engine on the
+	// analyzer result; the analyzer itself does not read it.
+	ColdStartLookahead bool
+
+	// MinOnDemandFraction is the minimum fraction of capacity that must stay on
+	// non-preemptible variants. Applied by the optimizer; the analyzer itself
+	// does not read it.
+	MinOnDemandFraction float64
+
+	// RequestClasses is the maximum number of ranges the histograms of input
+	// and output tokens per request are split into. Variants are sized for
+	// the combinations of an input and an output range, so that the SLO
+	// targets of each are met. Values below 2 size variants for the average
+	// request size.
+	RequestClasses int
+
+	// Priors maps variant names to the parameters profiled offline for the
+	// model on the accelerator of the variant. The tuner of a variant without
+	// learned parameters starts from its prior rather than from a guess.
+	Priors map[string]*ParameterPrior
+}
+
+// ParameterPrior holds parameters of a variant profiled offline, from
+// benchmarks or recorded metrics.
+type ParameterPrior struct {
+	Alpha float32 // baseline iteration time (msec)
+	Beta  float32 // compute time per token (msec)
+	Gamma float32 // memory access time per token (msec)
+
+	// MaxBatchSize is the profiled max batch size, used when the replicas of
+	// the variant do not report theirs. Zero if not profiled.
+	MaxBatchSize int
 }
 
 // SLOTarget defines TTFT/ITL targets for a model
@@ -50,6 +84,23 @@ func (c *QMConfig) GetSLOForModel(namespace, modelID string) *SLOTarget {
 	return c.SLOTargets[key]
 }
 
+// GetPrior retrieves the profiled parameters of a variant (nil if none)
+func (c *QMConfig) GetPrior(variantName string) *ParameterPrior {
+	if c.Priors == nil {
+		return nil
+	}
+	return c.Priors[variantName]
+}
+
+// defaultMaxBatchSize returns the max batch size of a variant whose replicas
+// do not report it: the profiled one if any, else DefaultMaxBatchSize.
+func (c *QMConfig) defaultMaxBatchSize(variantName string) int64 {
+	if prior := c.GetPrior(variantName); prior != nil && prior.MaxBatchSize > 0 {
+		return int64(prior.MaxBatchSize)
+	}
+	return DefaultMaxBatchSize
+}
+
 // make an SLOTarget the maximum of itself and other target
 func (t *SLOTarget) Max(other *SLOTarget) {
 	if other != nil {
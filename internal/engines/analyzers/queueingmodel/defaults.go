@@ -7,12 +7,6 @@ const (
 	DefaultMaxBatchSize = 256
 	DefaultMaxQueueSize = 100
 
-	// DefaultSLOMultiplier is the queueing delay multiplier for inferred SLOs.
-	// The SLO allows iteration time (queueing delay) to inflate by k× the idle
-	// baseline α, while keeping deterministic work components at their true cost.
-	// k=3 corresponds to utilization ρ = 1 - 1/k = 0.67.
-	DefaultSLOMultiplier = 3.0
-
 	// DefaultMaxFallbackTTFT caps the observation-based fallback TTFT SLO (ms).
 	DefaultMaxFallbackTTFT = 10000.0
 
@@ -0,0 +1,236 @@
+package queueingmodel
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"gonum.org/v1/gonum/optimize"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// DefaultMaxBatchSizeCandidates are the max batch sizes tried by
+// FitParameters when the max batch size of the observed server is unknown.
+var DefaultMaxBatchSizeCandidates = []int{16, 32, 64, 128, 256, 512, 1024}
+
+const (
+	// fitMaxIterations bounds the iterations of the least squares search for
+	// each max batch size.
+	fitMaxIterations = 1000
+
+	// fitOverloadCost is the cost of an observation at a request rate beyond
+	// the max rate of the queueing model, per unit of the logarithm of the
+	// ratio of the rates.
+	fitOverloadCost = 10.0
+
+	// fitInfeasibleCost is the cost of an observation that the queueing model
+	// cannot be evaluated for with the parameters searched.
+	fitInfeasibleCost = 1000.0
+)
+
+// FittedParameters are the parameters of the queueing model fitted offline to
+// observations of a server at several request rates.
+type FittedParameters struct {
+	Alpha        float32
+	Beta         float32
+	Gamma        float32
+	MaxBatchSize int
+
+	// Error is the root mean square of the logarithmic errors of the TTFT and
+	// ITL predicted with the parameters for the observations, about the
+	// relative errors when small.
+	Error float64
+}
+
+// FitParameters fits alpha, beta and gamma to observations of a server, by
+// nonlinear least squares on the logarithmic errors of the TTFT and ITL that the
+// queueing model predicts for the observed request rates and sizes. It is the
+// offline counterpart of the tuner, which tracks the parameters online from
+// one observation per cycle.
+//
+// maxBatchSizes are the max batch sizes to fit the parameters for; the one
+// with the lowest error is returned, the smallest on a tie. When empty, the
+// max batch size of each observation is used.
+func FitParameters(envs []*tuner.Environment, maxBatchSizes []int) (*FittedParameters, error) {
+	if len(envs) == 0 {
+		return nil, errors.New("no observations to fit")
+	}
+	for i, env := range envs {
+		if env == nil {
+			return nil, fmt.Errorf("invalid observation %d: nil", i)
+		}
+		e := *env
+		if len(maxBatchSizes) > 0 {
+			e.MaxBatchSize = maxBatchSizes[0]
+		}
+		if !e.Valid() {
+			return nil, fmt.Errorf("invalid observation %d: %v", i, env)
+		}
+	}
+	init := fitInitState(envs)
+
+	if len(maxBatchSizes) == 0 {
+		return fitForMaxBatchSize(envs, init, 0)
+	}
+	var best *FittedParameters
+	for _, maxBatchSize := range slices.Sorted(slices.Values(maxBatchSizes)) {
+		if maxBatchSize <= 0 {
+			return nil, fmt.Errorf("invalid max batch size %d", maxBatchSize)
+		}
+		fitted, err := fitForMaxBatchSize(envs, init, maxBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if best == nil || fitted.Error < best.Error {
+			best = fitted
+		}
+	}
+	return best, nil
+}
+
+// fitForMaxBatchSize fits the parameters with the given max batch size, or
+// with the max batch size of each observation if zero.
+//
+// The search is first run on the observations at the lower half of the
+// request rates, then on all observations from the parameters found. Near
+// saturation, the predicted latencies are steep in the parameters, and the
+// highest rates cannot be served at all with parameters far from the fitted
+// ones, so that a search started there may not find its way.
+func fitForMaxBatchSize(envs []*tuner.Environment, init []float64, maxBatchSize int) (*FittedParameters, error) {
+	fitEnvs := make([]*tuner.Environment, len(envs))
+	for i, env := range envs {
+		e := *env
+		if maxBatchSize > 0 {
+			e.MaxBatchSize = maxBatchSize
+		}
+		fitEnvs[i] = &e
+	}
+	slices.SortStableFunc(fitEnvs, func(a, b *tuner.Environment) int {
+		return cmp.Compare(a.Lambda, b.Lambda)
+	})
+
+	x := make([]float64, len(init))
+	for i, v := range init {
+		x[i] = math.Log(v)
+	}
+	var cost float64
+	for _, n := range []int{(len(fitEnvs) + 1) / 2, len(fitEnvs)} {
+		var err error
+		if x, cost, err = leastSquares(fitEnvs[:n], x); err != nil {
+			return nil, err
+		}
+	}
+
+	return &FittedParameters{
+		Alpha:        float32(math.Exp(x[tuner.StateIndexAlpha])),
+		Beta:         float32(math.Exp(x[tuner.StateIndexBeta])),
+		Gamma:        float32(math.Exp(x[tuner.StateIndexGamma])),
+		MaxBatchSize: maxBatchSize,
+		Error:        math.Sqrt(cost / float64(2*len(fitEnvs))),
+	}, nil
+}
+
+// leastSquares minimizes the sum of the squared logarithmic errors of the TTFT
+// and ITL predicted for the observations, starting from x. The search runs on
+// the logarithm of the parameters, which keeps them positive and copes with
+// their different orders of magnitude. Returns the logarithm of the
+// parameters found and their cost.
+func leastSquares(envs []*tuner.Environment, x []float64) ([]float64, float64, error) {
+	cost := func(x []float64) float64 {
+		parms := &analyzer.ServiceParms{
+			Alpha: float32(math.Exp(x[tuner.StateIndexAlpha])),
+			Beta:  float32(math.Exp(x[tuner.StateIndexBeta])),
+			Gamma: float32(math.Exp(x[tuner.StateIndexGamma])),
+		}
+		var sum float64
+		for _, env := range envs {
+			sum += observationCost(env, parms)
+		}
+		return sum
+	}
+
+	result, err := optimize.Minimize(
+		optimize.Problem{Func: cost},
+		x,
+		&optimize.Settings{MajorIterations: fitMaxIterations},
+		&optimize.NelderMead{},
+	)
+	if err != nil && result == nil {
+		return nil, 0, fmt.Errorf("failed to fit parameters: %w", err)
+	}
+	return result.X, result.F, nil
+}
+
+// observationCost is the sum of the squared logarithmic errors of the TTFT
+// and ITL predicted for an observation with the given parameters. Logarithmic
+// errors are close to relative errors when small, and keep the steep errors
+// near saturation from dominating the fit.
+//
+// When the observed rate is beyond the max rate of the queueing model with the
+// parameters, the cost is that of the observation at the max rate, plus a cost
+// growing with the overload. It is continuous at the max rate, and leads the
+// search towards parameters that serve the observed rate.
+func observationCost(env *tuner.Environment, parms *analyzer.ServiceParms) float64 {
+	ttft, itl, err := tuner.Predict(env, parms)
+	if err == nil {
+		return squaredLogError(ttft, env.AvgTTFT) + squaredLogError(itl, env.AvgITL)
+	}
+
+	maxRate, err := tuner.MaxRate(env, parms)
+	if err != nil || maxRate <= 0 {
+		return fitInfeasibleCost
+	}
+	atMaxRate := *env
+	atMaxRate.Lambda = maxRate * 60 * (1 - analyzer.Epsilon)
+	ttft, itl, err = tuner.Predict(&atMaxRate, parms)
+	if err != nil {
+		return fitInfeasibleCost
+	}
+	overload := math.Log(float64(env.Lambda) / float64(atMaxRate.Lambda))
+	return squaredLogError(ttft, env.AvgTTFT) + squaredLogError(itl, env.AvgITL) + fitOverloadCost*overload
+}
+
+// squaredLogError is the squared logarithmic error of a predicted value.
+func squaredLogError(predicted float64, observed float32) float64 {
+	e := math.Log(predicted / float64(observed))
+	return e * e
+}
+
+// fitInitState returns the state the fit starts from: the median of the
+// initial states guessed from each observation, or the tuner defaults if
+// none could be guessed.
+func fitInitState(envs []*tuner.Environment) []float64 {
+	var alphas, betas, gammas []float64
+	for _, env := range envs {
+		e := *env
+		if e.MaxBatchSize == 0 {
+			e.MaxBatchSize = DefaultMaxBatchSize
+		}
+		state, err := guessInitState(&e)
+		if err != nil {
+			continue
+		}
+		alpha, beta, gamma := StateVectorToParams(state)
+		alphas = append(alphas, alpha)
+		betas = append(betas, beta)
+		gammas = append(gammas, gamma)
+	}
+	if len(alphas) == 0 {
+		return ParamsToStateVector(tuner.DefaultAlpha, tuner.DefaultBeta, tuner.DefaultGamma)
+	}
+	return ParamsToStateVector(median(alphas), median(betas), median(gammas))
+}
+
+// median returns the median of a non-empty slice, sorting it in place.
+func median(values []float64) float64 {
+	slices.Sort(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
@@ -0,0 +1,85 @@
+package queueingmodel
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel/tuner"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// sweep returns the observations of a server with the given parameters at a
+// sweep of request rates (req/sec), as a benchmark would measure them.
+func sweep(t *testing.T, parms *analyzer.ServiceParms, maxBatchSize int, rates ...float32) []*tuner.Environment {
+	t.Helper()
+	envs := make([]*tuner.Environment, 0, len(rates))
+	for _, rate := range rates {
+		env := &tuner.Environment{
+			Lambda:        rate * 60,
+			AvgInputToks:  1024,
+			AvgOutputToks: 256,
+			MaxBatchSize:  maxBatchSize,
+		}
+		ttft, itl, err := tuner.Predict(env, parms)
+		if err != nil {
+			t.Fatalf("Predict at rate %v: %v", rate, err)
+		}
+		env.AvgTTFT, env.AvgITL = float32(ttft), float32(itl)
+		envs = append(envs, env)
+	}
+	return envs
+}
+
+func TestFitParameters(t *testing.T) {
+	want := &analyzer.ServiceParms{Alpha: 6, Beta: 0.04, Gamma: 0.0002}
+	envs := sweep(t, want, 64, 1, 2, 4, 6, 7)
+
+	fitted, err := FitParameters(envs, nil)
+	if err != nil {
+		t.Fatalf("FitParameters() error: %v", err)
+	}
+	if fitted.Error > 0.01 {
+		t.Errorf("Error = %v, want < 1%%", fitted.Error)
+	}
+	// the fitted parameters reproduce the observations
+	for _, env := range envs {
+		ttft, itl, err := tuner.Predict(env, &analyzer.ServiceParms{Alpha: fitted.Alpha, Beta: fitted.Beta, Gamma: fitted.Gamma})
+		if err != nil {
+			t.Fatalf("Predict with fitted parameters %+v: %v", fitted, err)
+		}
+		if math.Abs(ttft-float64(env.AvgTTFT)) > 0.02*float64(env.AvgTTFT) ||
+			math.Abs(itl-float64(env.AvgITL)) > 0.02*float64(env.AvgITL) {
+			t.Errorf("At %v req/min: fitted TTFT %.2f, ITL %.2f, observed %.2f, %.2f",
+				env.Lambda, ttft, itl, env.AvgTTFT, env.AvgITL)
+		}
+	}
+}
+
+func TestFitParameters_MaxBatchSize(t *testing.T) {
+	want := &analyzer.ServiceParms{Alpha: 6, Beta: 0.04, Gamma: 0.0002}
+	envs := sweep(t, want, 32, 1, 2, 4, 5, 6)
+	for _, env := range envs {
+		env.MaxBatchSize = 0
+	}
+
+	fitted, err := FitParameters(envs, []int{128, 16, 32})
+	if err != nil {
+		t.Fatalf("FitParameters() error: %v", err)
+	}
+	if fitted.MaxBatchSize != 32 {
+		t.Errorf("MaxBatchSize = %d, want 32 (error %v)", fitted.MaxBatchSize, fitted.Error)
+	}
+}
+
+func TestFitParameters_Invalid(t *testing.T) {
+	if _, err := FitParameters(nil, nil); err == nil {
+		t.Error("Expected an error without observations")
+	}
+	env := &tuner.Environment{Lambda: 60, AvgInputToks: 100, AvgOutputToks: 100, AvgTTFT: 50, AvgITL: 10}
+	if _, err := FitParameters([]*tuner.Environment{env}, nil); err == nil {
+		t.Error("Expected an error without a max batch size")
+	}
+	if _, err := FitParameters([]*tuner.Environment{env}, []int{0}); err == nil {
+		t.Error("Expected an error for a zero max batch size")
+	}
+}
@@ -79,3 +79,22 @@ func (s *ParameterStore) Set(namespace, variantName string, params *LearnedParam
 	key := makeVariantKey(namespace, variantName)
 	s.params[key] = params
 }
+
+// Delete removes the parameters of a variant
+func (s *ParameterStore) Delete(namespace, variantName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.params, makeVariantKey(namespace, variantName))
+}
+
+// snapshot returns deep copies of the parameters of all variants, keyed by
+// namespace/variantName.
+func (s *ParameterStore) snapshot() map[string]*LearnedParameters {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	params := make(map[string]*LearnedParameters, len(s.params))
+	for key, p := range s.params {
+		params[key] = p.deepCopy()
+	}
+	return params
+}
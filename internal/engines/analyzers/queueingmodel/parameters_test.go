@@ -105,6 +105,20 @@ func TestParameterStore_DifferentVariants(t *testing.T) {
 	}
 }
 
+func TestParameterStore_Delete(t *testing.T) {
+	store := NewParameterStore()
+	store.Set("ns", "v1", &LearnedParameters{Alpha: 1.0})
+	store.Set("ns", "v2", &LearnedParameters{Alpha: 2.0})
+	store.Delete("ns", "v1")
+
+	if got := store.Get("ns", "v1"); got != nil {
+		t.Errorf("ns/v1 = %v, want nil after delete", got)
+	}
+	if got := store.Get("ns", "v2"); got == nil || got.Alpha != 2.0 {
+		t.Errorf("ns/v2 = %v, want Alpha 2.0", got)
+	}
+}
+
 func TestParameterStore_ConcurrentAccess(t *testing.T) {
 	store := NewParameterStore()
 	var wg sync.WaitGroup
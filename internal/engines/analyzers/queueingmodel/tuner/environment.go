@@ -8,12 +8,13 @@ import (
 
 // Representation of the environment in which the system operates
 type Environment struct {
-	Lambda        float32 // request arrival rate (per minute)
-	AvgInputToks  float32 // average number of prompt (input) tokens per request
-	AvgOutputToks float32 // average number of output tokens per request
-	MaxBatchSize  int     // maximum batch size
-	AvgTTFT       float32 // average time to first token (TTFT) (msec)
-	AvgITL        float32 // average inter token latency (msec)
+	Lambda             float32 // request arrival rate (per minute)
+	AvgInputToks       float32 // average number of prompt (input) tokens per request
+	AvgOutputToks      float32 // average number of output tokens per request
+	PrefixCacheHitRate float32 // share of the prompt tokens served from the prefix cache [0, 1]
+	MaxBatchSize       int     // maximum batch size
+	AvgTTFT            float32 // average time to first token (TTFT) (msec)
+	AvgITL             float32 // average inter token latency (msec)
 }
 
 func (e *Environment) Valid() bool {
@@ -22,6 +23,8 @@ func (e *Environment) Valid() bool {
 		!math.IsNaN(float64(e.Lambda)) &&
 		e.AvgInputToks > 0 &&
 		e.AvgOutputToks > 0 &&
+		e.PrefixCacheHitRate >= 0 &&
+		e.PrefixCacheHitRate <= 1 &&
 		e.MaxBatchSize > 0 &&
 		e.AvgTTFT > 0 &&
 		e.AvgITL > 0
@@ -16,6 +16,10 @@ type Tuner struct {
 	configurator *Configurator
 	filter       *kalman.ExtendedKalmanFilter
 	env          *Environment
+
+	// observationErr is the last error of the observation function, which
+	// the filter only reports as a nil observation.
+	observationErr error
 }
 
 // TunedResults holds the results of parameter tuning
@@ -73,7 +77,7 @@ func NewTuner(configData *TunerConfigData, env *Environment) (tuner *Tuner, err
 
 	// assign observation function to filter
 	if err := f.SethH(t.makeObservationFunc()); err != nil {
-		return nil, fmt.Errorf("error on setting observation function: %v", err)
+		return nil, fmt.Errorf("error on setting observation function: %w", errors.Join(err, t.observationErr))
 	}
 
 	return t, nil
@@ -102,8 +106,9 @@ func (t *Tuner) Run() (tunedResults *TunedResults, err error) {
 
 	// update
 	Z := t.env.GetObservations()
+	t.observationErr = nil
 	if err := t.filter.Update(Z, t.configurator.observationNoiseCovariance); err != nil {
-		return nil, fmt.Errorf("failed to update filter: %w", err)
+		return nil, fmt.Errorf("failed to update filter: %w", errors.Join(err, t.observationErr))
 	}
 
 	// check validity of tunedResults
@@ -187,42 +192,70 @@ func (t *Tuner) GetEnvironment() *Environment {
 	return t.env
 }
 
+// makeObservationFunc returns the observation function of the filter. On
+// error, it records the error in observationErr and returns nil.
 func (t *Tuner) makeObservationFunc() func(x *mat.VecDense) *mat.VecDense {
 	return func(x *mat.VecDense) *mat.VecDense {
-		N := t.env.MaxBatchSize
-		maxQueue := N * config.MaxQueueToBatchRatio
-		qConfig := &analyzer.Configuration{
-			MaxBatchSize: N,
-			MaxQueueSize: maxQueue,
-			ServiceParms: &analyzer.ServiceParms{
-				Alpha: float32(x.AtVec(StateIndexAlpha)),
-				Beta:  float32(x.AtVec(StateIndexBeta)),
-				Gamma: float32(x.AtVec(StateIndexGamma)),
-			},
-		}
-		requestData := &analyzer.RequestSize{
-			AvgInputTokens:  t.env.AvgInputToks,
-			AvgOutputTokens: t.env.AvgOutputToks,
-		}
-
-		qa, err := analyzer.NewQueueAnalyzer(qConfig, requestData)
+		ttft, itl, err := Predict(t.env, &analyzer.ServiceParms{
+			Alpha: float32(x.AtVec(StateIndexAlpha)),
+			Beta:  float32(x.AtVec(StateIndexBeta)),
+			Gamma: float32(x.AtVec(StateIndexGamma)),
+		})
 		if err != nil {
-			fmt.Printf("%v: model tuner observation function: failed to create queue analyzer\n", err)
+			t.observationErr = fmt.Errorf("model tuner observation function: %w", err)
 			return nil
 		}
+		return mat.NewVecDense(2, []float64{ttft, itl})
+	}
+}
 
-		lambda := t.env.Lambda / 60 // convert to req per sec
-		metrics, err := qa.Analyze(lambda)
-		if err != nil {
-			fmt.Printf("%v: model tuner observation function: failed to analyze queueing model\n", err)
-			return nil
-		}
+// Predict returns the average TTFT and ITL (msec) predicted by the queueing
+// model for the environment, with the given parameters.
+func Predict(env *Environment, parms *analyzer.ServiceParms) (ttft, itl float64, err error) {
+	qa, err := newQueueAnalyzer(env, parms)
+	if err != nil {
+		return 0, 0, err
+	}
 
-		ttft := float64(metrics.AvgWaitTime + metrics.AvgPrefillTime)
-		itl := float64(metrics.AvgTokenTime)
+	lambda := env.Lambda / 60 // convert to req per sec
+	metrics, err := qa.Analyze(lambda)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to analyze queueing model: %w", err)
+	}
+	return float64(metrics.AvgWaitTime + metrics.AvgPrefillTime), float64(metrics.AvgTokenTime), nil
+}
 
-		return mat.NewVecDense(2, []float64{ttft, itl})
+// MaxRate returns the max request rate (req per sec) of the queueing model for
+// the environment, with the given parameters.
+func MaxRate(env *Environment, parms *analyzer.ServiceParms) (float32, error) {
+	qa, err := newQueueAnalyzer(env, parms)
+	if err != nil {
+		return 0, err
+	}
+	return qa.RateRange.Max, nil
+}
+
+// newQueueAnalyzer creates the queue analyzer of the queueing model for the
+// environment, with the given parameters.
+func newQueueAnalyzer(env *Environment, parms *analyzer.ServiceParms) (*analyzer.QueueAnalyzer, error) {
+	N := env.MaxBatchSize
+	maxQueue := N * config.MaxQueueToBatchRatio
+	qConfig := &analyzer.Configuration{
+		MaxBatchSize: N,
+		MaxQueueSize: maxQueue,
+		ServiceParms: parms,
+	}
+	requestData := &analyzer.RequestSize{
+		AvgInputTokens:     env.AvgInputToks,
+		AvgOutputTokens:    env.AvgOutputToks,
+		PrefixCacheHitRate: env.PrefixCacheHitRate,
+	}
+
+	qa, err := analyzer.NewQueueAnalyzer(qConfig, requestData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create queue analyzer: %w", err)
 	}
+	return qa, nil
 }
 
 func (t *Tuner) extractTunedResults() (*TunedResults, error) {
@@ -68,6 +68,26 @@ func (s *CapacityKnowledgeStore) Get(namespace, modelID, variantName string) *Ca
 	return s.records[storeKey(namespace, modelID, variantName)]
 }
 
+// Delete removes the capacity record of a specific variant, e.g. when its
+// serving configuration changed and the record no longer describes it.
+func (s *CapacityKnowledgeStore) Delete(namespace, modelID, variantName string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, storeKey(namespace, modelID, variantName))
+}
+
+// Snapshot returns a copy of all capacity records, keyed by
+// "namespace|modelID|variantName".
+func (s *CapacityKnowledgeStore) Snapshot() map[string]CapacityRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	records := make(map[string]CapacityRecord, len(s.records))
+	for key, record := range s.records {
+		records[key] = *record
+	}
+	return records
+}
+
 // IsStale returns true if the record for the given variant is older than
 // CapacityStalenessTimeout, or if no record exists.
 func (s *CapacityKnowledgeStore) IsStale(namespace, modelID, variantName string) bool {
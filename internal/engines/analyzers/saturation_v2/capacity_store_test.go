@@ -55,6 +55,18 @@ var _ = Describe("CapacityKnowledgeStore", func() {
 		})
 	})
 
+	Describe("Delete", func() {
+		It("should remove the record of a variant only", func() {
+			store.Update("ns-1", "model-a", "variant-h100", CapacityRecord{LearnedFrom: "live"})
+			store.Update("ns-1", "model-a", "variant-a100", CapacityRecord{LearnedFrom: "live"})
+
+			store.Delete("ns-1", "model-a", "variant-h100")
+
+			Expect(store.Get("ns-1", "model-a", "variant-h100")).To(BeNil())
+			Expect(store.Get("ns-1", "model-a", "variant-a100")).NotTo(BeNil())
+		})
+	})
+
 	Describe("Get missing key", func() {
 		It("should return nil for a key that does not exist", func() {
 			Expect(store.Get("ns-1", "nonexistent", "variant")).To(BeNil())
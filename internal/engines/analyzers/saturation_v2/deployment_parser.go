@@ -14,6 +14,7 @@ type VLLMEngineParams struct {
 	GpuMemoryUtilization  float64 // default: 0.9
 	BlockSize             int64   // default: 16
 	KvCacheDtype          string  // default: "auto"
+	Quantization          string  // default: "" (from the model config)
 	TensorParallelSize    int     // default: 1
 	NumGpuBlocksOverride  int64   // default: 0 (not set)
 	MaxNumBatchedTokens   int64   // default: 0 (auto)
@@ -197,6 +198,8 @@ func applyParam(key, value string, params *VLLMEngineParams) {
 		}
 	case "kv_cache_dtype":
 		params.KvCacheDtype = value
+	case "quantization":
+		params.Quantization = value
 	case "tensor_parallel_size":
 		if v, err := strconv.Atoi(value); err == nil {
 			params.TensorParallelSize = v
@@ -235,6 +238,7 @@ func (p *VLLMEngineParams) IsCapacityCompatible(other *VLLMEngineParams) bool {
 	return p.GpuMemoryUtilization == other.GpuMemoryUtilization &&
 		p.BlockSize == other.BlockSize &&
 		p.KvCacheDtype == other.KvCacheDtype &&
+		p.Quantization == other.Quantization &&
 		p.TensorParallelSize == other.TensorParallelSize &&
 		p.NumGpuBlocksOverride == other.NumGpuBlocksOverride &&
 		p.EffectiveMaxBatchedTokens == other.EffectiveMaxBatchedTokens
@@ -80,6 +80,7 @@ var _ = Describe("ParseVLLMArgs", func() {
 				"--gpu-memory-utilization=0.85",
 				"--block-size=32",
 				"--kv-cache-dtype=fp8",
+				"--quantization=awq",
 				"--tensor-parallel-size=4",
 				"--max-num-batched-tokens=4096",
 				"--max-num-seqs=128",
@@ -90,6 +91,7 @@ var _ = Describe("ParseVLLMArgs", func() {
 			Expect(params.GpuMemoryUtilization).To(Equal(0.85))
 			Expect(params.BlockSize).To(Equal(int64(32)))
 			Expect(params.KvCacheDtype).To(Equal("fp8"))
+			Expect(params.Quantization).To(Equal("awq"))
 			Expect(params.TensorParallelSize).To(Equal(4))
 			Expect(params.MaxNumBatchedTokens).To(Equal(int64(4096)))
 			Expect(params.MaxNumSeqs).To(Equal(int64(128)))
@@ -243,6 +245,15 @@ var _ = Describe("IsCapacityCompatible", func() {
 		Expect(p1.IsCapacityCompatible(&p2)).To(BeFalse())
 	})
 
+	It("should return false when Quantization differs", func() {
+		p1 := defaultVLLMEngineParams()
+		resolveEffectiveMaxBatchedTokens(&p1)
+		p2 := defaultVLLMEngineParams()
+		resolveEffectiveMaxBatchedTokens(&p2)
+		p2.Quantization = "fp8"
+		Expect(p1.IsCapacityCompatible(&p2)).To(BeFalse())
+	})
+
 	It("should return false when TensorParallelSize differs", func() {
 		p1 := defaultVLLMEngineParams()
 		resolveEffectiveMaxBatchedTokens(&p1)
@@ -0,0 +1,39 @@
+package coldstart
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// ApplyLookahead raises result.RequiredCapacity so that capacity requested now
+// also covers extraDemand, the demand growth expected before new replicas can
+// serve traffic. Pending replicas already count towards supply, so capacity
+// that is on the way is not requested twice.
+//
+// scaleUpThreshold is the target utilization used by the analyzer to derive
+// RequiredCapacity (1.0 for analyzers that size directly against demand).
+// SpareCapacity is cleared whenever capacity is added.
+// Returns the capacity added on top of the analyzer's own signal.
+func ApplyLookahead(result *interfaces.AnalyzerResult, extraDemand, scaleUpThreshold float64) float64 {
+	if result == nil || extraDemand <= 0 {
+		return 0
+	}
+	if scaleUpThreshold <= 0 {
+		scaleUpThreshold = 1.0
+	}
+
+	var anticipatedSupply float64
+	for _, vc := range result.VariantCapacities {
+		anticipatedSupply += float64(vc.ReplicaCount+vc.PendingReplicas) * vc.PerReplicaCapacity
+	}
+
+	projected := (result.TotalDemand+extraDemand)/scaleUpThreshold - anticipatedSupply
+	if projected <= result.RequiredCapacity {
+		return 0
+	}
+	added := projected - result.RequiredCapacity
+	result.RequiredCapacity = projected
+	// Demand is ramping towards the current supply: releasing capacity now
+	// would only have to be undone once the ramp arrives.
+	result.SpareCapacity = 0
+	return added
+}
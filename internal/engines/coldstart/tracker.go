@@ -0,0 +1,282 @@
+package coldstart
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Startup phases recorded by the tracker.
+const (
+	// PhaseReady is the interval from pod creation to the pod Ready condition.
+	// It covers scheduling, image pull, model download and weight loading.
+	PhaseReady = "ready"
+
+	// PhaseFirstRequest is the interval from the pod Ready condition to the
+	// first engine cycle in which the replica reports traffic. It covers
+	// endpoint propagation to the inference scheduler and warm-up.
+	PhaseFirstRequest = "first_request"
+)
+
+const (
+	// DefaultMaxSamples bounds the per-variant sample window. Startup latency
+	// drifts with image caches and node pools, so only recent starts are kept.
+	DefaultMaxSamples = 32
+
+	// DefaultStartupPercentile is the percentile of observed startup latency
+	// used as the expected lead time for new capacity. A high percentile is
+	// preferred: arriving early costs a little GPU time, arriving late costs SLOs.
+	DefaultStartupPercentile = 0.9
+)
+
+// Observation is a single startup latency sample produced by the tracker.
+// The engine forwards observations to the metrics emitter.
+type Observation struct {
+	Namespace   string
+	VariantName string
+	PodName     string
+	Phase       string
+	Latency     time.Duration
+}
+
+// StartupEstimate summarizes the learned startup latency of a variant.
+type StartupEstimate struct {
+	// ToReady is the percentile pod creation → Ready latency.
+	ToReady time.Duration
+	// ToFirstRequest is the percentile Ready → first request latency.
+	// Zero when no first-request samples have been observed yet.
+	ToFirstRequest time.Duration
+	// Samples is the number of creation → Ready samples backing the estimate.
+	Samples int
+}
+
+// Total returns the expected time from scale-up to a replica serving traffic.
+func (s StartupEstimate) Total() time.Duration {
+	return s.ToReady + s.ToFirstRequest
+}
+
+// podStartup tracks startup progress for a single pod.
+type podStartup struct {
+	name                 string
+	readyAt              time.Time
+	readyRecorded        bool
+	firstRequestRecorded bool
+}
+
+// variantStartupHistory holds bounded latency samples and in-flight pods for a variant.
+type variantStartupHistory struct {
+	readySamples        []time.Duration
+	firstRequestSamples []time.Duration
+	pods                map[types.UID]*podStartup
+	lastUpdated         time.Time
+}
+
+// StartupLatencyTracker learns per-variant replica startup latency from pod
+// status transitions. It is fed once per engine cycle with the current pods
+// of each variant and the set of pods that reported traffic in that cycle.
+//
+// Pods that are already Ready the first time the tracker sees them (e.g. after
+// a controller restart) are not sampled: their first request cannot be
+// attributed, and their creation → Ready interval may describe a rollout from
+// long ago rather than the current node pool and image cache state.
+type StartupLatencyTracker struct {
+	mu         sync.RWMutex
+	maxSamples int
+	percentile float64
+	variants   map[string]*variantStartupHistory
+}
+
+// NewStartupLatencyTracker creates a tracker with the default sample window and percentile.
+func NewStartupLatencyTracker() *StartupLatencyTracker {
+	return &StartupLatencyTracker{
+		maxSamples: DefaultMaxSamples,
+		percentile: DefaultStartupPercentile,
+		variants:   make(map[string]*variantStartupHistory),
+	}
+}
+
+// variantKey builds the map key for a variant. The pipe delimiter cannot
+// appear in Kubernetes resource names.
+func variantKey(namespace, variantName string) string {
+	return fmt.Sprintf("%s|%s", namespace, variantName)
+}
+
+// ObservePods records creation → Ready latency for pods of a variant that
+// became Ready since the last call, and forgets pods that no longer exist.
+// Returns the newly recorded observations.
+func (t *StartupLatencyTracker) ObservePods(namespace, variantName string, pods []corev1.Pod, now time.Time) []Observation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := t.historyLocked(namespace, variantName)
+	h.lastUpdated = now
+
+	var observations []Observation
+	seen := make(map[types.UID]bool, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		seen[pod.UID] = true
+
+		readyAt, ready := podReadyTime(pod)
+		ps, known := h.pods[pod.UID]
+		if !known {
+			ps = &podStartup{name: pod.Name}
+			h.pods[pod.UID] = ps
+			if ready {
+				// Already Ready on first sight: the transition happened while we
+				// were not watching, so neither phase can be attributed reliably.
+				ps.readyRecorded = true
+				ps.firstRequestRecorded = true
+				continue
+			}
+		}
+		if ps.readyRecorded || !ready {
+			continue
+		}
+
+		latency := readyAt.Sub(pod.CreationTimestamp.Time)
+		ps.readyAt = readyAt
+		ps.readyRecorded = true
+		if latency <= 0 {
+			continue
+		}
+		h.readySamples = appendBounded(h.readySamples, latency, t.maxSamples)
+		observations = append(observations, Observation{
+			Namespace:   namespace,
+			VariantName: variantName,
+			PodName:     pod.Name,
+			Phase:       PhaseReady,
+			Latency:     latency,
+		})
+	}
+
+	for uid := range h.pods {
+		if !seen[uid] {
+			delete(h.pods, uid)
+		}
+	}
+	return observations
+}
+
+// ObserveServing records Ready → first request latency for pods of a variant
+// that reported traffic in the current cycle. servingPods holds the names of
+// pods with non-zero traffic. Resolution is bounded by the engine interval.
+// Returns the newly recorded observations.
+func (t *StartupLatencyTracker) ObserveServing(namespace, variantName string, servingPods map[string]bool, now time.Time) []Observation {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h, ok := t.variants[variantKey(namespace, variantName)]
+	if !ok {
+		return nil
+	}
+
+	var observations []Observation
+	for _, ps := range h.pods {
+		if !ps.readyRecorded || ps.firstRequestRecorded || !servingPods[ps.name] {
+			continue
+		}
+		ps.firstRequestRecorded = true
+		latency := now.Sub(ps.readyAt)
+		if ps.readyAt.IsZero() || latency < 0 {
+			continue
+		}
+		h.firstRequestSamples = appendBounded(h.firstRequestSamples, latency, t.maxSamples)
+		observations = append(observations, Observation{
+			Namespace:   namespace,
+			VariantName: variantName,
+			PodName:     ps.name,
+			Phase:       PhaseFirstRequest,
+			Latency:     latency,
+		})
+	}
+	return observations
+}
+
+// Estimate returns the learned startup latency for a variant.
+// The second return value is false when no creation → Ready sample exists.
+func (t *StartupLatencyTracker) Estimate(namespace, variantName string) (StartupEstimate, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	h, ok := t.variants[variantKey(namespace, variantName)]
+	if !ok || len(h.readySamples) == 0 {
+		return StartupEstimate{}, false
+	}
+	return StartupEstimate{
+		ToReady:        percentile(h.readySamples, t.percentile),
+		ToFirstRequest: percentile(h.firstRequestSamples, t.percentile),
+		Samples:        len(h.readySamples),
+	}, true
+}
+
+// EvictStale removes variants that have not been observed within timeout,
+// e.g. after their VariantAutoscaling was deleted. Returns the number evicted.
+func (t *StartupLatencyTracker) EvictStale(now time.Time, timeout time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	evicted := 0
+	for key, h := range t.variants {
+		if now.Sub(h.lastUpdated) > timeout {
+			delete(t.variants, key)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+// historyLocked returns the history for a variant, creating it if needed.
+// Caller must hold t.mu for writing.
+func (t *StartupLatencyTracker) historyLocked(namespace, variantName string) *variantStartupHistory {
+	key := variantKey(namespace, variantName)
+	h, ok := t.variants[key]
+	if !ok {
+		h = &variantStartupHistory{pods: make(map[types.UID]*podStartup)}
+		t.variants[key] = h
+	}
+	return h
+}
+
+// podReadyTime returns the time the pod last transitioned to Ready, if it is Ready.
+func podReadyTime(pod *corev1.Pod) (time.Time, bool) {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			if c.Status != corev1.ConditionTrue {
+				return time.Time{}, false
+			}
+			return c.LastTransitionTime.Time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// appendBounded appends v, dropping the oldest samples beyond maxSize.
+func appendBounded(samples []time.Duration, v time.Duration, maxSize int) []time.Duration {
+	samples = append(samples, v)
+	if len(samples) > maxSize {
+		samples = samples[len(samples)-maxSize:]
+	}
+	return samples
+}
+
+// percentile returns the nearest-rank percentile p (0-1) of samples, or 0 if empty.
+func percentile(samples []time.Duration, p float64) time.Duration {
+	if len(samples) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+	idx = min(max(idx, 0), len(sorted)-1)
+	return sorted[idx]
+}
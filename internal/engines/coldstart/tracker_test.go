@@ -0,0 +1,136 @@
+package coldstart
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func makePod(name string, created time.Time, readyAt *time.Time) corev1.Pod {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	status := corev1.ConditionFalse
+	transition := created
+	if readyAt != nil {
+		status = corev1.ConditionTrue
+		transition = *readyAt
+	}
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:               corev1.PodReady,
+		Status:             status,
+		LastTransitionTime: metav1.NewTime(transition),
+	}}
+	return pod
+}
+
+func TestStartupLatencyTracker_ReadyAndFirstRequest(t *testing.T) {
+	tracker := NewStartupLatencyTracker()
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Cycle 1: pod exists but is not Ready yet.
+	obs := tracker.ObservePods("ns", "v1", []corev1.Pod{makePod("p1", created, nil)}, created.Add(30*time.Second))
+	if len(obs) != 0 {
+		t.Fatalf("expected no observations before Ready, got %d", len(obs))
+	}
+	if _, ok := tracker.Estimate("ns", "v1"); ok {
+		t.Fatal("expected no estimate before any pod became Ready")
+	}
+
+	// Cycle 2: pod became Ready after 4 minutes.
+	readyAt := created.Add(4 * time.Minute)
+	obs = tracker.ObservePods("ns", "v1", []corev1.Pod{makePod("p1", created, &readyAt)}, readyAt.Add(10*time.Second))
+	if len(obs) != 1 || obs[0].Phase != PhaseReady || obs[0].Latency != 4*time.Minute {
+		t.Fatalf("expected one ready observation of 4m, got %+v", obs)
+	}
+
+	// Repeated observation of the same Ready pod does not add samples.
+	obs = tracker.ObservePods("ns", "v1", []corev1.Pod{makePod("p1", created, &readyAt)}, readyAt.Add(40*time.Second))
+	if len(obs) != 0 {
+		t.Fatalf("expected no duplicate observations, got %d", len(obs))
+	}
+
+	// Cycle 3: pod reports traffic 45s after Ready.
+	obs = tracker.ObserveServing("ns", "v1", map[string]bool{"p1": true}, readyAt.Add(45*time.Second))
+	if len(obs) != 1 || obs[0].Phase != PhaseFirstRequest || obs[0].Latency != 45*time.Second {
+		t.Fatalf("expected one first_request observation of 45s, got %+v", obs)
+	}
+	obs = tracker.ObserveServing("ns", "v1", map[string]bool{"p1": true}, readyAt.Add(75*time.Second))
+	if len(obs) != 0 {
+		t.Fatalf("expected first request to be recorded once, got %d", len(obs))
+	}
+
+	estimate, ok := tracker.Estimate("ns", "v1")
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if estimate.Total() != 4*time.Minute+45*time.Second {
+		t.Errorf("expected total 4m45s, got %v", estimate.Total())
+	}
+	if estimate.Samples != 1 {
+		t.Errorf("expected 1 sample, got %d", estimate.Samples)
+	}
+}
+
+func TestStartupLatencyTracker_IgnoresPodsReadyOnFirstSight(t *testing.T) {
+	tracker := NewStartupLatencyTracker()
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	readyAt := created.Add(2 * time.Minute)
+
+	obs := tracker.ObservePods("ns", "v1", []corev1.Pod{makePod("old", created, &readyAt)}, created.Add(time.Hour))
+	if len(obs) != 0 {
+		t.Fatalf("expected pods Ready on first sight to be ignored, got %+v", obs)
+	}
+	obs = tracker.ObserveServing("ns", "v1", map[string]bool{"old": true}, created.Add(time.Hour))
+	if len(obs) != 0 {
+		t.Fatalf("expected no first_request sample for pre-existing pod, got %+v", obs)
+	}
+	if _, ok := tracker.Estimate("ns", "v1"); ok {
+		t.Fatal("expected no estimate from pre-existing pods")
+	}
+}
+
+func TestStartupLatencyTracker_PercentileAndWindow(t *testing.T) {
+	tracker := NewStartupLatencyTracker()
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Feed more samples than the window holds; latencies 1m..(DefaultMaxSamples+8)m.
+	for i := 1; i <= DefaultMaxSamples+8; i++ {
+		name := fmt.Sprintf("p%d", i)
+		created := base.Add(time.Duration(i) * time.Hour)
+		tracker.ObservePods("ns", "v1", []corev1.Pod{makePod(name, created, nil)}, created)
+		readyAt := created.Add(time.Duration(i) * time.Minute)
+		tracker.ObservePods("ns", "v1", []corev1.Pod{makePod(name, created, &readyAt)}, readyAt)
+	}
+
+	estimate, ok := tracker.Estimate("ns", "v1")
+	if !ok {
+		t.Fatal("expected an estimate")
+	}
+	if estimate.Samples != DefaultMaxSamples {
+		t.Errorf("expected window of %d samples, got %d", DefaultMaxSamples, estimate.Samples)
+	}
+	// Window holds 9m..40m; nearest-rank p90 of 32 samples is the 29th: 37m.
+	if estimate.ToReady != 37*time.Minute {
+		t.Errorf("expected p90 of 37m, got %v", estimate.ToReady)
+	}
+}
+
+func TestStartupLatencyTracker_EvictStale(t *testing.T) {
+	tracker := NewStartupLatencyTracker()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.ObservePods("ns", "old", nil, now.Add(-2*time.Hour))
+	tracker.ObservePods("ns", "fresh", nil, now)
+
+	if n := tracker.EvictStale(now, time.Hour); n != 1 {
+		t.Errorf("expected 1 evicted variant, got %d", n)
+	}
+}
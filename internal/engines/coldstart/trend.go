@@ -0,0 +1,113 @@
+package coldstart
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTrendWindow is how far back demand samples are kept for slope
+	// estimation. Long enough to smooth over a few noisy cycles, short enough
+	// to react to a ramp within a couple of minutes.
+	DefaultTrendWindow = 5 * time.Minute
+
+	// MinTrendSamples is the minimum number of samples required before a
+	// slope is reported.
+	MinTrendSamples = 3
+
+	// MaxProjectedGrowth caps the projected demand increase as a fraction of
+	// current demand, so that a short burst combined with a very long startup
+	// latency cannot request an unbounded amount of capacity.
+	MaxProjectedGrowth = 1.0
+)
+
+// demandSample is a single model-level demand observation.
+type demandSample struct {
+	at     time.Time
+	demand float64
+}
+
+// DemandTrend keeps a sliding window of model-level demand samples and
+// estimates the demand slope by least-squares regression.
+type DemandTrend struct {
+	mu     sync.Mutex
+	window time.Duration
+	series map[string][]demandSample
+}
+
+// NewDemandTrend creates a demand trend tracker with the given window.
+// A non-positive window uses DefaultTrendWindow.
+func NewDemandTrend(window time.Duration) *DemandTrend {
+	if window <= 0 {
+		window = DefaultTrendWindow
+	}
+	return &DemandTrend{
+		window: window,
+		series: make(map[string][]demandSample),
+	}
+}
+
+// Add records a demand sample for key and drops samples older than the window.
+func (d *DemandTrend) Add(key string, demand float64, at time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	samples := append(d.series[key], demandSample{at: at, demand: demand})
+	cutoff := at.Add(-d.window)
+	start := 0
+	for start < len(samples) && samples[start].at.Before(cutoff) {
+		start++
+	}
+	d.series[key] = samples[start:]
+}
+
+// Slope returns the demand slope for key in demand units per second.
+// The second return value is false when fewer than MinTrendSamples samples
+// exist or all samples share the same timestamp.
+func (d *DemandTrend) Slope(key string) (float64, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	samples := d.series[key]
+	if len(samples) < MinTrendSamples {
+		return 0, false
+	}
+
+	// Least-squares fit of demand over seconds since the first sample.
+	origin := samples[0].at
+	n := float64(len(samples))
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range samples {
+		x := s.at.Sub(origin).Seconds()
+		sumX += x
+		sumY += s.demand
+		sumXY += x * s.demand
+		sumXX += x * x
+	}
+	denom := n*sumXX - sumX*sumX
+	if denom == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denom, true
+}
+
+// Retain drops series whose key is not in keys.
+func (d *DemandTrend) Retain(keys map[string]bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key := range d.series {
+		if !keys[key] {
+			delete(d.series, key)
+		}
+	}
+}
+
+// ProjectedGrowth returns the demand increase expected over lead given a
+// slope in units per second. Non-positive slopes project no growth; the
+// result is capped at MaxProjectedGrowth × currentDemand.
+func ProjectedGrowth(slope float64, lead time.Duration, currentDemand float64) float64 {
+	if slope <= 0 || lead <= 0 || currentDemand <= 0 {
+		return 0
+	}
+	return min(slope*lead.Seconds(), MaxProjectedGrowth*currentDemand)
+}
@@ -0,0 +1,93 @@
+package coldstart
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestDemandTrend_Slope(t *testing.T) {
+	trend := NewDemandTrend(5 * time.Minute)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	trend.Add("m", 100, base)
+	trend.Add("m", 130, base.Add(30*time.Second))
+	if _, ok := trend.Slope("m"); ok {
+		t.Fatal("expected no slope with fewer than MinTrendSamples samples")
+	}
+	trend.Add("m", 160, base.Add(60*time.Second))
+
+	slope, ok := trend.Slope("m")
+	if !ok {
+		t.Fatal("expected a slope")
+	}
+	if math.Abs(slope-1.0) > 1e-9 {
+		t.Errorf("expected slope of 1.0/s, got %v", slope)
+	}
+}
+
+func TestDemandTrend_WindowAndRetain(t *testing.T) {
+	trend := NewDemandTrend(time.Minute)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// An old spike falls out of the window and does not affect the slope.
+	trend.Add("m", 1000, base)
+	for i := 1; i <= 3; i++ {
+		trend.Add("m", 50, base.Add(time.Duration(i)*30*time.Second+time.Minute))
+	}
+	slope, ok := trend.Slope("m")
+	if !ok || slope != 0 {
+		t.Errorf("expected flat slope after window eviction, got %v (ok=%v)", slope, ok)
+	}
+
+	trend.Retain(map[string]bool{"other": true})
+	if _, ok := trend.Slope("m"); ok {
+		t.Error("expected series to be dropped by Retain")
+	}
+}
+
+func TestProjectedGrowth(t *testing.T) {
+	if got := ProjectedGrowth(-1, time.Minute, 100); got != 0 {
+		t.Errorf("expected no growth for falling demand, got %v", got)
+	}
+	if got := ProjectedGrowth(0.5, 2*time.Minute, 100); got != 60 {
+		t.Errorf("expected growth of 60, got %v", got)
+	}
+	if got := ProjectedGrowth(10, time.Hour, 100); got != 100 {
+		t.Errorf("expected growth capped at current demand, got %v", got)
+	}
+}
+
+func TestApplyLookahead(t *testing.T) {
+	newResult := func() *interfaces.AnalyzerResult {
+		return &interfaces.AnalyzerResult{
+			VariantCapacities: []interfaces.VariantCapacity{
+				{VariantName: "v1", ReplicaCount: 2, PendingReplicas: 1, PerReplicaCapacity: 100},
+			},
+			TotalSupply:   200,
+			TotalDemand:   170,
+			SpareCapacity: 10,
+		}
+	}
+
+	// Projected demand 170+85=255 fits in anticipated supply 300 at threshold 0.85.
+	result := newResult()
+	if added := ApplyLookahead(result, 85, 0.85); added != 0 {
+		t.Errorf("expected no capacity added while pending replicas cover the ramp, got %v", added)
+	}
+	if result.SpareCapacity != 10 {
+		t.Errorf("expected spare capacity to be untouched, got %v", result.SpareCapacity)
+	}
+
+	// Projected demand 170+170=340 at threshold 0.85 needs 400, anticipated 300.
+	result = newResult()
+	added := ApplyLookahead(result, 170, 0.85)
+	if math.Abs(added-100) > 1e-9 || math.Abs(result.RequiredCapacity-100) > 1e-9 {
+		t.Errorf("expected 100 capacity added, got added=%v required=%v", added, result.RequiredCapacity)
+	}
+	if result.SpareCapacity != 0 {
+		t.Errorf("expected spare capacity to be cleared, got %v", result.SpareCapacity)
+	}
+}
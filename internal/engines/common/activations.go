@@ -0,0 +1,86 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// ActivationTTL is how long a variant stays active after requests were seen
+// pending for it while scaled to zero. It covers the time for the scale-up to
+// show in the variant's decision.
+const ActivationTTL = 1 * time.Minute
+
+// ActivationTracker records the variants scaled to zero that have requests
+// pending in the EPP flow control queue, and notifies their subscribers.
+// It is written by the scale-from-zero engine and read by the KEDA external
+// scaler, which pushes the activation to KEDA without waiting for its polling.
+type ActivationTracker struct {
+	sync.Mutex
+	pending     map[string]time.Time // namespace/name → time requests were last seen pending
+	subscribers map[string]map[chan struct{}]struct{}
+}
+
+// NewActivationTracker creates an empty tracker.
+func NewActivationTracker() *ActivationTracker {
+	return &ActivationTracker{
+		pending:     make(map[string]time.Time),
+		subscribers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+// MarkPending records that a variant has requests pending at the given time
+// and notifies its subscribers. Never blocks.
+func (t *ActivationTracker) MarkPending(name, namespace string, at time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	t.pending[key] = at
+	for ch := range t.subscribers[key] {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Pending reports whether requests were seen pending for a variant within
+// ActivationTTL of now, pruning expired entries.
+func (t *ActivationTracker) Pending(name, namespace string, now time.Time) bool {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	at, ok := t.pending[key]
+	if !ok {
+		return false
+	}
+	if now.Sub(at) > ActivationTTL {
+		delete(t.pending, key)
+		return false
+	}
+	return true
+}
+
+// Subscribe returns a channel receiving a notification when requests are seen
+// pending for a variant, and a function to unsubscribe. Notifications are
+// collapsed while the previous one is not received.
+func (t *ActivationTracker) Subscribe(name, namespace string) (<-chan struct{}, func()) {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	ch := make(chan struct{}, 1)
+	if t.subscribers[key] == nil {
+		t.subscribers[key] = make(map[chan struct{}]struct{})
+	}
+	t.subscribers[key][ch] = struct{}{}
+	return ch, func() {
+		t.Lock()
+		defer t.Unlock()
+		delete(t.subscribers[key], ch)
+		if len(t.subscribers[key]) == 0 {
+			delete(t.subscribers, key)
+		}
+	}
+}
+
+// Activations is the global activation tracker.
+var Activations = NewActivationTracker()
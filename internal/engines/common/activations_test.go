@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestActivationTrackerPending(t *testing.T) {
+	tracker := NewActivationTracker()
+	now := time.Now()
+
+	if tracker.Pending("va", "ns", now) {
+		t.Error("Expected unknown variant to not be pending")
+	}
+
+	tracker.MarkPending("va", "ns", now)
+	if !tracker.Pending("va", "ns", now.Add(ActivationTTL-time.Second)) {
+		t.Error("Expected variant to be pending")
+	}
+	if tracker.Pending("va", "other-ns", now) {
+		t.Error("Expected variant in another namespace to not be pending")
+	}
+	if tracker.Pending("va", "ns", now.Add(ActivationTTL+time.Second)) {
+		t.Error("Expected pending requests to expire after ActivationTTL")
+	}
+	if tracker.Pending("va", "ns", now) {
+		t.Error("Expected expired variant to be pruned")
+	}
+}
+
+func TestActivationTrackerSubscribe(t *testing.T) {
+	tracker := NewActivationTracker()
+	ch, unsubscribe := tracker.Subscribe("va", "ns")
+	other, unsubscribeOther := tracker.Subscribe("other-va", "ns")
+	defer unsubscribeOther()
+
+	// A burst of notifications collapses into one, and never blocks
+	tracker.MarkPending("va", "ns", time.Now())
+	tracker.MarkPending("va", "ns", time.Now())
+	select {
+	case <-ch:
+	default:
+		t.Fatal("Expected a notification")
+	}
+	select {
+	case <-ch:
+		t.Error("Expected notifications to be collapsed")
+	default:
+	}
+	select {
+	case <-other:
+		t.Error("Expected no notification for another variant")
+	default:
+	}
+
+	unsubscribe()
+	tracker.MarkPending("va", "ns", time.Now())
+	select {
+	case <-ch:
+		t.Error("Expected no notification after unsubscribing")
+	default:
+	}
+	if _, ok := tracker.subscribers[cacheKey("va", "ns")]; ok {
+		t.Error("Expected subscribers of the variant to be removed")
+	}
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"maps"
 	"sync"
 	"time"
 
@@ -36,6 +37,13 @@ func (c *InternalDecisionCache) Get(name, namespace string) (interfaces.VariantD
 	return val, ok
 }
 
+// Snapshot returns a copy of the cached decisions, keyed by namespace/name.
+func (c *InternalDecisionCache) Snapshot() map[string]interfaces.VariantDecision {
+	c.RLock()
+	defer c.RUnlock()
+	return maps.Clone(c.items)
+}
+
 // Global cache instance
 var DecisionCache = &InternalDecisionCache{
 	items: make(map[string]interfaces.VariantDecision),
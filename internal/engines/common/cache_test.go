@@ -67,3 +67,20 @@ func TestDecisionToOptimizedAlloc(t *testing.T) {
 		t.Errorf("Expected H100 accelerator, got %s", acc)
 	}
 }
+
+func TestInternalDecisionCacheSnapshot(t *testing.T) {
+	cache := &InternalDecisionCache{
+		items: make(map[string]interfaces.VariantDecision),
+	}
+	cache.Set("test-variant", "test-ns", interfaces.VariantDecision{TargetReplicas: 2})
+
+	snapshot := cache.Snapshot()
+	if snapshot["test-ns/test-variant"].TargetReplicas != 2 {
+		t.Errorf("Expected snapshot keyed by namespace/name, got %v", snapshot)
+	}
+
+	cache.Set("test-variant", "test-ns", interfaces.VariantDecision{TargetReplicas: 3})
+	if snapshot["test-ns/test-variant"].TargetReplicas != 2 {
+		t.Error("Expected snapshot to be a copy")
+	}
+}
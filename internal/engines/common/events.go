@@ -0,0 +1,176 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Reasons of the Events emitted for scaling decisions.
+const (
+	EventReasonScaledUp       = "ScaledUp"
+	EventReasonScaledDown     = "ScaledDown"
+	EventReasonScaledToZero   = "ScaledToZero"
+	EventReasonScaledFromZero = "ScaledFromZero"
+	EventReasonScalingLimited = "ScalingLimited"
+	EventReasonSafetyOverride = "SaturationSafetyOverride"
+	EventReasonWouldScale     = "WouldScale"
+
+	EventReasonServingConfigChanged = "ServingConfigChanged"
+)
+
+// DecisionEventInterval is the minimum time between two identical decision
+// Events on the same object. The optimization loop re-issues the same decision
+// every cycle until the scale target has caught up, so repeats within the
+// interval are dropped here, on top of the aggregation done by the broadcaster.
+const DecisionEventInterval = 5 * time.Minute
+
+// DecisionEvents emits Kubernetes Events for scaling decisions on the
+// VariantAutoscaling and on its scale target. A nil *DecisionEvents, or one
+// without a recorder, drops all events.
+type DecisionEvents struct {
+	recorder record.EventRecorder
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	sent map[string]time.Time // event key → last emission
+}
+
+// NewDecisionEvents creates a DecisionEvents emitting through recorder.
+func NewDecisionEvents(recorder record.EventRecorder) *DecisionEvents {
+	return &DecisionEvents{
+		recorder: recorder,
+		interval: DecisionEventInterval,
+		now:      time.Now,
+		sent:     make(map[string]time.Time),
+	}
+}
+
+// RecordDecision emits the Events for a decision of the given analyzer: the
+// scaling action when the target differs from the current replicas (WouldScale
+// for a VA in shadow mode), a limiter cut when the decision was limited, and a
+// saturation safety override.
+func (r *DecisionEvents) RecordDecision(va *wvav1alpha1.VariantAutoscaling, d interfaces.VariantDecision, analyzer string) {
+	if d.TargetReplicas != d.CurrentReplicas {
+		reason := EventReasonScaledUp
+		switch {
+		case d.TargetReplicas == 0:
+			reason = EventReasonScaledToZero
+		case d.TargetReplicas < d.CurrentReplicas:
+			reason = EventReasonScaledDown
+		}
+		if d.Shadow {
+			r.record(va, corev1.EventTypeNormal, EventReasonWouldScale, fmt.Sprintf("Shadow mode: would scale from %d to %d replicas (analyzer: %s): %s",
+				d.CurrentReplicas, d.TargetReplicas, analyzer, decisionSummary(d)))
+		} else {
+			r.record(va, corev1.EventTypeNormal, reason, fmt.Sprintf("Scaling from %d to %d replicas (analyzer: %s): %s",
+				d.CurrentReplicas, d.TargetReplicas, analyzer, decisionSummary(d)))
+		}
+	}
+
+	if d.WasLimited {
+		r.record(va, corev1.EventTypeWarning, EventReasonScalingLimited, fmt.Sprintf("Target limited from %d to %d replicas by %s (analyzer: %s)",
+			d.OriginalTargetReplicas, d.TargetReplicas, d.LimitedBy, analyzer))
+	}
+
+	if d.SafetyOverride {
+		r.record(va, corev1.EventTypeWarning, EventReasonSafetyOverride, fmt.Sprintf("Saturation safety override to %d replicas (analyzer: %s): %s",
+			d.TargetReplicas, analyzer, d.Reason))
+	}
+}
+
+// RecordScaleFromZero emits the Event for a scale-from-zero actuation.
+func (r *DecisionEvents) RecordScaleFromZero(va *wvav1alpha1.VariantAutoscaling, replicas int, reason string) {
+	r.record(va, corev1.EventTypeNormal, EventReasonScaledFromZero,
+		fmt.Sprintf("Scaling from 0 to %d replicas (analyzer: scale-from-zero): %s", replicas, reason))
+}
+
+// RecordWouldScaleFromZero emits the Event for a scale-from-zero of a VA in
+// shadow mode, which is not actuated.
+func (r *DecisionEvents) RecordWouldScaleFromZero(va *wvav1alpha1.VariantAutoscaling, replicas int, reason string) {
+	r.record(va, corev1.EventTypeNormal, EventReasonWouldScale,
+		fmt.Sprintf("Shadow mode: would scale from 0 to %d replicas (analyzer: scale-from-zero): %s", replicas, reason))
+}
+
+// RecordServingConfigChange emits the Event for a change of the serving
+// configuration of a VA, after which its learned parameters are reset.
+func (r *DecisionEvents) RecordServingConfigChange(va *wvav1alpha1.VariantAutoscaling, change string, relearnWindow time.Duration) {
+	r.record(va, corev1.EventTypeNormal, EventReasonServingConfigChanged,
+		fmt.Sprintf("%s: learned parameters and capacity reset, safety margin widened for %s", change, relearnWindow))
+}
+
+// record emits an Event on the VA and its scale target, unless the same Event
+// was emitted for the VA within the interval.
+func (r *DecisionEvents) record(va *wvav1alpha1.VariantAutoscaling, eventType, reason, message string) {
+	if r == nil || r.recorder == nil {
+		return
+	}
+	if !r.allow(va.Namespace + "/" + va.Name + "/" + reason + "/" + message) {
+		return
+	}
+
+	r.recorder.Event(va, eventType, reason, message)
+	if ref := scaleTargetReference(va); ref != nil {
+		r.recorder.Event(ref, eventType, reason, fmt.Sprintf("%s (VariantAutoscaling %s)", message, va.Name))
+	}
+}
+
+// allow reports whether an Event with the given key may be emitted now, and
+// records the emission. Expired keys are pruned.
+func (r *DecisionEvents) allow(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	for k, t := range r.sent {
+		if now.Sub(t) >= r.interval {
+			delete(r.sent, k)
+		}
+	}
+	if _, recent := r.sent[key]; recent {
+		return false
+	}
+	r.sent[key] = now
+	return true
+}
+
+// scaleTargetReference returns a reference to the VA's scale target, or nil if it is not set.
+func scaleTargetReference(va *wvav1alpha1.VariantAutoscaling) *corev1.ObjectReference {
+	name := va.GetScaleTargetName()
+	if name == "" || va.Spec.ScaleTargetRef.Kind == "" {
+		return nil
+	}
+	return &corev1.ObjectReference{
+		APIVersion: va.Spec.ScaleTargetRef.APIVersion,
+		Kind:       va.Spec.ScaleTargetRef.Kind,
+		Name:       name,
+		Namespace:  va.Namespace,
+	}
+}
+
+// decisionSummary describes why a decision was made: its reason, and the
+// pipeline stages that constrained it.
+func decisionSummary(d interfaces.VariantDecision) string {
+	var constrained []string
+	for _, step := range d.DecisionSteps {
+		if step.WasConstrained {
+			constrained = append(constrained, step.Name)
+		}
+	}
+	if len(constrained) == 0 {
+		return d.Reason
+	}
+	summary := "constrained by " + strings.Join(constrained, ", ")
+	if d.Reason == "" {
+		return summary
+	}
+	return d.Reason + "; " + summary
+}
@@ -0,0 +1,143 @@
+package common
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func newTestVA() *wvav1alpha1.VariantAutoscaling {
+	return &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-a100", Namespace: "default"},
+		Spec: wvav1alpha1.VariantAutoscalingSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "llama-a100",
+			},
+		},
+	}
+}
+
+func drainEvents(recorder *record.FakeRecorder) []string {
+	var events []string
+	for {
+		select {
+		case e := <-recorder.Events:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestDecisionEventsRecordDecision(t *testing.T) {
+	tests := []struct {
+		name     string
+		decision interfaces.VariantDecision
+		want     []string
+	}{
+		{
+			name:     "scale up",
+			decision: interfaces.VariantDecision{CurrentReplicas: 1, TargetReplicas: 3, Reason: "V2 scale-up"},
+			want:     []string{"Normal ScaledUp Scaling from 1 to 3 replicas (analyzer: saturation): V2 scale-up"},
+		},
+		{
+			name:     "scale down",
+			decision: interfaces.VariantDecision{CurrentReplicas: 3, TargetReplicas: 2},
+			want:     []string{"Normal ScaledDown Scaling from 3 to 2"},
+		},
+		{
+			name:     "scale to zero",
+			decision: interfaces.VariantDecision{CurrentReplicas: 2, TargetReplicas: 0},
+			want:     []string{"Normal ScaledToZero Scaling from 2 to 0"},
+		},
+		{
+			name:     "no change",
+			decision: interfaces.VariantDecision{CurrentReplicas: 2, TargetReplicas: 2},
+		},
+		{
+			name: "limited",
+			decision: interfaces.VariantDecision{
+				CurrentReplicas: 2, TargetReplicas: 3, OriginalTargetReplicas: 5, Reason: "V1 scale-up",
+				WasLimited: true, LimitedBy: "gpu-limiter",
+				DecisionSteps: []interfaces.DecisionStep{{Name: "gpu-limiter", WasConstrained: true}},
+			},
+			want: []string{
+				"Normal ScaledUp Scaling from 2 to 3 replicas (analyzer: saturation): V1 scale-up; constrained by gpu-limiter",
+				"Warning ScalingLimited Target limited from 5 to 3 replicas by gpu-limiter",
+			},
+		},
+		{
+			name:     "safety override",
+			decision: interfaces.VariantDecision{CurrentReplicas: 2, TargetReplicas: 2, SafetyOverride: true, Reason: "kv cache saturated"},
+			want:     []string{"Warning SaturationSafetyOverride Saturation safety override to 2 replicas (analyzer: saturation): kv cache saturated"},
+		},
+		{
+			name:     "shadow mode",
+			decision: interfaces.VariantDecision{CurrentReplicas: 1, TargetReplicas: 3, Shadow: true, Reason: "V2 scale-up"},
+			want:     []string{"Normal WouldScale Shadow mode: would scale from 1 to 3 replicas (analyzer: saturation): V2 scale-up"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			events := NewDecisionEvents(recorder)
+
+			events.RecordDecision(newTestVA(), tt.decision, "saturation")
+
+			got := drainEvents(recorder)
+			// Each event is emitted on the VA and on its scale target.
+			if len(got) != 2*len(tt.want) {
+				t.Fatalf("Expected %d events, got %v", 2*len(tt.want), got)
+			}
+			for i, want := range tt.want {
+				if !strings.HasPrefix(got[2*i], want) {
+					t.Errorf("Expected VA event %q to start with %q", got[2*i], want)
+				}
+				if !strings.HasSuffix(got[2*i+1], "(VariantAutoscaling llama-a100)") {
+					t.Errorf("Expected scale target event to name the VA, got %q", got[2*i+1])
+				}
+			}
+		})
+	}
+}
+
+func TestDecisionEventsRateLimit(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	events := NewDecisionEvents(recorder)
+	now := time.Now()
+	events.now = func() time.Time { return now }
+	va := newTestVA()
+	scaleUp := interfaces.VariantDecision{CurrentReplicas: 1, TargetReplicas: 3}
+
+	events.RecordDecision(va, scaleUp, "saturation")
+	events.RecordDecision(va, scaleUp, "saturation")
+	if got := drainEvents(recorder); len(got) != 2 {
+		t.Fatalf("Expected repeated decision to be suppressed, got %v", got)
+	}
+
+	events.RecordDecision(va, interfaces.VariantDecision{CurrentReplicas: 1, TargetReplicas: 4}, "saturation")
+	if got := drainEvents(recorder); len(got) != 2 {
+		t.Fatalf("Expected a different decision to be emitted, got %v", got)
+	}
+
+	now = now.Add(DecisionEventInterval)
+	events.RecordDecision(va, scaleUp, "saturation")
+	if got := drainEvents(recorder); len(got) != 2 {
+		t.Fatalf("Expected decision to be emitted again after the interval, got %v", got)
+	}
+}
+
+func TestDecisionEventsNilSafe(t *testing.T) {
+	var events *DecisionEvents
+	events.RecordScaleFromZero(newTestVA(), 1, "pending requests")
+	NewDecisionEvents(nil).RecordDecision(newTestVA(), interfaces.VariantDecision{TargetReplicas: 1}, "saturation")
+}
@@ -0,0 +1,114 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// NodeInterruptionTTL is how long an interrupted node is remembered after
+	// it was observed. Deleted nodes never clear themselves, so entries expire.
+	NodeInterruptionTTL = 1 * time.Hour
+
+	// VariantInterruptionCooldown is how long a preemptible variant stays
+	// ineligible for scale-up after it lost replicas to a node interruption.
+	// Replacement pods of such a variant are likely to stay unschedulable, or
+	// to land on capacity that is reclaimed next.
+	VariantInterruptionCooldown = 10 * time.Minute
+)
+
+// NodeInterruptionTracker records nodes that are being reclaimed (interruption
+// taint, cordon by a termination handler, or deletion) and the preemptible
+// variants that lost replicas to them.
+// It is written by the node interruption controller and read by the Engine.
+type NodeInterruptionTracker struct {
+	sync.RWMutex
+	nodes    map[string]time.Time // node name → time the interruption was first observed
+	variants map[string]time.Time // namespace/name → time of the latest interruption
+}
+
+// NewNodeInterruptionTracker creates an empty tracker.
+func NewNodeInterruptionTracker() *NodeInterruptionTracker {
+	return &NodeInterruptionTracker{
+		nodes:    make(map[string]time.Time),
+		variants: make(map[string]time.Time),
+	}
+}
+
+// MarkNode records that node is being reclaimed. Returns true if the node was
+// not already known to be interrupted.
+func (t *NodeInterruptionTracker) MarkNode(node string, at time.Time) bool {
+	t.Lock()
+	defer t.Unlock()
+	if _, ok := t.nodes[node]; ok {
+		return false
+	}
+	t.nodes[node] = at
+	return true
+}
+
+// ClearNode forgets an interruption, e.g. when the interruption taint was removed.
+func (t *NodeInterruptionTracker) ClearNode(node string) {
+	t.Lock()
+	defer t.Unlock()
+	delete(t.nodes, node)
+}
+
+// InterruptedNodes returns the nodes observed as interrupted within
+// NodeInterruptionTTL of now, pruning older entries.
+func (t *NodeInterruptionTracker) InterruptedNodes(now time.Time) map[string]bool {
+	t.Lock()
+	defer t.Unlock()
+	out := make(map[string]bool, len(t.nodes))
+	for node, at := range t.nodes {
+		if now.Sub(at) > NodeInterruptionTTL {
+			delete(t.nodes, node)
+			continue
+		}
+		out[node] = true
+	}
+	return out
+}
+
+// MarkVariant records that a preemptible variant lost replicas at the given time.
+func (t *NodeInterruptionTracker) MarkVariant(name, namespace string, at time.Time) {
+	t.Lock()
+	defer t.Unlock()
+	t.variants[cacheKey(name, namespace)] = at
+}
+
+// VariantInCooldown reports whether a variant lost replicas to an interruption
+// within VariantInterruptionCooldown of now, pruning expired entries.
+func (t *NodeInterruptionTracker) VariantInCooldown(name, namespace string, now time.Time) bool {
+	t.Lock()
+	defer t.Unlock()
+	key := cacheKey(name, namespace)
+	at, ok := t.variants[key]
+	if !ok {
+		return false
+	}
+	if now.Sub(at) > VariantInterruptionCooldown {
+		delete(t.variants, key)
+		return false
+	}
+	return true
+}
+
+// Interruptions is the global node interruption tracker.
+var Interruptions = NewNodeInterruptionTracker()
+
+// OptimizationTrigger requests an immediate optimization cycle from the Engine
+// instead of waiting for the next polling interval. Buffered with capacity one
+// so that a burst of requests collapses into a single extra cycle.
+var OptimizationTrigger = make(chan struct{}, 1)
+
+// RequestOptimization asks the Engine to run an optimization cycle as soon as
+// possible. Never blocks; returns false if a request is already pending.
+func RequestOptimization() bool {
+	select {
+	case OptimizationTrigger <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNodeInterruptionTracker(t *testing.T) {
+	tracker := NewNodeInterruptionTracker()
+	now := time.Now()
+
+	if !tracker.MarkNode("node-a", now) {
+		t.Error("Expected first MarkNode to report a new interruption")
+	}
+	if tracker.MarkNode("node-a", now.Add(time.Minute)) {
+		t.Error("Expected repeated MarkNode to report an existing interruption")
+	}
+
+	nodes := tracker.InterruptedNodes(now)
+	if !nodes["node-a"] || len(nodes) != 1 {
+		t.Errorf("Expected only node-a to be interrupted, got %v", nodes)
+	}
+
+	tracker.ClearNode("node-a")
+	if nodes := tracker.InterruptedNodes(now); len(nodes) != 0 {
+		t.Errorf("Expected no interrupted nodes after ClearNode, got %v", nodes)
+	}
+
+	tracker.MarkNode("node-b", now)
+	if nodes := tracker.InterruptedNodes(now.Add(NodeInterruptionTTL + time.Second)); len(nodes) != 0 {
+		t.Errorf("Expected interruption to expire after TTL, got %v", nodes)
+	}
+	if !tracker.MarkNode("node-b", now) {
+		t.Error("Expected expired node to be pruned")
+	}
+}
+
+func TestNodeInterruptionTrackerVariantCooldown(t *testing.T) {
+	tracker := NewNodeInterruptionTracker()
+	now := time.Now()
+
+	if tracker.VariantInCooldown("va", "ns", now) {
+		t.Error("Expected unknown variant to not be in cooldown")
+	}
+
+	tracker.MarkVariant("va", "ns", now)
+	if !tracker.VariantInCooldown("va", "ns", now.Add(VariantInterruptionCooldown-time.Second)) {
+		t.Error("Expected variant to be in cooldown")
+	}
+	if tracker.VariantInCooldown("va", "other-ns", now) {
+		t.Error("Expected cooldown to be namespaced")
+	}
+	if tracker.VariantInCooldown("va", "ns", now.Add(VariantInterruptionCooldown+time.Second)) {
+		t.Error("Expected cooldown to expire")
+	}
+}
+
+func TestRequestOptimization(t *testing.T) {
+	// Drain any pending request left by other tests.
+	select {
+	case <-OptimizationTrigger:
+	default:
+	}
+
+	if !RequestOptimization() {
+		t.Error("Expected first request to be queued")
+	}
+	if RequestOptimization() {
+		t.Error("Expected second request to collapse into the pending one")
+	}
+	<-OptimizationTrigger
+}
@@ -0,0 +1,135 @@
+package decisionrecord
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// configMapKeyPrefix prefixes the keys of the records in the ConfigMap,
+	// followed by a zero-padded sequence number so keys sort in write order.
+	configMapKeyPrefix = "cycle-"
+	configMapKeySuffix = ".json"
+
+	// MaxConfigMapBytes bounds the records kept in the ConfigMap, below the
+	// 1MiB limit of an object in etcd.
+	MaxConfigMapBytes = 900 * 1024
+)
+
+// ConfigMapSink keeps the most recent records in a ConfigMap, one data key
+// per cycle, as a ring buffer: once it holds maxRecords records or
+// MaxConfigMapBytes, the oldest records are dropped.
+type ConfigMapSink struct {
+	client     client.Client
+	namespace  string
+	name       string
+	maxRecords int
+}
+
+var _ Sink = &ConfigMapSink{}
+
+// NewConfigMapSink creates a ConfigMapSink writing to the ConfigMap
+// namespace/name, creating it on the first write.
+func NewConfigMapSink(c client.Client, namespace, name string, maxRecords int) (*ConfigMapSink, error) {
+	if maxRecords <= 0 {
+		return nil, fmt.Errorf("max records must be positive, got %d", maxRecords)
+	}
+	return &ConfigMapSink{client: c, namespace: namespace, name: name, maxRecords: maxRecords}, nil
+}
+
+// Write adds a record to the ConfigMap and drops the oldest records beyond
+// the bounds.
+func (s *ConfigMapSink) Write(ctx context.Context, record []byte) error {
+	if len(record) > MaxConfigMapBytes {
+		return fmt.Errorf("record of %d bytes exceeds the ConfigMap limit of %d bytes", len(record), MaxConfigMapBytes)
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		cm := &corev1.ConfigMap{}
+		err := s.client.Get(ctx, client.ObjectKey{Namespace: s.namespace, Name: s.name}, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Namespace: s.namespace, Name: s.name},
+				Data:       map[string]string{configMapKey(0): string(record)},
+			}
+			return s.client.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+
+		keys := recordKeys(cm.Data)
+		next := uint64(0)
+		if len(keys) > 0 {
+			last, _ := configMapSeq(keys[len(keys)-1])
+			next = last + 1
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[configMapKey(next)] = string(record)
+		keys = append(keys, configMapKey(next))
+
+		size := 0
+		for _, key := range keys {
+			size += len(key) + len(cm.Data[key])
+		}
+		for len(keys) > s.maxRecords || size > MaxConfigMapBytes {
+			size -= len(keys[0]) + len(cm.Data[keys[0]])
+			delete(cm.Data, keys[0])
+			keys = keys[1:]
+		}
+		return s.client.Update(ctx, cm)
+	})
+}
+
+// ConfigMapRecords returns the records held in a ConfigMap written by a
+// ConfigMapSink, oldest first.
+func ConfigMapRecords(cm *corev1.ConfigMap) ([]Cycle, error) {
+	var cycles []Cycle
+	for _, key := range recordKeys(cm.Data) {
+		cycle, err := decodeCycle([]byte(cm.Data[key]))
+		if err != nil {
+			return nil, fmt.Errorf("record %s: %w", key, err)
+		}
+		cycles = append(cycles, *cycle)
+	}
+	return cycles, nil
+}
+
+// recordKeys returns the record keys of the data, in write order. Other keys
+// are ignored.
+func recordKeys(data map[string]string) []string {
+	keys := slices.DeleteFunc(slices.Collect(maps.Keys(data)), func(key string) bool {
+		_, ok := configMapSeq(key)
+		return !ok
+	})
+	slices.Sort(keys)
+	return keys
+}
+
+func configMapKey(seq uint64) string {
+	return fmt.Sprintf("%s%020d%s", configMapKeyPrefix, seq, configMapKeySuffix)
+}
+
+func configMapSeq(key string) (uint64, bool) {
+	s, ok := strings.CutPrefix(key, configMapKeyPrefix)
+	if !ok {
+		return 0, false
+	}
+	s, ok = strings.CutSuffix(s, configMapKeySuffix)
+	if !ok {
+		return 0, false
+	}
+	seq, err := strconv.ParseUint(s, 10, 64)
+	return seq, err == nil
+}
@@ -0,0 +1,115 @@
+package decisionrecord
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileSink writes records as JSON lines to a local file. When a record would
+// grow the file beyond maxBytes, the file is rotated: path is renamed to
+// path.1, path.1 to path.2 and so on, keeping at most maxFiles rotated files.
+// The records on disk are thus bounded by (maxFiles+1) × maxBytes.
+type FileSink struct {
+	path     string
+	maxBytes int64
+	maxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+var _ Sink = &FileSink{}
+
+// NewFileSink creates a FileSink appending to the file at path, creating it
+// and its directory if needed.
+func NewFileSink(path string, maxBytes int64, maxFiles int) (*FileSink, error) {
+	if maxBytes <= 0 {
+		return nil, fmt.Errorf("max bytes must be positive, got %d", maxBytes)
+	}
+	if maxFiles < 0 {
+		return nil, fmt.Errorf("max files must not be negative, got %d", maxFiles)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("creating directory of %s: %w", path, err)
+	}
+	s := &FileSink{path: path, maxBytes: maxBytes, maxFiles: maxFiles}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write appends a record to the file, rotating it first if the record does
+// not fit. A record larger than maxBytes is written to a file of its own.
+func (s *FileSink) Write(_ context.Context, record []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line := append(record[:len(record):len(record)], '\n')
+	if s.size > 0 && s.size+int64(len(line)) > s.maxBytes {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("writing %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Close closes the current file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", s.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close() //nolint:errcheck
+		return fmt.Errorf("reading size of %s: %w", s.path, err)
+	}
+	s.file, s.size = f, info.Size()
+	return nil
+}
+
+// rotate shifts the rotated files by one, dropping the oldest, and starts a
+// new file.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", s.path, err)
+	}
+	if s.maxFiles == 0 {
+		if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing %s: %w", s.path, err)
+		}
+		return s.open()
+	}
+	for i := s.maxFiles - 1; i >= 1; i-- {
+		err := os.Rename(RotatedPath(s.path, i), RotatedPath(s.path, i+1))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("rotating %s: %w", s.path, err)
+		}
+	}
+	if err := os.Rename(s.path, RotatedPath(s.path, 1)); err != nil {
+		return fmt.Errorf("rotating %s: %w", s.path, err)
+	}
+	return s.open()
+}
+
+// RotatedPath returns the path of the i-th most recent rotated file of path.
+func RotatedPath(path string, i int) string {
+	return fmt.Sprintf("%s.%d", path, i)
+}
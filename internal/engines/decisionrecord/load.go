@@ -0,0 +1,79 @@
+package decisionrecord
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// maxRecordBytes bounds the size of a record line when loading.
+const maxRecordBytes = 64 * 1024 * 1024
+
+// Load reads JSON lines records, as written by a FileSink. Empty lines are
+// skipped.
+func Load(r io.Reader) ([]Cycle, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxRecordBytes)
+
+	var cycles []Cycle
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		cycle, err := decodeCycle(data)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		cycles = append(cycles, *cycle)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading records: %w", err)
+	}
+	return cycles, nil
+}
+
+// LoadFile reads the records written by a FileSink to path, including its
+// rotated files, oldest first.
+func LoadFile(path string) ([]Cycle, error) {
+	paths := []string{path}
+	for i := 1; ; i++ {
+		rotated := RotatedPath(path, i)
+		if _, err := os.Stat(rotated); errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		paths = append([]string{rotated}, paths...)
+	}
+
+	var cycles []Cycle
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return nil, fmt.Errorf("opening records: %w", err)
+		}
+		loaded, err := Load(f)
+		f.Close() //nolint:errcheck
+		if err != nil {
+			return nil, fmt.Errorf("loading %s: %w", p, err)
+		}
+		cycles = append(cycles, loaded...)
+	}
+	return cycles, nil
+}
+
+// decodeCycle decodes one record, rejecting schema versions this package
+// does not know.
+func decodeCycle(data []byte) (*Cycle, error) {
+	var cycle Cycle
+	if err := json.Unmarshal(data, &cycle); err != nil {
+		return nil, fmt.Errorf("parsing record: %w", err)
+	}
+	if cycle.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version %d, expected %d", cycle.SchemaVersion, SchemaVersion)
+	}
+	return &cycle, nil
+}
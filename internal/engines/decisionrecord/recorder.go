@@ -0,0 +1,172 @@
+package decisionrecord
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// Sink stores encoded cycle records.
+type Sink interface {
+	// Write stores the JSON encoding of one Cycle.
+	Write(ctx context.Context, record []byte) error
+}
+
+// Recorder assembles the record of an optimization cycle as the engine runs
+// it, and writes it to a Sink when the cycle ends. A nil *Recorder records
+// nothing, so the engine calls it unconditionally.
+type Recorder struct {
+	sink Sink
+
+	mu     sync.Mutex
+	cycle  *Cycle
+	models map[string]*Model // namespace/modelID → model record of the cycle
+}
+
+// NewRecorder creates a Recorder writing to sink.
+func NewRecorder(sink Sink) *Recorder {
+	return &Recorder{sink: sink}
+}
+
+// BeginCycle starts the record of a cycle running at t, dropping any cycle
+// that was not ended.
+func (r *Recorder) BeginCycle(t time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cycle = &Cycle{SchemaVersion: SchemaVersion, Time: t}
+	r.models = make(map[string]*Model)
+}
+
+// RecordInput records the input passed to a model's analyzer.
+func (r *Recorder) RecordInput(input interfaces.AnalyzerInput) {
+	r.update(input.Namespace, input.ModelID, func(m *Model) {
+		in := &AnalyzerInput{
+			ReplicaMetrics: slices.Clone(input.ReplicaMetrics),
+			VariantStates:  slices.Clone(input.VariantStates),
+			SchedulerQueue: input.SchedulerQueue,
+		}
+		if input.Config != nil {
+			// Configs are plain structs; a failure leaves the config out
+			// rather than dropping the record.
+			if raw, err := json.Marshal(input.Config); err == nil {
+				in.Config = raw
+			}
+		}
+		m.Input = in
+	})
+}
+
+// RecordResult records the result of a model's V2 saturation or queueing
+// model analyzer. The result is copied, since the engine adjusts it afterwards.
+func (r *Recorder) RecordResult(result *interfaces.AnalyzerResult) {
+	if result == nil {
+		return
+	}
+	r.update(result.Namespace, result.ModelID, func(m *Model) {
+		m.Result = copyResult(result)
+	})
+}
+
+// RecordSaturationAnalysis records the result of a model's V1 saturation analyzer.
+func (r *Recorder) RecordSaturationAnalysis(analysis *interfaces.ModelSaturationAnalysis) {
+	if analysis == nil {
+		return
+	}
+	r.update(analysis.Namespace, analysis.ModelID, func(m *Model) {
+		a := *analysis
+		a.VariantAnalyses = slices.Clone(analysis.VariantAnalyses)
+		m.SaturationAnalysis = &a
+	})
+}
+
+// RecordRequests records the requests passed to the optimizer.
+func (r *Recorder) RecordRequests(requests []pipeline.ModelScalingRequest) {
+	for i := range requests {
+		req := requests[i]
+		req.Result = copyResult(req.Result)
+		req.VariantStates = slices.Clone(req.VariantStates)
+		r.update(req.Namespace, req.ModelID, func(m *Model) {
+			m.Request = &req
+		})
+	}
+}
+
+// RecordRequestCount records the request count of a model over the
+// scale-to-zero retention period.
+func (r *Recorder) RecordRequestCount(modelID, namespace string, count float64) {
+	r.update(namespace, modelID, func(m *Model) {
+		m.RequestCount = &count
+	})
+}
+
+// EndCycle records the final decisions of the cycle run by analyzer and
+// writes the record to the sink. Models are written sorted by namespace and
+// model ID.
+func (r *Recorder) EndCycle(ctx context.Context, analyzer string, decisions []interfaces.VariantDecision) error {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	cycle, models := r.cycle, r.models
+	r.cycle, r.models = nil, nil
+	r.mu.Unlock()
+	if cycle == nil {
+		return nil
+	}
+
+	cycle.Analyzer = analyzer
+	cycle.Decisions = decisions
+	for _, key := range slices.Sorted(maps.Keys(models)) {
+		cycle.Models = append(cycle.Models, *models[key])
+	}
+
+	data, err := json.Marshal(cycle)
+	if err != nil {
+		return fmt.Errorf("encoding decision record: %w", err)
+	}
+	if err := r.sink.Write(ctx, data); err != nil {
+		return fmt.Errorf("writing decision record: %w", err)
+	}
+	return nil
+}
+
+// update applies fn to the record of a model in the current cycle. Outside a
+// cycle it does nothing.
+func (r *Recorder) update(namespace, modelID string, fn func(*Model)) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cycle == nil {
+		return
+	}
+	key := utils.GetNamespacedKey(namespace, modelID)
+	m, ok := r.models[key]
+	if !ok {
+		m = &Model{ModelID: modelID, Namespace: namespace}
+		r.models[key] = m
+	}
+	fn(m)
+}
+
+func copyResult(result *interfaces.AnalyzerResult) *interfaces.AnalyzerResult {
+	if result == nil {
+		return nil
+	}
+	c := *result
+	c.VariantCapacities = slices.Clone(result.VariantCapacities)
+	c.RoleCapacities = maps.Clone(result.RoleCapacities)
+	return &c
+}
@@ -0,0 +1,138 @@
+package decisionrecord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// memorySink keeps written records in memory.
+type memorySink struct {
+	records [][]byte
+}
+
+func (s *memorySink) Write(_ context.Context, record []byte) error {
+	s.records = append(s.records, record)
+	return nil
+}
+
+func testInput(modelID string) interfaces.AnalyzerInput {
+	return interfaces.AnalyzerInput{
+		ModelID:   modelID,
+		Namespace: "llm",
+		ReplicaMetrics: []interfaces.ReplicaMetrics{
+			{PodName: modelID + "-0", VariantName: "a100", ModelID: modelID, Namespace: "llm", KvCacheUsage: 0.9},
+		},
+		VariantStates: []interfaces.VariantReplicaState{{VariantName: "a100", CurrentReplicas: 1}},
+		Config:        &config.SaturationScalingConfig{KvCacheThreshold: 0.8, AnalyzerName: "saturation"},
+	}
+}
+
+func TestRecorderRoundTrip(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(sink)
+	at := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+
+	r.BeginCycle(at)
+	for _, modelID := range []string{"qwen", "llama"} {
+		r.RecordInput(testInput(modelID))
+	}
+	result := &interfaces.AnalyzerResult{ModelID: "llama", Namespace: "llm", RequiredCapacity: 100}
+	r.RecordResult(result)
+	// The engine adjusts the result after analysis; the record keeps the
+	// analyzer's output while the request carries the adjusted one.
+	result.RequiredCapacity = 150
+	r.RecordRequests([]pipeline.ModelScalingRequest{{ModelID: "llama", Namespace: "llm", Result: result}})
+	r.RecordRequestCount("llama", "llm", 42)
+	decisions := []interfaces.VariantDecision{{VariantName: "a100", ModelID: "llama", Namespace: "llm", TargetReplicas: 2}}
+	if err := r.EndCycle(context.Background(), "saturation", decisions); err != nil {
+		t.Fatalf("EndCycle() error = %v", err)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("Expected one record, got %d", len(sink.records))
+	}
+	cycles, err := Load(bytes.NewReader(sink.records[0]))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(cycles) != 1 {
+		t.Fatalf("Expected one cycle, got %d", len(cycles))
+	}
+	cycle := cycles[0]
+	if cycle.SchemaVersion != SchemaVersion || !cycle.Time.Equal(at) || cycle.Analyzer != "saturation" {
+		t.Errorf("Unexpected cycle header %+v", cycle)
+	}
+	if len(cycle.Decisions) != 1 || cycle.Decisions[0].TargetReplicas != 2 {
+		t.Errorf("Expected the final decision, got %+v", cycle.Decisions)
+	}
+	if len(cycle.Models) != 2 || cycle.Models[0].ModelID != "llama" || cycle.Models[1].ModelID != "qwen" {
+		t.Fatalf("Expected models sorted by ID, got %+v", cycle.Models)
+	}
+
+	llama := cycle.Models[0]
+	if llama.Result == nil || llama.Result.RequiredCapacity != 100 {
+		t.Errorf("Expected the analyzer result as analyzed, got %+v", llama.Result)
+	}
+	if llama.Request == nil || llama.Request.Result.RequiredCapacity != 150 {
+		t.Errorf("Expected the optimizer request with the adjusted result, got %+v", llama.Request)
+	}
+	if llama.RequestCount == nil || *llama.RequestCount != 42 {
+		t.Errorf("Expected request count 42, got %v", llama.RequestCount)
+	}
+	if cycle.Models[1].RequestCount != nil {
+		t.Errorf("Expected no request count for a model the enforcer did not query, got %v", *cycle.Models[1].RequestCount)
+	}
+
+	var satConfig config.SaturationScalingConfig
+	if err := json.Unmarshal(llama.Input.Config, &satConfig); err != nil {
+		t.Fatalf("Decoding recorded config: %v", err)
+	}
+	input := llama.AnalyzerInput(&satConfig)
+	if input.ModelID != "llama" || len(input.ReplicaMetrics) != 1 || input.ReplicaMetrics[0].KvCacheUsage != 0.9 {
+		t.Errorf("Expected the recorded analyzer input, got %+v", input)
+	}
+	if satConfig.KvCacheThreshold != 0.8 || satConfig.AnalyzerName != "saturation" {
+		t.Errorf("Expected the recorded analyzer config, got %+v", satConfig)
+	}
+}
+
+func TestRecorderOutsideCycle(t *testing.T) {
+	sink := &memorySink{}
+	r := NewRecorder(sink)
+
+	r.RecordInput(testInput("llama"))
+	if err := r.EndCycle(context.Background(), "saturation", nil); err != nil {
+		t.Fatalf("EndCycle() error = %v", err)
+	}
+	if len(sink.records) != 0 {
+		t.Errorf("Expected nothing recorded outside a cycle, got %d records", len(sink.records))
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.BeginCycle(time.Now())
+	r.RecordInput(testInput("llama"))
+	r.RecordResult(&interfaces.AnalyzerResult{ModelID: "llama"})
+	r.RecordRequests([]pipeline.ModelScalingRequest{{ModelID: "llama"}})
+	r.RecordRequestCount("llama", "llm", 1)
+	if err := r.EndCycle(context.Background(), "saturation", nil); err != nil {
+		t.Errorf("EndCycle() on nil recorder error = %v", err)
+	}
+}
+
+func TestLoadRejectsUnknownSchema(t *testing.T) {
+	if _, err := Load(bytes.NewReader([]byte(`{"schemaVersion": 2}`))); err == nil {
+		t.Error("Expected an error for an unknown schema version")
+	}
+	if _, err := Load(bytes.NewReader([]byte("not json\n"))); err == nil {
+		t.Error("Expected an error for a malformed record")
+	}
+}
@@ -0,0 +1,76 @@
+// Package decisionrecord records the inputs and outputs of the saturation
+// engine's optimization cycles, to debug scaling decisions after the fact and
+// to replay them offline or in unit tests.
+package decisionrecord
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// SchemaVersion is the version of the Cycle schema written by this package.
+// Fields may be added within a version; renaming or removing a field, or
+// changing its meaning, requires a new version.
+const SchemaVersion = 1
+
+// Cycle is the record of one optimization cycle.
+type Cycle struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	Time          time.Time `json:"time"`
+	// Analyzer is the analyzer path the cycle ran: "v1-saturation",
+	// "saturation" or "queueing-model".
+	Analyzer string  `json:"analyzer"`
+	Models   []Model `json:"models"`
+	// Decisions are the final decisions of the cycle, after the optimizer,
+	// the GPU limiter and the enforcer.
+	Decisions []interfaces.VariantDecision `json:"decisions"`
+}
+
+// Model is the record of one analyzed model in a cycle.
+type Model struct {
+	ModelID   string `json:"modelID"`
+	Namespace string `json:"namespace"`
+	// Input is the input the analyzer saw.
+	Input *AnalyzerInput `json:"input,omitempty"`
+	// Result is the result of the V2 saturation or queueing model analyzer,
+	// before cold-start lookahead and scoring are applied.
+	Result *interfaces.AnalyzerResult `json:"result,omitempty"`
+	// SaturationAnalysis is the result of the V1 saturation analyzer.
+	SaturationAnalysis *interfaces.ModelSaturationAnalysis `json:"saturationAnalysis,omitempty"`
+	// Request is the request passed to the optimizer.
+	Request *pipeline.ModelScalingRequest `json:"request,omitempty"`
+	// RequestCount is the number of requests to the model over the
+	// scale-to-zero retention period. Only recorded when the enforcer
+	// queried it.
+	RequestCount *float64 `json:"requestCount,omitempty"`
+}
+
+// AnalyzerInput is the serializable form of interfaces.AnalyzerInput.
+type AnalyzerInput struct {
+	ReplicaMetrics []interfaces.ReplicaMetrics       `json:"replicaMetrics"`
+	VariantStates  []interfaces.VariantReplicaState  `json:"variantStates"`
+	SchedulerQueue *interfaces.SchedulerQueueMetrics `json:"schedulerQueue,omitempty"`
+	// Config is the analyzer configuration, as the JSON encoding of
+	// config.SaturationScalingConfig or queueingmodel.QMConfig depending on
+	// the cycle's analyzer.
+	Config json.RawMessage `json:"config,omitempty"`
+}
+
+// AnalyzerInput returns the recorded analyzer input of the model with the
+// given config, as passed to Analyze.
+func (m *Model) AnalyzerInput(cfg interfaces.AnalyzerConfig) interfaces.AnalyzerInput {
+	input := interfaces.AnalyzerInput{
+		ModelID:   m.ModelID,
+		Namespace: m.Namespace,
+		Config:    cfg,
+	}
+	if m.Input != nil {
+		input.ReplicaMetrics = m.Input.ReplicaMetrics
+		input.VariantStates = m.Input.VariantStates
+		input.SchedulerQueue = m.Input.SchedulerQueue
+	}
+	return input
+}
@@ -0,0 +1,139 @@
+package decisionrecord
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// testRecord returns the encoding of a cycle whose analyzer names it n.
+func testRecord(n int) []byte {
+	return fmt.Appendf(nil, `{"schemaVersion":1,"time":"2025-06-04T12:00:00Z","analyzer":"cycle-%03d","models":null,"decisions":null}`, n)
+}
+
+func analyzers(cycles []Cycle) []string {
+	names := make([]string, len(cycles))
+	for i, c := range cycles {
+		names[i] = c.Analyzer
+	}
+	return names
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records", "decisions.jsonl")
+	recordSize := int64(len(testRecord(0)) + 1)
+	// Three records per file, two rotated files.
+	sink, err := NewFileSink(path, 3*recordSize, 2)
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	for i := range 10 {
+		if err := sink.Write(context.Background(), testRecord(i)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(RotatedPath(path, 3)); !os.IsNotExist(err) {
+		t.Errorf("Expected at most 2 rotated files, stat %s: %v", RotatedPath(path, 3), err)
+	}
+	for _, p := range []string{path, RotatedPath(path, 1), RotatedPath(path, 2)} {
+		info, err := os.Stat(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Size() > 3*recordSize {
+			t.Errorf("Expected %s within %d bytes, got %d", p, 3*recordSize, info.Size())
+		}
+	}
+
+	cycles, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	// Records 0-2 were dropped with the oldest rotated file.
+	want := "cycle-003,cycle-004,cycle-005,cycle-006,cycle-007,cycle-008,cycle-009"
+	if got := strings.Join(analyzers(cycles), ","); got != want {
+		t.Errorf("LoadFile() = %s, want %s", got, want)
+	}
+}
+
+func TestFileSinkAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.jsonl")
+	for i := range 2 {
+		sink, err := NewFileSink(path, 1<<20, 1)
+		if err != nil {
+			t.Fatalf("NewFileSink() error = %v", err)
+		}
+		if err := sink.Write(context.Background(), testRecord(i)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cycles, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if got := strings.Join(analyzers(cycles), ","); got != "cycle-000,cycle-001" {
+		t.Errorf("Expected a restarted sink to append, got %s", got)
+	}
+}
+
+func TestConfigMapSinkRingBuffer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	sink, err := NewConfigMapSink(c, "wva-system", "wva-decision-records", 3)
+	if err != nil {
+		t.Fatalf("NewConfigMapSink() error = %v", err)
+	}
+	for i := range 5 {
+		if err := sink.Write(context.Background(), testRecord(i)); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "wva-system", Name: "wva-decision-records"}, cm); err != nil {
+		t.Fatal(err)
+	}
+	cycles, err := ConfigMapRecords(cm)
+	if err != nil {
+		t.Fatalf("ConfigMapRecords() error = %v", err)
+	}
+	if got := strings.Join(analyzers(cycles), ","); got != "cycle-002,cycle-003,cycle-004" {
+		t.Errorf("Expected the 3 most recent records, got %s", got)
+	}
+
+	if err := sink.Write(context.Background(), make([]byte, MaxConfigMapBytes+1)); err == nil {
+		t.Error("Expected an error for a record beyond the ConfigMap limit")
+	}
+}
+
+func TestSinkValidation(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := NewFileSink(filepath.Join(dir, "a.jsonl"), 0, 1); err == nil {
+		t.Error("Expected an error for non-positive max bytes")
+	}
+	if _, err := NewFileSink(filepath.Join(dir, "b.jsonl"), 1024, -1); err == nil {
+		t.Error("Expected an error for negative max files")
+	}
+	if _, err := NewConfigMapSink(nil, "ns", "name", 0); err == nil {
+		t.Error("Expected an error for non-positive max records")
+	}
+}
@@ -0,0 +1,175 @@
+// Package drain picks the replicas of a variant to remove on scale-down, and
+// holds the scale-down until their in-flight requests drain.
+//
+// The picked pods are given a negative controller.kubernetes.io/pod-deletion-cost,
+// so that the ReplicaSet removes them rather than arbitrary pods when the HPA
+// lowers the replicas, and optionally a cordon label that takes them out of the
+// InferencePool. The engine lowers the desired replicas by the picked pods that
+// have drained, or whose drain timed out.
+package drain
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// DefaultTimeout is the drain timeout of a GracefulScaleDown without one.
+const DefaultTimeout = 5 * time.Minute
+
+// Deletion costs of the pods picked for removal. Drained pods cost less than
+// draining pods, so that a partial scale-down removes them first. Both cost
+// less than the default of 0.
+const (
+	DrainingDeletionCost = -1000
+	DrainedDeletionCost  = -2000
+)
+
+// Label is a label set on draining pods to cordon them from the endpoint picker.
+type Label struct {
+	Key   string
+	Value string
+}
+
+// ParseLabel parses a label given as key=value. An empty string is no label.
+func ParseLabel(s string) (Label, error) {
+	if s == "" {
+		return Label{}, nil
+	}
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return Label{}, fmt.Errorf("cordon label %q is not key=value", s)
+	}
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return Label{}, fmt.Errorf("invalid cordon label key %q: %s", key, strings.Join(errs, "; "))
+	}
+	if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+		return Label{}, fmt.Errorf("invalid cordon label value %q: %s", value, strings.Join(errs, "; "))
+	}
+	return Label{Key: key, Value: value}, nil
+}
+
+// Pick is a pod picked for removal.
+type Pick struct {
+	Pod *corev1.Pod
+	// Started is when the pod started draining.
+	Started time.Time
+	// Drained is true when the pod has no in-flight requests, or its drain
+	// timed out.
+	Drained bool
+}
+
+// Plan is the pods to drain for a scale-down.
+type Plan struct {
+	// Drain are the pods picked for removal.
+	Drain []Pick
+	// Release are the draining pods that are no longer picked, e.g. because
+	// the scale-down was cancelled.
+	Release []*corev1.Pod
+}
+
+// Drained returns the number of picked pods that can be removed.
+func (p *Plan) Drained() int {
+	drained := 0
+	for _, pick := range p.Drain {
+		if pick.Drained {
+			drained++
+		}
+	}
+	return drained
+}
+
+// Select picks excess pods for removal, and returns the draining pods to
+// release. Pods that are already draining are kept, least loaded first, so
+// that the pick is stable across cycles. Other pods are picked least loaded
+// first: pods that are not ready, which the ReplicaSet removes first anyway,
+// then by tokens in use, queue length and KV cache usage. metrics holds the
+// metrics of the pods by pod name; pods without metrics are considered idle.
+func Select(pods []corev1.Pod, metrics map[string]interfaces.ReplicaMetrics, excess int, timeout time.Duration, now time.Time) Plan {
+	var draining, others []*corev1.Pod
+	for i := range pods {
+		pod := &pods[i]
+		if pod.DeletionTimestamp != nil || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if Draining(*pod) {
+			draining = append(draining, pod)
+		} else {
+			others = append(others, pod)
+		}
+	}
+	byLoad := func(a, b *corev1.Pod) int {
+		return compareLoad(a, b, metrics)
+	}
+	slices.SortFunc(draining, byLoad)
+	slices.SortFunc(others, byLoad)
+
+	var plan Plan
+	excess = max(excess, 0)
+	kept := min(excess, len(draining))
+	plan.Release = draining[kept:]
+	for _, pod := range draining[:kept] {
+		plan.Drain = append(plan.Drain, pick(pod, metrics, timeout, now))
+	}
+	for _, pod := range others[:min(excess-kept, len(others))] {
+		plan.Drain = append(plan.Drain, pick(pod, metrics, timeout, now))
+	}
+	return plan
+}
+
+// pick picks a pod for removal. A pod whose drain start cannot be parsed
+// starts draining again.
+func pick(pod *corev1.Pod, metrics map[string]interfaces.ReplicaMetrics, timeout time.Duration, now time.Time) Pick {
+	started, err := time.Parse(time.RFC3339, pod.Annotations[constants.DrainStartedAnnotationKey])
+	if err != nil {
+		started = now
+	}
+	m, ok := metrics[pod.Name]
+	busy := ok && Busy(&m)
+	return Pick{
+		Pod:     pod,
+		Started: started,
+		Drained: !busy || now.Sub(started) >= timeout,
+	}
+}
+
+// Busy returns whether a replica has in-flight requests: queued requests, or
+// tokens in the KV cache.
+func Busy(m *interfaces.ReplicaMetrics) bool {
+	return m.QueueLength > 0 || m.TokensInUse > 0 || m.KvCacheUsage > 0
+}
+
+// compareLoad orders pods from the least to the most loaded, by readiness,
+// tokens in use, queue length and KV cache usage. Ties are broken by name.
+func compareLoad(a, b *corev1.Pod, metrics map[string]interfaces.ReplicaMetrics) int {
+	if ra, rb := podReady(a), podReady(b); ra != rb {
+		if !ra {
+			return -1
+		}
+		return 1
+	}
+	ma, mb := metrics[a.Name], metrics[b.Name]
+	return cmp.Or(
+		cmp.Compare(ma.TokensInUse, mb.TokensInUse),
+		cmp.Compare(ma.QueueLength, mb.QueueLength),
+		cmp.Compare(ma.KvCacheUsage, mb.KvCacheUsage),
+		strings.Compare(a.Name, b.Name),
+	)
+}
+
+func podReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
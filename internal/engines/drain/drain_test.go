@@ -0,0 +1,163 @@
+package drain
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func testPod(name string, ready bool, drainStarted string) corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+	if drainStarted != "" {
+		pod.Annotations = map[string]string{constants.DrainStartedAnnotationKey: drainStarted}
+	}
+	return pod
+}
+
+func pickNames(plan Plan) []string {
+	var names []string
+	for _, p := range plan.Drain {
+		names = append(names, p.Pod.Name)
+	}
+	return names
+}
+
+func TestSelect(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	started := now.Add(-time.Minute).Format(time.RFC3339)
+	metrics := map[string]interfaces.ReplicaMetrics{
+		"busy":    {PodName: "busy", TokensInUse: 5000, QueueLength: 2},
+		"light":   {PodName: "light", TokensInUse: 100},
+		"idle":    {PodName: "idle"},
+		"queued":  {PodName: "queued", QueueLength: 1},
+		"drainer": {PodName: "drainer", TokensInUse: 200},
+	}
+
+	tests := []struct {
+		name        string
+		pods        []corev1.Pod
+		excess      int
+		wantDrain   []string
+		wantDrained int
+		wantRelease []string
+	}{
+		{
+			name:        "least loaded first",
+			pods:        []corev1.Pod{testPod("busy", true, ""), testPod("light", true, ""), testPod("idle", true, ""), testPod("queued", true, "")},
+			excess:      2,
+			wantDrain:   []string{"idle", "queued"},
+			wantDrained: 1,
+		},
+		{
+			name:        "not ready pods first",
+			pods:        []corev1.Pod{testPod("idle", true, ""), testPod("starting", false, "")},
+			excess:      1,
+			wantDrain:   []string{"starting"},
+			wantDrained: 1,
+		},
+		{
+			name:        "draining pods are kept",
+			pods:        []corev1.Pod{testPod("idle", true, ""), testPod("drainer", true, started)},
+			excess:      1,
+			wantDrain:   []string{"drainer"},
+			wantDrained: 0,
+		},
+		{
+			name:        "cancelled scale-down releases draining pods",
+			pods:        []corev1.Pod{testPod("idle", true, ""), testPod("drainer", true, started)},
+			excess:      0,
+			wantRelease: []string{"drainer"},
+		},
+		{
+			name:        "excess beyond the pods",
+			pods:        []corev1.Pod{testPod("busy", true, "")},
+			excess:      3,
+			wantDrain:   []string{"busy"},
+			wantDrained: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := Select(tt.pods, metrics, tt.excess, DefaultTimeout, now)
+			if got := pickNames(plan); !slices.Equal(got, tt.wantDrain) {
+				t.Errorf("drain = %v, want %v", got, tt.wantDrain)
+			}
+			if got := plan.Drained(); got != tt.wantDrained {
+				t.Errorf("Drained() = %d, want %d", got, tt.wantDrained)
+			}
+			var released []string
+			for _, pod := range plan.Release {
+				released = append(released, pod.Name)
+			}
+			if !slices.Equal(released, tt.wantRelease) {
+				t.Errorf("release = %v, want %v", released, tt.wantRelease)
+			}
+		})
+	}
+}
+
+func TestSelect_Timeout(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	metrics := map[string]interfaces.ReplicaMetrics{"busy": {PodName: "busy", QueueLength: 3}}
+	pods := []corev1.Pod{testPod("busy", true, now.Add(-DefaultTimeout).Format(time.RFC3339))}
+
+	plan := Select(pods, metrics, 1, DefaultTimeout, now)
+	if plan.Drained() != 1 {
+		t.Error("busy pod not drained after the timeout")
+	}
+	if got := plan.Drain[0].Started; !got.Equal(now.Add(-DefaultTimeout)) {
+		t.Errorf("started = %v, want the annotated start", got)
+	}
+
+	plan = Select(pods, metrics, 1, 0, now)
+	if plan.Drained() != 1 {
+		t.Error("busy pod not drained without a timeout")
+	}
+
+	pods[0].Annotations[constants.DrainStartedAnnotationKey] = "invalid"
+	plan = Select(pods, metrics, 1, DefaultTimeout, now)
+	if plan.Drained() != 0 || !plan.Drain[0].Started.Equal(now) {
+		t.Errorf("pick = %+v, want the drain restarted", plan.Drain[0])
+	}
+}
+
+func TestParseLabel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Label
+		wantErr bool
+	}{
+		{in: "", want: Label{}},
+		{in: "llm-d.ai/routable=false", want: Label{Key: "llm-d.ai/routable", Value: "false"}},
+		{in: "draining=", want: Label{Key: "draining"}},
+		{in: "routable", wantErr: true},
+		{in: "-bad=x", wantErr: true},
+		{in: "ok=not valid", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseLabel(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseLabel(%q) error = %v, want error %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseLabel(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package drain
+
+import (
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// Draining returns whether a pod is marked as draining.
+func Draining(pod corev1.Pod) bool {
+	_, ok := pod.Annotations[constants.DrainStartedAnnotationKey]
+	return ok
+}
+
+// Mark marks a picked pod as draining: it sets its drain start, its deletion
+// cost and the cordon label, if any. It returns whether the pod changed.
+func Mark(pick Pick, cordon Label) bool {
+	pod := pick.Pod
+	cost := DrainingDeletionCost
+	if pick.Drained {
+		cost = DrainedDeletionCost
+	}
+	changed := setAnnotation(pod, constants.DrainStartedAnnotationKey, pick.Started.UTC().Format(time.RFC3339))
+	changed = setAnnotation(pod, corev1.PodDeletionCost, strconv.Itoa(cost)) || changed
+	if cordon.Key != "" {
+		changed = setAnnotation(pod, constants.DrainCordonLabelAnnotationKey, cordon.Key) || changed
+		if value, ok := pod.Labels[cordon.Key]; !ok || value != cordon.Value {
+			if pod.Labels == nil {
+				pod.Labels = make(map[string]string)
+			}
+			pod.Labels[cordon.Key] = cordon.Value
+			changed = true
+		}
+	}
+	return changed
+}
+
+// Unmark releases a draining pod: it removes its drain start, and restores
+// its deletion cost and cordon label from the pod template. It returns whether
+// the pod changed.
+func Unmark(pod *corev1.Pod, template *corev1.PodTemplateSpec) bool {
+	if !Draining(*pod) {
+		return false
+	}
+	delete(pod.Annotations, constants.DrainStartedAnnotationKey)
+	restore(pod.Annotations, template.Annotations, corev1.PodDeletionCost)
+	if key, ok := pod.Annotations[constants.DrainCordonLabelAnnotationKey]; ok {
+		delete(pod.Annotations, constants.DrainCordonLabelAnnotationKey)
+		if pod.Labels != nil {
+			restore(pod.Labels, template.Labels, key)
+		}
+	}
+	return true
+}
+
+func setAnnotation(pod *corev1.Pod, key, value string) bool {
+	if current, ok := pod.Annotations[key]; ok && current == value {
+		return false
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = make(map[string]string)
+	}
+	pod.Annotations[key] = value
+	return true
+}
+
+// restore sets key in m to its value in template, or deletes it when the
+// template does not set it.
+func restore(m, template map[string]string, key string) {
+	if value, ok := template[key]; ok {
+		m[key] = value
+	} else {
+		delete(m, key)
+	}
+}
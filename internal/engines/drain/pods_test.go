@@ -0,0 +1,59 @@
+package drain
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func TestMarkUnmark(t *testing.T) {
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	cordon := Label{Key: "llm-d.ai/routable", Value: "false"}
+	template := &corev1.PodTemplateSpec{}
+	template.Labels = map[string]string{"app": "llama", "llm-d.ai/routable": "true"}
+
+	pod := testPod("llama-0", true, "")
+	pod.Labels = map[string]string{"app": "llama", "llm-d.ai/routable": "true"}
+
+	if !Mark(Pick{Pod: &pod, Started: started}, cordon) {
+		t.Fatal("Mark() did not change the pod")
+	}
+	if got := pod.Annotations[corev1.PodDeletionCost]; got != "-1000" {
+		t.Errorf("deletion cost = %q, want -1000", got)
+	}
+	if got := pod.Annotations[constants.DrainStartedAnnotationKey]; got != "2025-01-01T12:00:00Z" {
+		t.Errorf("drain started = %q", got)
+	}
+	if got := pod.Labels[cordon.Key]; got != "false" {
+		t.Errorf("cordon label = %q, want false", got)
+	}
+	if Mark(Pick{Pod: &pod, Started: started}, cordon) {
+		t.Error("Mark() changed a marked pod")
+	}
+	if !Mark(Pick{Pod: &pod, Started: started, Drained: true}, cordon) || pod.Annotations[corev1.PodDeletionCost] != "-2000" {
+		t.Errorf("deletion cost = %q after drain, want -2000", pod.Annotations[corev1.PodDeletionCost])
+	}
+
+	if !Unmark(&pod, template) {
+		t.Fatal("Unmark() did not change the pod")
+	}
+	if len(pod.Annotations) != 0 {
+		t.Errorf("annotations = %v after Unmark(), want none", pod.Annotations)
+	}
+	if got := pod.Labels[cordon.Key]; got != "true" {
+		t.Errorf("cordon label = %q after Unmark(), want the template's", got)
+	}
+	if Unmark(&pod, template) {
+		t.Error("Unmark() changed a pod that is not draining")
+	}
+
+	// a cordon label the template does not set is removed
+	Mark(Pick{Pod: &pod, Started: started}, Label{Key: "draining", Value: "true"})
+	Unmark(&pod, template)
+	if _, ok := pod.Labels["draining"]; ok {
+		t.Error("cordon label not removed by Unmark()")
+	}
+}
@@ -20,15 +20,19 @@ import (
 	"context"
 	"time"
 
-	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 )
 
 // PollingExecutor executes the optimization function at fixed intervals.
+// An optional trigger channel runs the function early; the interval then
+// restarts from that run.
 type PollingExecutor struct {
 	config       Config
-	interval     time.Duration // polling interval
-	retryBackoff time.Duration // backoff duration between retries
+	interval     time.Duration   // polling interval
+	retryBackoff time.Duration   // backoff duration between retries
+	trigger      <-chan struct{} // optional early-run trigger
 }
 
 // PollingConfig holds polling-specific configuration.
@@ -36,6 +40,8 @@ type PollingConfig struct {
 	Config
 	Interval     time.Duration
 	RetryBackoff time.Duration
+	// Trigger, when set, runs the optimization as soon as a value is received.
+	Trigger <-chan struct{}
 }
 
 // NewPollingExecutor creates a new polling executor.
@@ -44,13 +50,26 @@ func NewPollingExecutor(config PollingConfig) *PollingExecutor {
 		config:       config.Config,
 		interval:     config.Interval,
 		retryBackoff: config.RetryBackoff,
+		trigger:      config.Trigger,
 	}
 }
 
 func (e *PollingExecutor) Start(ctx context.Context) {
-	wait.UntilWithContext(ctx, func(ctx context.Context) {
+	logger := log.FromContext(ctx)
+	for {
 		e.executeWithRetry(ctx)
-	}, e.interval)
+
+		timer := time.NewTimer(e.interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		case <-e.trigger: // nil channel never fires
+			timer.Stop()
+			logger.V(logging.DEBUG).Info("Optimization triggered before polling interval")
+		}
+	}
 }
 
 func (e *PollingExecutor) executeWithRetry(ctx context.Context) {
@@ -0,0 +1,190 @@
+package pipeline
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// isInterrupted reports whether a variant is a preemptible variant that is
+// currently losing replicas to node interruptions. Interrupted variants are
+// not eligible for scale-up.
+func isInterrupted(state interfaces.VariantReplicaState) bool {
+	return state.Preemptible && state.InterruptedReplicas > 0
+}
+
+// interruptedReplicas returns how many of a variant's target replicas are lost
+// to node interruptions, bounded by the target itself.
+func interruptedReplicas(state interfaces.VariantReplicaState, target int) int {
+	if !state.Preemptible || state.InterruptedReplicas <= 0 {
+		return 0
+	}
+	return min(state.InterruptedReplicas, target)
+}
+
+// withInterruptedCapacity returns a copy of req whose analyzer result treats the
+// capacity of interrupted preemptible replicas as lost. The lost capacity is
+// first taken out of the spare capacity, and the remainder is added to the
+// required capacity, so that the optimizer replaces it on other variants.
+// The original request and result are not modified.
+func withInterruptedCapacity(ctx context.Context, req ModelScalingRequest) ModelScalingRequest {
+	if req.Result == nil {
+		return req
+	}
+
+	stateMap := buildStateMap(req.VariantStates)
+	lost := 0.0
+	for _, vc := range req.Result.VariantCapacities {
+		state := stateMap[vc.VariantName]
+		lost += float64(interruptedReplicas(state, state.CurrentReplicas)) * vc.PerReplicaCapacity
+	}
+	if lost <= 0 {
+		return req
+	}
+
+	result := *req.Result
+	absorbed := math.Min(result.SpareCapacity, lost)
+	result.SpareCapacity -= absorbed
+	added := lost - absorbed
+	if added > 0 {
+		// Keep the score proportional to the required capacity it was derived from.
+		if result.Score > 0 && result.RequiredCapacity > 0 {
+			result.Score *= (result.RequiredCapacity + added) / result.RequiredCapacity
+		}
+		result.RequiredCapacity += added
+	}
+
+	ctrl.LoggerFrom(ctx).Info("Replacing capacity lost to node interruptions",
+		"modelID", req.ModelID,
+		"namespace", req.Namespace,
+		"lostCapacity", lost,
+		"requiredCapacity", result.RequiredCapacity,
+		"spareCapacity", result.SpareCapacity)
+
+	req.Result = &result
+	return req
+}
+
+// enforceOnDemandFloor moves capacity from preemptible to non-preemptible variants
+// until non-preemptible variants provide at least minFraction of the model's
+// capacity. Capacity is measured on the targets, excluding interrupted replicas.
+//
+// Replicas are added to the most cost-efficient on-demand variants (respecting
+// maxReplicas), then preemptible replicas worth at most the added capacity are
+// removed from the most expensive, non-interrupted preemptible variants
+// (respecting minReplicas), so total capacity never drops below what the
+// scale-up/scale-down step produced.
+func enforceOnDemandFloor(
+	ctx context.Context,
+	req ModelScalingRequest,
+	targets map[string]int,
+	stateMap map[string]interfaces.VariantReplicaState,
+) {
+	if req.MinOnDemandFraction <= 0 || req.Result == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	total, onDemand := 0.0, 0.0
+	var onDemandVCs, preemptibleVCs []interfaces.VariantCapacity
+	for _, vc := range req.Result.VariantCapacities {
+		if vc.PerReplicaCapacity <= 0 {
+			continue
+		}
+		state := stateMap[vc.VariantName]
+		target := targets[vc.VariantName]
+		capacity := float64(target-interruptedReplicas(state, target)) * vc.PerReplicaCapacity
+		total += capacity
+		if state.Preemptible {
+			preemptibleVCs = append(preemptibleVCs, vc)
+		} else {
+			onDemand += capacity
+			onDemandVCs = append(onDemandVCs, vc)
+		}
+	}
+
+	deficit := req.MinOnDemandFraction*total - onDemand
+	if total <= 0 || deficit <= 0 {
+		return
+	}
+	if len(onDemandVCs) == 0 {
+		logger.Info("Cannot enforce on-demand capacity floor: model has no on-demand variant",
+			"modelID", req.ModelID,
+			"namespace", req.Namespace,
+			"minOnDemandFraction", req.MinOnDemandFraction)
+		return
+	}
+
+	// Add on-demand replicas, cheapest capacity first.
+	added := 0.0
+	remaining := deficit
+	for _, vc := range sortByCostEfficiencyAsc(onDemandVCs) {
+		if remaining <= 0 {
+			break
+		}
+		n := int(math.Ceil(remaining / vc.PerReplicaCapacity))
+		state := stateMap[vc.VariantName]
+		if state.MaxReplicas != nil && *state.MaxReplicas > 0 {
+			n = min(n, *state.MaxReplicas-targets[vc.VariantName])
+		}
+		if n <= 0 {
+			continue
+		}
+		targets[vc.VariantName] += n
+		capacity := float64(n) * vc.PerReplicaCapacity
+		added += capacity
+		remaining -= capacity
+		logger.V(logging.DEBUG).Info("On-demand floor allocation",
+			"variant", vc.VariantName,
+			"added", n,
+			"costEfficiency", costEfficiency(vc))
+	}
+	if remaining > 0 {
+		logger.Info("On-demand capacity floor not fully met: on-demand variants are at maxReplicas",
+			"modelID", req.ModelID,
+			"namespace", req.Namespace,
+			"minOnDemandFraction", req.MinOnDemandFraction,
+			"missingCapacity", remaining)
+	}
+
+	// Remove preemptible replicas covered by the added capacity, most expensive first.
+	sort.SliceStable(preemptibleVCs, func(i, j int) bool {
+		return preemptibleVCs[i].Cost > preemptibleVCs[j].Cost
+	})
+	spare := added
+	for _, vc := range preemptibleVCs {
+		if spare <= 0 {
+			break
+		}
+		state := stateMap[vc.VariantName]
+		if isInterrupted(state) {
+			continue
+		}
+		minReplicas := 0
+		if state.MinReplicas != nil {
+			minReplicas = *state.MinReplicas
+		}
+		n := min(int(math.Floor(spare/vc.PerReplicaCapacity)), targets[vc.VariantName]-minReplicas)
+		if n <= 0 {
+			continue
+		}
+		targets[vc.VariantName] -= n
+		spare -= float64(n) * vc.PerReplicaCapacity
+		logger.V(logging.DEBUG).Info("On-demand floor preemptible removal",
+			"variant", vc.VariantName,
+			"removed", n,
+			"cost", vc.Cost)
+	}
+
+	logger.Info("Enforced on-demand capacity floor",
+		"modelID", req.ModelID,
+		"namespace", req.Namespace,
+		"minOnDemandFraction", req.MinOnDemandFraction,
+		"onDemandCapacity", onDemand+added,
+		"totalCapacity", total)
+}
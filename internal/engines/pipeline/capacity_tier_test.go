@@ -0,0 +1,187 @@
+package pipeline
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+var _ = Describe("Capacity tiers", func() {
+
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	// spotAndOnDemand builds a request with a cheap spot variant and a more
+	// expensive on-demand variant of equal per-replica capacity.
+	spotAndOnDemand := func(result *interfaces.AnalyzerResult, spot, onDemand interfaces.VariantReplicaState) ModelScalingRequest {
+		result.VariantCapacities = []interfaces.VariantCapacity{
+			{VariantName: "spot", AcceleratorName: "A100", Cost: 2.0, PerReplicaCapacity: 10000},
+			{VariantName: "on-demand", AcceleratorName: "A100", Cost: 5.0, PerReplicaCapacity: 10000},
+		}
+		spot.VariantName = "spot"
+		spot.Preemptible = true
+		onDemand.VariantName = "on-demand"
+		return ModelScalingRequest{
+			ModelID:       "model-1",
+			Namespace:     "default",
+			Result:        result,
+			VariantStates: []interfaces.VariantReplicaState{spot, onDemand},
+		}
+	}
+
+	Context("On-demand floor", func() {
+
+		It("should move capacity from spot to on-demand to meet the floor", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4},
+				interfaces.VariantReplicaState{CurrentReplicas: 0})
+			req.MinOnDemandFraction = 0.5
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(2))
+			Expect(dm["spot"].TargetReplicas).To(Equal(2))
+		})
+
+		It("should apply the floor to capacity added by scale-up", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{RequiredCapacity: 40000},
+				interfaces.VariantReplicaState{CurrentReplicas: 0},
+				interfaces.VariantReplicaState{CurrentReplicas: 0})
+			req.MinOnDemandFraction = 0.25
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			// Scale-up puts all 4 replicas on the cheaper spot variant; the floor moves one.
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(1))
+			Expect(dm["spot"].TargetReplicas).To(Equal(3))
+		})
+
+		It("should respect maxReplicas on on-demand and minReplicas on spot", func() {
+			maxOD, minSpot := 1, 4
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4, MinReplicas: &minSpot},
+				interfaces.VariantReplicaState{CurrentReplicas: 0, MaxReplicas: &maxOD})
+			req.MinOnDemandFraction = 0.5
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(1))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+
+		It("should leave targets unchanged when the floor is disabled", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4},
+				interfaces.VariantReplicaState{CurrentReplicas: 0})
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(0))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+
+		It("should skip the floor when the model has no on-demand variant", func() {
+			req := ModelScalingRequest{
+				ModelID:   "model-1",
+				Namespace: "default",
+				Result: &interfaces.AnalyzerResult{
+					VariantCapacities: []interfaces.VariantCapacity{
+						{VariantName: "spot", Cost: 2.0, PerReplicaCapacity: 10000},
+					},
+				},
+				VariantStates: []interfaces.VariantReplicaState{
+					{VariantName: "spot", CurrentReplicas: 3, Preemptible: true},
+				},
+				MinOnDemandFraction: 0.5,
+			}
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			Expect(dm["spot"].TargetReplicas).To(Equal(3))
+		})
+	})
+
+	Context("Node interruptions", func() {
+
+		It("should replace interrupted spot capacity on on-demand", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4, InterruptedReplicas: 2},
+				interfaces.VariantReplicaState{CurrentReplicas: 1})
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			// Spot is cheaper but interrupted, so the lost 2 replicas go to on-demand.
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(3))
+			Expect(dm["on-demand"].Action).To(Equal(interfaces.ActionScaleUp))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+
+		It("should absorb lost capacity in spare capacity first", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{SpareCapacity: 15000},
+				interfaces.VariantReplicaState{CurrentReplicas: 4, InterruptedReplicas: 2},
+				interfaces.VariantReplicaState{CurrentReplicas: 1})
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			// 20000 lost - 15000 spare = 5000 required → one on-demand replica.
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(2))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+
+		It("should not count interrupted replicas towards the floor", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4, InterruptedReplicas: 4},
+				interfaces.VariantReplicaState{CurrentReplicas: 1})
+			req.MinOnDemandFraction = 1.0
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			// All spot capacity is lost and replaced; the interrupted variant is left alone.
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(5))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+
+		It("should ignore interrupted replicas of non-preemptible variants", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 1},
+				interfaces.VariantReplicaState{CurrentReplicas: 2, InterruptedReplicas: 1})
+
+			dm := decisionMap(NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil))
+
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(2))
+			Expect(dm["spot"].TargetReplicas).To(Equal(1))
+		})
+
+		It("should not modify the caller's analyzer result", func() {
+			result := &interfaces.AnalyzerResult{SpareCapacity: 5000}
+			req := spotAndOnDemand(result,
+				interfaces.VariantReplicaState{CurrentReplicas: 4, InterruptedReplicas: 1},
+				interfaces.VariantReplicaState{CurrentReplicas: 1})
+
+			NewCostAwareOptimizer().Optimize(ctx, []ModelScalingRequest{req}, nil)
+
+			Expect(result.SpareCapacity).To(Equal(5000.0))
+			Expect(result.RequiredCapacity).To(Equal(0.0))
+		})
+
+		It("should skip interrupted spot variants in the greedy-by-score optimizer", func() {
+			req := spotAndOnDemand(&interfaces.AnalyzerResult{},
+				interfaces.VariantReplicaState{CurrentReplicas: 4, InterruptedReplicas: 2},
+				interfaces.VariantReplicaState{CurrentReplicas: 1})
+			constraints := []*ResourceConstraints{{
+				Pools: map[string]ResourcePool{"A100": {Limit: 100}},
+			}}
+
+			dm := decisionMap(NewGreedyByScoreOptimizer().Optimize(ctx, []ModelScalingRequest{req}, constraints))
+
+			Expect(dm["on-demand"].TargetReplicas).To(Equal(3))
+			Expect(dm["spot"].TargetReplicas).To(Equal(4))
+		})
+	})
+})
@@ -19,6 +19,9 @@ import (
 //   - Scale-down: removes replicas from the most expensive variant (highest absolute cost)
 //   - Only the cheapest variant is protected at >=1 replica; others can scale to 0
 //   - Variants with pending replicas are skipped for scale-up
+//   - Capacity of interrupted preemptible replicas is replaced on other variants,
+//     and interrupted variants are skipped for scale-up
+//   - Non-preemptible variants keep at least MinOnDemandFraction of the capacity
 //
 // This optimizer ignores ResourceConstraints (unlimited mode). For GPU-limited
 // environments, use GreedyByScoreOptimizer instead.
@@ -48,6 +51,7 @@ func (o *CostAwareOptimizer) Optimize(
 		if req.Result == nil {
 			continue
 		}
+		req = withInterruptedCapacity(ctx, req)
 
 		stateMap := buildStateMap(req.VariantStates)
 		vcMap := buildCapacityMap(req.Result.VariantCapacities)
@@ -58,6 +62,7 @@ func (o *CostAwareOptimizer) Optimize(
 		} else if req.Result.SpareCapacity > 0 {
 			costAwareScaleDown(ctx, req.Result, targets, stateMap)
 		}
+		enforceOnDemandFloor(ctx, req, targets, stateMap)
 
 		decisions := buildDecisionsWithOptimizer(req, stateMap, vcMap, targets, "cost-aware")
 		logger.V(logging.DEBUG).Info("Cost-aware optimizer decisions",
@@ -72,7 +77,7 @@ func (o *CostAwareOptimizer) Optimize(
 // costAwareScaleUp adds replicas to the most cost-efficient variant.
 // Sorts by cost-efficiency (cost/perReplicaCapacity) ascending, picks first eligible.
 // Respects maxReplicas per variant — if a variant hits its cap, remaining capacity
-// spills over to the next variant.
+// spills over to the next variant. Interrupted preemptible variants are not eligible.
 func costAwareScaleUp(
 	ctx context.Context,
 	result *interfaces.AnalyzerResult,
@@ -92,10 +97,14 @@ func costAwareScaleUp(
 			continue
 		}
 
+		state := stateMap[vc.VariantName]
+		if isInterrupted(state) {
+			continue
+		}
+
 		replicasNeeded := int(math.Ceil(remaining / vc.PerReplicaCapacity))
 
 		// Cap by maxReplicas if set
-		state := stateMap[vc.VariantName]
 		if state.MaxReplicas != nil && *state.MaxReplicas > 0 {
 			maxAdd := *state.MaxReplicas - targets[vc.VariantName]
 			if maxAdd <= 0 {
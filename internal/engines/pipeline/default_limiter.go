@@ -108,6 +108,12 @@ func (l *DefaultLimiter) buildStepReason(d *interfaces.VariantDecision) string {
 	return fmt.Sprintf("allocated %d GPUs for +%d replicas", d.GPUsAllocated, replicaChange)
 }
 
+// ResourcePools returns the per-type resource availability of the limiter's
+// inventory as of its last refresh.
+func (l *DefaultLimiter) ResourcePools() map[string]ResourcePool {
+	return l.inventory.GetResourcePools()
+}
+
 // ComputeConstraints refreshes the inventory and returns per-type resource availability.
 // This is the V2 path: expose constraints for the optimizer instead of modifying
 // decisions directly (which is what Limit() does for the V1 path).
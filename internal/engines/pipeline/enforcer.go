@@ -23,17 +23,31 @@ type Enforcer struct {
 	// requestCountFunc is a function that returns the total request count for a model.
 	// Injected for testability.
 	requestCountFunc RequestCountFuncType
+	// now returns the time scaling schedules are evaluated at. Injected for testability.
+	now func() time.Time
 }
 
 // NewEnforcer creates a new scale-to-zero enforcer.
 func NewEnforcer(requestCountFunc RequestCountFuncType) *Enforcer {
+	return NewEnforcerWithClock(requestCountFunc, time.Now)
+}
+
+// NewEnforcerWithClock creates a scale-to-zero enforcer that evaluates scaling
+// schedules at the time returned by now, e.g. the recorded time of a replayed cycle.
+func NewEnforcerWithClock(requestCountFunc RequestCountFuncType, now func() time.Time) *Enforcer {
 	return &Enforcer{
 		requestCountFunc: requestCountFunc,
+		now:              now,
 	}
 }
 
-// EnforcePolicyOnDecisions applies scale-to-zero and minimum replica enforcement
-// directly on VariantDecision slices. It operates on decisions in-place.
+// EnforcePolicyOnDecisions applies scaling schedule, scale-to-zero and minimum
+// replica enforcement directly on VariantDecision slices. It operates on decisions in-place.
+//
+// variantStates are the model's states after ApplySchedules. Decisions of variants
+// with active schedules are clamped to the scheduled bounds. Scale-to-zero and
+// minimum replica enforcement are skipped when any variant has minReplicas > 0,
+// and active schedules override the model's scale-to-zero setting.
 //
 // Returns true if scale-to-zero was applied (all variants scaled to zero).
 func (e *Enforcer) EnforcePolicyOnDecisions(
@@ -41,12 +55,30 @@ func (e *Enforcer) EnforcePolicyOnDecisions(
 	modelID string,
 	namespace string,
 	decisions []interfaces.VariantDecision,
+	variantStates []interfaces.VariantReplicaState,
 	scaleToZeroConfig config.ScaleToZeroConfigData,
 	optimizerName string,
 ) bool {
 	logger := ctrl.LoggerFrom(ctx)
 
-	scaleToZeroEnabled := config.IsScaleToZeroEnabled(scaleToZeroConfig, modelID)
+	states := make(map[string]interfaces.VariantReplicaState, len(variantStates))
+	for _, state := range variantStates {
+		states[state.VariantName] = state
+	}
+
+	applyScheduleBounds(modelID, namespace, decisions, states, optimizerName)
+
+	if hasMinReplicasAboveZero(variantStates) {
+		logger.V(logging.DEBUG).Info("Skipping scale-to-zero enforcement: variant has minReplicas > 0",
+			"modelID", modelID,
+			"optimizer", optimizerName)
+		return false
+	}
+
+	scaleToZeroEnabled, scheduled := scheduledScaleToZero(states)
+	if !scheduled {
+		scaleToZeroEnabled = config.IsScaleToZeroEnabled(scaleToZeroConfig, modelID)
+	}
 
 	if scaleToZeroEnabled {
 		applied := e.applyScaleToZeroOnDecisions(ctx, modelID, namespace, decisions, scaleToZeroConfig, optimizerName)
@@ -59,7 +91,7 @@ func (e *Enforcer) EnforcePolicyOnDecisions(
 	}
 
 	// Scale-to-zero disabled: ensure minimum replicas
-	applied := e.ensureMinimumReplicasOnDecisions(ctx, modelID, namespace, decisions, optimizerName)
+	applied := e.ensureMinimumReplicasOnDecisions(ctx, modelID, namespace, decisions, states, optimizerName)
 	logger.V(logging.DEBUG).Info("Minimum replica policy enforced",
 		"modelID", modelID,
 		"optimizer", optimizerName,
@@ -118,12 +150,13 @@ func (e *Enforcer) applyScaleToZeroOnDecisions(
 
 // ensureMinimumReplicasOnDecisions ensures at least 1 replica exists across all
 // matching decisions when scale-to-zero is disabled. If total TargetReplicas is 0,
-// preserves 1 replica on the cheapest variant.
+// preserves 1 replica on the cheapest variant not pinned to zero by a schedule.
 func (e *Enforcer) ensureMinimumReplicasOnDecisions(
 	ctx context.Context,
 	modelID string,
 	namespace string,
 	decisions []interfaces.VariantDecision,
+	states map[string]interfaces.VariantReplicaState,
 	optimizerName string,
 ) bool {
 	logger := ctrl.LoggerFrom(ctx)
@@ -146,7 +179,7 @@ func (e *Enforcer) ensureMinimumReplicasOnDecisions(
 
 	for i := range decisions {
 		d := &decisions[i]
-		if d.ModelID != modelID || d.Namespace != namespace {
+		if d.ModelID != modelID || d.Namespace != namespace || scheduledToZero(states[d.VariantName]) {
 			continue
 		}
 		cost := d.Cost
@@ -185,3 +218,13 @@ func updateDecisionAction(d *interfaces.VariantDecision, optimizerName string) {
 	}
 	d.Reason = fmt.Sprintf("V2 %s (optimizer: %s, enforced)", d.Action, optimizerName)
 }
+
+// hasMinReplicasAboveZero returns true if any variant in the states has MinReplicas > 0.
+func hasMinReplicasAboveZero(states []interfaces.VariantReplicaState) bool {
+	for _, state := range states {
+		if state.MinReplicas != nil && *state.MinReplicas > 0 {
+			return true
+		}
+	}
+	return false
+}
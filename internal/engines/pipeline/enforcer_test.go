@@ -44,7 +44,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(true), RetentionPeriod: "10m"},
 					}
 
-					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(applied).To(BeTrue())
 					Expect(decisions[0].TargetReplicas).To(Equal(0))
@@ -66,7 +66,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(true), RetentionPeriod: "10m"},
 					}
 
-					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(applied).To(BeFalse())
 					Expect(decisions[0].TargetReplicas).To(Equal(3))
@@ -87,7 +87,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(true), RetentionPeriod: "10m"},
 					}
 
-					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(applied).To(BeFalse())
 					Expect(decisions[0].TargetReplicas).To(Equal(2))
@@ -110,7 +110,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(false)},
 					}
 
-					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(decisions[0].TargetReplicas).To(Equal(0)) // expensive
 					Expect(decisions[1].TargetReplicas).To(Equal(1)) // cheapest gets 1
@@ -131,7 +131,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(false)},
 					}
 
-					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(decisions[0].TargetReplicas).To(Equal(2))
 					Expect(decisions[0].Reason).To(Equal("original"))
@@ -152,7 +152,7 @@ var _ = Describe("Enforcer", func() {
 						"test-model": {EnableScaleToZero: boolPtr(false)},
 					}
 
-					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "cost-aware")
+					enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 					Expect(decisions[0].TargetReplicas).To(Equal(0)) // variant-z
 					Expect(decisions[1].TargetReplicas).To(Equal(1)) // variant-a (alphabetically first)
@@ -175,7 +175,7 @@ var _ = Describe("Enforcer", func() {
 					"model-1": {EnableScaleToZero: boolPtr(true), RetentionPeriod: "10m"},
 				}
 
-				applied := enforcer.EnforcePolicyOnDecisions(ctx, "model-1", "ns-1", decisions, scaleToZeroConfig, "cost-aware")
+				applied := enforcer.EnforcePolicyOnDecisions(ctx, "model-1", "ns-1", decisions, nil, scaleToZeroConfig, "cost-aware")
 
 				Expect(applied).To(BeTrue())
 				// model-1/ns-1 → scaled to zero
@@ -205,7 +205,7 @@ var _ = Describe("Enforcer", func() {
 					"test-model": {EnableScaleToZero: boolPtr(true), RetentionPeriod: "10m"},
 				}
 
-				enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, scaleToZeroConfig, "greedy-by-saturation")
+				enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, nil, scaleToZeroConfig, "greedy-by-saturation")
 
 				Expect(decisions[0].Reason).To(ContainSubstring("greedy-by-saturation"))
 				Expect(decisions[0].Reason).To(ContainSubstring("enforced"))
@@ -21,6 +21,8 @@ import (
 //   - Fair-shares GPUs across models (highest-score model gets GPUs first)
 //   - Distributes replicas between P/D roles proportional to per-role demand
 //   - Scale-down is identical to CostAwareOptimizer (reuses costAwareScaleDown)
+//   - Interrupted preemptible capacity is replaced like in CostAwareOptimizer, but
+//     the on-demand capacity floor is not applied (it could exceed GPU budgets)
 type GreedyByScoreOptimizer struct{}
 
 // NewGreedyByScoreOptimizer creates a new GreedyByScoreOptimizer.
@@ -59,6 +61,7 @@ func (o *GreedyByScoreOptimizer) Optimize(
 		if req.Result == nil {
 			continue
 		}
+		req = withInterruptedCapacity(ctx, req)
 
 		if req.Result.RequiredCapacity > 0 || req.Result.Score > 0 {
 			w := o.buildScaleUpWork(req)
@@ -313,6 +316,9 @@ func (o *GreedyByScoreOptimizer) allocateToVariants(
 		}
 
 		state := stateMap[vc.VariantName]
+		if isInterrupted(state) {
+			continue
+		}
 		gpusPerReplica := state.GPUsPerReplica
 		if gpusPerReplica <= 0 {
 			gpusPerReplica = 1
@@ -15,6 +15,9 @@ type ModelScalingRequest struct {
 	VariantStates []interfaces.VariantReplicaState
 	Priority      float64 // Model priority (default 1.0)
 	Disaggregated bool    // true when model has prefill+decode variants
+	// MinOnDemandFraction is the minimum fraction (0-1) of the model's capacity
+	// that must be provided by non-preemptible variants. 0 disables the floor.
+	MinOnDemandFraction float64
 }
 
 // ScalingOptimizer makes final scaling decisions for all models.
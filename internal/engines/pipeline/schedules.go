@@ -0,0 +1,134 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+// scheduleStepName is the decision step recorded for variants with active schedules.
+const scheduleStepName = "schedule"
+
+// ApplySchedules resolves the scaling schedules active now for each variant of a
+// model — the model's schedules from the scale-to-zero ConfigMap, overridden by
+// the variant's own VA schedules — and records them in state.Schedule. The
+// states' MinReplicas and MaxReplicas are replaced by the scheduled bounds so
+// that the optimizer already works within them; EnforcePolicyOnDecisions then
+// clamps the final decisions.
+//
+// variantRules maps variant names to their VA schedules. States are modified in place.
+func (e *Enforcer) ApplySchedules(
+	ctx context.Context,
+	modelID string,
+	states []interfaces.VariantReplicaState,
+	variantRules map[string][]schedule.Rule,
+	scaleToZeroConfig config.ScaleToZeroConfigData,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	now := e.now()
+
+	model := schedule.Resolve(config.ModelScheduleRules(scaleToZeroConfig, modelID), now)
+	for i := range states {
+		state := &states[i]
+		o := model.Merge(schedule.Resolve(variantRules[state.VariantName], now))
+		if len(o.Active) == 0 {
+			continue
+		}
+		// A scheduled maximum below the minimum wins, so a window that pins a
+		// variant to zero replicas is not undone by a broader minimum.
+		if o.MinReplicas != nil && o.MaxReplicas != nil && *o.MinReplicas > *o.MaxReplicas {
+			o.MinReplicas = o.MaxReplicas
+		}
+		state.Schedule = o
+		if o.MinReplicas != nil {
+			state.MinReplicas = o.MinReplicas
+		}
+		if o.MaxReplicas != nil {
+			state.MaxReplicas = o.MaxReplicas
+		}
+
+		logger.V(logging.DEBUG).Info("Scaling schedules active for variant",
+			"modelID", modelID,
+			"variant", state.VariantName,
+			"schedules", activeScheduleNames(o.Active),
+			"minReplicas", o.MinReplicas,
+			"maxReplicas", o.MaxReplicas,
+			"scaleToZero", o.ScaleToZero)
+	}
+}
+
+// applyScheduleBounds clamps the model's decisions to the replica bounds of the
+// variants' active schedules and records a schedule step on each of them.
+func applyScheduleBounds(
+	modelID string,
+	namespace string,
+	decisions []interfaces.VariantDecision,
+	states map[string]interfaces.VariantReplicaState,
+	optimizerName string,
+) {
+	for i := range decisions {
+		d := &decisions[i]
+		if d.ModelID != modelID || d.Namespace != namespace {
+			continue
+		}
+		state, ok := states[d.VariantName]
+		if !ok || len(state.Schedule.Active) == 0 {
+			continue
+		}
+		o := state.Schedule
+
+		target := d.TargetReplicas
+		if o.MinReplicas != nil {
+			d.MinReplicas = o.MinReplicas
+			target = max(target, *o.MinReplicas)
+		}
+		if o.MaxReplicas != nil {
+			d.MaxReplicas = o.MaxReplicas
+			target = min(target, *o.MaxReplicas)
+		}
+
+		constrained := target != d.TargetReplicas
+		reason := fmt.Sprintf("schedules %s active", activeScheduleNames(o.Active))
+		if constrained {
+			reason = fmt.Sprintf("%s: target %d -> %d", reason, d.TargetReplicas, target)
+			d.TargetReplicas = target
+			updateDecisionAction(d, optimizerName)
+		}
+		d.AddDecisionStep(scheduleStepName, reason, constrained)
+	}
+}
+
+// scheduledScaleToZero returns the scale-to-zero eligibility set by the
+// variants' active schedules. When they disagree, false wins.
+func scheduledScaleToZero(states map[string]interfaces.VariantReplicaState) (enabled bool, ok bool) {
+	for _, state := range states {
+		if state.Schedule.ScaleToZero == nil {
+			continue
+		}
+		if !*state.Schedule.ScaleToZero {
+			return false, true
+		}
+		enabled, ok = true, true
+	}
+	return enabled, ok
+}
+
+// scheduledToZero reports whether an active schedule pins the variant to zero replicas.
+func scheduledToZero(state interfaces.VariantReplicaState) bool {
+	return state.Schedule.MaxReplicas != nil && *state.Schedule.MaxReplicas == 0
+}
+
+func activeScheduleNames(active []schedule.ActiveRule) string {
+	names := make([]string, len(active))
+	for i, a := range active {
+		names[i] = a.Name
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,177 @@
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+var _ = Describe("Scaling schedules", func() {
+
+	var (
+		ctx      context.Context
+		enforcer *Enforcer
+	)
+
+	intPtr := func(n int) *int { return &n }
+
+	// Wednesday 2025-06-04 12:00 UTC: inside "0 8 * * *" windows of 10h.
+	now := time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC)
+
+	rule := func(name string, spec schedule.RuleSpec) schedule.Rule {
+		spec.Name = name
+		spec.Schedule = "0 8 * * *"
+		spec.Duration = 10 * time.Hour
+		r, err := schedule.NewRule(spec, schedule.SourceVariantAutoscaling)
+		Expect(err).NotTo(HaveOccurred())
+		return r
+	}
+
+	modelSchedule := func(s config.ScheduleConfig) config.ScaleToZeroConfigData {
+		s.Name = "model-window"
+		s.Schedule = "0 8 * * *"
+		s.Duration = "10h"
+		return config.ScaleToZeroConfigData{
+			"test-model": {Schedules: []config.ScheduleConfig{s}},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		enforcer = NewEnforcer(func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
+			return 0, nil
+		})
+		enforcer.now = func() time.Time { return now }
+	})
+
+	Describe("ApplySchedules", func() {
+
+		It("should replace state bounds with the active model schedule", func() {
+			states := []interfaces.VariantReplicaState{
+				{VariantName: "v1", MinReplicas: intPtr(1), MaxReplicas: intPtr(4)},
+			}
+
+			enforcer.ApplySchedules(ctx, "test-model", states, nil,
+				modelSchedule(config.ScheduleConfig{MinReplicas: intPtr(3), MaxReplicas: intPtr(10)}))
+
+			Expect(*states[0].MinReplicas).To(Equal(3))
+			Expect(*states[0].MaxReplicas).To(Equal(10))
+			Expect(states[0].Schedule.Active).To(HaveLen(1))
+			Expect(states[0].Schedule.Active[0].Source).To(Equal(schedule.SourceConfigMap))
+		})
+
+		It("should give VA schedules precedence over model schedules", func() {
+			states := []interfaces.VariantReplicaState{
+				{VariantName: "v1", MinReplicas: intPtr(1)},
+				{VariantName: "v2", MinReplicas: intPtr(1)},
+			}
+			variantRules := map[string][]schedule.Rule{
+				"v1": {rule("va-window", schedule.RuleSpec{MinReplicas: intPtr(0)})},
+			}
+
+			enforcer.ApplySchedules(ctx, "test-model", states, variantRules,
+				modelSchedule(config.ScheduleConfig{MinReplicas: intPtr(3)}))
+
+			Expect(*states[0].MinReplicas).To(Equal(0))
+			Expect(states[0].Schedule.Active).To(HaveLen(2))
+			Expect(*states[1].MinReplicas).To(Equal(3))
+		})
+
+		It("should leave states untouched outside the window", func() {
+			enforcer.now = func() time.Time { return now.Add(8 * time.Hour) }
+			states := []interfaces.VariantReplicaState{
+				{VariantName: "v1", MinReplicas: intPtr(1)},
+			}
+
+			enforcer.ApplySchedules(ctx, "test-model", states, nil,
+				modelSchedule(config.ScheduleConfig{MinReplicas: intPtr(3)}))
+
+			Expect(*states[0].MinReplicas).To(Equal(1))
+			Expect(states[0].Schedule.Active).To(BeEmpty())
+		})
+	})
+
+	Describe("EnforcePolicyOnDecisions", func() {
+
+		It("should clamp decisions to the scheduled minimum and record a schedule step", func() {
+			states := []interfaces.VariantReplicaState{{VariantName: "v1", MinReplicas: intPtr(1)}}
+			cfg := modelSchedule(config.ScheduleConfig{MinReplicas: intPtr(3)})
+			enforcer.ApplySchedules(ctx, "test-model", states, nil, cfg)
+			decisions := []interfaces.VariantDecision{
+				{VariantName: "v1", ModelID: "test-model", Namespace: "test-ns", CurrentReplicas: 1, TargetReplicas: 1},
+			}
+
+			applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, states, cfg, "cost-aware")
+
+			Expect(applied).To(BeFalse())
+			Expect(decisions[0].TargetReplicas).To(Equal(3))
+			Expect(decisions[0].Action).To(Equal(interfaces.ActionScaleUp))
+			Expect(*decisions[0].MinReplicas).To(Equal(3))
+			step := decisions[0].LastStep()
+			Expect(step).NotTo(BeNil())
+			Expect(step.Name).To(Equal("schedule"))
+			Expect(step.WasConstrained).To(BeTrue())
+			Expect(step.Reason).To(ContainSubstring("model-window"))
+		})
+
+		It("should pin a variant to zero and keep the minimum replica on another variant", func() {
+			states := []interfaces.VariantReplicaState{
+				{VariantName: "cheap", MinReplicas: intPtr(0)},
+				{VariantName: "expensive", MinReplicas: intPtr(0)},
+			}
+			variantRules := map[string][]schedule.Rule{
+				"cheap": {rule("maintenance", schedule.RuleSpec{MaxReplicas: intPtr(0)})},
+			}
+			cfg := config.ScaleToZeroConfigData{}
+			enforcer.ApplySchedules(ctx, "test-model", states, variantRules, cfg)
+			decisions := []interfaces.VariantDecision{
+				{VariantName: "cheap", ModelID: "test-model", Namespace: "test-ns", Cost: 1.0, CurrentReplicas: 2, TargetReplicas: 2},
+				{VariantName: "expensive", ModelID: "test-model", Namespace: "test-ns", Cost: 5.0, CurrentReplicas: 0, TargetReplicas: 0},
+			}
+
+			enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, states, cfg, "cost-aware")
+
+			Expect(decisions[0].TargetReplicas).To(Equal(0))
+			Expect(decisions[1].TargetReplicas).To(Equal(1))
+		})
+
+		It("should let an active schedule enable scale-to-zero", func() {
+			states := []interfaces.VariantReplicaState{{VariantName: "v1", MinReplicas: intPtr(0)}}
+			cfg := modelSchedule(config.ScheduleConfig{EnableScaleToZero: boolPtr(true)})
+			enforcer.ApplySchedules(ctx, "test-model", states, nil, cfg)
+			decisions := []interfaces.VariantDecision{
+				{VariantName: "v1", ModelID: "test-model", Namespace: "test-ns", CurrentReplicas: 1, TargetReplicas: 1},
+			}
+
+			applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, states, cfg, "cost-aware")
+
+			Expect(applied).To(BeTrue())
+			Expect(decisions[0].TargetReplicas).To(Equal(0))
+		})
+
+		It("should let an active schedule disable scale-to-zero", func() {
+			states := []interfaces.VariantReplicaState{{VariantName: "v1", MinReplicas: intPtr(0)}}
+			variantRules := map[string][]schedule.Rule{
+				"v1": {rule("keep-warm", schedule.RuleSpec{ScaleToZero: boolPtr(false)})},
+			}
+			cfg := config.ScaleToZeroConfigData{
+				"test-model": {EnableScaleToZero: boolPtr(true)},
+			}
+			enforcer.ApplySchedules(ctx, "test-model", states, variantRules, cfg)
+			decisions := []interfaces.VariantDecision{
+				{VariantName: "v1", ModelID: "test-model", Namespace: "test-ns", CurrentReplicas: 1, TargetReplicas: 0},
+			}
+
+			applied := enforcer.EnforcePolicyOnDecisions(ctx, "test-model", "test-ns", decisions, states, cfg, "cost-aware")
+
+			Expect(applied).To(BeFalse())
+			Expect(decisions[0].TargetReplicas).To(Equal(1))
+		})
+	})
+})
@@ -4,67 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
 	"sync"
 
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 )
 
-// normalizeAcceleratorName converts a full GPU model name to a short name.
-// This enables matching between VA labels (e.g., "A100") and discovery results
-// (e.g., "NVIDIA-A100-PCIE-80GB").
-//
-// Examples:
-//   - "NVIDIA-A100-PCIE-80GB" -> "A100"
-//   - "NVIDIA-H100-SXM5-80GB" -> "H100"
-//   - "AMD-MI300X-192G" -> "MI300X"
-//   - "Intel-Gaudi-2-96GB" -> "Gaudi-2"
-//   - "A100" -> "A100" (already short)
-func normalizeAcceleratorName(fullName string) string {
-	// If already a short name (no hyphens or known pattern), return as-is
-	if !strings.Contains(fullName, "-") {
-		return fullName
-	}
-
-	// Common patterns for GPU model names:
-	// NVIDIA-{model}-{variant} -> extract {model}
-	// AMD-{model}-{memory} -> extract {model}
-	// Intel-{model}-{memory} -> extract {model}
-
-	parts := strings.Split(fullName, "-")
-	if len(parts) < 2 {
-		return fullName
-	}
-
-	// Check for known vendor prefixes
-	vendor := strings.ToUpper(parts[0])
-	switch vendor {
-	case "NVIDIA":
-		// NVIDIA-A100-PCIE-80GB -> A100
-		// NVIDIA-H100-SXM5-80GB -> H100
-		if len(parts) >= 2 {
-			return parts[1]
-		}
-	case "AMD":
-		// AMD-MI300X-192G -> MI300X
-		if len(parts) >= 2 {
-			return parts[1]
-		}
-	case "INTEL":
-		// Intel-Gaudi-2-96GB -> Gaudi-2
-		if len(parts) >= 3 {
-			return parts[1] + "-" + parts[2]
-		}
-		if len(parts) >= 2 {
-			return parts[1]
-		}
-	}
-
-	// Fallback: return the second part (after vendor)
-	return parts[1]
-}
-
 // TypeInventory tracks GPU capacity, usage, and availability per accelerator type (H100, A100, etc.).
 //
 // Unlike ClusterInventory which maintains a single pool of all GPUs, TypeInventory
@@ -185,7 +131,7 @@ func (i *TypeInventory) Refresh(ctx context.Context) error {
 	for _, accelerators := range nodeInventory {
 		for fullModelName, info := range accelerators {
 			// Normalize "NVIDIA-A100-PCIE-80GB" -> "A100"
-			shortName := normalizeAcceleratorName(fullModelName)
+			shortName := config.NormalizeAcceleratorName(fullModelName)
 			byType[shortName] += info.Count
 			total += info.Count
 		}
@@ -18,10 +18,13 @@ package saturation
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strconv"
+	"slices"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
@@ -38,12 +41,18 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
 	queueingmodel "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
 	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/coldstart"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/decisionrecord"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/executor"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/servingconfig"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/federation"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
 )
@@ -75,6 +84,13 @@ type Engine struct {
 	Recorder record.EventRecorder
 	Config   *config.Config // Unified configuration (injected from main.go)
 
+	// decisionEvents emits rate-limited Kubernetes Events for scaling decisions.
+	decisionEvents *common.DecisionEvents
+
+	// decisionRecorder records the inputs, analyzer results, optimizer
+	// requests and decisions of every cycle. Nil when recording is disabled.
+	decisionRecorder *decisionrecord.Recorder
+
 	// ReplicaMetricsCollector is the collector for replica metrics using the source infrastructure
 	ReplicaMetricsCollector *collector.ReplicaMetricsCollector
 
@@ -85,6 +101,10 @@ type Engine struct {
 	// Only applied when EnableLimiter is true in the saturation config.
 	GPULimiter pipeline.Limiter
 
+	// Federation divides the scaling of models served by several clusters
+	// among them. Nil when federation is disabled.
+	Federation *federation.Federation
+
 	// metricsRegistry is used to access metrics sources for request count queries
 	metricsRegistry *source.SourceRegistry
 
@@ -102,6 +122,36 @@ type Engine struct {
 	// AnalyzerResults. Selected per-cycle based on enableLimiter config:
 	// CostAwareOptimizer (unlimited) or GreedyByScoreOptimizer (limited).
 	optimizer pipeline.ScalingOptimizer
+
+	// startupTracker learns per-variant replica startup latency from pod
+	// status transitions. Used for cold-start-aware scale-up.
+	startupTracker *coldstart.StartupLatencyTracker
+
+	// demandTrend tracks model-level demand over a sliding window to detect
+	// upward trends for cold-start-aware scale-up.
+	demandTrend *coldstart.DemandTrend
+
+	// servingConfigs fingerprints the serving configuration of each variant
+	// to reset knowledge learned for a previous configuration.
+	servingConfigs *servingconfig.Tracker
+
+	// now returns the time of the current cycle. It is the recorded cycle time
+	// during replay.
+	now func() time.Time
+
+	// cycleResults collects the analyzer results of the running cycle, and
+	// debugState is the state published at the end of the last cycle.
+	cycleResults map[string]*interfaces.AnalyzerResult
+	debugMu      sync.RWMutex
+	debugState   DebugState
+
+	// cycleReplicaMetrics collects the replica metrics of the running cycle,
+	// keyed by variant and pod name, for graceful scale-down.
+	cycleReplicaMetrics map[string]map[string]interfaces.ReplicaMetrics
+
+	// cycleVariantPods collects the pods listed in the running cycle, keyed
+	// by namespace/VA name, for graceful scale-down.
+	cycleVariantPods map[string]variantPods
 }
 
 // NewEngine creates a new instance of the saturation engine.
@@ -113,9 +163,20 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 	}
 	promSource := metricsRegistry.Get("prometheus") // assume prometheus source is registered
 
+	// Recording is a debugging aid: a misconfigured sink disables it rather
+	// than the engine.
+	decisionRecorder, err := newDecisionRecorder(client, cfg.DecisionRecorder())
+	if err != nil {
+		ctrl.Log.WithName("saturation-engine").Error(err, "Decision recording disabled")
+	}
+
 	// Create request count function wrapper for scale-to-zero enforcer
 	requestCountFunc := func(ctx context.Context, modelID, namespace string, retentionPeriod time.Duration) (float64, error) {
-		return registration.CollectModelRequestCount(ctx, promSource, modelID, namespace, retentionPeriod)
+		count, err := registration.CollectModelRequestCount(ctx, promSource, modelID, namespace, retentionPeriod)
+		if err == nil {
+			decisionRecorder.RecordRequestCount(modelID, namespace, count)
+		}
+		return count, err
 	}
 
 	// Create GPU limiter with TypeInventory and GreedyBySaturation algorithm
@@ -131,12 +192,19 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 	// from ConfigMap), since config arrives after engine init.
 	var scalingOptimizer pipeline.ScalingOptimizer = pipeline.NewCostAwareOptimizer()
 
+	freshness := config.DefaultFreshnessThresholds()
+	if cacheConfig := cfg.PrometheusCacheConfig(); cacheConfig != nil {
+		freshness = cacheConfig.FreshnessThresholds
+	}
+
 	engine := Engine{
 		client:                  client,
 		scheme:                  scheme,
 		Recorder:                recorder,
 		Config:                  cfg,
-		ReplicaMetricsCollector: collector.NewReplicaMetricsCollector(promSource, client),
+		decisionEvents:          common.NewDecisionEvents(recorder),
+		decisionRecorder:        decisionRecorder,
+		ReplicaMetricsCollector: collector.NewReplicaMetricsCollector(promSource, client, freshness),
 		ScaleToZeroEnforcer:     pipeline.NewEnforcer(requestCountFunc),
 		GPULimiter:              gpuLimiter,
 		metricsRegistry:         metricsRegistry,
@@ -144,6 +212,10 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 		queueingModelAnalyzer:   queueingmodel.NewQueueingModelAnalyzer(),
 		capacityStore:           capacityStore,
 		optimizer:               scalingOptimizer,
+		startupTracker:          coldstart.NewStartupLatencyTracker(),
+		demandTrend:             coldstart.NewDemandTrend(coldstart.DefaultTrendWindow),
+		servingConfigs:          servingconfig.NewTracker(),
+		now:                     time.Now,
 	}
 
 	engine.executor = executor.NewPollingExecutor(executor.PollingConfig{
@@ -152,6 +224,8 @@ func NewEngine(client client.Client, scheme *runtime.Scheme, recorder record.Eve
 		},
 		Interval:     30 * time.Second,
 		RetryBackoff: 100 * time.Millisecond,
+		// Node interruptions request an immediate cycle.
+		Trigger: common.OptimizationTrigger,
 	})
 
 	// Register saturation queries in the metrics registry.
@@ -180,7 +254,9 @@ func (e *Engine) StartOptimizeLoop(ctx context.Context) {
 }
 
 // optimize performs the optimization logic.
-func (e *Engine) optimize(ctx context.Context) error {
+func (e *Engine) optimize(ctx context.Context) (err error) {
+	ctx, span := tracing.Start(ctx, "Engine.optimize")
+	defer func() { tracing.End(span, err) }()
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Get optimization interval from Config (already a time.Duration)
@@ -208,6 +284,7 @@ func (e *Engine) optimize(ctx context.Context) error {
 
 	if len(activeVAs) == 0 {
 		logger.Info("No active VariantAutoscalings found, skipping optimization")
+		metrics.NewMetricsEmitter().RetainModels(nil)
 		return nil
 	}
 
@@ -229,6 +306,15 @@ func (e *Engine) optimize(ctx context.Context) error {
 		"modelCount", len(modelGroups),
 		"totalVAs", len(activeVAs))
 
+	models := cycleModels(modelGroups)
+
+	// Drop cold-start history for models and variants that are gone
+	e.startupTracker.EvictStale(time.Now(), startupHistoryTimeout)
+	e.servingConfigs.EvictStale(e.now(), servingConfigHistoryTimeout)
+
+	// Drop the analyzer metrics of models that are gone
+	metrics.NewMetricsEmitter().RetainModels(modelKeys(models))
+
 	// Create VA lookup map for applySaturationDecisions (used to access VA status and update decisions)
 	// Use namespace/vaName as key to avoid collisions when multiple namespaces have same VA name
 	// Use slice index directly to avoid pointer-to-loop-variable bug
@@ -241,7 +327,54 @@ func (e *Engine) optimize(ctx context.Context) error {
 	// Keyed by VariantAutoscaling Namespace/Name
 	currentAllocations := make(map[string]*interfaces.Allocation)
 
-	// Determine which analyzer to use.
+	var analyzerName string
+	cycleStart := time.Now()
+	defer func() { emitCycleDuration(ctx, analyzerName, time.Since(cycleStart)) }()
+	span.SetAttributes(attribute.Int("wva.models", len(modelGroups)))
+
+	e.decisionRecorder.BeginCycle(e.now())
+	analyzerName, allDecisions := e.decideCycle(ctx, models, &clusterSource{engine: e, currentAllocations: currentAllocations}, vaMap)
+
+	span.SetAttributes(tracing.AnalyzerKey.String(analyzerName), attribute.Int("wva.decisions", len(allDecisions)))
+
+	e.drainScaleDowns(ctx, allDecisions, vaMap)
+
+	if err := e.decisionRecorder.EndCycle(ctx, analyzerName, allDecisions); err != nil {
+		logger.Error(err, "Failed to record optimization cycle")
+	}
+	e.publishDebugState(analyzerName, modelKeys(models))
+
+	// STEP 3: Apply decisions and update VA status
+	// Always call applySaturationDecisions, even with empty decisions.
+	// This function also updates VA.Status.CurrentAlloc with collected metrics
+	// and emits HPA metrics, which must happen every reconciliation cycle.
+	if len(allDecisions) > 0 {
+		logger.Info("Applying scaling decisions",
+			"totalDecisions", len(allDecisions))
+	} else {
+		logger.Info("No scaling decisions to apply, updating VA status with metrics")
+	}
+	applyCtx, applySpan := tracing.Start(ctx, "Engine.applySaturationDecisions")
+	err = e.applySaturationDecisions(applyCtx, allDecisions, vaMap, currentAllocations, analyzerName)
+	tracing.End(applySpan, err)
+	if err != nil {
+		logger.Error(err, "Failed to apply saturation decisions")
+		return err
+	}
+
+	logger.Info("Optimization completed successfully",
+		"mode", "saturation-only",
+		"modelsProcessed", len(modelGroups),
+		"decisionsApplied", len(allDecisions))
+
+	return nil
+}
+
+// selectAnalyzer determines which analyzer path to run this cycle and selects
+// the optimizer for the V2 and queueing model paths. An empty name selects V1.
+func (e *Engine) selectAnalyzer(ctx context.Context) string {
+	logger := ctrl.LoggerFrom(ctx)
+
 	// Priority: queueing model ConfigMap (presence-based) > saturation config analyzerName.
 	// If wva-queueing-model-config exists with a "default" entry, the queueing model
 	// analyzer is active regardless of the saturation config's analyzerName field.
@@ -274,65 +407,21 @@ func (e *Engine) optimize(ctx context.Context) error {
 		logger.V(logging.DEBUG).Info("Optimizer selected", "analyzer", analyzerName, "optimizer", e.optimizer.Name(), "enableLimiter", enableLimiter)
 	}
 
-	var allDecisions []interfaces.VariantDecision
-
-	// Each analyzer has a separate optimize path because they use fundamentally
-	// different analysis types and target-building flows:
-	//   - V1: saturation.Analyzer → ModelSaturationAnalysis → CalculateSaturationTargets → Enforcer → Limiter
-	//   - V2 (saturation): saturation_v2.Analyzer → AnalyzerResult → Optimizer.Optimize → Enforcer bridge
-	//   - Queueing model: QueueingModelAnalyzer → AnalyzerResult → Optimizer.Optimize → Enforcer bridge
-	// V1 will be deprecated once V2 is fully validated.
-	// Queueing model is activated by presence of wva-queueing-model-config ConfigMap.
-	switch analyzerName {
-	case interfaces.QueueingModelAnalyzerName:
-		allDecisions = e.optimizeQueueingModel(ctx, modelGroups, currentAllocations)
-	case interfaces.SaturationAnalyzerName:
-		allDecisions = e.optimizeV2(ctx, modelGroups, currentAllocations)
-	default:
-		allDecisions = e.optimizeV1(ctx, modelGroups, currentAllocations)
-	}
-
-	// STEP 3: Apply decisions and update VA status
-	// Always call applySaturationDecisions, even with empty decisions.
-	// This function also updates VA.Status.CurrentAlloc with collected metrics
-	// and emits HPA metrics, which must happen every reconciliation cycle.
-	if len(allDecisions) > 0 {
-		logger.Info("Applying scaling decisions",
-			"totalDecisions", len(allDecisions))
-	} else {
-		logger.Info("No scaling decisions to apply, updating VA status with metrics")
-	}
-	if err := e.applySaturationDecisions(ctx, allDecisions, vaMap, currentAllocations); err != nil {
-		logger.Error(err, "Failed to apply saturation decisions")
-		return err
-	}
-
-	logger.Info("Optimization completed successfully",
-		"mode", "saturation-only",
-		"modelsProcessed", len(modelGroups),
-		"decisionsApplied", len(allDecisions))
-
-	return nil
+	return analyzerName
 }
 
 // optimizeV1 runs the V1 percentage-based saturation analysis path (saturation-percentage-based).
 // Processes each model independently: analyze → enforce → convert → limiter.
-func (e *Engine) optimizeV1(
-	ctx context.Context,
-	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
-	currentAllocations map[string]*interfaces.Allocation,
-) []interfaces.VariantDecision {
+func (e *Engine) optimizeV1(ctx context.Context, models []cycleModel, src cycleSource) []interfaces.VariantDecision {
 	logger := ctrl.LoggerFrom(ctx)
 	var allDecisions []interfaces.VariantDecision
 
-	for groupKey, modelVAs := range modelGroups {
-		modelID := modelVAs[0].Spec.ModelID
-		namespace := modelVAs[0].Namespace
+	for _, model := range models {
+		modelID, namespace := model.modelID, model.namespace
 		logger.Info("Processing model (V1)",
 			"modelID", modelID,
 			"namespace", namespace,
-			"variantCount", len(modelVAs),
-			"groupKey", groupKey)
+			"variantCount", len(model.vas))
 
 		// Get namespace-aware saturation config (namespace-local > global)
 		saturationConfigMap := e.Config.SaturationConfigForNamespace(namespace)
@@ -340,54 +429,81 @@ func (e *Engine) optimizeV1(
 			logger.Info("Saturation scaling config not loaded yet for namespace, skipping model",
 				"namespace", namespace,
 				"modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipConfigMissing)
 			continue
 		}
-
 		saturationConfig := resolveSaturationConfig(saturationConfigMap, modelID, namespace)
-		saturationTargets, saturationAnalysis, data, err := e.RunSaturationAnalysis(ctx, modelID, modelVAs, saturationConfig, e.client)
+
+		data, err := src.prepare(ctx, model)
+		if errors.Is(err, errStaleMetrics) {
+			logger.Info("Skipping model: metrics of all replicas are stale", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipStaleMetrics)
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "Model data preparation failed", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipCollectionFailed)
+			src.failed(ctx, model, nil)
+			continue
+		}
+		if data == nil {
+			logger.V(logging.DEBUG).Info("Skipping model: no metrics available", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipNoMetrics)
+			continue
+		}
+
+		saturationTargets, saturationAnalysis, err := e.analyzeV1(ctx, data, saturationConfig)
 		if err != nil {
 			logger.Error(err, "Saturation analysis failed", "modelID", modelID)
-			if data == nil {
-				e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, nil)
-			} else {
-				e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, data.scaleTargets)
-			}
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipAnalysisFailed)
+			src.failed(ctx, model, data)
 			continue
 		}
+		allDecisions = append(allDecisions, e.decideV1Model(ctx, data, saturationTargets, saturationAnalysis)...)
+	}
 
-		var finalDecisions []interfaces.VariantDecision
-		if saturationAnalysis != nil && data != nil {
-			// Convert saturation targets to decisions first, then apply enforcer
-			finalDecisions = e.convertSaturationTargetsToDecisions(ctx, saturationTargets, saturationAnalysis, data.variantStates)
-
-			// Check if any variant has minReplicas > 0 — if so, skip scale-to-zero enforcement
-			if !hasMinReplicasAboveZero(data.variantStates) {
-				// Apply scale-to-zero enforcement on decisions
-				scaleToZeroConfig := e.Config.ScaleToZeroConfigForNamespace(namespace)
-				scaledToZero := e.ScaleToZeroEnforcer.EnforcePolicyOnDecisions(
-					ctx, modelID, namespace,
-					finalDecisions, scaleToZeroConfig, "v1-saturation",
-				)
-				if scaledToZero {
-					logger.Info("Scale-to-zero enforcement applied",
-						"modelID", modelID)
-				}
-			} else {
-				logger.V(logging.DEBUG).Info("Skipping scale-to-zero enforcement: variant has minReplicas > 0",
-					"modelID", modelID)
-			}
+	e.limitV1Decisions(ctx, allDecisions)
 
-			logger.Info("Saturation-only decisions made for model",
-				"modelID", modelID,
-				"decisionCount", len(finalDecisions))
-			allDecisions = append(allDecisions, finalDecisions...)
-		} else {
-			logger.V(logging.DEBUG).Info("Skipping decision application for model: saturation analysis is nil (likely no metrics)",
-				"modelID", modelID)
-		}
+	return allDecisions
+}
+
+// decideV1Model converts a model's V1 saturation targets to decisions and
+// applies schedule and scale-to-zero enforcement on them.
+func (e *Engine) decideV1Model(
+	ctx context.Context,
+	data *modelData,
+	saturationTargets map[string]int,
+	saturationAnalysis *interfaces.ModelSaturationAnalysis,
+) []interfaces.VariantDecision {
+	logger := ctrl.LoggerFrom(ctx)
+
+	// Convert saturation targets to decisions first, then apply enforcer
+	decisions := e.convertSaturationTargetsToDecisions(ctx, saturationTargets, saturationAnalysis, data.variantStates)
+
+	// Apply schedule and scale-to-zero enforcement on decisions
+	scaleToZeroConfig := e.Config.ScaleToZeroConfigForNamespace(data.namespace)
+	enforceCtx, span := tracing.Start(ctx, "Enforcer.EnforcePolicyOnDecisions", tracing.Model(data.namespace, data.modelID)...)
+	scaledToZero := e.ScaleToZeroEnforcer.EnforcePolicyOnDecisions(
+		enforceCtx, data.modelID, data.namespace,
+		decisions, data.variantStates, scaleToZeroConfig, "v1-saturation",
+	)
+	tracing.End(span, nil)
+	if scaledToZero {
+		logger.Info("Scale-to-zero enforcement applied",
+			"modelID", data.modelID)
 	}
 
-	// Apply GPU limiter if enabled
+	logger.Info("Saturation-only decisions made for model",
+		"modelID", data.modelID,
+		"decisionCount", len(decisions))
+	return decisions
+}
+
+// limitV1Decisions applies the GPU limiter to the V1 decisions of all models
+// when it is enabled in the global saturation config.
+func (e *Engine) limitV1Decisions(ctx context.Context, allDecisions []interfaces.VariantDecision) {
+	logger := ctrl.LoggerFrom(ctx)
+
 	// Note: Limiter uses global saturation config since it's applied globally to all decisions
 	globalSaturationConfigMap := e.Config.SaturationConfig()
 	var globalSaturationConfig config.SaturationScalingConfig
@@ -396,127 +512,161 @@ func (e *Engine) optimizeV1(
 			globalSaturationConfig = cfg
 		}
 	}
-	if globalSaturationConfig.EnableLimiter && len(allDecisions) > 0 {
-		logger.Info("Applying GPU limiter to scaling decisions",
-			"decisionCount", len(allDecisions))
+	if !globalSaturationConfig.EnableLimiter || len(allDecisions) == 0 {
+		return
+	}
 
-		decisionPtrs := make([]*interfaces.VariantDecision, len(allDecisions))
-		for i := range allDecisions {
-			decisionPtrs[i] = &allDecisions[i]
-		}
+	logger.Info("Applying GPU limiter to scaling decisions",
+		"decisionCount", len(allDecisions))
 
-		if err := e.GPULimiter.Limit(ctx, decisionPtrs); err != nil {
-			logger.Error(err, "GPU limiter failed, proceeding with original decisions")
-		} else {
-			for _, d := range decisionPtrs {
-				if d.WasLimited {
-					logger.Info("Decision was limited by GPU availability",
-						"variant", d.VariantName,
-						"originalTarget", d.OriginalTargetReplicas,
-						"limitedTarget", d.TargetReplicas,
-						"limitedBy", d.LimitedBy)
-				}
-			}
-		}
+	decisionPtrs := make([]*interfaces.VariantDecision, len(allDecisions))
+	for i := range allDecisions {
+		decisionPtrs[i] = &allDecisions[i]
 	}
 
-	return allDecisions
+	limitCtx, span := tracing.Start(ctx, "Limiter.Limit", attribute.Int("wva.decisions", len(decisionPtrs)))
+	err := e.GPULimiter.Limit(limitCtx, decisionPtrs)
+	tracing.End(span, err)
+	if err != nil {
+		logger.Error(err, "GPU limiter failed, proceeding with original decisions")
+		return
+	}
+	for _, d := range decisionPtrs {
+		if d.WasLimited {
+			logger.Info("Decision was limited by GPU availability",
+				"variant", d.VariantName,
+				"originalTarget", d.OriginalTargetReplicas,
+				"limitedTarget", d.TargetReplicas,
+				"limitedBy", d.LimitedBy)
+		}
+	}
 }
 
 // optimizeV2 runs the V2 token-based optimizer path (saturation-token-based).
 // Collects AnalyzerResults for all models, calls the optimizer once, then applies enforcer per-model.
-func (e *Engine) optimizeV2(
-	ctx context.Context,
-	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
-	currentAllocations map[string]*interfaces.Allocation,
-) []interfaces.VariantDecision {
+func (e *Engine) optimizeV2(ctx context.Context, models []cycleModel, src cycleSource) []interfaces.VariantDecision {
 	logger := ctrl.LoggerFrom(ctx)
 
+	// The queueing model analyzer may run for comparison
+	e.updateQueueingModels(models)
+
 	// Stage 1: Collect ModelScalingRequests for all models
 	var requests []pipeline.ModelScalingRequest
+	cmp := newAnalyzerComparison(interfaces.SaturationAnalyzerName)
 
-	for groupKey, modelVAs := range modelGroups {
-		modelID := modelVAs[0].Spec.ModelID
-		namespace := modelVAs[0].Namespace
+	for _, model := range models {
+		modelID, namespace := model.modelID, model.namespace
 		logger.Info("Processing model (V2)",
 			"modelID", modelID,
 			"namespace", namespace,
-			"variantCount", len(modelVAs),
-			"groupKey", groupKey)
+			"variantCount", len(model.vas))
 
 		// Get namespace-aware saturation config
 		saturationConfigMap := e.Config.SaturationConfigForNamespace(namespace)
 		if len(saturationConfigMap) == 0 {
 			logger.Info("Saturation scaling config not loaded yet for namespace, skipping model",
 				"namespace", namespace, "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipConfigMissing)
 			continue
 		}
 		saturationConfig := resolveSaturationConfig(saturationConfigMap, modelID, namespace)
 
-		data, err := e.prepareModelData(ctx, modelID, modelVAs, e.client)
+		data, err := src.prepare(ctx, model)
+		if errors.Is(err, errStaleMetrics) {
+			logger.Info("Skipping model: metrics of all replicas are stale", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipStaleMetrics)
+			continue
+		}
 		if err != nil {
 			logger.Error(err, "Model data preparation failed", "modelID", modelID)
-			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, nil)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipCollectionFailed)
+			src.failed(ctx, model, nil)
 			continue
 		}
 		if data == nil {
 			logger.V(logging.DEBUG).Info("Skipping model: no metrics available", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipNoMetrics)
 			continue
 		}
 
-		req, err := e.collectV2ModelRequest(ctx, modelID, namespace,
-			data.replicaMetrics, saturationConfig, data.variantStates,
-			data.scaleTargets, data.variantAutoscalings)
+		req, err := e.collectV2ModelRequest(ctx, data, saturationConfig)
 		if err != nil {
 			logger.Error(err, "V2 analysis failed", "modelID", modelID)
-			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, data.scaleTargets)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipAnalysisFailed)
+			src.failed(ctx, model, data)
 			continue
 		}
 
 		requests = append(requests, *req)
+		e.compareModel(ctx, cmp, data, saturationConfig, req)
 	}
 
 	if len(requests) == 0 {
 		return nil
 	}
+	e.emitComparison(ctx, cmp)
 
-	// Stage 2: Compute GPU constraints and call optimizer
-	var constraints []*pipeline.ResourceConstraints
-	if _, ok := e.optimizer.(*pipeline.GreedyByScoreOptimizer); ok {
-		currentUsage := computeCurrentGPUUsage(requests)
-		if limiter, ok := e.GPULimiter.(*pipeline.DefaultLimiter); ok {
-			constraint, err := limiter.ComputeConstraints(ctx, currentUsage)
-			if err != nil {
-				logger.Error(err, "Failed to compute GPU constraints, falling back to unlimited")
-			} else {
-				constraints = append(constraints, constraint)
-			}
-		}
+	// Stages 2 and 3: Compute GPU constraints and call optimizer, then apply enforcer per-model
+	return e.optimizeRequests(ctx, requests, e.gpuConstraints(ctx, requests), interfaces.SaturationAnalyzerName)
+}
+
+// gpuConstraints returns the GPU constraints for the optimizer, or nil when the
+// selected optimizer does not take constraints or they cannot be computed.
+func (e *Engine) gpuConstraints(ctx context.Context, requests []pipeline.ModelScalingRequest) []*pipeline.ResourceConstraints {
+	if _, ok := e.optimizer.(*pipeline.GreedyByScoreOptimizer); !ok {
+		return nil
+	}
+	limiter, ok := e.GPULimiter.(*pipeline.DefaultLimiter)
+	if !ok {
+		return nil
+	}
+	ctx, span := tracing.Start(ctx, "Limiter.ComputeConstraints")
+	constraint, err := limiter.ComputeConstraints(ctx, computeCurrentGPUUsage(requests))
+	tracing.End(span, err)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to compute GPU constraints, falling back to unlimited")
+		return nil
 	}
-	allDecisions := e.optimizer.Optimize(ctx, requests, constraints)
+	return []*pipeline.ResourceConstraints{constraint}
+}
+
+// optimizeRequests calls the optimizer once for the requests of all models, then
+// applies the enforcer per model on the resulting decisions. Shared by the V2
+// and queueing model paths. With federation, the requests are first adjusted to
+// the share of the local cluster.
+func (e *Engine) optimizeRequests(
+	ctx context.Context,
+	requests []pipeline.ModelScalingRequest,
+	constraints []*pipeline.ResourceConstraints,
+	analyzerName string,
+) []interfaces.VariantDecision {
+	logger := ctrl.LoggerFrom(ctx)
+
+	requests = e.federate(ctx, requests, constraints, analyzerName)
+	e.decisionRecorder.RecordRequests(requests)
+	optimizeCtx, span := tracing.Start(ctx, "Optimizer.Optimize",
+		attribute.String("wva.optimizer", e.optimizer.Name()), attribute.Int("wva.models", len(requests)))
+	allDecisions := e.optimizer.Optimize(optimizeCtx, requests, constraints)
+	tracing.End(span, nil)
 
-	logger.Info("V2 optimizer produced decisions",
+	logger.Info("Optimizer produced decisions",
+		"analyzer", analyzerName,
 		"optimizer", e.optimizer.Name(),
 		"decisionCount", len(allDecisions),
 		"modelCount", len(requests))
 
-	// Stage 3: Apply enforcer per-model (directly on decisions)
 	for _, req := range requests {
-		// Skip scale-to-zero enforcement if any variant has minReplicas > 0
-		if hasMinReplicasAboveZero(req.VariantStates) {
-			logger.V(logging.DEBUG).Info("Skipping scale-to-zero enforcement (V2): variant has minReplicas > 0",
-				"modelID", req.ModelID)
-			continue
-		}
-
 		scaleToZeroConfig := e.Config.ScaleToZeroConfigForNamespace(req.Namespace)
 
+		enforceCtx, span := tracing.Start(ctx, "Enforcer.EnforcePolicyOnDecisions", tracing.Model(req.Namespace, req.ModelID)...)
 		scaledToZero := e.ScaleToZeroEnforcer.EnforcePolicyOnDecisions(
-			ctx, req.ModelID, req.Namespace,
-			allDecisions, scaleToZeroConfig, e.optimizer.Name(),
+			enforceCtx, req.ModelID, req.Namespace,
+			allDecisions, req.VariantStates, scaleToZeroConfig, e.optimizer.Name(),
 		)
+		tracing.End(span, nil)
 		if scaledToZero {
-			logger.Info("Scale-to-zero enforcement applied (V2)",
+			logger.Info("Scale-to-zero enforcement applied",
+				"analyzer", analyzerName,
 				"modelID", req.ModelID)
 		}
 	}
@@ -608,6 +758,7 @@ func (e *Engine) BuildVariantStates(
 			Role:            role,
 			MinReplicas:     minReplicas,
 			MaxReplicas:     maxReplicas,
+			Preemptible:     va.Spec.PricingTier == config.PricingTierSpot,
 		})
 	}
 
@@ -720,16 +871,6 @@ func (e *Engine) convertSaturationTargetsToDecisions(
 	return decisions
 }
 
-// hasMinReplicasAboveZero returns true if any variant in the states has MinReplicas > 0.
-func hasMinReplicasAboveZero(states []interfaces.VariantReplicaState) bool {
-	for _, state := range states {
-		if state.MinReplicas != nil && *state.MinReplicas > 0 {
-			return true
-		}
-	}
-	return false
-}
-
 // modelData holds the pre-processed data for a model, shared between V1 and V2 paths.
 type modelData struct {
 	modelID             string
@@ -739,28 +880,45 @@ type modelData struct {
 	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling
 	variantCosts        map[string]float64
 	variantStates       []interfaces.VariantReplicaState
+	// schedulerQueue is the model's scheduler flow control queue. Only set
+	// during replay until flow control metrics are collected.
+	schedulerQueue *interfaces.SchedulerQueueMetrics
+}
+
+// errStaleMetrics is returned when none of the replicas of a model has fresh
+// metrics.
+var errStaleMetrics = errors.New("metrics of all replicas are stale")
+
+// freshReplicaMetrics returns whether replica metrics are fresh. Metrics
+// without freshness information are considered fresh.
+func freshReplicaMetrics(rm interfaces.ReplicaMetrics) bool {
+	return rm.Metadata == nil || rm.Metadata.FreshnessStatus == "fresh"
 }
 
 // prepareModelData collects metrics and builds lookup maps for a model's VAs.
 // This is shared by both V1 and V2 paths.
 // Also shared by the Queueing Model Analyzer engine.
-// Returns nil modelData (not error) when no metrics are available — caller should skip the model.
+// Returns nil modelData (not error) when no metrics are available, and
+// errStaleMetrics when no replica has fresh metrics — caller should skip the model.
 func (e *Engine) prepareModelData(
 	ctx context.Context,
 	modelID string,
 	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	k8sClient client.Client,
-) (*modelData, error) {
+) (_ *modelData, err error) {
 	if len(modelVAs) == 0 {
 		return nil, fmt.Errorf("no VAs provided for model %s", modelID)
 	}
 
 	logger := ctrl.LoggerFrom(ctx)
 	namespace := modelVAs[0].Namespace
+	ctx, span := tracing.Start(ctx, "Engine.prepareModelData", tracing.Model(namespace, modelID)...)
+	defer func() { tracing.End(span, err) }()
 
 	variantCosts := make(map[string]float64)
 	scaleTargets := make(map[string]scaletarget.ScaleTargetAccessor)
 	variantAutoscalings := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
+	priceCatalog := e.Config.PriceCatalogForNamespace(namespace)
 
 	for i := range modelVAs {
 		va := &modelVAs[i]
@@ -773,24 +931,24 @@ func (e *Engine) prepareModelData(
 			continue
 		}
 
-		cost := saturation.DefaultVariantCost
-		if va.Spec.VariantCost != "" {
-			if parsedCost, err := strconv.ParseFloat(va.Spec.VariantCost, 64); err == nil {
-				cost = parsedCost
-			} else {
-				logger.V(logging.DEBUG).Info("Failed to parse variant cost, using default",
-					"variant", va.Name, "variantCost", va.Spec.VariantCost, "default", cost, "error", err)
-			}
-		}
+		cost := utils.ResolveVariantCost(va, scaleTarget, priceCatalog)
+		logger.V(logging.DEBUG).Info("Resolved variant cost",
+			"variant", va.Name, "cost", cost.Cost, "source", cost.Source,
+			"accelerator", cost.Accelerator, "pricingTier", cost.PricingTier)
 
 		key := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
 		scaleTargets[key] = scaleTarget
 
 		variantKey := utils.GetNamespacedKey(va.Namespace, va.Name)
 		variantAutoscalings[variantKey] = va
-		variantCosts[variantKey] = cost
+		variantCosts[variantKey] = cost.Cost
 	}
 
+	// Reset knowledge learned for a previous serving configuration before it
+	// is used by the analyzers
+	podsByVariant := e.listVariantPods(ctx, modelVAs, scaleTargets)
+	e.observeServingConfigs(ctx, modelID, modelVAs, scaleTargets, podsByVariant)
+
 	logger.V(logging.DEBUG).Info("Using source infrastructure for replica metrics",
 		"modelID", modelID,
 		"namespace", namespace)
@@ -810,9 +968,17 @@ func (e *Engine) prepareModelData(
 			"namespace", namespace)
 		return nil, nil // nil modelData signals skip
 	}
+	if !slices.ContainsFunc(replicaMetrics, freshReplicaMetrics) {
+		return nil, errStaleMetrics
+	}
 
 	variantStates := e.BuildVariantStates(ctx, modelVAs, scaleTargets, k8sClient)
 
+	e.observeReplicaStartup(ctx, modelVAs, podsByVariant, replicaMetrics)
+	e.observeReplicaLoad(replicaMetrics)
+	e.observeInterruptions(ctx, modelVAs, podsByVariant, variantStates)
+	e.applySchedules(ctx, modelID, namespace, modelVAs, variantStates)
+
 	return &modelData{
 		modelID:             modelID,
 		namespace:           namespace,
@@ -833,8 +999,6 @@ func (e *Engine) RunSaturationAnalysis(
 	saturationConfig config.SaturationScalingConfig,
 	k8sClient client.Client,
 ) (map[string]int, *interfaces.ModelSaturationAnalysis, *modelData, error) {
-	logger := ctrl.LoggerFrom(ctx)
-
 	saturationConfig.ApplyDefaults()
 
 	data, err := e.prepareModelData(ctx, modelID, modelVAs, k8sClient)
@@ -845,11 +1009,40 @@ func (e *Engine) RunSaturationAnalysis(
 		return nil, nil, nil, nil // No metrics available
 	}
 
+	saturationTargets, saturationAnalysis, err := e.analyzeV1(ctx, data, saturationConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return saturationTargets, saturationAnalysis, data, nil
+}
+
+// analyzeV1 runs the V1 saturation analyzer on prepared model data and
+// calculates per-variant targets.
+func (e *Engine) analyzeV1(
+	ctx context.Context,
+	data *modelData,
+	saturationConfig config.SaturationScalingConfig,
+) (map[string]int, *interfaces.ModelSaturationAnalysis, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	modelID := data.modelID
+
+	e.decisionRecorder.RecordInput(interfaces.AnalyzerInput{
+		ModelID:        modelID,
+		Namespace:      data.namespace,
+		ReplicaMetrics: data.replicaMetrics,
+		VariantStates:  data.variantStates,
+		Config:         &saturationConfig,
+	})
+
 	saturationAnalyzer := saturation.NewAnalyzer()
-	saturationAnalysis, err := saturationAnalyzer.AnalyzeModelSaturation(ctx, modelID, data.namespace, data.replicaMetrics, saturationConfig)
+	analyzeCtx, span := tracing.Start(ctx, "Analyzer.Analyze",
+		append(tracing.Model(data.namespace, modelID), tracing.AnalyzerKey.String("v1-saturation"))...)
+	saturationAnalysis, err := saturationAnalyzer.AnalyzeModelSaturation(analyzeCtx, modelID, data.namespace, data.replicaMetrics, saturationConfig)
+	tracing.End(span, err)
 	if err != nil {
-		return nil, nil, nil, fmt.Errorf("failed to analyze Saturation for model %s: %w", modelID, err)
+		return nil, nil, fmt.Errorf("failed to analyze Saturation for model %s: %w", modelID, err)
 	}
+	e.decisionRecorder.RecordSaturationAnalysis(saturationAnalysis)
 
 	logger.Info("Saturation analysis completed",
 		"modelID", modelID,
@@ -867,15 +1060,17 @@ func (e *Engine) RunSaturationAnalysis(
 		"modelID", modelID,
 		"targets", saturationTargets)
 
-	return saturationTargets, saturationAnalysis, data, nil
+	return saturationTargets, saturationAnalysis, nil
 }
 
-// applySaturationDecisions updates VA status and emits metrics based on Saturation decisions.
+// applySaturationDecisions updates VA status, emits metrics and records Events
+// based on the decisions produced by the given analyzer.
 func (e *Engine) applySaturationDecisions(
 	ctx context.Context,
 	decisions []interfaces.VariantDecision,
 	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 	currentAllocations map[string]*interfaces.Allocation,
+	analyzerName string,
 ) error {
 	logger := ctrl.LoggerFrom(ctx)
 	// Create a map of decisions for O(1) lookup
@@ -1099,6 +1294,8 @@ func (e *Engine) applySaturationDecisions(
 		}
 
 		if hasDecision {
+			e.decisionEvents.RecordDecision(&updateVa, decision, analyzerName)
+			emitDecisionMetrics(ctx, decision)
 			logger.Info("Applied saturation decision via shared cache",
 				"variant", vaName,
 				"namespace", updateVa.Namespace,
@@ -1143,7 +1340,18 @@ func (e *Engine) emitSafetyNetMetrics(
 		}
 
 		// Strategy 1: Use previous desired replicas if available
-		if va.Status.DesiredOptimizedAlloc.NumReplicas != nil && *va.Status.DesiredOptimizedAlloc.NumReplicas > 0 {
+		// In shadow mode the previous desired replicas were never actuated: keep the current replicas
+		if utils.IsShadowMode(ctx, e.client, &va) {
+			if scaleTarget == nil {
+				if currentReplicas, err = act.GetCurrentScaleTargetReplicasFromVA(ctx, &va); err != nil {
+					logger.Error(err, "Safety net: failed to get current replicas for VA in shadow mode, skipping metric emission",
+						"variant", va.Name)
+					continue
+				}
+			}
+			desiredReplicas = currentReplicas
+			fallbackSource = "shadow-mode"
+		} else if va.Status.DesiredOptimizedAlloc.NumReplicas != nil && *va.Status.DesiredOptimizedAlloc.NumReplicas > 0 {
 			desiredReplicas = *va.Status.DesiredOptimizedAlloc.NumReplicas
 			fallbackSource = "previous-desired"
 		} else {
@@ -1190,6 +1398,10 @@ func (e *Engine) emitSafetyNetMetrics(
 			continue
 		}
 
+		if err := act.MetricsEmitter.EmitSafetyNet(va.Name, va.Namespace); err != nil {
+			logger.V(logging.DEBUG).Info("Failed to emit safety net metric", "error", err)
+		}
+
 		logger.Info("Safety net activated: emitted fallback metrics",
 			"variant", va.Name,
 			"currentReplicas", currentReplicas,
@@ -0,0 +1,182 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/coldstart"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+// startupHistoryTimeout is how long startup latency history is kept for a
+// variant that is no longer observed (e.g. its VA was deleted).
+const startupHistoryTimeout = 1 * time.Hour
+
+// observeReplicaStartup feeds the startup latency tracker with the current
+// pods of each variant and the replicas that reported traffic this cycle,
+// and emits the resulting startup latency metrics. Runs for every analyzer
+// path so that latency is learned even when lookahead is disabled. Pods are
+// those of listVariantPods.
+func (e *Engine) observeReplicaStartup(
+	ctx context.Context,
+	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	podsByVariant map[string][]corev1.Pod,
+	replicaMetrics []interfaces.ReplicaMetrics,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	emitter := metrics.NewMetricsEmitter()
+	now := time.Now()
+
+	servingByVariant := make(map[string]map[string]bool)
+	for _, rm := range replicaMetrics {
+		if rm.ArrivalRate <= 0 && rm.TokensInUse <= 0 && rm.KvCacheUsage <= 0 {
+			continue
+		}
+		if servingByVariant[rm.VariantName] == nil {
+			servingByVariant[rm.VariantName] = make(map[string]bool)
+		}
+		servingByVariant[rm.VariantName][rm.PodName] = true
+	}
+
+	for i := range modelVAs {
+		va := &modelVAs[i]
+		pods, ok := podsByVariant[utils.GetNamespacedKey(va.Namespace, va.Name)]
+		if !ok {
+			continue
+		}
+
+		observations := e.startupTracker.ObservePods(va.Namespace, va.Name, pods, now)
+		observations = append(observations,
+			e.startupTracker.ObserveServing(va.Namespace, va.Name, servingByVariant[va.Name], now)...)
+		for _, obs := range observations {
+			logger.V(logging.DEBUG).Info("Observed replica startup latency",
+				"variant", obs.VariantName, "pod", obs.PodName, "phase", obs.Phase, "latency", obs.Latency)
+			if err := emitter.EmitStartupLatency(obs.VariantName, obs.Namespace, obs.Phase, obs.Latency); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to emit startup latency metric", "error", err)
+			}
+		}
+
+		if estimate, ok := e.startupTracker.Estimate(va.Namespace, va.Name); ok {
+			if err := emitter.EmitExpectedStartup(va.Name, va.Namespace, estimate.Total()); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to emit expected startup metric", "error", err)
+			}
+		}
+	}
+}
+
+// variantPods are the pods of a variant listed in the running cycle, with the
+// scale target they were listed for.
+type variantPods struct {
+	scaleTarget scaletarget.ScaleTargetAccessor
+	pods        []corev1.Pod
+}
+
+// listVariantPods lists the pods of each variant of a model once per cycle,
+// for the observers of the model and for graceful scale-down. The result is
+// keyed by namespace/VA name; variants without a scale target or whose pods
+// could not be listed are absent.
+func (e *Engine) listVariantPods(
+	ctx context.Context,
+	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	scaleTargets map[string]scaletarget.ScaleTargetAccessor,
+) map[string][]corev1.Pod {
+	logger := ctrl.LoggerFrom(ctx)
+	if e.cycleVariantPods == nil {
+		e.cycleVariantPods = make(map[string]variantPods)
+	}
+
+	podsByVariant := make(map[string][]corev1.Pod, len(modelVAs))
+	for i := range modelVAs {
+		va := &modelVAs[i]
+		scaleTarget := scaleTargets[utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())]
+		if scaleTarget == nil {
+			continue
+		}
+		pods, err := listScaleTargetPods(ctx, e.client, scaleTarget)
+		if err != nil {
+			logger.V(logging.DEBUG).Info("Could not list pods for variant",
+				"variant", va.Name, "namespace", va.Namespace, "error", err)
+			continue
+		}
+		key := utils.GetNamespacedKey(va.Namespace, va.Name)
+		podsByVariant[key] = pods
+		e.cycleVariantPods[key] = variantPods{scaleTarget: scaleTarget, pods: pods}
+	}
+	return podsByVariant
+}
+
+// listScaleTargetPods lists the pods selected by the scale target's pod
+// selector.
+func listScaleTargetPods(ctx context.Context, c client.Client, scaleTarget scaletarget.ScaleTargetAccessor) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(scaleTarget.GetPodSelector())
+	if err != nil || selector.Empty() {
+		return nil, err
+	}
+	podList := &corev1.PodList{}
+	if err := c.List(ctx, podList,
+		client.InNamespace(scaleTarget.GetNamespace()),
+		client.MatchingLabelsSelector{Selector: selector},
+	); err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+// applyColdStartLookahead records the model's demand for trend estimation and,
+// when enabled, raises the result's required capacity by the demand growth
+// expected over the learned startup latency of its variants. The slowest
+// variant with startup history sets the lead time, since the optimizer may
+// pick any of them for scale-up.
+func (e *Engine) applyColdStartLookahead(
+	ctx context.Context,
+	result *interfaces.AnalyzerResult,
+	variantStates []interfaces.VariantReplicaState,
+	scaleUpThreshold float64,
+	enabled bool,
+) {
+	if result == nil {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	key := utils.GetNamespacedKey(result.Namespace, result.ModelID)
+	e.demandTrend.Add(key, result.TotalDemand, e.now())
+	if !enabled {
+		return
+	}
+
+	var lead time.Duration
+	for _, vs := range variantStates {
+		if estimate, ok := e.startupTracker.Estimate(result.Namespace, vs.VariantName); ok {
+			lead = max(lead, estimate.Total())
+		}
+	}
+	slope, ok := e.demandTrend.Slope(key)
+	added := 0.0
+	if ok && lead > 0 {
+		extra := coldstart.ProjectedGrowth(slope, lead, result.TotalDemand)
+		added = coldstart.ApplyLookahead(result, extra, scaleUpThreshold)
+	}
+
+	if err := metrics.NewMetricsEmitter().EmitColdStartLookahead(result.ModelID, result.Namespace, added); err != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit cold-start lookahead metric", "error", err)
+	}
+	if added > 0 {
+		logger.Info("Cold-start lookahead raised required capacity",
+			"modelID", result.ModelID,
+			"namespace", result.Namespace,
+			"demandSlopePerSec", slope,
+			"leadTime", lead,
+			"addedCapacity", added,
+			"requiredCapacity", result.RequiredCapacity)
+	}
+}
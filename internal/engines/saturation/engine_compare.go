@@ -0,0 +1,176 @@
+package saturation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	queueingmodel "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
+)
+
+// analyzerComparison holds, for one cycle, the model scaling requests built by
+// the primary analyzer and by the analyzers run alongside it for comparison
+// (SaturationScalingConfig.CompareAnalyzers). Only models with comparison
+// analyzers are held.
+type analyzerComparison struct {
+	primary  string
+	requests map[string][]pipeline.ModelScalingRequest // analyzer name → requests
+}
+
+func newAnalyzerComparison(primary string) *analyzerComparison {
+	return &analyzerComparison{primary: primary, requests: make(map[string][]pipeline.ModelScalingRequest)}
+}
+
+// compareModel runs the comparison analyzers configured for a model on the
+// data collected for the primary analyzer. Comparison analyses are not
+// recorded, not published on the debug endpoint and do not apply the
+// cold-start lookahead, so they do not affect the primary's decisions.
+func (e *Engine) compareModel(
+	ctx context.Context,
+	cmp *analyzerComparison,
+	data *modelData,
+	satConfig config.SaturationScalingConfig,
+	primaryRequest *pipeline.ModelScalingRequest,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var names []string
+	for _, name := range satConfig.CompareAnalyzers {
+		if name != cmp.primary && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	cmp.requests[cmp.primary] = append(cmp.requests[cmp.primary], *primaryRequest)
+	for _, name := range names {
+		req, err := e.comparisonRequest(ctx, name, data, satConfig)
+		if err != nil {
+			logger.Info("Comparison analyzer failed, skipping it for this model",
+				"analyzer", name, "modelID", data.modelID, "namespace", data.namespace, "error", err.Error())
+			continue
+		}
+		cmp.requests[name] = append(cmp.requests[name], *req)
+	}
+}
+
+// comparisonRequest runs a comparison analyzer on the data of a model and
+// returns its ModelScalingRequest.
+func (e *Engine) comparisonRequest(
+	ctx context.Context,
+	name string,
+	data *modelData,
+	satConfig config.SaturationScalingConfig,
+) (*pipeline.ModelScalingRequest, error) {
+	switch name {
+	case interfaces.SaturationAnalyzerName:
+		// The primary may not be V2: make sure the V2 thresholds are set
+		cfg := satConfig
+		cfg.AnalyzerName = interfaces.SaturationAnalyzerName
+		cfg.ApplyDefaults()
+		cfg = withSaturationThresholds(cfg)
+
+		result, err := e.analyze(ctx, e.saturationV2Analyzer, e.buildV2Input(ctx, data, cfg))
+		if err != nil {
+			return nil, err
+		}
+		emitAnalyzerMetrics(ctx, result)
+		scoreV2Result(result, cfg)
+		return v2ModelRequest(data, result, cfg), nil
+
+	case interfaces.QueueingModelAnalyzerName:
+		qConfig := buildQMConfig(e.Config.QMAnalyzerConfigForNamespace(data.namespace), data.namespace, data.modelID)
+		qConfig.Priors = qmPriors(e.Config.PerfDataForNamespace(data.namespace), data)
+		result, err := e.analyze(ctx, e.queueingModelAnalyzer, qmInput(data, qConfig))
+		if err != nil {
+			return nil, err
+		}
+		emitAnalyzerMetrics(ctx, result)
+		return qmModelRequest(data, result, qConfig), nil
+
+	default:
+		return nil, fmt.Errorf("unknown comparison analyzer %q", name)
+	}
+}
+
+// emitComparison computes the replicas recommended by each analyzer of the
+// comparison and emits them, with the divergence of each comparison analyzer
+// from the primary. Recommendations are the targets of the cost-aware
+// optimizer, which ignores GPU constraints, before the enforcer, so that
+// analyzers are compared on their own. The engine's optimizer is not used: in
+// limited mode it allocates from the available GPUs, and without constraints
+// it would keep every variant at its current replicas.
+func (e *Engine) emitComparison(ctx context.Context, cmp *analyzerComparison) {
+	if len(cmp.requests) == 0 {
+		return
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	ctx, span := tracing.Start(ctx, "Engine.compareAnalyzers")
+	defer tracing.End(span, nil)
+
+	optimizer := pipeline.NewCostAwareOptimizer()
+	recommended := make(map[string]map[modelKey]int, len(cmp.requests))
+	for name, requests := range cmp.requests {
+		recommended[name] = recommendedReplicas(optimizer.Optimize(ctx, requests, nil))
+	}
+
+	emitter := metrics.NewMetricsEmitter()
+	primary := recommended[cmp.primary]
+	for name, byModel := range recommended {
+		for key, replicas := range byModel {
+			if err := emitter.EmitAnalyzerRecommendation(key.modelID, key.namespace, name, replicas); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to emit analyzer recommendation metric", "error", err)
+			}
+			primaryReplicas, ok := primary[key]
+			if name == cmp.primary || !ok {
+				continue
+			}
+			if err := emitter.EmitAnalyzerDivergence(key.modelID, key.namespace, name, cmp.primary, replicas-primaryReplicas); err != nil {
+				logger.V(logging.DEBUG).Info("Failed to emit analyzer divergence metric", "error", err)
+			}
+			logger.V(logging.DEBUG).Info("Analyzer comparison",
+				"modelID", key.modelID,
+				"namespace", key.namespace,
+				"primary", cmp.primary,
+				"primaryReplicas", primaryReplicas,
+				"analyzer", name,
+				"replicas", replicas)
+		}
+	}
+}
+
+// modelKey identifies a model in a namespace.
+type modelKey struct {
+	namespace string
+	modelID   string
+}
+
+// recommendedReplicas sums the target replicas of the decisions per model.
+func recommendedReplicas(decisions []interfaces.VariantDecision) map[modelKey]int {
+	byModel := make(map[modelKey]int)
+	for _, d := range decisions {
+		byModel[modelKey{namespace: d.Namespace, modelID: d.ModelID}] += d.TargetReplicas
+	}
+	return byModel
+}
+
+// updateQueueingModels drops the queueing model state of the models that are
+// no longer active. Called by every path that may run the queueing model
+// analyzer, as primary or for comparison.
+func (e *Engine) updateQueueingModels(models []cycleModel) {
+	currentModelKeys := make(map[string]bool, len(models))
+	for _, m := range models {
+		currentModelKeys[queueingmodel.MakeModelKey(m.namespace, m.modelID)] = true
+	}
+	e.queueingModelAnalyzer.Update(currentModelKeys)
+}
@@ -0,0 +1,124 @@
+package saturation
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+// gaugeValue returns the value of the gauge of a family with the given
+// analyzer label.
+func gaugeValue(registry *prometheus.Registry, family, analyzer string) float64 {
+	families, err := registry.Gather()
+	Expect(err).NotTo(HaveOccurred())
+	for _, f := range families {
+		if f.GetName() != family {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == constants.LabelAnalyzer && l.GetValue() == analyzer {
+					return m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	Fail("no " + family + " gauge for analyzer " + analyzer)
+	return 0
+}
+
+var _ = Describe("Analyzer comparison", func() {
+
+	It("should sum the recommended replicas per model", func() {
+		decisions := []interfaces.VariantDecision{
+			{ModelID: "llama", Namespace: "default", VariantName: "llama-a100", TargetReplicas: 2},
+			{ModelID: "llama", Namespace: "default", VariantName: "llama-h100", TargetReplicas: 3},
+			{ModelID: "llama", Namespace: "prod", VariantName: "llama-a100", TargetReplicas: 1},
+		}
+		Expect(recommendedReplicas(decisions)).To(Equal(map[modelKey]int{
+			{namespace: "default", modelID: "llama"}: 5,
+			{namespace: "prod", modelID: "llama"}:    1,
+		}))
+	})
+
+	It("should only hold models with comparison analyzers other than the primary", func() {
+		engine := &Engine{}
+		cmp := newAnalyzerComparison(interfaces.SaturationAnalyzerName)
+		data := &modelData{modelID: "llama", namespace: "default"}
+		req := &pipeline.ModelScalingRequest{ModelID: "llama", Namespace: "default"}
+
+		engine.compareModel(context.Background(), cmp, data, config.SaturationScalingConfig{}, req)
+		engine.compareModel(context.Background(), cmp, data,
+			config.SaturationScalingConfig{CompareAnalyzers: []string{interfaces.SaturationAnalyzerName}}, req)
+		Expect(cmp.requests).To(BeEmpty())
+	})
+
+	It("should apply the saturation analyzer's threshold overrides", func() {
+		enabled := true
+		up, down := 0.9, 0.6
+		cfg := withSaturationThresholds(config.SaturationScalingConfig{
+			ScaleUpThreshold:  0.85,
+			ScaleDownBoundary: 0.70,
+			Analyzers: []config.AnalyzerScoreConfig{
+				{Name: interfaces.SaturationAnalyzerName, Enabled: &enabled, ScaleUpThreshold: &up, ScaleDownBoundary: &down},
+			},
+		})
+		Expect(cfg.ScaleUpThreshold).To(Equal(0.9))
+		Expect(cfg.ScaleDownBoundary).To(Equal(0.6))
+	})
+
+	It("should score a V2 result by priority and analyzer weight", func() {
+		enabled := true
+		result := &interfaces.AnalyzerResult{RequiredCapacity: 1000}
+		scoreV2Result(result, config.SaturationScalingConfig{
+			Priority:  2,
+			Analyzers: []config.AnalyzerScoreConfig{{Name: interfaces.SaturationAnalyzerName, Enabled: &enabled, Score: 0.5}},
+		})
+		Expect(result.Score).To(Equal(1000.0))
+	})
+
+	It("should recommend scale-ups regardless of the engine's optimizer", func() {
+		registry := prometheus.NewRegistry()
+		Expect(metrics.InitMetrics(registry)).To(Succeed())
+
+		request := func(required float64) pipeline.ModelScalingRequest {
+			return pipeline.ModelScalingRequest{
+				ModelID:   "llama",
+				Namespace: "default",
+				Result: &interfaces.AnalyzerResult{
+					ModelID:          "llama",
+					Namespace:        "default",
+					RequiredCapacity: required,
+					Score:            required,
+					VariantCapacities: []interfaces.VariantCapacity{
+						{VariantName: "llama-a100", AcceleratorName: "A100", Cost: 10, PerReplicaCapacity: 10},
+					},
+				},
+				VariantStates: []interfaces.VariantReplicaState{
+					{VariantName: "llama-a100", CurrentReplicas: 2, GPUsPerReplica: 1, MaxReplicas: ptr.To(10)},
+				},
+			}
+		}
+		// In limited mode the engine's optimizer allocates from the available
+		// GPUs, which the comparison does not pass.
+		engine := &Engine{optimizer: pipeline.NewGreedyByScoreOptimizer()}
+		cmp := newAnalyzerComparison(interfaces.SaturationAnalyzerName)
+		cmp.requests[interfaces.SaturationAnalyzerName] = []pipeline.ModelScalingRequest{request(0)}
+		cmp.requests[interfaces.QueueingModelAnalyzerName] = []pipeline.ModelScalingRequest{request(25)}
+
+		engine.emitComparison(context.Background(), cmp)
+
+		Expect(gaugeValue(registry, constants.WVAAnalyzerRecommendedReplicas, interfaces.SaturationAnalyzerName)).To(Equal(2.0))
+		Expect(gaugeValue(registry, constants.WVAAnalyzerRecommendedReplicas, interfaces.QueueingModelAnalyzerName)).To(Equal(5.0))
+		Expect(gaugeValue(registry, constants.WVAAnalyzerReplicaDivergence, interfaces.QueueingModelAnalyzerName)).To(Equal(3.0))
+	})
+})
@@ -0,0 +1,109 @@
+package saturation
+
+import (
+	"context"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// cycleModel is a model of an optimization cycle, with its active VAs.
+type cycleModel struct {
+	modelID   string
+	namespace string
+	vas       []llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+}
+
+// cycleSource provides the data of the models of a cycle: the cluster and
+// Prometheus in the optimization loop, a recorded trace in replay.
+type cycleSource interface {
+	// prepare returns the data of a model. It returns nil data, and no
+	// error, for a model without metrics, and errStaleMetrics for a model
+	// whose metrics are all stale.
+	prepare(ctx context.Context, model cycleModel) (*modelData, error)
+	// failed handles a model whose data or analysis failed; data is nil
+	// when the data could not be prepared.
+	failed(ctx context.Context, model cycleModel, data *modelData)
+}
+
+// decideCycle runs the decision pipeline of one optimization cycle over the
+// models: analysis with the selected analyzer and the comparison analyzers,
+// optimization with federation, GPU limiting, schedule and scale-to-zero
+// enforcement, and shadow marking. It returns the analyzer that ran and the
+// decisions. It is shared by the optimization loop and the Replayer; the
+// steps acting on the cluster (graceful scale-down, actuation and VA status)
+// are left to the caller.
+func (e *Engine) decideCycle(
+	ctx context.Context,
+	models []cycleModel,
+	src cycleSource,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) (string, []interfaces.VariantDecision) {
+	// Drop demand history for models that are gone
+	e.demandTrend.Retain(modelKeys(models))
+
+	// Each analyzer has a separate path because they use fundamentally
+	// different analysis types and target-building flows:
+	//   - V1: saturation.Analyzer → ModelSaturationAnalysis → CalculateSaturationTargets → Enforcer → Limiter
+	//   - V2 (saturation): saturation_v2.Analyzer → AnalyzerResult → Optimizer.Optimize → Enforcer bridge
+	//   - Queueing model: QueueingModelAnalyzer → AnalyzerResult → Optimizer.Optimize → Enforcer bridge
+	// V1 will be deprecated once V2 is fully validated.
+	// Queueing model is activated by presence of wva-queueing-model-config ConfigMap.
+	analyzerName := e.selectAnalyzer(ctx)
+	var decisions []interfaces.VariantDecision
+	switch analyzerName {
+	case interfaces.QueueingModelAnalyzerName:
+		decisions = e.optimizeQueueingModel(ctx, models, src)
+	case interfaces.SaturationAnalyzerName:
+		decisions = e.optimizeV2(ctx, models, src)
+	default:
+		analyzerName = "v1-saturation"
+		decisions = e.optimizeV1(ctx, models, src)
+	}
+
+	e.markShadowDecisions(ctx, decisions, vaMap)
+	return analyzerName, decisions
+}
+
+// cycleModels returns the models of VAs grouped by model.
+func cycleModels(modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling) []cycleModel {
+	models := make([]cycleModel, 0, len(modelGroups))
+	for _, modelVAs := range modelGroups {
+		models = append(models, cycleModel{
+			modelID:   modelVAs[0].Spec.ModelID,
+			namespace: modelVAs[0].Namespace,
+			vas:       modelVAs,
+		})
+	}
+	return models
+}
+
+// modelKeys returns the namespaced keys of the models.
+func modelKeys(models []cycleModel) map[string]bool {
+	keys := make(map[string]bool, len(models))
+	for _, m := range models {
+		keys[utils.GetNamespacedKey(m.namespace, m.modelID)] = true
+	}
+	return keys
+}
+
+// clusterSource prepares the models of a cycle from the cluster and Prometheus.
+type clusterSource struct {
+	engine *Engine
+	// currentAllocations are filled with the allocations of the VAs whose
+	// analysis failed, by the safety-net metrics.
+	currentAllocations map[string]*interfaces.Allocation
+}
+
+func (s *clusterSource) prepare(ctx context.Context, model cycleModel) (*modelData, error) {
+	return s.engine.prepareModelData(ctx, model.modelID, model.vas, s.engine.client)
+}
+
+func (s *clusterSource) failed(ctx context.Context, model cycleModel, data *modelData) {
+	if data == nil {
+		s.engine.emitSafetyNetMetrics(ctx, model.vas, s.currentAllocations, nil)
+		return
+	}
+	s.engine.emitSafetyNetMetrics(ctx, model.vas, s.currentAllocations, data.scaleTargets)
+}
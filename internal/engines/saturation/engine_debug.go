@@ -0,0 +1,79 @@
+package saturation
+
+import (
+	"maps"
+	"time"
+
+	queueingmodel "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// DebugState is what the engine currently believes about the models it
+// scales, for the debug endpoint.
+type DebugState struct {
+	// LastCycle is when the last optimization cycle ran, and Analyzer the
+	// analyzer path it took. Zero before the first cycle.
+	LastCycle time.Time
+	Analyzer  string
+	// AnalyzerResults is the last V2 or queueing model result per model,
+	// keyed by namespace/modelID, after cold-start lookahead and scoring.
+	AnalyzerResults map[string]*interfaces.AnalyzerResult
+	// CapacityRecords is the V2 analyzer's capacity knowledge, keyed by
+	// namespace|modelID|variantName.
+	CapacityRecords map[string]saturation_v2.CapacityRecord
+	// LearnedParameters are the queueing model's tuned parameters, keyed by
+	// namespace/modelID, then namespace/variantName.
+	LearnedParameters map[string]map[string]*queueingmodel.LearnedParameters
+	// ResourcePools is the GPU limiter's inventory per accelerator type as of
+	// its last refresh.
+	ResourcePools map[string]pipeline.ResourcePool
+}
+
+// DebugState returns the engine's state as of its last optimization cycle.
+// Capacity records are read live. Safe to call concurrently with the
+// optimization loop.
+func (e *Engine) DebugState() DebugState {
+	e.debugMu.RLock()
+	state := e.debugState
+	e.debugMu.RUnlock()
+	state.CapacityRecords = e.capacityStore.Snapshot()
+	return state
+}
+
+// observeResult keeps the analyzer result of a model for the debug state
+// published at the end of the cycle.
+func (e *Engine) observeResult(result *interfaces.AnalyzerResult) {
+	if e.cycleResults == nil {
+		e.cycleResults = make(map[string]*interfaces.AnalyzerResult)
+	}
+	e.cycleResults[utils.GetNamespacedKey(result.Namespace, result.ModelID)] = result
+}
+
+// publishDebugState publishes the state at the end of a cycle. Results of
+// active models that were not analyzed this cycle are kept from earlier
+// cycles. Runs on the optimization loop, which owns the analyzer state.
+func (e *Engine) publishDebugState(analyzerName string, activeModelKeys map[string]bool) {
+	state := DebugState{
+		LastCycle:         e.now(),
+		Analyzer:          analyzerName,
+		AnalyzerResults:   make(map[string]*interfaces.AnalyzerResult),
+		LearnedParameters: e.queueingModelAnalyzer.LearnedParameters(),
+	}
+	if limiter, ok := e.GPULimiter.(*pipeline.DefaultLimiter); ok {
+		state.ResourcePools = limiter.ResourcePools()
+	}
+
+	e.debugMu.Lock()
+	defer e.debugMu.Unlock()
+	for key, result := range e.debugState.AnalyzerResults {
+		if activeModelKeys[key] {
+			state.AnalyzerResults[key] = result
+		}
+	}
+	maps.Copy(state.AnalyzerResults, e.cycleResults)
+	e.cycleResults = nil
+	e.debugState = state
+}
@@ -0,0 +1,177 @@
+package saturation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/drain"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// observeReplicaLoad keeps the replica metrics of the running cycle by
+// variant and pod, for picking the replicas to drain on scale-down.
+func (e *Engine) observeReplicaLoad(replicaMetrics []interfaces.ReplicaMetrics) {
+	if e.cycleReplicaMetrics == nil {
+		e.cycleReplicaMetrics = make(map[string]map[string]interfaces.ReplicaMetrics)
+	}
+	for _, rm := range replicaMetrics {
+		key := utils.GetNamespacedKey(rm.Namespace, rm.VariantName)
+		if e.cycleReplicaMetrics[key] == nil {
+			e.cycleReplicaMetrics[key] = make(map[string]interfaces.ReplicaMetrics)
+		}
+		e.cycleReplicaMetrics[key][rm.PodName] = rm
+	}
+}
+
+// drainScaleDowns applies the graceful scale-down of the decided variants.
+// On scale-down, the least-loaded pods are marked for removal, and the target
+// replicas are raised by the marked pods that still have in-flight requests
+// within the drain timeout. Pods marked for a scale-down that is no longer
+// decided are released, including when graceful scale-down is disabled.
+// Shadow decisions are not drained. Pods are those listed in the cycle by
+// listVariantPods.
+func (e *Engine) drainScaleDowns(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	replicaMetrics := e.cycleReplicaMetrics
+	e.cycleReplicaMetrics = nil
+	podsByVariant := e.cycleVariantPods
+	e.cycleVariantPods = nil
+	for i := range decisions {
+		key := utils.GetNamespacedKey(decisions[i].Namespace, decisions[i].VariantName)
+		va, ok := vaMap[key]
+		if !ok {
+			continue
+		}
+		if variant, ok := podsByVariant[key]; ok {
+			e.drainVariant(ctx, &decisions[i], va, variant, replicaMetrics[key])
+		}
+	}
+}
+
+// drainVariant applies the graceful scale-down of a variant to its decision.
+// Only Deployments are supported: their pods are selected by the Deployment's
+// selector, which does not include the cordon label. Without graceful
+// scale-down configured, only pods still marked from before are released.
+func (e *Engine) drainVariant(
+	ctx context.Context,
+	decision *interfaces.VariantDecision,
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	variant variantPods,
+	replicaMetrics map[string]interfaces.ReplicaMetrics,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	config := va.Spec.GracefulScaleDown
+	if config == nil && !slices.ContainsFunc(variant.pods, drain.Draining) {
+		return
+	}
+	if kind := va.Spec.ScaleTargetRef.Kind; kind != "" && kind != constants.DeploymentKind {
+		if config != nil {
+			logger.V(logging.DEBUG).Info("Graceful scale-down only supports Deployments, skipping",
+				"variant", va.Name, "namespace", va.Namespace, "kind", kind)
+		}
+		return
+	}
+	template := variant.scaleTarget.GetLeaderPodTemplateSpec()
+	if template == nil {
+		return
+	}
+
+	excess := 0
+	timeout := drain.DefaultTimeout
+	var cordon drain.Label
+	if config != nil && !decision.Shadow {
+		excess = decision.CurrentReplicas - decision.TargetReplicas
+		if config.DrainTimeout != nil {
+			timeout = config.DrainTimeout.Duration
+		}
+		var err error
+		cordon, err = drain.ParseLabel(config.CordonLabel)
+		if err == nil && selectorHasKey(variant.scaleTarget.GetPodSelector(), cordon.Key) {
+			err = fmt.Errorf("cordon label %q is part of the selector of Deployment %s", cordon.Key, variant.scaleTarget.GetName())
+		}
+		if err != nil {
+			logger.Error(err, "Draining without cordon label", "variant", va.Name, "namespace", va.Namespace)
+			cordon = drain.Label{}
+		}
+	}
+
+	plan := drain.Select(variant.pods, replicaMetrics, excess, timeout, e.now())
+	for _, pod := range plan.Release {
+		if err := e.patchPod(ctx, pod, func() bool { return drain.Unmark(pod, template) }); err != nil {
+			logger.Error(err, "Failed to release draining pod", "pod", pod.Name, "namespace", pod.Namespace)
+			continue
+		}
+		logger.Info("Released draining pod", "variant", va.Name, "pod", pod.Name, "namespace", pod.Namespace)
+	}
+	var held int
+	var deadline time.Time
+	for _, pick := range plan.Drain {
+		if err := e.patchPod(ctx, pick.Pod, func() bool { return drain.Mark(pick, cordon) }); err != nil {
+			logger.Error(err, "Failed to mark pod for removal", "pod", pick.Pod.Name, "namespace", pick.Pod.Namespace)
+		}
+		if !pick.Drained {
+			held++
+			if end := pick.Started.Add(timeout); end.After(deadline) {
+				deadline = end
+			}
+		}
+	}
+	if excess <= 0 || held == 0 {
+		return
+	}
+
+	decision.TargetReplicas += held
+	if decision.TargetReplicas >= decision.CurrentReplicas {
+		decision.Action = interfaces.ActionNoChange
+	}
+	decision.AddDecisionStep("drain", fmt.Sprintf(
+		"graceful scale-down: holding %d replica(s) until their in-flight requests drain, at most until %s",
+		held, deadline.UTC().Format(time.RFC3339)), true)
+	logger.Info("Holding scale-down until replicas drain",
+		"variant", va.Name,
+		"namespace", va.Namespace,
+		"current", decision.CurrentReplicas,
+		"target", decision.TargetReplicas,
+		"draining", len(plan.Drain),
+		"held", held,
+		"deadline", deadline)
+}
+
+// patchPod applies mutate to a pod, and patches the pod when it changed.
+func (e *Engine) patchPod(ctx context.Context, pod *corev1.Pod, mutate func() bool) error {
+	original := pod.DeepCopy()
+	if !mutate() {
+		return nil
+	}
+	return e.client.Patch(ctx, pod, client.MergeFrom(original))
+}
+
+// selectorHasKey returns whether a label selector selects on key.
+func selectorHasKey(selector *metav1.LabelSelector, key string) bool {
+	if selector == nil || key == "" {
+		return false
+	}
+	if _, ok := selector.MatchLabels[key]; ok {
+		return true
+	}
+	for _, req := range selector.MatchExpressions {
+		if req.Key == key {
+			return true
+		}
+	}
+	return false
+}
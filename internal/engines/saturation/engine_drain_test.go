@@ -0,0 +1,190 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+var _ = Describe("Graceful scale-down", func() {
+	const namespace = "llm"
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace,
+				Labels: map[string]string{"app": "llama", "llm-d.ai/routable": "true"}},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+
+	var (
+		engine *Engine
+		deploy *appsv1.Deployment
+		va     *llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+		vaMap  map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+		calls  int
+	)
+
+	BeforeEach(func() {
+		deploy = &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: namespace},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "llama"}},
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "llama", "llm-d.ai/routable": "true"}}},
+			},
+		}
+		calls = 0
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+			WithObjects(deploy, newPod("llama-a"), newPod("llama-b"), newPod("llama-c")).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					calls++
+					return c.Get(ctx, key, obj, opts...)
+				},
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					calls++
+					return c.List(ctx, list, opts...)
+				},
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					calls++
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			}).Build()
+		engine = &Engine{client: c, now: func() time.Time { return now }}
+
+		va = &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+			ObjectMeta: metav1.ObjectMeta{Name: "llama-a100", Namespace: namespace},
+		}
+		va.Spec.ScaleTargetRef.Name = "llama"
+		va.Spec.GracefulScaleDown = &llmdVariantAutoscalingV1alpha1.GracefulScaleDown{
+			CordonLabel: "llm-d.ai/routable=false",
+		}
+		vaMap = map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling{namespace + "/llama-a100": va}
+	})
+
+	scaleDown := func(target int) []interfaces.VariantDecision {
+		return []interfaces.VariantDecision{{
+			VariantName: "llama-a100", Namespace: namespace,
+			Action: interfaces.ActionScaleDown, CurrentReplicas: 3, TargetReplicas: target,
+		}}
+	}
+
+	listPods := func() {
+		engine.listVariantPods(context.Background(), []llmdVariantAutoscalingV1alpha1.VariantAutoscaling{*va},
+			map[string]scaletarget.ScaleTargetAccessor{namespace + "/llama": scaletarget.NewDeploymentAccessor(deploy)})
+	}
+
+	// drainScaleDowns runs the drain of a cycle: the pods of the variant are
+	// listed, then the decisions are drained.
+	drainScaleDowns := func(decisions []interfaces.VariantDecision) {
+		listPods()
+		engine.drainScaleDowns(context.Background(), decisions, vaMap)
+	}
+
+	getPod := func(name string) *corev1.Pod {
+		pod := &corev1.Pod{}
+		Expect(engine.client.Get(context.Background(), client.ObjectKey{Namespace: namespace, Name: name}, pod)).To(Succeed())
+		return pod
+	}
+
+	It("should hold the scale-down until the least-loaded replica drains", func() {
+		engine.observeReplicaLoad([]interfaces.ReplicaMetrics{
+			{PodName: "llama-a", VariantName: "llama-a100", Namespace: namespace, TokensInUse: 9000},
+			{PodName: "llama-b", VariantName: "llama-a100", Namespace: namespace, TokensInUse: 300},
+			{PodName: "llama-c", VariantName: "llama-a100", Namespace: namespace, TokensInUse: 5000},
+		})
+		decisions := scaleDown(2)
+		drainScaleDowns(decisions)
+
+		Expect(decisions[0].TargetReplicas).To(Equal(3))
+		Expect(decisions[0].Action).To(Equal(interfaces.ActionNoChange))
+		Expect(decisions[0].LastStep().Name).To(Equal("drain"))
+
+		picked := getPod("llama-b")
+		Expect(picked.Annotations).To(HaveKeyWithValue(corev1.PodDeletionCost, "-1000"))
+		Expect(picked.Annotations).To(HaveKeyWithValue(constants.DrainStartedAnnotationKey, "2025-01-01T12:00:00Z"))
+		Expect(picked.Labels).To(HaveKeyWithValue("llm-d.ai/routable", "false"))
+		Expect(getPod("llama-a").Annotations).NotTo(HaveKey(corev1.PodDeletionCost))
+
+		// once drained, the scale-down goes through and removes the drained replica
+		engine.observeReplicaLoad([]interfaces.ReplicaMetrics{
+			{PodName: "llama-b", VariantName: "llama-a100", Namespace: namespace},
+		})
+		decisions = scaleDown(2)
+		drainScaleDowns(decisions)
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+		Expect(getPod("llama-b").Annotations).To(HaveKeyWithValue(corev1.PodDeletionCost, "-2000"))
+	})
+
+	It("should release draining replicas when the scale-down is cancelled", func() {
+		engine.observeReplicaLoad([]interfaces.ReplicaMetrics{
+			{PodName: "llama-a", VariantName: "llama-a100", Namespace: namespace, QueueLength: 1},
+		})
+		drainScaleDowns(scaleDown(2))
+		Expect(getPod("llama-b").Annotations).To(HaveKey(constants.DrainStartedAnnotationKey))
+
+		decisions := scaleDown(3)
+		decisions[0].Action = interfaces.ActionNoChange
+		drainScaleDowns(decisions)
+
+		released := getPod("llama-b")
+		Expect(released.Annotations).NotTo(HaveKey(constants.DrainStartedAnnotationKey))
+		Expect(released.Annotations).NotTo(HaveKey(corev1.PodDeletionCost))
+		Expect(released.Labels).To(HaveKeyWithValue("llm-d.ai/routable", "true"))
+	})
+
+	It("should not drain shadow decisions", func() {
+		decisions := scaleDown(1)
+		decisions[0].Shadow = true
+		drainScaleDowns(decisions)
+
+		Expect(decisions[0].TargetReplicas).To(Equal(1))
+		for _, name := range []string{"llama-a", "llama-b", "llama-c"} {
+			Expect(getPod(name).Annotations).NotTo(HaveKey(constants.DrainStartedAnnotationKey))
+		}
+	})
+
+	It("should release draining replicas when graceful scale-down is disabled", func() {
+		drainScaleDowns(scaleDown(2))
+		Expect(getPod("llama-a").Annotations).To(HaveKey(constants.DrainStartedAnnotationKey))
+
+		va.Spec.GracefulScaleDown = nil
+		drainScaleDowns(scaleDown(2))
+
+		released := getPod("llama-a")
+		Expect(released.Annotations).NotTo(HaveKey(constants.DrainStartedAnnotationKey))
+		Expect(released.Labels).To(HaveKeyWithValue("llm-d.ai/routable", "true"))
+	})
+
+	It("should not call the API server when graceful scale-down is disabled and no replica drains", func() {
+		va.Spec.GracefulScaleDown = nil
+		listPods()
+		calls = 0
+
+		decisions := scaleDown(2)
+		engine.drainScaleDowns(context.Background(), decisions, vaMap)
+
+		Expect(calls).To(BeZero())
+		Expect(decisions[0].TargetReplicas).To(Equal(2))
+	})
+})
@@ -0,0 +1,41 @@
+package saturation
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
+)
+
+// federate replaces the scaling signals of the requests with the share of the
+// local cluster in the federation plan. The headroom of the cluster is bounded
+// by the available GPUs, computed for the purpose when the optimizer does not
+// use GPU constraints. Requests are returned unchanged without federation.
+func (e *Engine) federate(
+	ctx context.Context,
+	requests []pipeline.ModelScalingRequest,
+	constraints []*pipeline.ResourceConstraints,
+	analyzerName string,
+) []pipeline.ModelScalingRequest {
+	if e.Federation == nil {
+		return requests
+	}
+	if constraints == nil {
+		if limiter, ok := e.GPULimiter.(*pipeline.DefaultLimiter); ok {
+			constraint, err := limiter.ComputeConstraints(ctx, computeCurrentGPUUsage(requests))
+			if err != nil {
+				ctrl.LoggerFrom(ctx).Error(err, "Failed to compute GPU availability for federation, headroom is bounded by maxReplicas only")
+			} else {
+				constraints = []*pipeline.ResourceConstraints{constraint}
+			}
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, "Federation.Adjust",
+		attribute.String("wva.federation.cluster", e.Federation.Cluster()), attribute.Int("wva.models", len(requests)))
+	defer tracing.End(span, nil)
+	return e.Federation.Adjust(ctx, analyzerName, requests, constraints)
+}
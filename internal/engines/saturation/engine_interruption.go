@@ -0,0 +1,82 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// observeInterruptions sets InterruptedReplicas on the states of preemptible
+// variants. A replica counts as interrupted when its pod runs on a node that
+// is being reclaimed, or when it is an unscheduled replacement pod while the
+// variant is in its interruption cooldown (spot capacity is likely exhausted).
+// Pods are those of listVariantPods.
+func (e *Engine) observeInterruptions(
+	ctx context.Context,
+	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	podsByVariant map[string][]corev1.Pod,
+	variantStates []interfaces.VariantReplicaState,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	now := time.Now()
+	interruptedNodes := common.Interruptions.InterruptedNodes(now)
+
+	vaByName := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling, len(modelVAs))
+	for i := range modelVAs {
+		vaByName[modelVAs[i].Name] = &modelVAs[i]
+	}
+
+	for i := range variantStates {
+		state := &variantStates[i]
+		va := vaByName[state.VariantName]
+		if !state.Preemptible || va == nil {
+			continue
+		}
+		inCooldown := common.Interruptions.VariantInCooldown(va.Name, va.Namespace, now)
+		if len(interruptedNodes) == 0 && !inCooldown {
+			continue
+		}
+		pods, ok := podsByVariant[utils.GetNamespacedKey(va.Namespace, va.Name)]
+		if !ok {
+			continue
+		}
+
+		onInterruptedNodes, unscheduled := 0, 0
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+				continue
+			}
+			switch {
+			case pod.Spec.NodeName == "":
+				unscheduled++
+			case interruptedNodes[pod.Spec.NodeName]:
+				onInterruptedNodes++
+			}
+		}
+
+		if onInterruptedNodes > 0 {
+			common.Interruptions.MarkVariant(va.Name, va.Namespace, now)
+			inCooldown = true
+		}
+		state.InterruptedReplicas = onInterruptedNodes
+		if inCooldown {
+			state.InterruptedReplicas += unscheduled
+		}
+
+		if state.InterruptedReplicas > 0 {
+			logger.Info("Preemptible variant is losing replicas to node interruptions",
+				"variant", va.Name,
+				"namespace", va.Namespace,
+				"onInterruptedNodes", onInterruptedNodes,
+				"unscheduled", unscheduled,
+				"interruptedReplicas", state.InterruptedReplicas)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package saturation
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+)
+
+// emitAnalyzerMetrics sets the analyzer gauges of a model from its result.
+func emitAnalyzerMetrics(ctx context.Context, result *interfaces.AnalyzerResult) {
+	if err := metrics.NewMetricsEmitter().EmitAnalyzerResult(result); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit analyzer metrics", "error", err)
+	}
+}
+
+// emitCycleDuration records the duration of an optimization cycle.
+func emitCycleDuration(ctx context.Context, analyzerName string, duration time.Duration) {
+	if err := metrics.NewMetricsEmitter().ObserveCycleDuration(analyzerName, duration); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit cycle duration metric", "error", err)
+	}
+}
+
+// emitDecisionMetrics counts the limiter cuts, safety overrides and
+// scale-to-zero of a decision.
+func emitDecisionMetrics(ctx context.Context, decision interfaces.VariantDecision) {
+	if err := metrics.NewMetricsEmitter().EmitDecision(decision); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit decision metrics", "error", err)
+	}
+}
+
+// emitModelSkip counts a model skipped in this cycle for reason.
+func emitModelSkip(ctx context.Context, modelID, namespace, reason string) {
+	if err := metrics.NewMetricsEmitter().EmitModelSkip(modelID, namespace, reason); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to emit model skip metric", "error", err)
+	}
+}
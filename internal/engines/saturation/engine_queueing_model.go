@@ -2,15 +2,18 @@ package saturation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	queueingmodel "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 )
 
 // optimizeQueueingModel runs the queueing model-based analysis path.
@@ -18,119 +21,119 @@ import (
 //  1. Collect ModelScalingRequests (metrics + analysis per model)
 //  2. Call optimizer to produce VariantDecisions
 //  3. Apply enforcer constraints per model
-func (e *Engine) optimizeQueueingModel(
-	ctx context.Context,
-	modelGroups map[string][]llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
-	currentAllocations map[string]*interfaces.Allocation,
-) []interfaces.VariantDecision {
+func (e *Engine) optimizeQueueingModel(ctx context.Context, models []cycleModel, src cycleSource) []interfaces.VariantDecision {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// update analyzer given current models
-	currentModelKeys := make(map[string]bool, len(modelGroups))
-	for _, modelVAs := range modelGroups {
-		namespace := modelVAs[0].Namespace // there should be at least one VA in a model group
-		modelID := modelVAs[0].Spec.ModelID
-		currentModelKeys[queueingmodel.MakeModelKey(namespace, modelID)] = true
-	}
-	e.queueingModelAnalyzer.Update(currentModelKeys)
+	e.updateQueueingModels(models)
 
 	// Stage 1: Collect ModelScalingRequests for all models
 	var requests []pipeline.ModelScalingRequest
+	cmp := newAnalyzerComparison(interfaces.QueueingModelAnalyzerName)
 
-	for groupKey, modelVAs := range modelGroups {
-		modelID := modelVAs[0].Spec.ModelID
-		namespace := modelVAs[0].Namespace
+	for _, model := range models {
+		modelID, namespace := model.modelID, model.namespace
 		logger.Info("Processing model (queueing-model)",
 			"modelID", modelID,
 			"namespace", namespace,
-			"variantCount", len(modelVAs),
-			"groupKey", groupKey)
+			"variantCount", len(model.vas))
 
-		data, err := e.prepareModelData(ctx, modelID, modelVAs, e.client)
+		data, err := src.prepare(ctx, model)
+		if errors.Is(err, errStaleMetrics) {
+			logger.Info("Skipping model: metrics of all replicas are stale", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipStaleMetrics)
+			continue
+		}
 		if err != nil {
 			logger.Error(err, "Model data preparation failed", "modelID", modelID)
-			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, nil)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipCollectionFailed)
+			src.failed(ctx, model, nil)
 			continue
 		}
 		if data == nil {
 			logger.V(logging.DEBUG).Info("Skipping model: no metrics available", "modelID", modelID)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipNoMetrics)
 			continue
 		}
 
-		qmConfigMap := e.Config.QMAnalyzerConfigForNamespace(namespace)
-		qConfig := buildQMConfig(qmConfigMap, namespace, modelID)
-
-		result, err := e.runQueueingModelAnalysis(ctx, modelID, namespace,
-			data.replicaMetrics, qConfig, data.variantStates)
+		req, err := e.collectQMModelRequest(ctx, data)
 		if err != nil {
 			logger.Error(err, "Queueing model analysis failed", "modelID", modelID)
-			e.emitSafetyNetMetrics(ctx, modelVAs, currentAllocations, data.scaleTargets)
+			emitModelSkip(ctx, modelID, namespace, metrics.SkipAnalysisFailed)
+			src.failed(ctx, model, data)
 			continue
 		}
 
-		requests = append(requests, pipeline.ModelScalingRequest{
-			ModelID:       modelID,
-			Namespace:     namespace,
-			Result:        result,
-			VariantStates: data.variantStates,
-		})
+		requests = append(requests, *req)
+
+		// Comparison analyzers are configured in the saturation scaling config
+		if satConfigMap := e.Config.SaturationConfigForNamespace(namespace); len(satConfigMap) > 0 {
+			e.compareModel(ctx, cmp, data, resolveSaturationConfig(satConfigMap, modelID, namespace), req)
+		}
 	}
 
 	if len(requests) == 0 {
 		return nil
 	}
+	e.emitComparison(ctx, cmp)
 
-	// Stage 2: Call optimizer
-	allDecisions := e.optimizer.Optimize(ctx, requests, nil)
-
-	logger.Info("Queueing model optimizer produced decisions",
-		"optimizer", e.optimizer.Name(),
-		"decisionCount", len(allDecisions),
-		"modelCount", len(requests))
+	// Stages 2 and 3: Call optimizer, then apply enforcer per-model
+	return e.optimizeRequests(ctx, requests, nil, interfaces.QueueingModelAnalyzerName)
+}
 
-	// Stage 3: Apply enforcer per-model (directly on decisions)
-	for _, req := range requests {
-		scaleToZeroConfig := e.Config.ScaleToZeroConfigForNamespace(req.Namespace)
+// collectQMModelRequest performs queueing model analysis for a single model and
+// returns a ModelScalingRequest for the optimizer.
+func (e *Engine) collectQMModelRequest(ctx context.Context, data *modelData) (*pipeline.ModelScalingRequest, error) {
+	qmConfigMap := e.Config.QMAnalyzerConfigForNamespace(data.namespace)
+	qConfig := buildQMConfig(qmConfigMap, data.namespace, data.modelID)
+	qConfig.Priors = qmPriors(e.Config.PerfDataForNamespace(data.namespace), data)
 
-		scaledToZero := e.ScaleToZeroEnforcer.EnforcePolicyOnDecisions(
-			ctx, req.ModelID, req.Namespace,
-			allDecisions, scaleToZeroConfig, e.optimizer.Name(),
-		)
-		if scaledToZero {
-			logger.Info("Scale-to-zero enforcement applied (queueing-model)",
-				"modelID", req.ModelID)
-		}
+	result, err := e.runQueueingModelAnalysis(ctx, data, qConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	return allDecisions
+	// The queueing model sizes directly against demand, so the
+	// lookahead uses a threshold of 1.0.
+	e.applyColdStartLookahead(ctx, result, data.variantStates, 1.0, qConfig.ColdStartLookahead)
+	e.applyRelearningMargin(ctx, result, 1.0)
+
+	return qmModelRequest(data, result, qConfig), nil
+}
+
+// qmModelRequest builds the ModelScalingRequest of a queueing model result.
+func qmModelRequest(data *modelData, result *interfaces.AnalyzerResult, config *queueingmodel.QMConfig) *pipeline.ModelScalingRequest {
+	return &pipeline.ModelScalingRequest{
+		ModelID:             data.modelID,
+		Namespace:           data.namespace,
+		Result:              result,
+		VariantStates:       data.variantStates,
+		MinOnDemandFraction: config.MinOnDemandFraction,
+	}
 }
 
 // runQueueingModelAnalysis runs the queueing model analyzer for a single model
 // and returns the raw AnalyzerResult.
 func (e *Engine) runQueueingModelAnalysis(
 	ctx context.Context,
-	modelID, namespace string,
-	replicaMetrics []interfaces.ReplicaMetrics,
+	data *modelData,
 	config *queueingmodel.QMConfig,
-	variantStates []interfaces.VariantReplicaState,
 ) (*interfaces.AnalyzerResult, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
-	input := interfaces.AnalyzerInput{
-		ModelID:        modelID,
-		Namespace:      namespace,
-		ReplicaMetrics: replicaMetrics,
-		VariantStates:  variantStates,
-		Config:         config,
-	}
+	input := qmInput(data, config)
+	e.decisionRecorder.RecordInput(input)
 
-	result, err := e.queueingModelAnalyzer.Analyze(ctx, input)
+	result, err := e.analyze(ctx, e.queueingModelAnalyzer, input)
 	if err != nil {
 		return nil, fmt.Errorf("queueing model analysis failed: %w", err)
 	}
+	e.decisionRecorder.RecordResult(result)
+	e.observeResult(result)
+	emitAnalyzerMetrics(ctx, result)
 
 	logger.Info("Queueing model analysis completed",
-		"modelID", modelID,
+		"modelID", data.modelID,
 		"totalSupply", result.TotalSupply,
 		"totalDemand", result.TotalDemand,
 		"utilization", result.Utilization,
@@ -140,10 +143,63 @@ func (e *Engine) runQueueingModelAnalysis(
 	return result, nil
 }
 
+// qmInput builds the input of the queueing model analyzer.
+func qmInput(data *modelData, config *queueingmodel.QMConfig) interfaces.AnalyzerInput {
+	return interfaces.AnalyzerInput{
+		ModelID:        data.modelID,
+		Namespace:      data.namespace,
+		ReplicaMetrics: data.replicaMetrics,
+		VariantStates:  data.variantStates,
+		Config:         config,
+		SchedulerQueue: data.schedulerQueue,
+	}
+}
+
+// qmPriors returns the profiled parameters of the model's variants found in
+// the performance data catalog, keyed by variant name. A variant's accelerator
+// is taken from its scale target, or else from its replica metrics, and its
+// accelerator count from its variant state.
+func qmPriors(catalog config.PerfDataCatalog, data *modelData) map[string]*queueingmodel.ParameterPrior {
+	if len(catalog) == 0 {
+		return nil
+	}
+
+	accelerators := make(map[string]string)
+	for _, rm := range data.replicaMetrics {
+		if rm.AcceleratorName != "" {
+			accelerators[rm.VariantName] = rm.AcceleratorName
+		}
+	}
+	for _, va := range data.variantAutoscalings {
+		scaleTarget := data.scaleTargets[utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())]
+		if acc := utils.GetAcceleratorNameFromScaleTarget(va, scaleTarget); acc != "" {
+			accelerators[va.Name] = acc
+		}
+	}
+
+	var priors map[string]*queueingmodel.ParameterPrior
+	for _, state := range data.variantStates {
+		perfData, ok := catalog.Lookup(data.modelID, accelerators[state.VariantName], state.GPUsPerReplica)
+		if !ok {
+			continue
+		}
+		if priors == nil {
+			priors = make(map[string]*queueingmodel.ParameterPrior)
+		}
+		priors[state.VariantName] = &queueingmodel.ParameterPrior{
+			Alpha:        perfData.ServiceParms.Alpha,
+			Beta:         perfData.ServiceParms.Beta,
+			Gamma:        perfData.ServiceParms.Gamma,
+			MaxBatchSize: perfData.MaxBatchSize,
+		}
+	}
+	return priors
+}
+
 // buildQMConfig creates a QMConfig for a specific model.
 // It starts from the "default" entry in allConfigs, then applies any per-model
 // override whose ModelID and Namespace match. Per-model entries can override
-// sloMultiplier, tuningEnabled, and provide explicit SLO targets (targetTTFT/targetITL).
+// sloMultiplier, tuningEnabled, coldStartLookahead, minOnDemandFraction, requestClasses, and provide explicit SLO targets (targetTTFT/targetITL).
 // Falls back to defaults when fields are zero/nil.
 func buildQMConfig(
 	allConfigs map[string]interfaces.QueueingModelScalingConfig,
@@ -151,7 +207,7 @@ func buildQMConfig(
 ) *queueingmodel.QMConfig {
 	cfg := &queueingmodel.QMConfig{
 		TuningEnabled: true,
-		SLOMultiplier: queueingmodel.DefaultSLOMultiplier,
+		SLOMultiplier: config.DefaultSLOMultiplier,
 	}
 
 	// Apply "default" entry as base
@@ -162,6 +218,15 @@ func buildQMConfig(
 		if defaultCfg.SLOMultiplier > 1.0 {
 			cfg.SLOMultiplier = defaultCfg.SLOMultiplier
 		}
+		if defaultCfg.ColdStartLookahead != nil {
+			cfg.ColdStartLookahead = *defaultCfg.ColdStartLookahead
+		}
+		if defaultCfg.MinOnDemandFraction != nil {
+			cfg.MinOnDemandFraction = *defaultCfg.MinOnDemandFraction
+		}
+		if defaultCfg.RequestClasses != nil {
+			cfg.RequestClasses = *defaultCfg.RequestClasses
+		}
 	}
 
 	// Scan for a per-model override matching this model
@@ -180,6 +245,15 @@ func buildQMConfig(
 		if entry.TuningEnabled != nil {
 			cfg.TuningEnabled = *entry.TuningEnabled
 		}
+		if entry.ColdStartLookahead != nil {
+			cfg.ColdStartLookahead = *entry.ColdStartLookahead
+		}
+		if entry.MinOnDemandFraction != nil {
+			cfg.MinOnDemandFraction = *entry.MinOnDemandFraction
+		}
+		if entry.RequestClasses != nil {
+			cfg.RequestClasses = *entry.RequestClasses
+		}
 
 		// Populate explicit SLO targets if both are set
 		if entry.TargetTTFT > 0 && entry.TargetITL > 0 {
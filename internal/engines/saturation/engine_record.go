@@ -0,0 +1,31 @@
+package saturation
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/decisionrecord"
+)
+
+// newDecisionRecorder creates the recorder of the optimization cycles
+// configured in cfg. Returns nil when recording is disabled.
+func newDecisionRecorder(k8sClient client.Client, cfg config.DecisionRecorderConfig) (*decisionrecord.Recorder, error) {
+	var sink decisionrecord.Sink
+	var err error
+	switch cfg.Sink {
+	case "":
+		return nil, nil
+	case config.DecisionRecorderSinkFile:
+		sink, err = decisionrecord.NewFileSink(cfg.Path, cfg.MaxBytes, cfg.MaxFiles)
+	case config.DecisionRecorderSinkConfigMap:
+		sink, err = decisionrecord.NewConfigMapSink(k8sClient, config.SystemNamespace(), cfg.ConfigMapName, cfg.MaxRecords)
+	default:
+		err = fmt.Errorf("unknown sink %q", cfg.Sink)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating decision recorder: %w", err)
+	}
+	return decisionrecord.NewRecorder(sink), nil
+}
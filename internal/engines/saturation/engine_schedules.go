@@ -0,0 +1,42 @@
+package saturation
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+// applySchedules applies the scaling schedules active now — per-model schedules
+// from the scale-to-zero ConfigMap and the VAs' spec.schedules — to the variant
+// states. Invalid VA schedules are logged and ignored.
+func (e *Engine) applySchedules(
+	ctx context.Context,
+	modelID, namespace string,
+	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	variantStates []interfaces.VariantReplicaState,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	variantRules := make(map[string][]schedule.Rule)
+	for i := range modelVAs {
+		va := &modelVAs[i]
+		rules, err := utils.VariantScheduleRules(va)
+		if err != nil {
+			logger.Info("Ignoring invalid scaling schedules",
+				"variant", va.Name,
+				"namespace", va.Namespace,
+				"error", err)
+		}
+		if len(rules) > 0 {
+			variantRules[va.Name] = rules
+		}
+	}
+
+	scaleToZeroConfig := e.Config.ScaleToZeroConfigForNamespace(namespace)
+	e.ScaleToZeroEnforcer.ApplySchedules(ctx, modelID, variantStates, variantRules, scaleToZeroConfig)
+}
@@ -0,0 +1,93 @@
+package saturation
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/servingconfig"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+// servingConfigHistoryTimeout is how long the serving configuration of a
+// variant that is no longer observed (e.g. its VA was deleted) is kept.
+const servingConfigHistoryTimeout = 24 * time.Hour
+
+// observeServingConfigs fingerprints the serving configuration of each variant
+// of a model from its scale target and the pods of listVariantPods. When it
+// changed, the queueing model parameters and the capacity record learned for
+// the previous configuration are dropped, and an Event is emitted. The
+// capacity record is reloaded from the new scale target, and the parameters
+// are learned again from an initial guess, while applyRelearningMargin widens
+// the safety margin of the variant.
+func (e *Engine) observeServingConfigs(
+	ctx context.Context,
+	modelID string,
+	modelVAs []llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	scaleTargets map[string]scaletarget.ScaleTargetAccessor,
+	podsByVariant map[string][]corev1.Pod,
+) {
+	logger := ctrl.LoggerFrom(ctx)
+	now := e.now()
+
+	for i := range modelVAs {
+		va := &modelVAs[i]
+		scaleTarget := scaleTargets[utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())]
+		if scaleTarget == nil {
+			continue
+		}
+		// The pods resolve the digests of the images; without them only the
+		// image references are compared
+		pods := podsByVariant[utils.GetNamespacedKey(va.Namespace, va.Name)]
+		change := e.servingConfigs.Observe(va.Namespace, va.Name, servingconfig.FromScaleTarget(va, scaleTarget, pods), now)
+		if change == nil {
+			continue
+		}
+
+		logger.Info("Serving configuration changed, resetting learned parameters and capacity",
+			"variant", va.Name,
+			"namespace", va.Namespace,
+			"modelID", modelID,
+			"changes", change.Current.Changes(change.Previous),
+			"previousFingerprint", change.PreviousFingerprint,
+			"fingerprint", change.Fingerprint)
+		e.queueingModelAnalyzer.ResetParameters(modelID, va.Namespace, va.Name)
+		e.capacityStore.Delete(va.Namespace, modelID, va.Name)
+		e.decisionEvents.RecordServingConfigChange(va, change.String(), servingconfig.DefaultRelearnWindow)
+	}
+}
+
+// applyRelearningMargin widens the safety margin of the variants of a result
+// whose serving configuration changed recently: their per-replica capacity is
+// lowered and the model does not scale down until they have re-learned it.
+func (e *Engine) applyRelearningMargin(ctx context.Context, result *interfaces.AnalyzerResult, scaleUpThreshold float64) {
+	if result == nil {
+		return
+	}
+	now := e.now()
+	relearning := make(map[string]bool)
+	for _, vc := range result.VariantCapacities {
+		if e.servingConfigs.Relearning(result.Namespace, vc.VariantName, now) {
+			relearning[vc.VariantName] = true
+		}
+	}
+	if len(relearning) == 0 {
+		return
+	}
+
+	added := servingconfig.ApplyRelearningMargin(result, relearning, servingconfig.DefaultRelearningMargin, scaleUpThreshold)
+	ctrl.LoggerFrom(ctx).Info("Widened safety margin of variants re-learning their serving configuration",
+		"modelID", result.ModelID,
+		"namespace", result.Namespace,
+		"variants", slices.Sorted(maps.Keys(relearning)),
+		"margin", servingconfig.DefaultRelearningMargin,
+		"addedCapacity", added,
+		"requiredCapacity", result.RequiredCapacity)
+}
@@ -0,0 +1,26 @@
+package saturation
+
+import (
+	"context"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// markShadowDecisions flags the decisions of the VAs in shadow mode, so that
+// they are recorded and reported as recommendations rather than scaling. The
+// actuator keeps wva_desired_replicas at the current replicas for these VAs.
+func (e *Engine) markShadowDecisions(
+	ctx context.Context,
+	decisions []interfaces.VariantDecision,
+	vaMap map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+) {
+	for i := range decisions {
+		va, ok := vaMap[utils.GetNamespacedKey(decisions[i].Namespace, decisions[i].VariantName)]
+		if ok && utils.IsShadowMode(ctx, e.client, va) {
+			decisions[i].Shadow = true
+			decisions[i].AddDecisionStep("shadow", "shadow mode: recommendation not actuated", false)
+		}
+	}
+}
@@ -6,13 +6,12 @@ import (
 
 	ctrl "sigs.k8s.io/controller-runtime"
 
-	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
-	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
 )
 
 // runV2AnalysisOnly runs the V2 saturation analyzer and returns the raw AnalyzerResult
@@ -20,19 +19,48 @@ import (
 // target building across all models.
 func (e *Engine) runV2AnalysisOnly(
 	ctx context.Context,
-	modelID, namespace string,
-	replicaMetrics []interfaces.ReplicaMetrics,
+	data *modelData,
 	config config.SaturationScalingConfig,
-	variantStates []interfaces.VariantReplicaState,
-	scaleTargets map[string]scaletarget.ScaleTargetAccessor,
-	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 ) (*interfaces.AnalyzerResult, error) {
 	logger := ctrl.LoggerFrom(ctx)
+	modelID := data.modelID
+
+	input := e.buildV2Input(ctx, data, config)
+	e.decisionRecorder.RecordInput(input)
+
+	result, err := e.analyze(ctx, e.saturationV2Analyzer, input)
+	if err != nil {
+		return nil, fmt.Errorf("V2 saturation analysis failed: %w", err)
+	}
+	e.decisionRecorder.RecordResult(result)
+	e.observeResult(result)
+	emitAnalyzerMetrics(ctx, result)
+
+	logger.Info("V2 saturation analysis completed",
+		"modelID", modelID,
+		"totalSupply", result.TotalSupply,
+		"totalDemand", result.TotalDemand,
+		"utilization", result.Utilization,
+		"requiredCapacity", result.RequiredCapacity,
+		"spareCapacity", result.SpareCapacity)
+
+	return result, nil
+}
+
+// buildV2Input pre-populates the capacity store from the model's scale targets
+// and builds the input of the V2 analyzer.
+func (e *Engine) buildV2Input(
+	ctx context.Context,
+	data *modelData,
+	config config.SaturationScalingConfig,
+) interfaces.AnalyzerInput {
+	logger := ctrl.LoggerFrom(ctx)
+	modelID, namespace := data.modelID, data.namespace
 
 	// 1. Pre-populate capacity store with scale target-derived params
-	for _, va := range variantAutoscalings {
+	for _, va := range data.variantAutoscalings {
 		key := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
-		scaleTarget := scaleTargets[key]
+		scaleTarget := data.scaleTargets[key]
 		if scaleTarget == nil {
 			logger.V(logging.DEBUG).Info("No scale target found for VA, skipping capacity store pre-population",
 				"variant", va.Name, "scaleTargetKey", key)
@@ -47,46 +75,54 @@ func (e *Engine) runV2AnalysisOnly(
 	}
 
 	// 2. Build AnalyzerInput
-	input := interfaces.AnalyzerInput{
+	return interfaces.AnalyzerInput{
 		ModelID:        modelID,
 		Namespace:      namespace,
-		ReplicaMetrics: replicaMetrics,
-		VariantStates:  variantStates,
+		ReplicaMetrics: data.replicaMetrics,
+		VariantStates:  data.variantStates,
 		Config:         &config,
-		// TODO: populate SchedulerQueue when flow control metrics are collected
+		// TODO: collect SchedulerQueue when flow control metrics are available
+		SchedulerQueue: data.schedulerQueue,
 	}
+}
 
-	// 3. Run V2 analyzer
-	result, err := e.saturationV2Analyzer.Analyze(ctx, input)
-	if err != nil {
-		return nil, fmt.Errorf("V2 saturation analysis failed: %w", err)
-	}
-
-	logger.Info("V2 saturation analysis completed",
-		"modelID", modelID,
-		"totalSupply", result.TotalSupply,
-		"totalDemand", result.TotalDemand,
-		"utilization", result.Utilization,
-		"requiredCapacity", result.RequiredCapacity,
-		"spareCapacity", result.SpareCapacity)
-
-	return result, nil
+// analyze runs an analyzer on the input of a model in its own span.
+func (e *Engine) analyze(ctx context.Context, analyzer interfaces.Analyzer, input interfaces.AnalyzerInput) (*interfaces.AnalyzerResult, error) {
+	ctx, span := tracing.Start(ctx, "Analyzer.Analyze",
+		append(tracing.Model(input.Namespace, input.ModelID), tracing.AnalyzerKey.String(analyzer.Name()))...)
+	result, err := analyzer.Analyze(ctx, input)
+	tracing.End(span, err)
+	return result, err
 }
 
 // runAnalyzersAndScore runs the V2 saturation analyzer, then computes the
 // weighted composite score from enabled analyzers and model priority.
 func (e *Engine) runAnalyzersAndScore(
 	ctx context.Context,
-	modelID, namespace string,
-	replicaMetrics []interfaces.ReplicaMetrics,
+	data *modelData,
 	config config.SaturationScalingConfig,
-	variantStates []interfaces.VariantReplicaState,
-	scaleTargets map[string]scaletarget.ScaleTargetAccessor,
-	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 ) (*interfaces.AnalyzerResult, error) {
-	// Resolve per-analyzer threshold overrides before running the analyzer.
-	// The saturation analyzer reads thresholds from the config, so we apply
-	// per-analyzer overrides to the config's top-level fields.
+	config = withSaturationThresholds(config)
+
+	// Run saturation analyzer (always needed for PerReplicaCapacity)
+	baseResult, err := e.runV2AnalysisOnly(ctx, data, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scale up ahead of an upward demand trend by the learned startup latency.
+	// Applied before scoring so the optimizer sees the anticipated requirement.
+	e.applyColdStartLookahead(ctx, baseResult, data.variantStates, config.ScaleUpThreshold, config.ColdStartLookahead)
+	e.applyRelearningMargin(ctx, baseResult, config.ScaleUpThreshold)
+
+	scoreV2Result(baseResult, config)
+	return baseResult, nil
+}
+
+// withSaturationThresholds resolves the per-analyzer threshold overrides of the
+// saturation analyzer. The saturation analyzer reads thresholds from the config,
+// so the overrides are applied to the config's top-level fields.
+func withSaturationThresholds(config config.SaturationScalingConfig) config.SaturationScalingConfig {
 	for _, aw := range config.Analyzers {
 		if aw.Name == interfaces.SaturationAnalyzerName && (aw.Enabled == nil || *aw.Enabled) {
 			if aw.ScaleUpThreshold != nil {
@@ -98,29 +134,25 @@ func (e *Engine) runAnalyzersAndScore(
 			break
 		}
 	}
+	return config
+}
 
-	// Run saturation analyzer (always needed for PerReplicaCapacity)
-	baseResult, err := e.runV2AnalysisOnly(ctx, modelID, namespace, replicaMetrics, config,
-		variantStates, scaleTargets, variantAutoscalings)
-	if err != nil {
-		return nil, err
-	}
-
-	// Compute weighted score from enabled analyzers
+// scoreV2Result sets the score of a V2 result: the model priority times the
+// weighted sum of the enabled analyzers' required capacity.
+func scoreV2Result(result *interfaces.AnalyzerResult, config config.SaturationScalingConfig) {
 	totalWeighted := 0.0
 	for _, aw := range config.Analyzers {
 		if aw.Enabled != nil && !*aw.Enabled {
 			continue
 		}
 		if aw.Name == interfaces.SaturationAnalyzerName {
-			totalWeighted += baseResult.RequiredCapacity * aw.Score
+			totalWeighted += result.RequiredCapacity * aw.Score
 			// future: add "throughput", "slo" cases
 		}
 	}
 
 	// Score = priority * weighted sum
-	baseResult.Score = config.Priority * totalWeighted
-	return baseResult, nil
+	result.Score = config.Priority * totalWeighted
 }
 
 // computeCurrentGPUUsage iterates over model scaling requests to compute the
@@ -152,22 +184,21 @@ func computeCurrentGPUUsage(requests []pipeline.ModelScalingRequest) map[string]
 // a ModelScalingRequest for the optimizer, or nil if analysis should be skipped.
 func (e *Engine) collectV2ModelRequest(
 	ctx context.Context,
-	modelID, namespace string,
-	replicaMetrics []interfaces.ReplicaMetrics,
+	data *modelData,
 	config config.SaturationScalingConfig,
-	variantStates []interfaces.VariantReplicaState,
-	scaleTargets map[string]scaletarget.ScaleTargetAccessor,
-	variantAutoscalings map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
 ) (*pipeline.ModelScalingRequest, error) {
-	result, err := e.runAnalyzersAndScore(ctx, modelID, namespace, replicaMetrics, config,
-		variantStates, scaleTargets, variantAutoscalings)
+	result, err := e.runAnalyzersAndScore(ctx, data, config)
 	if err != nil {
-		return nil, fmt.Errorf("collecting V2 model request for %s/%s: %w", namespace, modelID, err)
+		return nil, fmt.Errorf("collecting V2 model request for %s/%s: %w", data.namespace, data.modelID, err)
 	}
+	return v2ModelRequest(data, result, config), nil
+}
 
+// v2ModelRequest builds the ModelScalingRequest of a V2 result.
+func v2ModelRequest(data *modelData, result *interfaces.AnalyzerResult, config config.SaturationScalingConfig) *pipeline.ModelScalingRequest {
 	// Detect P/D disaggregation: true when any variant has role != interfaces.RoleBoth
 	disaggregated := false
-	for _, vs := range variantStates {
+	for _, vs := range data.variantStates {
 		if vs.Role != "" && vs.Role != interfaces.RoleBoth {
 			disaggregated = true
 			break
@@ -175,11 +206,12 @@ func (e *Engine) collectV2ModelRequest(
 	}
 
 	return &pipeline.ModelScalingRequest{
-		ModelID:       modelID,
-		Namespace:     namespace,
-		Result:        result,
-		VariantStates: variantStates,
-		Priority:      config.Priority,
-		Disaggregated: disaggregated,
-	}, nil
+		ModelID:             data.modelID,
+		Namespace:           data.namespace,
+		Result:              result,
+		VariantStates:       data.variantStates,
+		Priority:            config.Priority,
+		Disaggregated:       disaggregated,
+		MinOnDemandFraction: config.MinOnDemandFraction,
+	}
 }
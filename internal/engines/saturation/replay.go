@@ -0,0 +1,228 @@
+package saturation
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/discovery"
+	queueingmodel "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/queueingmodel"
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/coldstart"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/decisionrecord"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/servingconfig"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// ReplayTrace is a recorded sequence of optimization cycles, replayed offline
+// through the decision pipeline to compare configurations.
+type ReplayTrace struct {
+	Cycles []ReplayCycle `json:"cycles"`
+}
+
+// ReplayCycle is the recorded input of one optimization cycle.
+type ReplayCycle struct {
+	// Time is when the cycle ran. Scaling schedules and demand trends are
+	// evaluated at this time.
+	Time time.Time `json:"time"`
+	// GPULimits is the GPU capacity per accelerator type available to the GPU
+	// limiter. Only used when enableLimiter is set.
+	GPULimits map[string]int `json:"gpuLimits,omitempty"`
+	// Models are the models with active VariantAutoscalings in the cycle.
+	Models []ReplayModel `json:"models"`
+}
+
+// ReplayModel is the recorded input of one model in a cycle.
+type ReplayModel struct {
+	ModelID        string                            `json:"modelID"`
+	Namespace      string                            `json:"namespace"`
+	ReplicaMetrics []interfaces.ReplicaMetrics       `json:"replicaMetrics"`
+	VariantStates  []interfaces.VariantReplicaState  `json:"variantStates"`
+	SchedulerQueue *interfaces.SchedulerQueueMetrics `json:"schedulerQueue,omitempty"`
+	// RequestCount is the number of requests to the model over the
+	// scale-to-zero retention period.
+	RequestCount float64 `json:"requestCount"`
+	// VariantAutoscalings are the VAs of the model, for their scaling
+	// schedules and shadow mode annotation. Optional: without them, only the
+	// schedules of the scale-to-zero ConfigMap apply and no variant is in
+	// shadow mode.
+	VariantAutoscalings []llmdVariantAutoscalingV1alpha1.VariantAutoscaling `json:"variantAutoscalings,omitempty"`
+}
+
+// ReplayTraceFromRecords builds a trace from the cycles recorded by the
+// engine's decision recorder, to replay them with another configuration.
+// Models recorded without an analyzer input are left out. GPU limits are not
+// recorded, so the limiter sees no capacity.
+func ReplayTraceFromRecords(records []decisionrecord.Cycle) *ReplayTrace {
+	trace := &ReplayTrace{Cycles: make([]ReplayCycle, 0, len(records))}
+	for _, record := range records {
+		cycle := ReplayCycle{Time: record.Time}
+		for _, m := range record.Models {
+			if m.Input == nil {
+				continue
+			}
+			model := ReplayModel{
+				ModelID:        m.ModelID,
+				Namespace:      m.Namespace,
+				ReplicaMetrics: m.Input.ReplicaMetrics,
+				VariantStates:  m.Input.VariantStates,
+				SchedulerQueue: m.Input.SchedulerQueue,
+			}
+			if m.RequestCount != nil {
+				model.RequestCount = *m.RequestCount
+			}
+			cycle.Models = append(cycle.Models, model)
+		}
+		trace.Cycles = append(trace.Cycles, cycle)
+	}
+	return trace
+}
+
+// ReplayResult holds the decisions of one replayed cycle.
+type ReplayResult struct {
+	Time      time.Time
+	Analyzer  string
+	Decisions []interfaces.VariantDecision
+}
+
+// Replayer runs recorded cycles through the decision pipeline of the engine's
+// optimization loop (see Engine.decideCycle), without a cluster or Prometheus.
+// Analyzer state (capacity knowledge, queueing model tuning, demand trends)
+// carries over between cycles, as it does in the engine.
+type Replayer struct {
+	engine *Engine
+	cycle  *ReplayCycle
+}
+
+// NewReplayer creates a Replayer deciding with the saturation, queueing model
+// and scale-to-zero configuration in cfg.
+func NewReplayer(cfg *config.Config) *Replayer {
+	r := &Replayer{}
+	now := func() time.Time { return r.cycle.Time }
+
+	requestCountFunc := func(_ context.Context, modelID, namespace string, _ time.Duration) (float64, error) {
+		for _, m := range r.cycle.Models {
+			if m.ModelID == modelID && m.Namespace == namespace {
+				return m.RequestCount, nil
+			}
+		}
+		return 0, fmt.Errorf("model %s/%s not recorded in cycle", namespace, modelID)
+	}
+
+	gpuInventory := pipeline.NewTypeInventory("replay-gpu-inventory", replayDiscovery{r})
+	capacityStore := saturation_v2.NewCapacityKnowledgeStore()
+
+	r.engine = &Engine{
+		Config:                cfg,
+		ScaleToZeroEnforcer:   pipeline.NewEnforcerWithClock(requestCountFunc, now),
+		GPULimiter:            pipeline.NewDefaultLimiter("gpu-limiter", gpuInventory, pipeline.NewGreedyBySaturation()),
+		saturationV2Analyzer:  saturation_v2.NewSaturationAnalyzer(capacityStore),
+		queueingModelAnalyzer: queueingmodel.NewQueueingModelAnalyzer(),
+		capacityStore:         capacityStore,
+		optimizer:             pipeline.NewCostAwareOptimizer(),
+		startupTracker:        coldstart.NewStartupLatencyTracker(),
+		demandTrend:           coldstart.NewDemandTrend(coldstart.DefaultTrendWindow),
+		servingConfigs:        servingconfig.NewTracker(),
+		now:                   now,
+	}
+	return r
+}
+
+// Replay runs the cycles of the trace in order and returns their decisions,
+// sorted by namespace, model and variant.
+func (r *Replayer) Replay(ctx context.Context, trace *ReplayTrace) []ReplayResult {
+	results := make([]ReplayResult, 0, len(trace.Cycles))
+	for i := range trace.Cycles {
+		results = append(results, r.replayCycle(ctx, &trace.Cycles[i]))
+	}
+	return results
+}
+
+// replayCycle runs the decision pipeline of the engine on one recorded cycle.
+func (r *Replayer) replayCycle(ctx context.Context, cycle *ReplayCycle) ReplayResult {
+	ctx = ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues("cycle", cycle.Time))
+	r.cycle = cycle
+
+	models := make([]cycleModel, 0, len(cycle.Models))
+	vaMap := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling)
+	for i := range cycle.Models {
+		m := &cycle.Models[i]
+		models = append(models, cycleModel{modelID: m.ModelID, namespace: m.Namespace, vas: m.VariantAutoscalings})
+		for j := range m.VariantAutoscalings {
+			va := &m.VariantAutoscalings[j]
+			vaMap[utils.GetNamespacedKey(va.Namespace, va.Name)] = va
+		}
+	}
+
+	analyzerName, decisions := r.engine.decideCycle(ctx, models, replaySource{r}, vaMap)
+
+	sort.Slice(decisions, func(i, j int) bool {
+		a, b := decisions[i], decisions[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ModelID != b.ModelID {
+			return a.ModelID < b.ModelID
+		}
+		return a.VariantName < b.VariantName
+	})
+
+	return ReplayResult{Time: cycle.Time, Analyzer: analyzerName, Decisions: decisions}
+}
+
+// replaySource prepares the models of a cycle from the recorded cycle.
+type replaySource struct {
+	r *Replayer
+}
+
+// prepare builds the model data of a recorded model, as prepareModelData
+// does from the cluster. Returns nil for models without metrics.
+func (s replaySource) prepare(ctx context.Context, model cycleModel) (*modelData, error) {
+	var m *ReplayModel
+	for i := range s.r.cycle.Models {
+		if s.r.cycle.Models[i].ModelID == model.modelID && s.r.cycle.Models[i].Namespace == model.namespace {
+			m = &s.r.cycle.Models[i]
+			break
+		}
+	}
+	if m == nil || len(m.ReplicaMetrics) == 0 {
+		return nil, nil
+	}
+
+	// Copy the states, since schedules are applied to them in place and
+	// the trace may be replayed again.
+	variantStates := append([]interfaces.VariantReplicaState(nil), m.VariantStates...)
+	s.r.engine.applySchedules(ctx, m.ModelID, m.Namespace, m.VariantAutoscalings, variantStates)
+
+	return &modelData{
+		modelID:        m.ModelID,
+		namespace:      m.Namespace,
+		replicaMetrics: m.ReplicaMetrics,
+		variantStates:  variantStates,
+		schedulerQueue: m.SchedulerQueue,
+	}, nil
+}
+
+// failed does nothing: there are no safety-net metrics in replay.
+func (replaySource) failed(context.Context, cycleModel, *modelData) {}
+
+// replayDiscovery reports the GPU limits of the cycle being replayed as the
+// capacity of a single node.
+type replayDiscovery struct {
+	r *Replayer
+}
+
+func (d replayDiscovery) Discover(context.Context) (map[string]map[string]discovery.AcceleratorModelInfo, error) {
+	accelerators := make(map[string]discovery.AcceleratorModelInfo, len(d.r.cycle.GPULimits))
+	for accelerator, count := range d.r.cycle.GPULimits {
+		accelerators[accelerator] = discovery.AcceleratorModelInfo{Count: count}
+	}
+	return map[string]map[string]discovery.AcceleratorModelInfo{"replay": accelerators}, nil
+}
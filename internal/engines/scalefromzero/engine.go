@@ -20,7 +20,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strconv"
 	"sync"
 	"time"
 
@@ -28,6 +27,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -42,6 +42,7 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/executor"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
 	poolutil "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/pool"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
@@ -65,11 +66,13 @@ type Engine struct {
 	Mapper         meta.RESTMapper
 	maxConcurrency int
 	config         *config.Config // Unified configuration (injected from main.go)
+	// decisionEvents emits rate-limited Kubernetes Events for scale-from-zero actuations.
+	decisionEvents *common.DecisionEvents
 }
 
 // NewEngine creates a new instance of the scale-from-zero engine.
 // cfg must be non-nil (validated in main.go before engine creation).
-func NewEngine(client client.Client, mapper meta.RESTMapper, restConfig *rest.Config, ds datastore.Datastore, cfg *config.Config) (*Engine, error) {
+func NewEngine(client client.Client, mapper meta.RESTMapper, restConfig *rest.Config, ds datastore.Datastore, recorder record.EventRecorder, cfg *config.Config) (*Engine, error) {
 	if cfg == nil {
 		return nil, errors.New("config is nil in NewEngine - this should not happen")
 	}
@@ -97,6 +100,7 @@ func NewEngine(client client.Client, mapper meta.RESTMapper, restConfig *rest.Co
 		Mapper:         mapper,
 		maxConcurrency: maxConcurrency,
 		config:         cfg,
+		decisionEvents: common.NewDecisionEvents(recorder),
 	}
 
 	// TODO: replace by an hybrid, polling and reactive executor when available
@@ -300,6 +304,16 @@ func (e *Engine) processInactiveVariant(ctx context.Context, scaleTargets map[st
 		return nil
 	}
 
+	// In shadow mode, record the scale-up the engine would have done and leave the target at zero
+	if utils.IsShadowMode(ctx, e.client, &va) {
+		actuator.NewActuator(e.client).EmitShadowMetrics(ctx, &va, 0, int32(targetWorkloadReplicas))
+		e.decisionEvents.RecordWouldScaleFromZero(&va, targetWorkloadReplicas, reason)
+		return nil
+	}
+
+	// Push the activation to KEDA ScaledObjects pointing at the external scaler
+	common.Activations.MarkPending(va.Name, va.Namespace, time.Now())
+
 	// 1.  Scale up from zero to one
 	// TODO: Right now we are scaling all the VA for the same target model. We need to scale only the VA that has the lowest cost.
 	err = e.Actuator.ScaleTargetObject(ctx, unstructuredObj, int32(targetWorkloadReplicas))
@@ -316,26 +330,17 @@ func (e *Engine) processInactiveVariant(ctx context.Context, scaleTargets map[st
 	accelerator = va.Status.DesiredOptimizedAlloc.Accelerator
 	if accelerator == "" {
 		// Try to get from deployment/LWS nodeSelector/nodeAffinity, or VA labels
-		key := utils.GetNamespacedKey(va.Namespace, va.GetScaleTargetName())
-		if scaleTarget, found := scaleTargets[key]; found {
-			accelerator = utils.GetAcceleratorNameFromScaleTarget(&va, scaleTarget)
-		} else {
-			// Deployment/LWS not cached, fall back to VA label via nil deployment/LWS
-			accelerator = utils.GetAcceleratorNameFromScaleTarget(&va, nil)
-		}
+		accelerator = utils.GetAcceleratorNameFromScaleTarget(&va, scaleTarget)
 	}
 
 	decision, hasDecision := common.DecisionCache.Get(va.Name, va.Namespace)
 	if !hasDecision {
-		cost, err := strconv.ParseFloat(va.Spec.VariantCost, 64)
-		if err != nil {
-			return err
-		}
+		cost := utils.ResolveVariantCost(&va, scaleTarget, e.config.PriceCatalogForNamespace(va.Namespace))
 		common.DecisionCache.Set(va.Name, va.Namespace, interfaces.VariantDecision{
 			VariantName:        va.Name,
 			Namespace:          va.Namespace,
 			ModelID:            va.Spec.ModelID,
-			Cost:               cost,
+			Cost:               cost.Cost,
 			TargetReplicas:     targetWorkloadReplicas, // Scale up to 1 replica
 			CurrentReplicas:    targetWorkloadReplicas,
 			DesiredReplicas:    targetWorkloadReplicas,
@@ -391,6 +396,11 @@ func (e *Engine) processInactiveVariant(ctx context.Context, scaleTargets map[st
 		Object: &va,
 	}
 
+	e.decisionEvents.RecordScaleFromZero(&va, targetWorkloadReplicas, reason)
+	if err := metrics.NewMetricsEmitter().EmitScaleFromZero(va.Name, va.Namespace); err != nil {
+		logger.V(logging.DEBUG).Info("Failed to emit scale-from-zero metric", "error", err)
+	}
+
 	// Log scaling decision for E2E and operators (mirrors saturation engine "Applied ... via shared cache").
 	logger.Info("Scale-from-zero decision written to cache",
 		"va", va.Name,
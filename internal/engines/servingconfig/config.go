@@ -0,0 +1,138 @@
+// Package servingconfig detects changes of the serving configuration of
+// variants, such as a new vLLM image, a different quantization or a new
+// --max-num-seqs, so that the engine stops applying knowledge learned for the
+// previous configuration.
+package servingconfig
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	saturation_v2 "github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/analyzers/saturation_v2"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+// fingerprintLength is the number of hex characters kept from the hash of a
+// configuration.
+const fingerprintLength = 16
+
+// Config is the serving configuration of a variant: what its learned
+// performance parameters and capacity depend on.
+type Config struct {
+	// Images are the container images of the leader pod template, then of
+	// the worker pod template of a LeaderWorkerSet. An image pinned by
+	// digest changes with the digest.
+	Images []string `json:"images"`
+	// Digests are the sorted image digests the containers of the newest
+	// running replica were started from, so that a tag moved to a new image
+	// is a change too. They are empty when no replica runs.
+	Digests      []string                       `json:"digests,omitempty"`
+	Accelerator  string                         `json:"accelerator"`
+	GPUCount     int                            `json:"gpuCount"`
+	EngineParams saturation_v2.VLLMEngineParams `json:"engineParams"`
+}
+
+// FromScaleTarget returns the serving configuration of a variant from its
+// scale target and the pods it runs, which resolve the image digests.
+func FromScaleTarget(
+	va *llmdVariantAutoscalingV1alpha1.VariantAutoscaling,
+	scaleTarget scaletarget.ScaleTargetAccessor,
+	pods []corev1.Pod,
+) Config {
+	config := Config{
+		Digests:      imageDigests(pods),
+		Accelerator:  utils.GetAcceleratorNameFromScaleTarget(va, scaleTarget),
+		GPUCount:     scaleTarget.GetTotalGPUsPerReplica(),
+		EngineParams: saturation_v2.ParseVLLMArgs(scaleTarget),
+	}
+	leader := scaleTarget.GetLeaderPodTemplateSpec()
+	if leader != nil {
+		for _, container := range leader.Spec.Containers {
+			config.Images = append(config.Images, container.Image)
+		}
+	}
+	if worker := scaleTarget.GetWorkerPodTemplateSpec(); worker != nil && worker != leader {
+		for _, container := range worker.Spec.Containers {
+			config.Images = append(config.Images, container.Image)
+		}
+	}
+	return config
+}
+
+// imageDigests returns the sorted image digests of the newest running pod
+// whose containers all report the image they run. The newest pod reflects the
+// latest rollout of the scale target.
+func imageDigests(pods []corev1.Pod) []string {
+	var newest *corev1.Pod
+	var digests []string
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil || len(pod.Status.ContainerStatuses) == 0 {
+			continue
+		}
+		if newest != nil && !newest.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			continue
+		}
+		podDigests := make([]string, 0, len(pod.Status.ContainerStatuses))
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.ImageID == "" {
+				podDigests = nil
+				break
+			}
+			podDigests = append(podDigests, imageDigest(status.ImageID))
+		}
+		if podDigests != nil {
+			newest, digests = pod, podDigests
+		}
+	}
+	slices.Sort(digests)
+	return digests
+}
+
+// imageDigest returns the digest of a container image ID, e.g. sha256:abc
+// for docker.io/vllm/vllm-openai@sha256:abc, without the repository and the
+// runtime-specific prefix.
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i >= 0 {
+		return imageID[i+1:]
+	}
+	return imageID
+}
+
+// Fingerprint returns a short hash of the configuration. Equal configurations
+// have equal fingerprints.
+func (c Config) Fingerprint() string {
+	// Marshaling a struct of plain fields cannot fail
+	data, _ := json.Marshal(c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:fingerprintLength]
+}
+
+// Changes lists what differs between the configuration and a previous one:
+// "image", "imageDigest", "accelerator", "gpuCount" and "engineParams".
+func (c Config) Changes(previous Config) []string {
+	var changes []string
+	if !slices.Equal(c.Images, previous.Images) {
+		changes = append(changes, "image")
+	}
+	if !slices.Equal(c.Digests, previous.Digests) {
+		changes = append(changes, "imageDigest")
+	}
+	if c.Accelerator != previous.Accelerator {
+		changes = append(changes, "accelerator")
+	}
+	if c.GPUCount != previous.GPUCount {
+		changes = append(changes, "gpuCount")
+	}
+	if c.EngineParams != previous.EngineParams {
+		changes = append(changes, "engineParams")
+	}
+	return changes
+}
@@ -0,0 +1,169 @@
+package servingconfig
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+func makeDeployment(image string, gpus int64, args ...string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"nvidia.com/gpu.product": "NVIDIA-H100-80GB-HBM3"},
+					Containers: []corev1.Container{{
+						Name:    "vllm",
+						Image:   image,
+						Command: []string{"vllm", "serve", "model-name"},
+						Args:    args,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{"nvidia.com/gpu": *resource.NewQuantity(gpus, resource.DecimalSI)},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+// makePod returns a running vLLM pod created at offset after a fixed time,
+// whose container runs the image with the given digest.
+func makePod(offset time.Duration, digest string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC).Add(offset)),
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:    "vllm",
+				ImageID: "docker.io/vllm/vllm-openai@" + digest,
+			}},
+		},
+	}
+}
+
+func TestFromScaleTarget(t *testing.T) {
+	deploy := makeDeployment("vllm/vllm-openai:v0.10.0", 2, "--max-num-seqs=128")
+	config := FromScaleTarget(nil, scaletarget.NewDeploymentAccessor(deploy), nil)
+
+	if !slices.Equal(config.Images, []string{"vllm/vllm-openai:v0.10.0"}) {
+		t.Errorf("Images = %v, want the vLLM image once", config.Images)
+	}
+	if config.Accelerator != "NVIDIA-H100-80GB-HBM3" {
+		t.Errorf("Accelerator = %q, want NVIDIA-H100-80GB-HBM3", config.Accelerator)
+	}
+	if config.GPUCount != 2 {
+		t.Errorf("GPUCount = %d, want 2", config.GPUCount)
+	}
+	if config.EngineParams.MaxNumSeqs != 128 {
+		t.Errorf("MaxNumSeqs = %d, want 128", config.EngineParams.MaxNumSeqs)
+	}
+}
+
+func TestFromScaleTarget_Digests(t *testing.T) {
+	deploy := scaletarget.NewDeploymentAccessor(makeDeployment("vllm/vllm-openai:v0.10.0", 1))
+	pending := makePod(2*time.Minute, "sha256:ccc")
+	pending.Status.Phase = corev1.PodPending
+	pulling := makePod(3*time.Minute, "")
+	pulling.Status.ContainerStatuses[0].ImageID = ""
+
+	config := FromScaleTarget(nil, deploy, []corev1.Pod{makePod(time.Minute, "sha256:bbb"), makePod(0, "sha256:aaa"), pending, pulling})
+	if !slices.Equal(config.Digests, []string{"sha256:bbb"}) {
+		t.Errorf("Digests = %v, want the digest of the newest running pod", config.Digests)
+	}
+	if config := FromScaleTarget(nil, deploy, nil); config.Digests != nil {
+		t.Errorf("Digests = %v, want none without running pods", config.Digests)
+	}
+}
+
+func TestFromScaleTarget_LeaderWorkerSet(t *testing.T) {
+	leader := makeDeployment("vllm/vllm-openai:v0.10.0", 1).Spec.Template
+	worker := makeDeployment("vllm/vllm-openai:v0.10.1", 1).Spec.Template
+	lws := &lwsv1.LeaderWorkerSet{
+		Spec: lwsv1.LeaderWorkerSetSpec{
+			LeaderWorkerTemplate: lwsv1.LeaderWorkerTemplate{
+				LeaderTemplate: &leader,
+				WorkerTemplate: worker,
+			},
+		},
+	}
+	config := FromScaleTarget(nil, scaletarget.NewLWSAccessor(lws), nil)
+
+	if want := []string{"vllm/vllm-openai:v0.10.0", "vllm/vllm-openai:v0.10.1"}; !slices.Equal(config.Images, want) {
+		t.Errorf("Images = %v, want %v", config.Images, want)
+	}
+}
+
+func TestConfig_FingerprintAndChanges(t *testing.T) {
+	base := makeDeployment("vllm/vllm-openai:v0.10.0", 1, "--max-num-seqs=128")
+	fingerprint := func(deploy *appsv1.Deployment, pods []corev1.Pod) Config {
+		return FromScaleTarget(nil, scaletarget.NewDeploymentAccessor(deploy), pods)
+	}
+	previous := fingerprint(base, []corev1.Pod{makePod(0, "sha256:aaa")})
+
+	tests := []struct {
+		name    string
+		deploy  *appsv1.Deployment
+		pods    []corev1.Pod
+		changes []string
+	}{
+		{
+			name:   "same configuration",
+			deploy: makeDeployment("vllm/vllm-openai:v0.10.0", 1, "--max-num-seqs=128"),
+			pods:   []corev1.Pod{makePod(0, "sha256:aaa")},
+		},
+		{
+			name:    "same tag, new digest",
+			deploy:  makeDeployment("vllm/vllm-openai:v0.10.0", 1, "--max-num-seqs=128"),
+			pods:    []corev1.Pod{makePod(0, "sha256:aaa"), makePod(time.Minute, "sha256:bbb")},
+			changes: []string{"imageDigest"},
+		},
+		{
+			name:    "new image",
+			deploy:  makeDeployment("vllm/vllm-openai:v0.11.0", 1, "--max-num-seqs=128"),
+			changes: []string{"image"},
+		},
+		{
+			name:    "new max-num-seqs",
+			deploy:  makeDeployment("vllm/vllm-openai:v0.10.0", 1, "--max-num-seqs=256"),
+			changes: []string{"engineParams"},
+		},
+		{
+			name:    "new quantization",
+			deploy:  makeDeployment("vllm/vllm-openai:v0.10.0", 1, "--max-num-seqs=128", "--quantization=fp8"),
+			changes: []string{"engineParams"},
+		},
+		{
+			name:    "more GPUs",
+			deploy:  makeDeployment("vllm/vllm-openai:v0.10.0", 2, "--max-num-seqs=128"),
+			changes: []string{"gpuCount"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods := tt.pods
+			if pods == nil {
+				pods = []corev1.Pod{makePod(0, "sha256:aaa")}
+			}
+			current := fingerprint(tt.deploy, pods)
+			if got := current.Changes(previous); !slices.Equal(got, tt.changes) {
+				t.Errorf("Changes() = %v, want %v", got, tt.changes)
+			}
+			if same := current.Fingerprint() == previous.Fingerprint(); same != (len(tt.changes) == 0) {
+				t.Errorf("Fingerprint() %s vs %s, want equal only without changes",
+					current.Fingerprint(), previous.Fingerprint())
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package servingconfig
+
+import (
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// ApplyRelearningMargin lowers the per-replica capacity of the re-learning
+// variants of a result by margin (0-1), since it was estimated with knowledge
+// that may still describe their previous serving configuration, and raises
+// result.RequiredCapacity to cover the demand with the lowered capacity.
+// Pending replicas count towards supply, as in the analyzers.
+//
+// scaleUpThreshold is the target utilization used by the analyzer to derive
+// RequiredCapacity (1.0 for analyzers that size directly against demand).
+// SpareCapacity is cleared when a variant is re-learning, so that the model
+// does not scale down on capacity estimates that are not trusted yet.
+// Returns the capacity added on top of the analyzer's own signal.
+func ApplyRelearningMargin(result *interfaces.AnalyzerResult, relearning map[string]bool, margin, scaleUpThreshold float64) float64 {
+	if result == nil || len(relearning) == 0 || margin <= 0 {
+		return 0
+	}
+	if scaleUpThreshold <= 0 {
+		scaleUpThreshold = 1.0
+	}
+	margin = min(margin, 1)
+
+	var affected bool
+	var totalSupply, anticipatedSupply float64
+	for i := range result.VariantCapacities {
+		vc := &result.VariantCapacities[i]
+		if relearning[vc.VariantName] {
+			affected = true
+			vc.PerReplicaCapacity *= 1 - margin
+			vc.TotalCapacity = float64(vc.ReplicaCount) * vc.PerReplicaCapacity
+			if vc.TotalCapacity > 0 {
+				vc.Utilization = vc.TotalDemand / vc.TotalCapacity
+			}
+		}
+		totalSupply += vc.TotalCapacity
+		anticipatedSupply += float64(vc.ReplicaCount+vc.PendingReplicas) * vc.PerReplicaCapacity
+	}
+	if !affected {
+		return 0
+	}
+
+	result.TotalSupply = totalSupply
+	if totalSupply > 0 {
+		result.Utilization = result.TotalDemand / totalSupply
+	}
+	result.SpareCapacity = 0
+
+	required := result.TotalDemand/scaleUpThreshold - anticipatedSupply
+	if required <= result.RequiredCapacity {
+		return 0
+	}
+	added := required - result.RequiredCapacity
+	result.RequiredCapacity = required
+	return added
+}
@@ -0,0 +1,50 @@
+package servingconfig
+
+import (
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestApplyRelearningMargin(t *testing.T) {
+	newResult := func() *interfaces.AnalyzerResult {
+		return &interfaces.AnalyzerResult{
+			VariantCapacities: []interfaces.VariantCapacity{
+				{VariantName: "new", ReplicaCount: 2, PerReplicaCapacity: 100, TotalCapacity: 200, TotalDemand: 150},
+				{VariantName: "old", ReplicaCount: 1, PendingReplicas: 1, PerReplicaCapacity: 100, TotalCapacity: 100, TotalDemand: 50},
+			},
+			TotalSupply:   300,
+			TotalDemand:   200,
+			SpareCapacity: 100,
+		}
+	}
+
+	result := newResult()
+	if added := ApplyRelearningMargin(result, nil, 0.2, 1.0); added != 0 || result.SpareCapacity != 100 {
+		t.Errorf("Without re-learning variants: added %v, spare %v, want 0 and 100", added, result.SpareCapacity)
+	}
+
+	// new: 2 × 80, old: 2 × 100 with the pending replica, for a demand of 200 / 0.8
+	result = newResult()
+	added := ApplyRelearningMargin(result, map[string]bool{"new": true}, 0.2, 0.8)
+	if result.VariantCapacities[0].PerReplicaCapacity != 80 || result.VariantCapacities[0].TotalCapacity != 160 {
+		t.Errorf("Re-learning variant capacity = %+v, want 80 per replica", result.VariantCapacities[0])
+	}
+	if result.VariantCapacities[1].PerReplicaCapacity != 100 {
+		t.Errorf("Other variant capacity = %+v, want unchanged", result.VariantCapacities[1])
+	}
+	if result.TotalSupply != 260 || result.SpareCapacity != 0 {
+		t.Errorf("Supply %v, spare %v, want 260 and 0", result.TotalSupply, result.SpareCapacity)
+	}
+	if added != 0 || result.RequiredCapacity != 0 {
+		t.Errorf("Added %v, required %v, want no added capacity while supply covers demand", added, result.RequiredCapacity)
+	}
+
+	// demand above the lowered supply is required
+	result = newResult()
+	result.TotalDemand = 400
+	added = ApplyRelearningMargin(result, map[string]bool{"new": true, "old": true}, 0.5, 1.0)
+	if added != 200 || result.RequiredCapacity != 200 {
+		t.Errorf("Added %v, required %v, want 200 and 200", added, result.RequiredCapacity)
+	}
+}
@@ -0,0 +1,150 @@
+package servingconfig
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRelearnWindow is how long after a change of its serving
+	// configuration a variant is considered to be re-learning its parameters.
+	// It covers the convergence of the queueing model tuner, which takes a few
+	// cycles after the new replicas receive traffic, and a rollout of the
+	// scale target.
+	DefaultRelearnWindow = 30 * time.Minute
+
+	// DefaultRelearningMargin is the share of the per-replica capacity of a
+	// re-learning variant that is held back as safety margin.
+	DefaultRelearningMargin = 0.2
+)
+
+// Change is a change of the serving configuration of a variant.
+type Change struct {
+	Namespace   string
+	VariantName string
+
+	Previous            Config
+	PreviousFingerprint string
+	Current             Config
+	Fingerprint         string
+}
+
+// String describes the change for Events and logs.
+func (c *Change) String() string {
+	return fmt.Sprintf("serving configuration changed (%v): fingerprint %s -> %s",
+		c.Current.Changes(c.Previous), c.PreviousFingerprint, c.Fingerprint)
+}
+
+// variantConfig is the last observed serving configuration of a variant.
+type variantConfig struct {
+	config      Config
+	fingerprint string
+	changedAt   time.Time // zero if never changed since first observed
+	lastSeen    time.Time
+}
+
+// Tracker remembers the serving configuration of each variant, and when it
+// last changed. It is fed once per engine cycle with the configuration of
+// each variant read from its scale target.
+type Tracker struct {
+	mu            sync.RWMutex
+	relearnWindow time.Duration
+	variants      map[string]*variantConfig
+}
+
+// NewTracker creates a tracker with the default re-learn window.
+func NewTracker() *Tracker {
+	return &Tracker{
+		relearnWindow: DefaultRelearnWindow,
+		variants:      make(map[string]*variantConfig),
+	}
+}
+
+// variantKey builds the map key for a variant. The pipe delimiter cannot
+// appear in Kubernetes resource names.
+func variantKey(namespace, variantName string) string {
+	return fmt.Sprintf("%s|%s", namespace, variantName)
+}
+
+// Observe records the current serving configuration of a variant, and returns
+// the change from the previously observed configuration, or nil if it did not
+// change. The first observation of a variant is not a change: there is no
+// knowledge learned for another configuration yet.
+//
+// Image digests are only known while a replica runs: the last known digests
+// are kept while none runs, unless the images changed, and digests known for
+// the first time are not a change.
+func (t *Tracker) Observe(namespace, variantName string, config Config, now time.Time) *Change {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := variantKey(namespace, variantName)
+	v, ok := t.variants[key]
+	if !ok {
+		t.variants[key] = &variantConfig{config: config, fingerprint: config.Fingerprint(), lastSeen: now}
+		return nil
+	}
+	v.lastSeen = now
+	if len(config.Digests) == 0 {
+		if slices.Equal(config.Images, v.config.Images) {
+			config.Digests = v.config.Digests
+		}
+	} else if len(v.config.Digests) == 0 {
+		v.config.Digests = config.Digests
+		v.fingerprint = v.config.Fingerprint()
+	}
+	fingerprint := config.Fingerprint()
+	if v.fingerprint == fingerprint {
+		return nil
+	}
+
+	change := &Change{
+		Namespace:           namespace,
+		VariantName:         variantName,
+		Previous:            v.config,
+		PreviousFingerprint: v.fingerprint,
+		Current:             config,
+		Fingerprint:         fingerprint,
+	}
+	v.config, v.fingerprint, v.changedAt = config, fingerprint, now
+	return change
+}
+
+// Fingerprint returns the fingerprint of the last observed serving
+// configuration of a variant, and false if the variant was not observed.
+func (t *Tracker) Fingerprint(namespace, variantName string) (string, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	v, ok := t.variants[variantKey(namespace, variantName)]
+	if !ok {
+		return "", false
+	}
+	return v.fingerprint, true
+}
+
+// Relearning reports whether the serving configuration of a variant changed
+// within the re-learn window.
+func (t *Tracker) Relearning(namespace, variantName string, now time.Time) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	v, ok := t.variants[variantKey(namespace, variantName)]
+	return ok && !v.changedAt.IsZero() && now.Sub(v.changedAt) < t.relearnWindow
+}
+
+// EvictStale removes variants that have not been observed within timeout,
+// e.g. after their VariantAutoscaling was deleted. Returns the number evicted.
+func (t *Tracker) EvictStale(now time.Time, timeout time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	evicted := 0
+	for key, v := range t.variants {
+		if now.Sub(v.lastSeen) > timeout {
+			delete(t.variants, key)
+			evicted++
+		}
+	}
+	return evicted
+}
@@ -0,0 +1,93 @@
+package servingconfig
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTracker_Observe(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	v1 := Config{Images: []string{"vllm/vllm-openai:v0.10.0"}, GPUCount: 1}
+	v2 := Config{Images: []string{"vllm/vllm-openai:v0.11.0"}, GPUCount: 1}
+
+	if change := tracker.Observe("ns", "variant", v1, now); change != nil {
+		t.Fatalf("First observation reported a change: %s", change)
+	}
+	if tracker.Relearning("ns", "variant", now) {
+		t.Error("Variant re-learning without a change")
+	}
+	if change := tracker.Observe("ns", "variant", v1, now.Add(time.Minute)); change != nil {
+		t.Fatalf("Same configuration reported a change: %s", change)
+	}
+
+	changedAt := now.Add(2 * time.Minute)
+	change := tracker.Observe("ns", "variant", v2, changedAt)
+	if change == nil {
+		t.Fatal("New image not reported as a change")
+	}
+	if change.PreviousFingerprint != v1.Fingerprint() || change.Fingerprint != v2.Fingerprint() {
+		t.Errorf("Change fingerprints %s -> %s, want %s -> %s",
+			change.PreviousFingerprint, change.Fingerprint, v1.Fingerprint(), v2.Fingerprint())
+	}
+	if fingerprint, ok := tracker.Fingerprint("ns", "variant"); !ok || fingerprint != v2.Fingerprint() {
+		t.Errorf("Fingerprint() = %s, %v, want %s", fingerprint, ok, v2.Fingerprint())
+	}
+
+	// the variant re-learns for the window after the change, in its namespace only
+	if !tracker.Relearning("ns", "variant", changedAt.Add(DefaultRelearnWindow-time.Second)) {
+		t.Error("Variant not re-learning within the window")
+	}
+	if tracker.Relearning("ns", "variant", changedAt.Add(DefaultRelearnWindow)) {
+		t.Error("Variant still re-learning after the window")
+	}
+	if tracker.Relearning("other", "variant", changedAt) {
+		t.Error("Variant of another namespace re-learning")
+	}
+}
+
+func TestTracker_ObserveDigests(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	image := []string{"vllm/vllm-openai:latest"}
+	withDigest := func(digests ...string) Config {
+		return Config{Images: image, Digests: digests, GPUCount: 1}
+	}
+
+	steps := []struct {
+		name   string
+		config Config
+		change bool
+	}{
+		{name: "first observation without replicas", config: withDigest()},
+		{name: "digest known for the first time", config: withDigest("sha256:aaa")},
+		{name: "scaled to zero", config: withDigest()},
+		{name: "scaled up again", config: withDigest("sha256:aaa")},
+		{name: "tag moved to a new digest", config: withDigest("sha256:bbb"), change: true},
+		{name: "new tag without replicas", config: Config{Images: []string{"vllm/vllm-openai:v0.11.0"}, GPUCount: 1}, change: true},
+		{name: "digest of the new tag", config: Config{Images: []string{"vllm/vllm-openai:v0.11.0"}, Digests: []string{"sha256:ccc"}, GPUCount: 1}},
+	}
+	for i, step := range steps {
+		change := tracker.Observe("ns", "variant", step.config, now.Add(time.Duration(i)*time.Minute))
+		if (change != nil) != step.change {
+			t.Errorf("%s: change = %v, want change %v", step.name, change, step.change)
+		}
+	}
+}
+
+func TestTracker_EvictStale(t *testing.T) {
+	tracker := NewTracker()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker.Observe("ns", "old", Config{}, now)
+	tracker.Observe("ns", "recent", Config{}, now.Add(time.Hour))
+
+	if evicted := tracker.EvictStale(now.Add(90*time.Minute), time.Hour); evicted != 1 {
+		t.Errorf("EvictStale() = %d, want 1", evicted)
+	}
+	if _, ok := tracker.Fingerprint("ns", "old"); ok {
+		t.Error("Stale variant not evicted")
+	}
+	if _, ok := tracker.Fingerprint("ns", "recent"); !ok {
+		t.Error("Recent variant evicted")
+	}
+}
@@ -0,0 +1,160 @@
+package externalmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// groupPath and versionPath are the discovery paths of the API.
+const (
+	groupPath   = "/apis/" + GroupName
+	versionPath = groupPath + "/" + Version
+)
+
+// MetricsLister returns the values of an external metric. It is implemented
+// by Provider.
+type MetricsLister interface {
+	Values(ctx context.Context, namespace, metricName string, selector labels.Selector) ([]ExternalMetricValue, error)
+}
+
+// Handler serves the external metrics API:
+//
+//	GET /apis/external.metrics.k8s.io                                     API group
+//	GET /apis/external.metrics.k8s.io/v1beta1                             metrics served
+//	GET /apis/external.metrics.k8s.io/v1beta1/namespaces/<ns>/<metric>    values, filtered by ?labelSelector=
+//
+// It does not authenticate or authorize requests, see Server.
+type Handler struct {
+	lister MetricsLister
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates a Handler serving the values of the lister.
+func NewHandler(lister MetricsLister) *Handler {
+	return &Handler{lister: lister}
+}
+
+// ServeHTTP serves the API. Only GET is allowed.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeStatus(w, http.StatusMethodNotAllowed, metav1.StatusReasonMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	switch path {
+	case groupPath:
+		writeJSON(r.Context(), w, apiGroup())
+		return
+	case versionPath:
+		writeJSON(r.Context(), w, apiResourceList())
+		return
+	}
+
+	namespace, metricName, ok := parseMetricPath(path)
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the server could not find the requested resource")
+		return
+	}
+	selector, err := labels.Parse(r.URL.Query().Get("labelSelector"))
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, metav1.StatusReasonBadRequest, "invalid labelSelector: "+err.Error())
+		return
+	}
+
+	values, err := h.lister.Values(r.Context(), namespace, metricName, selector)
+	if errors.Is(err, ErrUnknownMetric) {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		ctrl.LoggerFrom(r.Context()).Error(err, "Failed to compute external metric",
+			"namespace", namespace, "metric", metricName)
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, err.Error())
+		return
+	}
+	writeJSON(r.Context(), w, &ExternalMetricValueList{
+		TypeMeta: metav1.TypeMeta{Kind: "ExternalMetricValueList", APIVersion: GroupVersion},
+		Items:    values,
+	})
+}
+
+// parseMetricPath returns the namespace and metric of a path of the form
+// /apis/external.metrics.k8s.io/v1beta1/namespaces/<ns>/<metric>.
+func parseMetricPath(path string) (namespace, metricName string, ok bool) {
+	rest, found := strings.CutPrefix(path, versionPath+"/namespaces/")
+	if !found {
+		return "", "", false
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// apiGroup is the discovery document of the API group.
+func apiGroup() *metav1.APIGroup {
+	version := metav1.GroupVersionForDiscovery{GroupVersion: GroupVersion, Version: Version}
+	return &metav1.APIGroup{
+		TypeMeta:         metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"},
+		Name:             GroupName,
+		Versions:         []metav1.GroupVersionForDiscovery{version},
+		PreferredVersion: version,
+	}
+}
+
+// apiResourceList is the discovery document of the API version: one
+// namespaced resource per metric served.
+func apiResourceList() *metav1.APIResourceList {
+	list := &metav1.APIResourceList{
+		TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+		GroupVersion: GroupVersion,
+	}
+	for _, name := range Metrics {
+		list.APIResources = append(list.APIResources, metav1.APIResource{
+			Name:       name,
+			Namespaced: true,
+			Kind:       "ExternalMetricValueList",
+			Verbs:      metav1.Verbs{"get"},
+		})
+	}
+	return list
+}
+
+// writeJSON writes the object as a JSON response.
+func writeJSON(ctx context.Context, w http.ResponseWriter, obj any) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to encode external metrics response")
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
+
+// writeStatus writes a failure as a Kubernetes Status, which is how clients of
+// aggregated APIs expect errors.
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	}
+	data, _ := json.Marshal(status)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
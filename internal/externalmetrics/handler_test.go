@@ -0,0 +1,164 @@
+package externalmetrics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+// fakeLister returns one value of the metric when the selector matches its
+// labels.
+type fakeLister struct {
+	err error
+}
+
+func (l fakeLister) Values(_ context.Context, namespace, metricName string, selector labels.Selector) ([]ExternalMetricValue, error) {
+	if l.err != nil {
+		return nil, l.err
+	}
+	metricLabels := map[string]string{"variant_name": "llama-a100", "namespace": namespace}
+	if !selector.Matches(labels.Set(metricLabels)) {
+		return []ExternalMetricValue{}, nil
+	}
+	return []ExternalMetricValue{{
+		MetricName:   metricName,
+		MetricLabels: metricLabels,
+		Value:        resource.MustParse("3"),
+	}}, nil
+}
+
+func serve(t *testing.T, lister MetricsLister, method, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	NewHandler(lister).ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Expected JSON content type, got %q", ct)
+	}
+	return rec
+}
+
+func decode[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+	var obj T
+	if err := json.Unmarshal(rec.Body.Bytes(), &obj); err != nil {
+		t.Fatalf("Failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	return obj
+}
+
+func TestHandlerDiscovery(t *testing.T) {
+	rec := serve(t, fakeLister{}, http.MethodGet, "/apis/external.metrics.k8s.io")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	group := decode[metav1.APIGroup](t, rec)
+	if group.Name != GroupName || group.PreferredVersion.GroupVersion != GroupVersion {
+		t.Errorf("Unexpected API group %+v", group)
+	}
+
+	rec = serve(t, fakeLister{}, http.MethodGet, "/apis/external.metrics.k8s.io/v1beta1/")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	resources := decode[metav1.APIResourceList](t, rec)
+	if resources.GroupVersion != GroupVersion || len(resources.APIResources) != len(Metrics) {
+		t.Fatalf("Unexpected API resources %+v", resources)
+	}
+	for i, r := range resources.APIResources {
+		if r.Name != Metrics[i] || !r.Namespaced {
+			t.Errorf("Expected namespaced resource %q, got %+v", Metrics[i], r)
+		}
+	}
+}
+
+func TestHandlerValues(t *testing.T) {
+	path := "/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_desired_replicas"
+
+	rec := serve(t, fakeLister{}, http.MethodGet, path+"?labelSelector=variant_name%3Dllama-a100")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	list := decode[ExternalMetricValueList](t, rec)
+	if list.Kind != "ExternalMetricValueList" || list.APIVersion != GroupVersion {
+		t.Errorf("Unexpected type %+v", list.TypeMeta)
+	}
+	if len(list.Items) != 1 || list.Items[0].MetricName != "wva_desired_replicas" ||
+		list.Items[0].MetricLabels["namespace"] != "llm-d" || list.Items[0].Value.String() != "3" {
+		t.Errorf("Unexpected values %+v", list.Items)
+	}
+
+	// No match is an empty list, not null
+	rec = serve(t, fakeLister{}, http.MethodGet, path+"?labelSelector=variant_name%3Dother")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rec.Code)
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &raw); err != nil {
+		t.Fatal(err)
+	}
+	if string(raw["items"]) != "[]" {
+		t.Errorf("Expected empty items, got %s", raw["items"])
+	}
+}
+
+func TestHandlerErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		lister fakeLister
+		method string
+		target string
+		code   int
+	}{
+		{"write", fakeLister{}, http.MethodPost, "/apis/external.metrics.k8s.io/v1beta1", http.StatusMethodNotAllowed},
+		{"unknown path", fakeLister{}, http.MethodGet, "/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d", http.StatusNotFound},
+		{"other group", fakeLister{}, http.MethodGet, "/apis/custom.metrics.k8s.io/v1beta1", http.StatusNotFound},
+		{"invalid selector", fakeLister{}, http.MethodGet,
+			"/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_desired_replicas?labelSelector=%3D%3D", http.StatusBadRequest},
+		{"unknown metric", fakeLister{err: ErrUnknownMetric}, http.MethodGet,
+			"/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_current_replicas", http.StatusNotFound},
+		{"lister failure", fakeLister{err: errors.New("cache not synced")}, http.MethodGet,
+			"/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_desired_replicas", http.StatusInternalServerError},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := serve(t, tc.lister, tc.method, tc.target)
+			if rec.Code != tc.code {
+				t.Fatalf("Expected status %d, got %d", tc.code, rec.Code)
+			}
+			status := decode[metav1.Status](t, rec)
+			if status.Kind != "Status" || status.Code != int32(tc.code) {
+				t.Errorf("Unexpected status %+v", status)
+			}
+		})
+	}
+}
+
+func TestRequestAttributes(t *testing.T) {
+	attributes := requestAttributes(httptest.NewRequest(http.MethodGet,
+		"/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_desired_ratio?labelSelector=a%3Db", nil))
+	want := authorizer.AttributesRecord{
+		Verb:            "list",
+		Namespace:       "llm-d",
+		APIGroup:        GroupName,
+		APIVersion:      Version,
+		Resource:        "wva_desired_ratio",
+		ResourceRequest: true,
+		Path:            "/apis/external.metrics.k8s.io/v1beta1/namespaces/llm-d/wva_desired_ratio",
+	}
+	if !reflect.DeepEqual(attributes, want) {
+		t.Errorf("Expected %+v, got %+v", want, attributes)
+	}
+
+	attributes = requestAttributes(httptest.NewRequest(http.MethodGet, "/apis/external.metrics.k8s.io/v1beta1", nil))
+	if attributes.ResourceRequest || attributes.Verb != "get" {
+		t.Errorf("Expected a non-resource get for discovery, got %+v", attributes)
+	}
+}
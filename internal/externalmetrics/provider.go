@@ -0,0 +1,160 @@
+package externalmetrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/metrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+)
+
+// Metrics are the external metrics served. They have the names and labels of
+// the Prometheus metrics emitted by the actuator, so an HPA written for
+// prometheus-adapter works unchanged.
+var Metrics = []string{constants.WVADesiredReplicas, constants.WVADesiredRatio}
+
+// labelExportedNamespace is the namespace label of the metrics once scraped
+// by Prometheus, as it conflicts with the namespace of the controller.
+const labelExportedNamespace = "exported_namespace"
+
+// ErrUnknownMetric is returned for a metric that is not served.
+var ErrUnknownMetric = fmt.Errorf("unknown external metric, expected one of %v", Metrics)
+
+// Provider computes the external metrics of the VariantAutoscalings.
+type Provider struct {
+	client client.Client
+}
+
+// NewProvider creates a Provider reading VariantAutoscalings and their scale
+// targets with the given client.
+func NewProvider(c client.Client) *Provider {
+	return &Provider{client: c}
+}
+
+// replicaState is what the actuator reports for a variant.
+type replicaState struct {
+	desired     int32
+	current     int32
+	accelerator string
+	timestamp   metav1.Time
+}
+
+// Values returns the values of the metric for the VariantAutoscalings in the
+// namespace whose labels match the selector: variant_name, accelerator_type,
+// namespace and, when CONTROLLER_INSTANCE is set, controller_instance. The
+// namespace is also set as exported_namespace, its name once scraped by
+// Prometheus, which HPAs written for prometheus-adapter select.
+//
+// The desired replicas are taken from the DecisionCache, which is only filled
+// on the leader. Other replicas, and the leader before its first cycle, use
+// the optimized allocation the leader wrote in the VariantAutoscaling status.
+// Variants in shadow mode report their current replicas, as the actuator does.
+func (p *Provider) Values(ctx context.Context, namespace, metricName string, selector labels.Selector) ([]ExternalMetricValue, error) {
+	if !slices.Contains(Metrics, metricName) {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownMetric, metricName)
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	listOpts := []client.ListOption{client.InNamespace(namespace)}
+	controllerInstance := metrics.GetControllerInstance()
+	if controllerInstance != "" {
+		listOpts = append(listOpts, client.MatchingLabels{constants.ControllerInstanceLabelKey: controllerInstance})
+	}
+	var vaList wvav1alpha1.VariantAutoscalingList
+	if err := p.client.List(ctx, &vaList, listOpts...); err != nil {
+		return nil, fmt.Errorf("listing VariantAutoscalings in %s: %w", namespace, err)
+	}
+
+	act := actuator.NewActuator(p.client)
+	values := []ExternalMetricValue{}
+	for i := range vaList.Items {
+		va := &vaList.Items[i]
+		if !va.DeletionTimestamp.IsZero() {
+			continue
+		}
+		state, ok := desiredState(va)
+		if !ok {
+			continue
+		}
+
+		metricLabels := map[string]string{
+			constants.LabelVariantName:     va.Name,
+			constants.LabelAcceleratorType: state.accelerator,
+			constants.LabelNamespace:       va.Namespace,
+			labelExportedNamespace:         va.Namespace,
+		}
+		if controllerInstance != "" {
+			metricLabels[constants.LabelControllerInstance] = controllerInstance
+		}
+		if !selector.Matches(labels.Set(metricLabels)) {
+			continue
+		}
+
+		current, err := act.GetCurrentScaleTargetReplicasFromVA(ctx, va)
+		if err != nil {
+			logger.V(logging.DEBUG).Info("Could not get current scale target replicas, using 0",
+				"variantName", va.Name, "namespace", va.Namespace, "error", err.Error())
+			current = 0
+		}
+		state.current = current
+		if utils.IsShadowMode(ctx, p.client, va) {
+			state.desired = current
+		}
+
+		values = append(values, ExternalMetricValue{
+			MetricName:   metricName,
+			MetricLabels: metricLabels,
+			Timestamp:    state.timestamp,
+			Value:        state.value(metricName),
+		})
+	}
+	return values, nil
+}
+
+// desiredState returns the desired replicas and accelerator of a variant, from
+// the DecisionCache or else from its status. It returns false when the variant
+// has no optimization decision yet.
+func desiredState(va *wvav1alpha1.VariantAutoscaling) (replicaState, bool) {
+	if decision, ok := common.DecisionCache.Get(va.Name, va.Namespace); ok && decision.AcceleratorName != "" {
+		return replicaState{
+			desired:     int32(decision.TargetReplicas),
+			accelerator: decision.AcceleratorName,
+			timestamp:   decision.LastRunTime,
+		}, true
+	}
+	alloc := va.Status.DesiredOptimizedAlloc
+	if alloc.NumReplicas == nil {
+		return replicaState{}, false
+	}
+	return replicaState{
+		desired:     *alloc.NumReplicas,
+		accelerator: alloc.Accelerator,
+		timestamp:   alloc.LastRunTime,
+	}, true
+}
+
+// value returns the value of the metric. The desired ratio is the desired
+// replicas when there are no current replicas, as in the Prometheus metric.
+func (s replicaState) value(metricName string) resource.Quantity {
+	if metricName == constants.WVADesiredReplicas {
+		return *resource.NewQuantity(int64(s.desired), resource.DecimalSI)
+	}
+	ratio := float64(s.desired)
+	if s.current > 0 {
+		ratio /= float64(s.current)
+	}
+	return *resource.NewMilliQuantity(int64(math.Round(ratio*1000)), resource.DecimalSI)
+}
@@ -0,0 +1,151 @@
+package externalmetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+const testNamespace = "external-metrics-test"
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, appsv1.AddToScheme, wvav1alpha1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// newTestVariant returns a VA with the given desired replicas in its status,
+// if any, and its Deployment with the given current replicas.
+func newTestVariant(name, accelerator string, desired *int32, current int32, annotations map[string]string) (*wvav1alpha1.VariantAutoscaling, *appsv1.Deployment) {
+	va := &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Annotations: annotations},
+		Spec: wvav1alpha1.VariantAutoscalingSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+		},
+	}
+	if desired != nil {
+		va.Status.DesiredOptimizedAlloc = wvav1alpha1.OptimizedAlloc{NumReplicas: desired, Accelerator: accelerator}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(current)},
+		Status:     appsv1.DeploymentStatus{Replicas: current},
+	}
+	return va, deployment
+}
+
+func TestProviderValues(t *testing.T) {
+	// Decided by this replica's engine: the cached decision wins over the status
+	cachedVA, cachedDeployment := newTestVariant("ext-cached-a100", "A100", ptr.To(int32(1)), 2, nil)
+	common.DecisionCache.Set(cachedVA.Name, testNamespace, interfaces.VariantDecision{
+		VariantName:     cachedVA.Name,
+		Namespace:       testNamespace,
+		TargetReplicas:  3,
+		AcceleratorName: "A100",
+	})
+	// Decided by the leader: read from the status
+	statusVA, statusDeployment := newTestVariant("ext-status-h100", "H100", ptr.To(int32(4)), 0, nil)
+	// In shadow mode: the current replicas
+	shadowVA, shadowDeployment := newTestVariant("ext-shadow-l40s", "L40S", ptr.To(int32(5)), 2,
+		map[string]string{constants.ShadowModeAnnotationKey: constants.AnnotationValueTrue})
+	// Not optimized yet: no value
+	pendingVA, pendingDeployment := newTestVariant("ext-pending-a100", "A100", nil, 1, nil)
+
+	provider := NewProvider(newTestClient(t,
+		cachedVA, cachedDeployment, statusVA, statusDeployment,
+		shadowVA, shadowDeployment, pendingVA, pendingDeployment))
+
+	for _, tc := range []struct {
+		name     string
+		metric   string
+		selector string
+		want     map[string]string
+	}{
+		{
+			name:   "desired replicas",
+			metric: constants.WVADesiredReplicas,
+			want:   map[string]string{cachedVA.Name: "3", statusVA.Name: "4", shadowVA.Name: "2"},
+		},
+		{
+			name:   "desired ratio",
+			metric: constants.WVADesiredRatio,
+			want:   map[string]string{cachedVA.Name: "1500m", statusVA.Name: "4", shadowVA.Name: "1"},
+		},
+		{
+			name:     "selected variant",
+			metric:   constants.WVADesiredReplicas,
+			selector: constants.LabelVariantName + "=" + statusVA.Name,
+			want:     map[string]string{statusVA.Name: "4"},
+		},
+		{
+			name:     "selected as by prometheus-adapter",
+			metric:   constants.WVADesiredReplicas,
+			selector: constants.LabelVariantName + "=" + cachedVA.Name + ",exported_namespace=" + testNamespace,
+			want:     map[string]string{cachedVA.Name: "3"},
+		},
+		{
+			name:     "selected accelerator",
+			metric:   constants.WVADesiredReplicas,
+			selector: constants.LabelAcceleratorType + " in (A100,L40S)",
+			want:     map[string]string{cachedVA.Name: "3", shadowVA.Name: "2"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			selector, err := labels.Parse(tc.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			values, err := provider.Values(context.Background(), testNamespace, tc.metric, selector)
+			if err != nil {
+				t.Fatalf("Values() failed: %v", err)
+			}
+			got := make(map[string]string, len(values))
+			for _, value := range values {
+				if value.MetricName != tc.metric {
+					t.Errorf("Expected metric name %q, got %q", tc.metric, value.MetricName)
+				}
+				got[value.MetricLabels[constants.LabelVariantName]] = value.Value.String()
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("Expected values %v, got %v", tc.want, got)
+			}
+			for variant, want := range tc.want {
+				if got[variant] != want {
+					t.Errorf("%s: expected %s, got %s", variant, want, got[variant])
+				}
+			}
+		})
+	}
+}
+
+func TestProviderUnknownMetric(t *testing.T) {
+	provider := NewProvider(newTestClient(t))
+	_, err := provider.Values(context.Background(), testNamespace, "wva_current_replicas", labels.Everything())
+	if !errors.Is(err, ErrUnknownMetric) {
+		t.Errorf("Expected ErrUnknownMetric, got %v", err)
+	}
+}
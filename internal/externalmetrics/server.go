@@ -0,0 +1,285 @@
+package externalmetrics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/apis/apiserver"
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authentication/request/headerrequest"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	certutil "k8s.io/client-go/util/cert"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// The ConfigMap in which the kube-apiserver publishes how extension API servers
+// authenticate the requests it proxies, and its keys.
+const (
+	authenticationConfigMapNamespace = "kube-system"
+	authenticationConfigMapName      = "extension-apiserver-authentication"
+
+	requestHeaderClientCAKey     = "requestheader-client-ca-file"
+	requestHeaderUsernameKey     = "requestheader-username-headers"
+	requestHeaderUIDKey          = "requestheader-uid-headers"
+	requestHeaderGroupKey        = "requestheader-group-headers"
+	requestHeaderExtraPrefixKey  = "requestheader-extra-headers-prefix"
+	requestHeaderAllowedNamesKey = "requestheader-allowed-names"
+)
+
+const (
+	serverShutdownTimeout   = 10 * time.Second
+	serverReadHeaderTimeout = 10 * time.Second
+	tokenReviewTimeout      = 10 * time.Second
+
+	// selfSignedHost is the host of the certificate generated when none is
+	// provided.
+	selfSignedHost = "workload-variant-autoscaler-external-metrics"
+)
+
+// webhookRetryBackoff is the retry backoff of TokenReviews and
+// SubjectAccessReviews, the default of k8s.io/apiserver.
+var webhookRetryBackoff = wait.Backoff{
+	Duration: 500 * time.Millisecond,
+	Factor:   1.5,
+	Jitter:   0.2,
+	Steps:    5,
+}
+
+// Options configures a Server.
+type Options struct {
+	// BindAddress is the address the server listens on, e.g. ":6443".
+	BindAddress string
+	// GetCertificate returns the serving certificate. When nil, a self-signed
+	// certificate is generated, and the APIService must skip TLS verification.
+	GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	// TLSOpts are applied to the TLS configuration of the server.
+	TLSOpts []func(*tls.Config)
+}
+
+// Server serves a handler over TLS as an extension API server of the
+// kube-apiserver, delegating authentication and authorization to it:
+//
+//   - requests proxied by the kube-apiserver are authenticated by its
+//     front-proxy client certificate and the user headers it sets, as
+//     configured in kube-system/extension-apiserver-authentication;
+//   - requests with a bearer token are authenticated with a TokenReview;
+//   - requests are authorized with a SubjectAccessReview. The values of a
+//     metric are a "list" of the resource named after the metric in the
+//     external.metrics.k8s.io group, which is what the HPA controller is
+//     granted.
+type Server struct {
+	opts          Options
+	handler       http.Handler
+	requestHeader *headerrequest.RequestHeaderAuthRequestController
+	clientCA      *dynamiccertificates.ConfigMapCAController
+	authenticator authenticator.Request
+	authorizer    authorizer.Authorizer
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NewServer creates a Server for the handler. The clients of the
+// kube-apiserver are created from restConfig.
+func NewServer(restConfig *rest.Config, handler http.Handler, opts Options) (*Server, error) {
+	if opts.BindAddress == "" {
+		return nil, errors.New("external metrics server bind address is required")
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating Kubernetes client: %w", err)
+	}
+
+	requestHeader := headerrequest.NewRequestHeaderAuthRequestController(
+		authenticationConfigMapName, authenticationConfigMapNamespace, clientset,
+		requestHeaderUsernameKey, requestHeaderUIDKey, requestHeaderGroupKey,
+		requestHeaderExtraPrefixKey, requestHeaderAllowedNamesKey)
+	clientCA, err := dynamiccertificates.NewDynamicCAFromConfigMapController("request-header",
+		authenticationConfigMapNamespace, authenticationConfigMapName, requestHeaderClientCAKey, clientset)
+	if err != nil {
+		return nil, fmt.Errorf("creating front-proxy CA controller: %w", err)
+	}
+
+	authenticatorConfig := authenticatorfactory.DelegatingAuthenticatorConfig{
+		Anonymous:                &apiserver.AnonymousAuthConfig{Enabled: false},
+		CacheTTL:                 1 * time.Minute,
+		TokenAccessReviewClient:  clientset.AuthenticationV1(),
+		TokenAccessReviewTimeout: tokenReviewTimeout,
+		WebhookRetryBackoff:      &webhookRetryBackoff,
+		RequestHeaderConfig: &authenticatorfactory.RequestHeaderConfig{
+			UsernameHeaders:     headerrequest.StringSliceProviderFunc(requestHeader.UsernameHeaders),
+			UIDHeaders:          headerrequest.StringSliceProviderFunc(requestHeader.UIDHeaders),
+			GroupHeaders:        headerrequest.StringSliceProviderFunc(requestHeader.GroupHeaders),
+			ExtraHeaderPrefixes: headerrequest.StringSliceProviderFunc(requestHeader.ExtraHeaderPrefixes),
+			AllowedClientNames:  headerrequest.StringSliceProviderFunc(requestHeader.AllowedClientNames),
+			CAContentProvider:   clientCA,
+		},
+	}
+	authn, _, err := authenticatorConfig.New()
+	if err != nil {
+		return nil, fmt.Errorf("creating authenticator: %w", err)
+	}
+
+	authorizerConfig := authorizerfactory.DelegatingAuthorizerConfig{
+		SubjectAccessReviewClient: clientset.AuthorizationV1(),
+		AllowCacheTTL:             5 * time.Minute,
+		DenyCacheTTL:              30 * time.Second,
+		WebhookRetryBackoff:       &webhookRetryBackoff,
+	}
+	authz, err := authorizerConfig.New()
+	if err != nil {
+		return nil, fmt.Errorf("creating authorizer: %w", err)
+	}
+
+	return &Server{
+		opts:          opts,
+		handler:       handler,
+		requestHeader: requestHeader,
+		clientCA:      clientCA,
+		authenticator: authn,
+		authorizer:    authz,
+	}, nil
+}
+
+// NeedLeaderElection returns false: every replica serves the API, as the
+// kube-apiserver proxies to any endpoint of the Service.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("external-metrics")
+
+	// Load the front-proxy configuration before serving, then keep it up to date
+	if err := s.requestHeader.RunOnce(ctx); err != nil {
+		logger.Error(err, "Could not read the front-proxy configuration, proxied requests are rejected until it is",
+			"configMap", authenticationConfigMapNamespace+"/"+authenticationConfigMapName)
+	}
+	go s.requestHeader.Run(ctx, 1)
+	go s.clientCA.Run(ctx, 1)
+
+	tlsConfig, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	listener, err := tls.Listen("tcp", s.opts.BindAddress, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.opts.BindAddress, err)
+	}
+
+	server := &http.Server{
+		Handler:           s.authenticated(logger, s.handler),
+		ReadHeaderTimeout: serverReadHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return ctrl.LoggerInto(ctx, logger) },
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serverShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			logger.Error(err, "Failed to shut down the external metrics server")
+		}
+	}()
+
+	logger.Info("Serving external metrics", "address", s.opts.BindAddress, "groupVersion", GroupVersion)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving external metrics: %w", err)
+	}
+	return nil
+}
+
+// tlsConfig returns the TLS configuration of the server. Client certificates
+// are requested but verified by the authenticator, against the front-proxy CA.
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		ClientAuth:     tls.RequestClientCert,
+		GetCertificate: s.opts.GetCertificate,
+	}
+	if config.GetCertificate == nil {
+		certPEM, keyPEM, err := certutil.GenerateSelfSignedCertKey(selfSignedHost, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("generating self-signed certificate: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading self-signed certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	for _, opt := range s.opts.TLSOpts {
+		opt(config)
+	}
+	return config, nil
+}
+
+// authenticated wraps the handler with the authentication and authorization
+// of requests.
+func (s *Server) authenticated(logger logr.Logger, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok, err := s.authenticator.AuthenticateRequest(r)
+		if err != nil {
+			logger.V(logging.DEBUG).Info("Authentication failed", "error", err.Error())
+		}
+		if !ok {
+			writeStatus(w, http.StatusUnauthorized, metav1.StatusReasonUnauthorized, "Unauthorized")
+			return
+		}
+
+		attributes := requestAttributes(r)
+		attributes.User = res.User
+		decision, reason, err := s.authorizer.Authorize(r.Context(), attributes)
+		if err != nil {
+			logger.Error(err, "Authorization failed", "user", res.User.GetName())
+			writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "authorization failed")
+			return
+		}
+		if decision != authorizer.DecisionAllow {
+			logger.V(logging.DEBUG).Info("Authorization denied", "user", res.User.GetName(),
+				"path", r.URL.Path, "reason", reason)
+			writeStatus(w, http.StatusForbidden, metav1.StatusReasonForbidden,
+				fmt.Sprintf("user %q cannot %s %s", res.User.GetName(), attributes.Verb, r.URL.Path))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// requestAttributes returns the attributes a request is authorized for. The
+// values of a metric are a "list" of the resource named after the metric;
+// other paths are non-resource requests.
+func requestAttributes(r *http.Request) authorizer.AttributesRecord {
+	if namespace, metricName, ok := parseMetricPath(strings.TrimSuffix(r.URL.Path, "/")); ok {
+		return authorizer.AttributesRecord{
+			Verb:            "list",
+			Namespace:       namespace,
+			APIGroup:        GroupName,
+			APIVersion:      Version,
+			Resource:        metricName,
+			ResourceRequest: true,
+			Path:            r.URL.Path,
+		}
+	}
+	return authorizer.AttributesRecord{
+		Verb: strings.ToLower(r.Method),
+		Path: r.URL.Path,
+	}
+}
@@ -0,0 +1,44 @@
+package externalmetrics
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The API group and version served, registered by the APIService
+// v1beta1.external.metrics.k8s.io.
+const (
+	GroupName = "external.metrics.k8s.io"
+	Version   = "v1beta1"
+)
+
+// GroupVersion is the served API group and version.
+var GroupVersion = GroupName + "/" + Version
+
+// ExternalMetricValueList is a list of values of an external metric. It is
+// the wire format of k8s.io/metrics/pkg/apis/external_metrics/v1beta1, which
+// this module does not depend on.
+type ExternalMetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ExternalMetricValue `json:"items"`
+}
+
+// ExternalMetricValue is a value of an external metric for one series,
+// identified by its labels.
+type ExternalMetricValue struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// MetricLabels are the labels of the series, matched by the HPA selector.
+	MetricLabels map[string]string `json:"metricLabels"`
+	// Timestamp is when the value was computed.
+	Timestamp metav1.Time `json:"timestamp"`
+	// WindowSeconds is the window the value was computed over, unset for
+	// values that are not aggregated over time.
+	WindowSeconds *int64 `json:"window,omitempty"`
+	// Value is the value of the metric.
+	Value resource.Quantity `json:"value"`
+}
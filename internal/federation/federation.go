@@ -0,0 +1,332 @@
+// Package federation shares the supply and demand of models between WVA
+// instances of several clusters serving the same models, and divides the
+// scaling of each model among the clusters by cost and GPU availability.
+//
+// Each instance serves the summary of its last optimization cycle to its peers
+// over HTTP, and polls the summaries of its peers. Before optimizing, the
+// engine replaces the scaling signals of each model with the cluster's share
+// of the plan computed from all summaries. Each cluster still optimizes and
+// actuates its own variants.
+package federation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// SummaryPath is the path peers serve their ClusterSummary at.
+const SummaryPath = "/federation/v1/summary"
+
+const (
+	defaultSyncInterval = 30 * time.Second
+	defaultStaleAfter   = 2 * time.Minute
+)
+
+// Options configures a Federation.
+type Options struct {
+	// Cluster is the name of the local cluster, unique among peers.
+	Cluster string
+	// Peers are the base URLs of the peers, e.g. "https://wva.us-east.example.com:9443".
+	Peers []string
+	// Token is sent to peers as a bearer token. Empty to send none.
+	Token string
+	// SyncInterval is the interval peers are polled at. Defaults to 30s.
+	SyncInterval time.Duration
+	// StaleAfter is the age after which the summary of a peer is ignored.
+	// Defaults to 2m.
+	StaleAfter time.Duration
+	// CACertPath is the CA bundle that verifies the certificates of peers.
+	// When empty, the system roots verify them. Ignored with HTTPClient.
+	CACertPath string
+	// HTTPClient polls the peers. Defaults to a client with a timeout of
+	// SyncInterval.
+	HTTPClient *http.Client
+}
+
+// Federation adjusts the scaling requests of the local cluster to its share of
+// the demand of all clusters.
+type Federation struct {
+	opts Options
+	now  func() time.Time
+
+	mu    sync.RWMutex
+	local *ClusterSummary
+	peers map[string]*peer
+}
+
+// peer is the last sync of a peer.
+type peer struct {
+	summary  *ClusterSummary
+	err      error
+	lastSync time.Time
+}
+
+// PeerStatus is the status of a peer, for debugging.
+type PeerStatus struct {
+	URL      string    `json:"url"`
+	Cluster  string    `json:"cluster,omitempty"`
+	Time     time.Time `json:"time,omitzero"`
+	LastSync time.Time `json:"lastSync,omitzero"`
+	Error    string    `json:"error,omitempty"`
+	Models   int       `json:"models"`
+}
+
+var _ manager.Runnable = &Federation{}
+var _ manager.LeaderElectionRunnable = &Federation{}
+
+// New creates a Federation.
+func New(opts Options) (*Federation, error) {
+	if opts.Cluster == "" {
+		return nil, errors.New("federation cluster name is required")
+	}
+	if opts.SyncInterval <= 0 {
+		opts.SyncInterval = defaultSyncInterval
+	}
+	if opts.StaleAfter <= 0 {
+		opts.StaleAfter = defaultStaleAfter
+	}
+	if opts.HTTPClient == nil {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if opts.CACertPath != "" {
+			caCert, err := os.ReadFile(opts.CACertPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read federation CA certificate from %s: %w", opts.CACertPath, err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to parse federation CA certificate from %s", opts.CACertPath)
+			}
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		opts.HTTPClient = &http.Client{Timeout: opts.SyncInterval, Transport: transport}
+	}
+	peers := make(map[string]*peer, len(opts.Peers))
+	for _, url := range opts.Peers {
+		peers[strings.TrimSuffix(url, "/")] = &peer{}
+	}
+	return &Federation{opts: opts, now: time.Now, peers: peers}, nil
+}
+
+// Cluster returns the name of the local cluster.
+func (f *Federation) Cluster() string {
+	return f.opts.Cluster
+}
+
+// Summary returns the summary of the last optimization cycle of the local
+// cluster, or nil before the first cycle.
+func (f *Federation) Summary() *ClusterSummary {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.local
+}
+
+// Peers returns the status of the peers, sorted by URL.
+func (f *Federation) Peers() []PeerStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	statuses := make([]PeerStatus, 0, len(f.peers))
+	for url, p := range f.peers {
+		status := PeerStatus{URL: url, LastSync: p.lastSync}
+		if p.summary != nil {
+			status.Cluster = p.summary.Cluster
+			status.Time = p.summary.Time
+			status.Models = len(p.summary.Models)
+		}
+		if p.err != nil {
+			status.Error = p.err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	slices.SortFunc(statuses, func(a, b PeerStatus) int { return strings.Compare(a.URL, b.URL) })
+	return statuses
+}
+
+// Adjust publishes the summary of the requests of an optimization cycle, and
+// returns the requests with the scaling signals of each model replaced by the
+// local share of the plan for the model. Models that no fresh peer running the
+// same analyzer serves are returned unchanged. The results of the requests are
+// copied, not modified.
+func (f *Federation) Adjust(
+	ctx context.Context,
+	analyzer string,
+	requests []pipeline.ModelScalingRequest,
+	constraints []*pipeline.ResourceConstraints,
+) []pipeline.ModelScalingRequest {
+	logger := ctrl.LoggerFrom(ctx).WithName("federation")
+
+	local := Summarize(f.opts.Cluster, analyzer, f.now(), requests, constraints)
+	f.mu.Lock()
+	f.local = &local
+	f.mu.Unlock()
+
+	// The summaries of each model, keyed by cluster
+	models := make(map[string]map[string]ModelSummary, len(local.Models))
+	for _, m := range local.Models {
+		models[modelKey(m.Namespace, m.ModelID)] = map[string]ModelSummary{local.Cluster: m}
+	}
+	for _, summary := range f.freshPeers(analyzer) {
+		for _, m := range summary.Models {
+			if clusters, ok := models[modelKey(m.Namespace, m.ModelID)]; ok {
+				clusters[summary.Cluster] = m
+			}
+		}
+	}
+
+	adjusted := make([]pipeline.ModelScalingRequest, len(requests))
+	copy(adjusted, requests)
+	for i := range adjusted {
+		req := &adjusted[i]
+		clusters := models[modelKey(req.Namespace, req.ModelID)]
+		if req.Result == nil || len(clusters) < 2 {
+			continue
+		}
+		share := Plan(local.Cluster, clusters)
+		if share.RequiredCapacity == req.Result.RequiredCapacity && share.SpareCapacity == req.Result.SpareCapacity {
+			continue
+		}
+		logger.Info("Adjusted scaling signals to the federation plan",
+			"modelID", req.ModelID, "namespace", req.Namespace, "clusters", len(clusters),
+			"requiredCapacity", share.RequiredCapacity, "localRequiredCapacity", req.Result.RequiredCapacity,
+			"spareCapacity", share.SpareCapacity, "localSpareCapacity", req.Result.SpareCapacity)
+		result := *req.Result
+		result.Score = rescore(result.Score, req.Priority, result.RequiredCapacity, share.RequiredCapacity)
+		result.RequiredCapacity = share.RequiredCapacity
+		result.SpareCapacity = share.SpareCapacity
+		req.Result = &result
+	}
+	return adjusted
+}
+
+// rescore scales the score of a model to its new required capacity. A model
+// that required nothing has no score to scale, and is scored from its
+// priority.
+func rescore(score float64, priority, oldRequired, newRequired float64) float64 {
+	if newRequired <= 0 {
+		return 0
+	}
+	if oldRequired > 0 && score > 0 {
+		return score * newRequired / oldRequired
+	}
+	if priority <= 0 {
+		priority = 1
+	}
+	return priority * newRequired
+}
+
+// freshPeers returns the summaries of the peers running the analyzer that are
+// no older than StaleAfter, one per cluster.
+func (f *Federation) freshPeers(analyzer string) []*ClusterSummary {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	latest := make(map[string]*ClusterSummary, len(f.peers))
+	for _, p := range f.peers {
+		s := p.summary
+		if s == nil || s.Cluster == f.opts.Cluster || s.Analyzer != analyzer ||
+			f.now().Sub(s.Time) > f.opts.StaleAfter {
+			continue
+		}
+		if existing, ok := latest[s.Cluster]; !ok || s.Time.After(existing.Time) {
+			latest[s.Cluster] = s
+		}
+	}
+	summaries := make([]*ClusterSummary, 0, len(latest))
+	for _, s := range latest {
+		summaries = append(summaries, s)
+	}
+	return summaries
+}
+
+// NeedLeaderElection returns true: only the leader optimizes, so only it
+// needs the summaries of the peers.
+func (f *Federation) NeedLeaderElection() bool {
+	return true
+}
+
+// Start polls the peers every SyncInterval until the context is done.
+func (f *Federation) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("federation")
+	logger.Info("Starting federation", "cluster", f.opts.Cluster, "peers", len(f.peers),
+		"syncInterval", f.opts.SyncInterval)
+
+	ticker := time.NewTicker(f.opts.SyncInterval)
+	defer ticker.Stop()
+	for {
+		f.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync polls every peer once.
+func (f *Federation) sync(ctx context.Context) {
+	logger := ctrl.LoggerFrom(ctx).WithName("federation")
+	var wg sync.WaitGroup
+	for url := range f.peers {
+		wg.Go(func() {
+			summary, err := f.fetch(ctx, url)
+			if err != nil {
+				logger.V(logging.DEBUG).Info("Failed to sync federation peer", "peer", url, "error", err.Error())
+			}
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			p := f.peers[url]
+			p.err = err
+			if err == nil {
+				p.summary = summary
+				p.lastSync = f.now()
+			}
+		})
+	}
+	wg.Wait()
+}
+
+// fetch gets the summary of a peer.
+func (f *Federation) fetch(ctx context.Context, url string) (*ClusterSummary, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+SummaryPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	if f.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+f.opts.Token)
+	}
+	resp, err := f.opts.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	var summary ClusterSummary
+	if err := json.NewDecoder(resp.Body).Decode(&summary); err != nil {
+		return nil, fmt.Errorf("decoding summary: %w", err)
+	}
+	if summary.Cluster == "" {
+		return nil, errors.New("summary without cluster name")
+	}
+	return &summary, nil
+}
+
+func modelKey(namespace, modelID string) string {
+	return namespace + "/" + modelID
+}
@@ -0,0 +1,205 @@
+package federation
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name   string
+		models map[string]ModelSummary
+		want   map[string]Share
+	}{
+		{
+			name: "demand moves to the cheapest cluster with headroom",
+			models: map[string]ModelSummary{
+				"east": {RequiredCapacity: 30, Headroom: 10, ScaleUpCost: 1},
+				"west": {SpareCapacity: 5, Headroom: 100, ScaleUpCost: 2},
+			},
+			want: map[string]Share{
+				"east": {RequiredCapacity: 10},
+				"west": {RequiredCapacity: 15},
+			},
+		},
+		{
+			name: "demand without headroom stays where it is",
+			models: map[string]ModelSummary{
+				"east": {RequiredCapacity: 30, Headroom: 5, ScaleUpCost: 1},
+				"west": {RequiredCapacity: 10, Headroom: 5, ScaleUpCost: 1},
+			},
+			// 30 beyond the headroom, split 3:1
+			want: map[string]Share{
+				"east": {RequiredCapacity: 5 + 22.5},
+				"west": {RequiredCapacity: 5 + 7.5},
+			},
+		},
+		{
+			name: "surplus is removed from the most expensive cluster",
+			models: map[string]ModelSummary{
+				"east": {SpareCapacity: 10, ScaleDownCost: 1},
+				"west": {RequiredCapacity: 5, SpareCapacity: 10, ScaleDownCost: 3},
+			},
+			want: map[string]Share{
+				"west": {SpareCapacity: 10},
+				"east": {SpareCapacity: 5},
+			},
+		},
+		{
+			name: "balanced clusters do not scale",
+			models: map[string]ModelSummary{
+				"east": {RequiredCapacity: 10, Headroom: 10},
+				"west": {SpareCapacity: 10},
+			},
+			want: map[string]Share{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for cluster := range tt.models {
+				if got := Plan(cluster, tt.models); got != tt.want[cluster] {
+					t.Errorf("Plan(%s) = %+v, want %+v", cluster, got, tt.want[cluster])
+				}
+			}
+		})
+	}
+}
+
+func testRequest(required, spare float64) pipeline.ModelScalingRequest {
+	return pipeline.ModelScalingRequest{
+		ModelID:   "meta/llama",
+		Namespace: "llm",
+		Priority:  1,
+		Result: &interfaces.AnalyzerResult{
+			RequiredCapacity: required,
+			SpareCapacity:    spare,
+			Score:            required,
+			VariantCapacities: []interfaces.VariantCapacity{
+				{VariantName: "llama-a100", AcceleratorName: "A100", Cost: 40, PerReplicaCapacity: 10},
+				{VariantName: "llama-h100", AcceleratorName: "H100", Cost: 60, PerReplicaCapacity: 20},
+			},
+		},
+		VariantStates: []interfaces.VariantReplicaState{
+			{VariantName: "llama-a100", CurrentReplicas: 2, GPUsPerReplica: 1, MaxReplicas: ptr.To(4)},
+			{VariantName: "llama-h100", CurrentReplicas: 1, GPUsPerReplica: 2},
+		},
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	constraints := []*pipeline.ResourceConstraints{{Pools: map[string]pipeline.ResourcePool{
+		"A100": {Limit: 8, Used: 2},
+		"H100": {Limit: 8, Used: 3},
+	}}}
+	disaggregated := testRequest(10, 0)
+	disaggregated.Disaggregated = true
+
+	summary := Summarize("east", "saturation", time.Unix(0, 0), []pipeline.ModelScalingRequest{
+		testRequest(10, 0), disaggregated, {ModelID: "no-result"},
+	}, constraints)
+
+	if len(summary.Models) != 1 {
+		t.Fatalf("models = %+v, want the request with a result", summary.Models)
+	}
+	m := summary.Models[0]
+	// a100: 2 replicas up to maxReplicas, h100: 5 GPUs available for 2 replicas
+	if m.Headroom != 2*10+2*20 {
+		t.Errorf("headroom = %v, want 60", m.Headroom)
+	}
+	if m.ScaleUpCost != 3 || m.ScaleDownCost != 4 {
+		t.Errorf("costs = %v up, %v down, want 3 and 4", m.ScaleUpCost, m.ScaleDownCost)
+	}
+
+	unbounded := Summarize("east", "saturation", time.Unix(0, 0), []pipeline.ModelScalingRequest{testRequest(10, 0)}, nil)
+	if unbounded.Models[0].Headroom < math.MaxInt32 {
+		t.Errorf("headroom = %v without constraints, want unbounded", unbounded.Models[0].Headroom)
+	}
+}
+
+func TestAdjust(t *testing.T) {
+	now := time.Unix(1000, 0)
+	f, err := New(Options{Cluster: "east", Peers: []string{"http://west", "http://stale"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.now = func() time.Time { return now }
+	f.peers["http://west"].summary = &ClusterSummary{Cluster: "west", Time: now, Analyzer: "saturation",
+		Models: []ModelSummary{{ModelID: "meta/llama", Namespace: "llm", SpareCapacity: 30, ScaleDownCost: 5}}}
+	f.peers["http://stale"].summary = &ClusterSummary{Cluster: "north", Time: now.Add(-time.Hour), Analyzer: "saturation",
+		Models: []ModelSummary{{ModelID: "meta/llama", Namespace: "llm", RequiredCapacity: 100}}}
+
+	requests := []pipeline.ModelScalingRequest{testRequest(20, 0)}
+	adjusted := f.Adjust(context.Background(), "saturation", requests, nil)
+
+	// west serves the demand of east with its spare capacity, and keeps the rest
+	if got := adjusted[0].Result; got.RequiredCapacity != 0 || got.SpareCapacity != 0 || got.Score != 0 {
+		t.Errorf("adjusted result = %+v, want no scaling", got)
+	}
+	if requests[0].Result.RequiredCapacity != 20 {
+		t.Error("Adjust() modified the result of the request")
+	}
+	if s := f.Summary(); s == nil || s.Models[0].RequiredCapacity != 20 {
+		t.Errorf("summary = %+v, want the local demand before federation", s)
+	}
+
+	adjusted = f.Adjust(context.Background(), "queueing-model", requests, nil)
+	if adjusted[0].Result != requests[0].Result {
+		t.Error("Adjust() adjusted a request against peers of another analyzer")
+	}
+}
+
+func TestSync(t *testing.T) {
+	west, err := New(Options{Cluster: "west"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := NewServer(west, ServerOptions{BindAddress: ":0", Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	east, err := New(Options{Cluster: "east", Peers: []string{ts.URL + "/"}, Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	east.sync(context.Background())
+	if peers := east.Peers(); len(peers) != 1 || peers[0].Error == "" {
+		t.Errorf("peers = %+v, want an error before the first cycle of the peer", peers)
+	}
+
+	west.Adjust(context.Background(), "saturation", []pipeline.ModelScalingRequest{testRequest(0, 10)}, nil)
+	east.sync(context.Background())
+	peers := east.Peers()
+	if len(peers) != 1 || peers[0].Error != "" || peers[0].Cluster != "west" || peers[0].Models != 1 {
+		t.Errorf("peers = %+v, want the summary of west", peers)
+	}
+
+	resp, err := http.Get(ts.URL + SummaryPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status without token = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{}); err == nil {
+		t.Error("New() without a cluster name: want an error")
+	}
+	if _, err := New(Options{Cluster: "east", CACertPath: "/nonexistent/ca.crt"}); err == nil {
+		t.Error("New() with a missing CA certificate: want an error")
+	}
+}
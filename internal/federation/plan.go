@@ -0,0 +1,75 @@
+package federation
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// Share is a cluster's share of the scaling of a model across clusters, in the
+// units of the analyzer. It replaces the cluster's own scaling signals.
+type Share struct {
+	RequiredCapacity float64
+	SpareCapacity    float64
+}
+
+// Plan divides the net demand of a model among the clusters serving it, and
+// returns the share of cluster. models holds the model's summary in each
+// cluster, keyed by cluster name.
+//
+// Every cluster computes the same plan from the same summaries:
+//   - When the clusters together need capacity, it is added to the clusters
+//     with the lowest ScaleUpCost first, up to their Headroom. Capacity that no
+//     cluster has headroom for stays with the clusters that need it, in
+//     proportion to their RequiredCapacity.
+//   - When the clusters together have spare capacity, it is removed from the
+//     clusters with the highest ScaleDownCost first, up to their SpareCapacity.
+//
+// Ties are broken by cluster name.
+func Plan(cluster string, models map[string]ModelSummary) Share {
+	names := slices.Sorted(maps.Keys(models))
+	required, spare := 0.0, 0.0
+	for _, name := range names {
+		required += models[name].RequiredCapacity
+		spare += models[name].SpareCapacity
+	}
+	net := required - spare
+
+	shares := make(map[string]Share, len(models))
+	switch {
+	case net > 0:
+		slices.SortStableFunc(names, func(a, b string) int {
+			return cmp.Compare(models[a].ScaleUpCost, models[b].ScaleUpCost)
+		})
+		remaining := net
+		for _, name := range names {
+			add := min(remaining, models[name].Headroom)
+			if add <= 0 {
+				continue
+			}
+			shares[name] = Share{RequiredCapacity: add}
+			remaining -= add
+		}
+		if remaining > 0 {
+			for _, name := range names {
+				share := shares[name]
+				share.RequiredCapacity += remaining * models[name].RequiredCapacity / required
+				shares[name] = share
+			}
+		}
+	case net < 0:
+		slices.SortStableFunc(names, func(a, b string) int {
+			return cmp.Compare(models[b].ScaleDownCost, models[a].ScaleDownCost)
+		})
+		surplus := -net
+		for _, name := range names {
+			remove := min(surplus, models[name].SpareCapacity)
+			if remove <= 0 {
+				continue
+			}
+			shares[name] = Share{SpareCapacity: remove}
+			surplus -= remove
+		}
+	}
+	return shares[cluster]
+}
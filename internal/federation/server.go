@@ -0,0 +1,112 @@
+package federation
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+// ServerOptions configures a Server.
+type ServerOptions struct {
+	// BindAddress is the address the server listens on, e.g. ":9443".
+	BindAddress string
+	// Token is the bearer token peers must send. Empty to accept any request.
+	Token string
+	// GetCertificate returns the serving certificate. When nil, the server
+	// serves plain HTTP.
+	GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+}
+
+// Server serves the summary of the local cluster to peers.
+type Server struct {
+	opts       ServerOptions
+	federation *Federation
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NewServer creates a Server for the federation.
+func NewServer(federation *Federation, opts ServerOptions) (*Server, error) {
+	if opts.BindAddress == "" {
+		return nil, errors.New("federation bind address is required")
+	}
+	return &Server{opts: opts, federation: federation}, nil
+}
+
+// NeedLeaderElection returns false: every replica serves, and only the leader
+// has a summary. Replicas without one answer 503, so peers behind a Service
+// retry until they reach the leader.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("federation")
+
+	mux := http.NewServeMux()
+	mux.Handle(SummaryPath, s)
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if s.opts.GetCertificate != nil {
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.opts.GetCertificate,
+		}
+	}
+
+	listener, err := net.Listen("tcp", s.opts.BindAddress)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.opts.BindAddress, err)
+	}
+	if server.TLSConfig != nil {
+		listener = tls.NewListener(listener, server.TLSConfig)
+	}
+
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+
+	logger.Info("Serving federation summary", "address", s.opts.BindAddress, "tls", s.opts.GetCertificate != nil)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving federation summary: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP serves the summary of the last optimization cycle.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if s.opts.Token != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.opts.Token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	summary := s.federation.Summary()
+	if summary == nil {
+		http.Error(w, "no optimization cycle yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(summary)
+}
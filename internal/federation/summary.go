@@ -0,0 +1,132 @@
+package federation
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/pipeline"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// ClusterSummary is the supply and demand of the models of a cluster in its
+// last optimization cycle. It is what a cluster serves to its peers.
+type ClusterSummary struct {
+	// Cluster is the name of the cluster, unique among peers.
+	Cluster string `json:"cluster"`
+	// Time is the time of the optimization cycle.
+	Time time.Time `json:"time"`
+	// Analyzer is the analyzer of the cycle. Capacities are in its units, and
+	// are only compared between clusters running the same analyzer.
+	Analyzer string         `json:"analyzer"`
+	Models   []ModelSummary `json:"models"`
+}
+
+// ModelSummary is the supply and demand of a model in a cluster, before
+// federation. Capacities are in the units of the analyzer.
+type ModelSummary struct {
+	ModelID   string `json:"modelID"`
+	Namespace string `json:"namespace"`
+	// RequiredCapacity and SpareCapacity are the analyzer's scaling signals.
+	RequiredCapacity float64 `json:"requiredCapacity"`
+	SpareCapacity    float64 `json:"spareCapacity"`
+	// Headroom is the capacity the cluster can add, within the maxReplicas of
+	// the variants and the available GPUs.
+	Headroom float64 `json:"headroom"`
+	// ScaleUpCost is the cost per unit of capacity of the most cost-efficient
+	// variant with headroom, and ScaleDownCost that of the least cost-efficient
+	// variant with replicas. 0 when there is no such variant.
+	ScaleUpCost   float64 `json:"scaleUpCost"`
+	ScaleDownCost float64 `json:"scaleDownCost"`
+}
+
+// Summarize returns the summary of the requests of a cycle. constraints bound
+// the headroom by the available GPUs; without constraints, only maxReplicas
+// bound it. Requests without a result and disaggregated models are left out.
+func Summarize(
+	cluster, analyzer string,
+	now time.Time,
+	requests []pipeline.ModelScalingRequest,
+	constraints []*pipeline.ResourceConstraints,
+) ClusterSummary {
+	available := availableGPUs(constraints)
+	summary := ClusterSummary{Cluster: cluster, Time: now, Analyzer: analyzer, Models: []ModelSummary{}}
+	for _, req := range requests {
+		if req.Result == nil || req.Disaggregated {
+			continue
+		}
+		states := make(map[string]interfaces.VariantReplicaState, len(req.VariantStates))
+		for _, s := range req.VariantStates {
+			states[s.VariantName] = s
+		}
+
+		// Variants of the same accelerator type share its available GPUs
+		modelAvailable := maps.Clone(available)
+		m := ModelSummary{
+			ModelID:          req.ModelID,
+			Namespace:        req.Namespace,
+			RequiredCapacity: req.Result.RequiredCapacity,
+			SpareCapacity:    req.Result.SpareCapacity,
+		}
+		for _, vc := range req.Result.VariantCapacities {
+			if vc.PerReplicaCapacity <= 0 {
+				continue
+			}
+			state := states[vc.VariantName]
+			costPerCapacity := vc.Cost / vc.PerReplicaCapacity
+			if state.CurrentReplicas > 0 && costPerCapacity > m.ScaleDownCost {
+				m.ScaleDownCost = costPerCapacity
+			}
+			if state.Preemptible && state.InterruptedReplicas > 0 {
+				continue // not eligible for scale-up
+			}
+			replicas := variantHeadroom(state, vc.AcceleratorName, modelAvailable)
+			if replicas <= 0 {
+				continue
+			}
+			if modelAvailable != nil {
+				modelAvailable[vc.AcceleratorName] -= replicas * max(state.GPUsPerReplica, 1)
+			}
+			m.Headroom += float64(replicas) * vc.PerReplicaCapacity
+			if m.ScaleUpCost == 0 || costPerCapacity < m.ScaleUpCost {
+				m.ScaleUpCost = costPerCapacity
+			}
+		}
+		summary.Models = append(summary.Models, m)
+	}
+	return summary
+}
+
+// variantHeadroom returns the number of replicas a variant can add. available
+// holds the available GPUs per accelerator type, nil when unknown.
+func variantHeadroom(state interfaces.VariantReplicaState, accelerator string, available map[string]int) int {
+	replicas := math.MaxInt32 // unbounded
+	if state.MaxReplicas != nil && *state.MaxReplicas > 0 {
+		replicas = *state.MaxReplicas - state.CurrentReplicas
+	}
+	if available != nil {
+		gpusPerReplica := max(state.GPUsPerReplica, 1)
+		replicas = min(replicas, available[accelerator]/gpusPerReplica)
+	}
+	return replicas
+}
+
+// availableGPUs returns the smallest available GPUs per accelerator type among
+// the constraints, or nil without constraints.
+func availableGPUs(constraints []*pipeline.ResourceConstraints) map[string]int {
+	var available map[string]int
+	for _, c := range constraints {
+		if c == nil {
+			continue
+		}
+		if available == nil {
+			available = make(map[string]int, len(c.Pools))
+		}
+		for accelerator, pool := range c.Pools {
+			if existing, ok := available[accelerator]; !ok || pool.Available() < existing {
+				available[accelerator] = pool.Available()
+			}
+		}
+	}
+	return available
+}
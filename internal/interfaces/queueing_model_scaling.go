@@ -41,8 +41,29 @@ type QueueingModelScalingConfig struct {
 	// TargetITL is the target inter-token latency in milliseconds.
 	// Zero means infer from metrics using the queueing model.
 	TargetITL float32 `yaml:"targetITL,omitempty"`
+
+	// ColdStartLookahead enables scaling up ahead of an upward demand trend by
+	// the learned replica startup latency. Read from the "default" entry and
+	// overridable per model.
+	// Pointer to distinguish unset (nil = default false) from explicitly false.
+	ColdStartLookahead *bool `yaml:"coldStartLookahead,omitempty"`
+
+	// MinOnDemandFraction is the minimum fraction (0-1) of a model's capacity that
+	// must stay on non-preemptible variants. Read from the "default" entry and
+	// overridable per model. nil means no floor.
+	MinOnDemandFraction *float64 `yaml:"minOnDemandFraction,omitempty"`
+
+	// RequestClasses is the maximum number of ranges (2-MaxRequestClasses) the
+	// histograms of input and output tokens per request are split into, to size
+	// variants for each combination of an input and an output range rather than
+	// for the average request. Read from the "default" entry and overridable per
+	// model. nil means use the default (0); 0 or 1 sizes for the average request.
+	RequestClasses *int `yaml:"requestClasses,omitempty"`
 }
 
+// MaxRequestClasses is the maximum value of RequestClasses.
+const MaxRequestClasses = 4
+
 // GetAnalyzerName implements the AnalyzerConfig interface.
 func (c *QueueingModelScalingConfig) GetAnalyzerName() string {
 	return QueueingModelAnalyzerName
@@ -61,6 +82,13 @@ func (c *QueueingModelScalingConfig) Validate() error {
 	if c.TargetITL < 0 {
 		return fmt.Errorf("targetITL must be >= 0, got %.2f", c.TargetITL)
 	}
+	if c.MinOnDemandFraction != nil && (*c.MinOnDemandFraction < 0 || *c.MinOnDemandFraction > 1) {
+		return fmt.Errorf("minOnDemandFraction must be between 0 and 1, got %.2f", *c.MinOnDemandFraction)
+	}
+
+	if c.RequestClasses != nil && (*c.RequestClasses < 0 || *c.RequestClasses > MaxRequestClasses) {
+		return fmt.Errorf("requestClasses must be between 0 and %d, got %d", MaxRequestClasses, *c.RequestClasses)
+	}
 
 	// Both or neither SLO target must be set
 	if (c.TargetTTFT > 0) != (c.TargetITL > 0) {
@@ -2,6 +2,8 @@ package interfaces
 
 import (
 	"testing"
+
+	"k8s.io/utils/ptr"
 )
 
 func TestQueueingModelScalingConfig_Validate(t *testing.T) {
@@ -30,6 +32,21 @@ func TestQueueingModelScalingConfig_Validate(t *testing.T) {
 			config:  QueueingModelScalingConfig{SLOMultiplier: 0.5},
 			wantErr: true,
 		},
+		{
+			name:    "valid requestClasses",
+			config:  QueueingModelScalingConfig{RequestClasses: ptr.To(3)},
+			wantErr: false,
+		},
+		{
+			name:    "invalid negative requestClasses",
+			config:  QueueingModelScalingConfig{RequestClasses: ptr.To(-1)},
+			wantErr: true,
+		},
+		{
+			name:    "invalid requestClasses above max",
+			config:  QueueingModelScalingConfig{RequestClasses: ptr.To(MaxRequestClasses + 1)},
+			wantErr: true,
+		},
 		{
 			name:    "valid both SLO targets set",
 			config:  QueueingModelScalingConfig{TargetTTFT: 500.0, TargetITL: 50.0},
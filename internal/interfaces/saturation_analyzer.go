@@ -6,6 +6,8 @@ import (
 
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
 )
 
 // SaturationAnalyzerName is the canonical name for the saturation analyzer.
@@ -85,6 +87,12 @@ type ReplicaMetrics struct {
 	// Used by queueing model analyzer.
 	MaxBatchSize int64
 
+	// MaxNumBatchedTokens is the per-step token budget of this replica.
+	// Parsed from the --max-num-batched-tokens flag in the pod's parent Deployment
+	// container args, or resolved from the vLLM defaults when not set.
+	// Used by queueing model analyzer.
+	MaxNumBatchedTokens int64
+
 	// AvgTTFT is the average time-to-first-token on this replica in seconds.
 	// Derived from rate(vllm:time_to_first_token_seconds_sum[5m]) / rate(..._count[5m]).
 	// Used by queueing model tuner as observed TTFT for Kalman filter parameter learning.
@@ -96,6 +104,29 @@ type ReplicaMetrics struct {
 	// Used by queueing model tuner as observed ITL for Kalman filter parameter learning.
 	// Zero when metrics are unavailable.
 	AvgITL float64
+
+	// InputTokensHistogram is the cumulative histogram of input (prompt) tokens
+	// per request on this replica, sorted by upper bound.
+	// Derived from rate(vllm:request_prompt_tokens_bucket[5m]).
+	// Used by queueing model analyzer to split the workload into request classes.
+	// Empty when metrics are unavailable.
+	InputTokensHistogram []HistogramBucket
+
+	// OutputTokensHistogram is the cumulative histogram of output (generation)
+	// tokens per request on this replica, sorted by upper bound.
+	// Derived from rate(vllm:request_generation_tokens_bucket[5m]).
+	// Used by queueing model analyzer to split the workload into request classes.
+	// Empty when metrics are unavailable.
+	OutputTokensHistogram []HistogramBucket
+}
+
+// HistogramBucket is a bucket of a cumulative Prometheus histogram.
+type HistogramBucket struct {
+	// UpperBound is the inclusive upper bound of the bucket (the "le" label),
+	// +Inf for the last bucket.
+	UpperBound float64
+	// Rate is the rate of observations less than or equal to UpperBound (per second).
+	Rate float64
 }
 
 // ReplicaMetricsMetadata contains freshness information for replica metrics
@@ -209,6 +240,7 @@ type VariantDecision struct {
 	SafetyOverride     bool        // True if saturation veto overrode model-based decision
 	LastRunTime        metav1.Time // Time when decision was made (for status updates)
 	SaturationOnly     bool        // True if operating in saturation-only mode (no model-based analysis)
+	Shadow             bool        // True if the VA runs in shadow mode: the decision is recorded but not actuated
 
 	// --- Allocation state ---
 	// CurrentAllocation carries the collected metrics/allocation state
@@ -295,6 +327,16 @@ type VariantReplicaState struct {
 	// MaxReplicas is the maximum number of replicas for this variant (from VA spec field).
 	// nil means not set (default: 0, no cap).
 	MaxReplicas *int
+	// Preemptible is true for variants on capacity the provider may reclaim
+	// (VA spec.pricingTier "spot"). Used by the on-demand capacity floor.
+	Preemptible bool
+	// InterruptedReplicas are replicas of a preemptible variant that are lost or
+	// about to be lost to node interruptions: pods on interrupted nodes, and
+	// unscheduled replacement pods while the variant is in its interruption cooldown.
+	InterruptedReplicas int
+	// Schedule holds the scaling schedule overrides active for this variant.
+	// When it has active rules, MinReplicas and MaxReplicas already reflect them.
+	Schedule schedule.Overrides
 }
 
 // SaturationAnalyzer analyzes replica saturation metrics and recommends scaling decisions
@@ -0,0 +1,221 @@
+// Package kedascaler implements the KEDA external scaler protocol, so that
+// KEDA ScaledObjects scale variants on the decisions of WVA without a
+// Prometheus trigger.
+package kedascaler
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/actuator"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/externalmetrics"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/kedascaler/externalscaler"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// MetadataVariantName is the trigger metadata naming the VariantAutoscaling
+// of a ScaledObject. It defaults to the name of the ScaledObject.
+const MetadataVariantName = "variantName"
+
+// streamCheckInterval is how often StreamIsActive re-evaluates the activity of
+// a variant, on top of the activations pushed by the scale-from-zero engine.
+// It bounds the delay on replicas that are not the leader, which see decisions
+// through the VariantAutoscaling status.
+const streamCheckInterval = 5 * time.Second
+
+// Scaler serves the KEDA external scaler protocol for VariantAutoscalings:
+//
+//   - the metric is wva_desired_replicas with a target of 1, so the HPA KEDA
+//     creates scales the target to the desired replicas of the variant;
+//   - a variant is active when its desired replicas are not zero, or when the
+//     scale-from-zero engine saw requests pending for it. Before the first
+//     decision, a variant is active when its scale target has replicas.
+//
+// The desired replicas are those served by the external metrics API, see
+// externalmetrics.Provider.
+type Scaler struct {
+	externalscaler.UnimplementedExternalScalerServer
+
+	client         client.Client
+	provider       *externalmetrics.Provider
+	activations    *common.ActivationTracker
+	streamInterval time.Duration
+}
+
+var _ externalscaler.ExternalScalerServer = &Scaler{}
+
+// NewScaler creates a Scaler reading VariantAutoscalings and their scale
+// targets with the given client.
+func NewScaler(c client.Client) *Scaler {
+	return &Scaler{
+		client:         c,
+		provider:       externalmetrics.NewProvider(c),
+		activations:    common.Activations,
+		streamInterval: streamCheckInterval,
+	}
+}
+
+// IsActive reports whether the variant of the ScaledObject is active.
+func (s *Scaler) IsActive(ctx context.Context, ref *externalscaler.ScaledObjectRef) (*externalscaler.IsActiveResponse, error) {
+	va, err := s.variant(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	active, err := s.isActive(ctx, va)
+	if err != nil {
+		return nil, err
+	}
+	return &externalscaler.IsActiveResponse{Result: active}, nil
+}
+
+// StreamIsActive sends the activity of the variant when it changes, starting
+// with its current activity. Activations detected by the scale-from-zero
+// engine are sent as soon as they are seen.
+func (s *Scaler) StreamIsActive(ref *externalscaler.ScaledObjectRef, stream grpc.ServerStreamingServer[externalscaler.IsActiveResponse]) error {
+	ctx := stream.Context()
+	va, err := s.variant(ctx, ref)
+	if err != nil {
+		return err
+	}
+	activations, unsubscribe := s.activations.Subscribe(va.Name, va.Namespace)
+	defer unsubscribe()
+	ticker := time.NewTicker(s.streamInterval)
+	defer ticker.Stop()
+
+	sent, last := false, false
+	for {
+		active, err := s.isActive(ctx, va)
+		if err != nil {
+			return err
+		}
+		if !sent || active != last {
+			if err := stream.Send(&externalscaler.IsActiveResponse{Result: active}); err != nil {
+				return err
+			}
+			sent, last = true, active
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-activations:
+		case <-ticker.C:
+		}
+		if va, err = s.variant(ctx, ref); err != nil {
+			return err
+		}
+	}
+}
+
+// GetMetricSpec returns the wva_desired_replicas metric with a target of 1.
+func (s *Scaler) GetMetricSpec(ctx context.Context, ref *externalscaler.ScaledObjectRef) (*externalscaler.GetMetricSpecResponse, error) {
+	if _, err := s.variant(ctx, ref); err != nil {
+		return nil, err
+	}
+	return &externalscaler.GetMetricSpecResponse{
+		MetricSpecs: []*externalscaler.MetricSpec{{
+			MetricName:      constants.WVADesiredReplicas,
+			TargetSize:      1,
+			TargetSizeFloat: 1,
+		}},
+	}, nil
+}
+
+// GetMetrics returns the desired replicas of the variant. It fails with
+// Unavailable before the first decision, so that the HPA keeps the current
+// replicas.
+func (s *Scaler) GetMetrics(ctx context.Context, req *externalscaler.GetMetricsRequest) (*externalscaler.GetMetricsResponse, error) {
+	va, err := s.variant(ctx, req.GetScaledObjectRef())
+	if err != nil {
+		return nil, err
+	}
+	desired, ok, err := s.desiredReplicas(ctx, va)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "VariantAutoscaling %s/%s has no scaling decision yet", va.Namespace, va.Name)
+	}
+	metricName := req.GetMetricName()
+	if metricName == "" {
+		metricName = constants.WVADesiredReplicas
+	}
+	return &externalscaler.GetMetricsResponse{
+		MetricValues: []*externalscaler.MetricValue{{
+			MetricName:       metricName,
+			MetricValue:      int64(desired),
+			MetricValueFloat: float64(desired),
+		}},
+	}, nil
+}
+
+// variant returns the VariantAutoscaling of a ScaledObject.
+func (s *Scaler) variant(ctx context.Context, ref *externalscaler.ScaledObjectRef) (*wvav1alpha1.VariantAutoscaling, error) {
+	if ref == nil || ref.GetNamespace() == "" {
+		return nil, status.Error(codes.InvalidArgument, "scaled object reference with a namespace is required")
+	}
+	name := ref.GetScalerMetadata()[MetadataVariantName]
+	if name == "" {
+		name = ref.GetName()
+	}
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s metadata or scaled object name is required", MetadataVariantName)
+	}
+
+	var va wvav1alpha1.VariantAutoscaling
+	if err := s.client.Get(ctx, types.NamespacedName{Name: name, Namespace: ref.GetNamespace()}, &va); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, status.Errorf(codes.NotFound, "VariantAutoscaling %s/%s not found", ref.GetNamespace(), name)
+		}
+		return nil, status.Errorf(codes.Internal, "getting VariantAutoscaling %s/%s: %v", ref.GetNamespace(), name, err)
+	}
+	return &va, nil
+}
+
+// isActive reports whether a variant is active.
+func (s *Scaler) isActive(ctx context.Context, va *wvav1alpha1.VariantAutoscaling) (bool, error) {
+	if s.activations.Pending(va.Name, va.Namespace, time.Now()) {
+		return true, nil
+	}
+	desired, ok, err := s.desiredReplicas(ctx, va)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		return desired > 0, nil
+	}
+
+	current, err := actuator.NewActuator(s.client).GetCurrentScaleTargetReplicasFromVA(ctx, va)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Could not get current scale target replicas, reporting inactive",
+			"variantName", va.Name, "namespace", va.Namespace, "error", err.Error())
+		return false, nil
+	}
+	return current > 0, nil
+}
+
+// desiredReplicas returns the desired replicas of a variant, and false when it
+// has no decision yet.
+func (s *Scaler) desiredReplicas(ctx context.Context, va *wvav1alpha1.VariantAutoscaling) (int32, bool, error) {
+	selector := labels.SelectorFromSet(labels.Set{constants.LabelVariantName: va.Name})
+	values, err := s.provider.Values(ctx, va.Namespace, constants.WVADesiredReplicas, selector)
+	if err != nil {
+		return 0, false, status.Errorf(codes.Unavailable, "reading desired replicas: %v", err)
+	}
+	if len(values) == 0 {
+		return 0, false, nil
+	}
+	return int32(values[0].Value.Value()), true, nil
+}
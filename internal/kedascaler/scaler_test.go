@@ -0,0 +1,187 @@
+package kedascaler
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/common"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/kedascaler/externalscaler"
+)
+
+const testNamespace = "keda-scaler-test"
+
+func newTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{corev1.AddToScheme, appsv1.AddToScheme, wvav1alpha1.AddToScheme} {
+		if err := add(scheme); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+// newTestVariant returns a VA with the given desired replicas in its status,
+// if any, and its Deployment with the given current replicas.
+func newTestVariant(name string, desired *int32, current int32) []client.Object {
+	va := &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: wvav1alpha1.VariantAutoscalingSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       name,
+			},
+		},
+	}
+	if desired != nil {
+		va.Status.DesiredOptimizedAlloc = wvav1alpha1.OptimizedAlloc{NumReplicas: desired, Accelerator: "A100"}
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To(current)},
+		Status:     appsv1.DeploymentStatus{Replicas: current},
+	}
+	return []client.Object{va, deployment}
+}
+
+func ref(name string) *externalscaler.ScaledObjectRef {
+	return &externalscaler.ScaledObjectRef{Name: name, Namespace: testNamespace}
+}
+
+func TestScalerIsActive(t *testing.T) {
+	var objs []client.Object
+	objs = append(objs, newTestVariant("keda-scaled", ptr.To(int32(2)), 1)...)
+	objs = append(objs, newTestVariant("keda-idle", ptr.To(int32(0)), 1)...)
+	objs = append(objs, newTestVariant("keda-pending", ptr.To(int32(0)), 0)...)
+	objs = append(objs, newTestVariant("keda-new-running", nil, 1)...)
+	objs = append(objs, newTestVariant("keda-new-stopped", nil, 0)...)
+	scaler := NewScaler(newTestClient(t, objs...))
+	scaler.activations = common.NewActivationTracker()
+	scaler.activations.MarkPending("keda-pending", testNamespace, time.Now())
+
+	for name, want := range map[string]bool{
+		"keda-scaled":      true,
+		"keda-idle":        false,
+		"keda-pending":     true,
+		"keda-new-running": true,
+		"keda-new-stopped": false,
+	} {
+		resp, err := scaler.IsActive(context.Background(), ref(name))
+		if err != nil {
+			t.Fatalf("IsActive(%s) failed: %v", name, err)
+		}
+		if resp.Result != want {
+			t.Errorf("IsActive(%s): expected %v, got %v", name, want, resp.Result)
+		}
+	}
+
+	// The variant is named by the trigger metadata, else by the ScaledObject
+	withMetadata := ref("scaled-object")
+	withMetadata.ScalerMetadata = map[string]string{MetadataVariantName: "keda-scaled"}
+	if resp, err := scaler.IsActive(context.Background(), withMetadata); err != nil || !resp.Result {
+		t.Errorf("Expected the variant of the metadata to be active, got %v, %v", resp, err)
+	}
+	if _, err := scaler.IsActive(context.Background(), ref("missing")); status.Code(err) != codes.NotFound {
+		t.Errorf("Expected NotFound for a missing variant, got %v", err)
+	}
+	if _, err := scaler.IsActive(context.Background(), &externalscaler.ScaledObjectRef{Name: "keda-scaled"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Expected InvalidArgument without namespace, got %v", err)
+	}
+}
+
+func TestScalerMetrics(t *testing.T) {
+	var objs []client.Object
+	objs = append(objs, newTestVariant("keda-scaled", ptr.To(int32(3)), 1)...)
+	objs = append(objs, newTestVariant("keda-new", nil, 1)...)
+	scaler := NewScaler(newTestClient(t, objs...))
+
+	spec, err := scaler.GetMetricSpec(context.Background(), ref("keda-scaled"))
+	if err != nil {
+		t.Fatalf("GetMetricSpec() failed: %v", err)
+	}
+	if len(spec.MetricSpecs) != 1 || spec.MetricSpecs[0].MetricName != constants.WVADesiredReplicas || spec.MetricSpecs[0].TargetSize != 1 {
+		t.Errorf("Unexpected metric spec %v", spec.MetricSpecs)
+	}
+
+	metrics, err := scaler.GetMetrics(context.Background(), &externalscaler.GetMetricsRequest{
+		ScaledObjectRef: ref("keda-scaled"),
+		MetricName:      "s0-" + constants.WVADesiredReplicas,
+	})
+	if err != nil {
+		t.Fatalf("GetMetrics() failed: %v", err)
+	}
+	if len(metrics.MetricValues) != 1 || metrics.MetricValues[0].MetricValue != 3 ||
+		metrics.MetricValues[0].MetricName != "s0-"+constants.WVADesiredReplicas {
+		t.Errorf("Unexpected metric values %v", metrics.MetricValues)
+	}
+
+	_, err = scaler.GetMetrics(context.Background(), &externalscaler.GetMetricsRequest{ScaledObjectRef: ref("keda-new")})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable before the first decision, got %v", err)
+	}
+}
+
+// TestScalerStreamIsActive checks the protocol over gRPC, and that an
+// activation is pushed without waiting for the stream interval.
+func TestScalerStreamIsActive(t *testing.T) {
+	scaler := NewScaler(newTestClient(t, newTestVariant("keda-stream", ptr.To(int32(0)), 0)...))
+	scaler.activations = common.NewActivationTracker()
+	scaler.streamInterval = time.Hour
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	externalscaler.RegisterExternalScalerServer(server, scaler)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := externalscaler.NewExternalScalerClient(conn).StreamIsActive(ctx, ref("keda-stream"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Result {
+		t.Error("Expected the variant to start inactive")
+	}
+
+	// The stream subscribed before its first response
+	scaler.activations.MarkPending("keda-stream", testNamespace, time.Now())
+	resp, err = stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !resp.Result {
+		t.Error("Expected the activation to be pushed")
+	}
+}
@@ -0,0 +1,111 @@
+package kedascaler
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/kedascaler/externalscaler"
+)
+
+// Options configures a Server.
+type Options struct {
+	// BindAddress is the address the server listens on, e.g. ":9000".
+	BindAddress string
+	// GetCertificate returns the serving certificate. Required unless
+	// Insecure is set.
+	GetCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)
+	// ClientCACertPath is the CA bundle that verifies the client certificates
+	// of KEDA. Clients without a certificate signed by it are rejected.
+	// Required with GetCertificate.
+	ClientCACertPath string
+	// Insecure allows serving plaintext gRPC without client authentication
+	// when GetCertificate is nil.
+	Insecure bool
+}
+
+// Server serves a Scaler over gRPC.
+type Server struct {
+	opts      Options
+	scaler    externalscaler.ExternalScalerServer
+	clientCAs *x509.CertPool
+}
+
+var _ manager.Runnable = &Server{}
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// NewServer creates a Server for the scaler.
+func NewServer(scaler externalscaler.ExternalScalerServer, opts Options) (*Server, error) {
+	if opts.BindAddress == "" {
+		return nil, errors.New("KEDA scaler bind address is required")
+	}
+	if opts.GetCertificate == nil {
+		if !opts.Insecure {
+			return nil, errors.New("KEDA scaler serving certificate is required unless insecure")
+		}
+		return &Server{opts: opts, scaler: scaler}, nil
+	}
+
+	if opts.ClientCACertPath == "" {
+		return nil, errors.New("KEDA scaler client CA certificate is required with a serving certificate")
+	}
+	caCert, err := os.ReadFile(opts.ClientCACertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KEDA scaler client CA certificate from %s: %w", opts.ClientCACertPath, err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse KEDA scaler client CA certificate from %s", opts.ClientCACertPath)
+	}
+	return &Server{opts: opts, scaler: scaler, clientCAs: clientCAs}, nil
+}
+
+// NeedLeaderElection returns false: every replica serves the scaler, as KEDA
+// connects to any endpoint of the Service.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	logger := ctrl.LoggerFrom(ctx).WithName("keda-scaler")
+
+	var serverOpts []grpc.ServerOption
+	if s.opts.GetCertificate != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: s.opts.GetCertificate,
+			ClientAuth:     tls.RequireAndVerifyClientCert,
+			ClientCAs:      s.clientCAs,
+		})))
+	}
+	server := grpc.NewServer(serverOpts...)
+	externalscaler.RegisterExternalScalerServer(server, s.scaler)
+
+	listener, err := net.Listen("tcp", s.opts.BindAddress)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", s.opts.BindAddress, err)
+	}
+
+	// Streams never end by themselves: stop without waiting for them, KEDA
+	// reconnects to another replica
+	go func() {
+		<-ctx.Done()
+		server.Stop()
+	}()
+
+	logger.Info("Serving KEDA external scaler", "address", s.opts.BindAddress, "mTLS", s.opts.GetCertificate != nil)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serving KEDA external scaler: %w", err)
+	}
+	return nil
+}
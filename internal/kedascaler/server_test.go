@@ -0,0 +1,173 @@
+package kedascaler
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"k8s.io/utils/ptr"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/kedascaler/externalscaler"
+)
+
+// testCA is a certificate authority issuing the certificates of a test.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a certificate for 127.0.0.1 signed by the CA, for the given usage.
+func (ca *testCA) issue(t *testing.T, usage x509.ExtKeyUsage) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func writeCA(t *testing.T, content []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestNewServer(t *testing.T) {
+	ca := newTestCA(t)
+	serving := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	getCertificate := func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &serving, nil }
+
+	tests := []struct {
+		name    string
+		opts    Options
+		wantErr bool
+	}{
+		{name: "mTLS", opts: Options{BindAddress: ":9000", GetCertificate: getCertificate, ClientCACertPath: writeCA(t, ca.pem)}},
+		{name: "explicitly insecure", opts: Options{BindAddress: ":9000", Insecure: true}},
+		{name: "no bind address", opts: Options{Insecure: true}, wantErr: true},
+		{name: "plaintext without insecure", opts: Options{BindAddress: ":9000"}, wantErr: true},
+		{name: "TLS without client CA", opts: Options{BindAddress: ":9000", GetCertificate: getCertificate}, wantErr: true},
+		{name: "missing client CA", opts: Options{BindAddress: ":9000", GetCertificate: getCertificate, ClientCACertPath: "/nonexistent/ca.crt"}, wantErr: true},
+		{name: "invalid client CA", opts: Options{BindAddress: ":9000", GetCertificate: getCertificate, ClientCACertPath: writeCA(t, []byte("not a certificate"))}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewServer(NewScaler(newTestClient(t)), tt.opts); (err != nil) != tt.wantErr {
+				t.Errorf("NewServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServerRequiresClientCertificate(t *testing.T) {
+	ca := newTestCA(t)
+	serving := ca.issue(t, x509.ExtKeyUsageServerAuth)
+	otherCA := newTestCA(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	server, err := NewServer(NewScaler(newTestClient(t, newTestVariant("llama", ptr.To(int32(2)), 1)...)), Options{
+		BindAddress:      address,
+		GetCertificate:   func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return &serving, nil },
+		ClientCACertPath: writeCA(t, ca.pem),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = server.Start(ctx) }()
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(ca.cert)
+	tests := []struct {
+		name    string
+		certs   []tls.Certificate
+		wantErr bool
+	}{
+		{name: "client certificate of the CA", certs: []tls.Certificate{ca.issue(t, x509.ExtKeyUsageClientAuth)}},
+		{name: "no client certificate", wantErr: true},
+		{name: "client certificate of another CA", certs: []tls.Certificate{otherCA.issue(t, x509.ExtKeyUsageClientAuth)}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				RootCAs:      rootCAs,
+				Certificates: tt.certs,
+			})))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer func() { _ = conn.Close() }()
+
+			callCtx, callCancel := context.WithTimeout(ctx, 5*time.Second)
+			defer callCancel()
+			_, err = externalscaler.NewExternalScalerClient(conn).IsActive(callCtx, ref("llama"), grpc.WaitForReady(!tt.wantErr))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("IsActive() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
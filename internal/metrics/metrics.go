@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	llmdOptv1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
@@ -20,6 +21,10 @@ var (
 	currentReplicas     *prometheus.GaugeVec
 	desiredRatio        *prometheus.GaugeVec
 
+	replicaStartupLatency *prometheus.HistogramVec
+	expectedStartup       *prometheus.GaugeVec
+	coldStartLookahead    *prometheus.GaugeVec
+
 	// controllerInstance stores the optional controller instance identifier.
 	// When set, it's added as a label to all emitted metrics.
 	controllerInstance string
@@ -43,9 +48,16 @@ func InitMetrics(registry prometheus.Registerer) error {
 	baseLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelAcceleratorType}
 	scalingLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelDirection, constants.LabelReason}
 
+	startupLabels := []string{constants.LabelVariantName, constants.LabelNamespace, constants.LabelPhase}
+	expectedStartupLabels := []string{constants.LabelVariantName, constants.LabelNamespace}
+	modelLabels := []string{constants.LabelModelName, constants.LabelNamespace}
+
 	if controllerInstance != "" {
 		baseLabels = append(baseLabels, constants.LabelControllerInstance)
 		scalingLabels = append(scalingLabels, constants.LabelControllerInstance)
+		startupLabels = append(startupLabels, constants.LabelControllerInstance)
+		expectedStartupLabels = append(expectedStartupLabels, constants.LabelControllerInstance)
+		modelLabels = append(modelLabels, constants.LabelControllerInstance)
 	}
 
 	replicaScalingTotal = prometheus.NewCounterVec(
@@ -77,6 +89,30 @@ func InitMetrics(registry prometheus.Registerer) error {
 		baseLabels,
 	)
 
+	replicaStartupLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: constants.WVAReplicaStartupLatencySeconds,
+			Help: "Observed replica startup latency in seconds, by phase (ready, first_request)",
+			// Large models take from tens of seconds to tens of minutes to load.
+			Buckets: []float64{10, 30, 60, 120, 180, 300, 450, 600, 900, 1200, 1800},
+		},
+		startupLabels,
+	)
+	expectedStartup = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAExpectedStartupSeconds,
+			Help: "Learned startup lead time in seconds (creation to first request) for each variant",
+		},
+		expectedStartupLabels,
+	)
+	coldStartLookahead = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAColdStartLookaheadCapacity,
+			Help: "Capacity added to cover demand growth expected while new replicas start, in analyzer units",
+		},
+		modelLabels,
+	)
+
 	// Register metrics with the registry
 	if err := registry.Register(replicaScalingTotal); err != nil {
 		return fmt.Errorf("failed to register replicaScalingTotal metric: %w", err)
@@ -90,8 +126,20 @@ func InitMetrics(registry prometheus.Registerer) error {
 	if err := registry.Register(desiredRatio); err != nil {
 		return fmt.Errorf("failed to register desiredRatio metric: %w", err)
 	}
+	if err := registry.Register(replicaStartupLatency); err != nil {
+		return fmt.Errorf("failed to register replicaStartupLatency metric: %w", err)
+	}
+	if err := registry.Register(expectedStartup); err != nil {
+		return fmt.Errorf("failed to register expectedStartup metric: %w", err)
+	}
+	if err := registry.Register(coldStartLookahead); err != nil {
+		return fmt.Errorf("failed to register coldStartLookahead metric: %w", err)
+	}
 
-	return nil
+	if err := initPipelineMetrics(registry); err != nil {
+		return err
+	}
+	return initShadowMetrics(registry)
 }
 
 // InitMetricsAndEmitter registers metrics with Prometheus and creates a metrics emitter
@@ -164,3 +212,52 @@ func (m *MetricsEmitter) EmitReplicaMetrics(ctx context.Context, va *llmdOptv1al
 	desiredRatio.With(baseLabels).Set(float64(desired) / float64(current))
 	return nil
 }
+
+// EmitStartupLatency records an observed replica startup latency sample for a phase
+func (m *MetricsEmitter) EmitStartupLatency(variantName, namespace, phase string, latency time.Duration) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+		constants.LabelPhase:       phase,
+	}
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+	if replicaStartupLatency == nil {
+		return errors.New("replicaStartupLatency metric not initialized")
+	}
+	replicaStartupLatency.With(labels).Observe(latency.Seconds())
+	return nil
+}
+
+// EmitExpectedStartup sets the learned startup lead time for a variant
+func (m *MetricsEmitter) EmitExpectedStartup(variantName, namespace string, expected time.Duration) error {
+	labels := prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+	}
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+	if expectedStartup == nil {
+		return errors.New("expectedStartup metric not initialized")
+	}
+	expectedStartup.With(labels).Set(expected.Seconds())
+	return nil
+}
+
+// EmitColdStartLookahead sets the capacity added by cold-start lookahead for a model
+func (m *MetricsEmitter) EmitColdStartLookahead(modelID, namespace string, added float64) error {
+	labels := prometheus.Labels{
+		constants.LabelModelName: modelID,
+		constants.LabelNamespace: namespace,
+	}
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+	if coldStartLookahead == nil {
+		return errors.New("coldStartLookahead metric not initialized")
+	}
+	coldStartLookahead.With(labels).Set(added)
+	return nil
+}
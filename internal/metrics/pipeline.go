@@ -0,0 +1,407 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// Values of the role label of the analyzer metrics and of the reason label of
+// the safety override and model skip counters.
+const (
+	RoleAll = "all"
+
+	SafetyOverrideSaturationVeto = "saturation_veto"
+	SafetyOverrideSafetyNet      = "safety_net"
+
+	SkipNoMetrics        = "no_metrics"
+	SkipStaleMetrics     = "stale_metrics"
+	SkipCollectionFailed = "collection_failed"
+	SkipAnalysisFailed   = "analysis_failed"
+	SkipConfigMissing    = "config_missing"
+)
+
+var (
+	analyzerSupply           *prometheus.GaugeVec
+	analyzerDemand           *prometheus.GaugeVec
+	analyzerUtilization      *prometheus.GaugeVec
+	analyzerRequiredCapacity *prometheus.GaugeVec
+	analyzerSpareCapacity    *prometheus.GaugeVec
+
+	analyzerRecommendedReplicas *prometheus.GaugeVec
+	analyzerReplicaDivergence   *prometheus.GaugeVec
+
+	cycleDuration *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+
+	limiterCuts      *prometheus.CounterVec
+	safetyOverrides  *prometheus.CounterVec
+	scaleToZeroTotal *prometheus.CounterVec
+	scaleFromZero    *prometheus.CounterVec
+	modelSkips       *prometheus.CounterVec
+
+	// modelSeries are the analyzer roles with series of each model, keyed by
+	// namespace/model ID and then by analyzer, for deleting the series of
+	// models and roles that are gone.
+	modelSeriesMu sync.Mutex
+	modelSeries   = make(map[string]map[string]map[string]bool)
+)
+
+// withInstance appends the controller_instance label name if configured.
+func withInstance(labels ...string) []string {
+	if controllerInstance != "" {
+		labels = append(labels, constants.LabelControllerInstance)
+	}
+	return labels
+}
+
+// instanceLabels adds the controller_instance label value if configured.
+func instanceLabels(labels prometheus.Labels) prometheus.Labels {
+	if controllerInstance != "" {
+		labels[constants.LabelControllerInstance] = controllerInstance
+	}
+	return labels
+}
+
+// initPipelineMetrics creates and registers the metrics of the analyzers and
+// the pipeline stages. Called by InitMetrics after the controller instance is read.
+func initPipelineMetrics(registry prometheus.Registerer) error {
+	modelSeriesMu.Lock()
+	modelSeries = make(map[string]map[string]map[string]bool)
+	modelSeriesMu.Unlock()
+
+	analyzerLabels := withInstance(constants.LabelModelName, constants.LabelNamespace, constants.LabelAnalyzer, constants.LabelRole)
+	analyzerGauge := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: name, Help: help}, analyzerLabels)
+	}
+	analyzerSupply = analyzerGauge(constants.WVAAnalyzerSupply,
+		"Total capacity of the model's replicas as estimated by the analyzer, in analyzer units")
+	analyzerDemand = analyzerGauge(constants.WVAAnalyzerDemand,
+		"Total demand on the model as estimated by the analyzer, in analyzer units")
+	analyzerUtilization = analyzerGauge(constants.WVAAnalyzerUtilization,
+		"Ratio of the analyzer's demand and supply for the model")
+	analyzerRequiredCapacity = analyzerGauge(constants.WVAAnalyzerRequiredCapacity,
+		"Capacity the analyzer requires to be added for the model, in analyzer units")
+	analyzerSpareCapacity = analyzerGauge(constants.WVAAnalyzerSpareCapacity,
+		"Capacity the analyzer considers safe to remove for the model, in analyzer units")
+
+	analyzerRecommendedReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAAnalyzerRecommendedReplicas,
+			Help: "Replicas recommended for the model by the primary and the comparison analyzers, before GPU constraints and the enforcer",
+		},
+		withInstance(constants.LabelModelName, constants.LabelNamespace, constants.LabelAnalyzer),
+	)
+	analyzerReplicaDivergence = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAAnalyzerReplicaDivergence,
+			Help: "Replicas recommended for the model by a comparison analyzer minus those recommended by the primary analyzer",
+		},
+		withInstance(constants.LabelModelName, constants.LabelNamespace, constants.LabelAnalyzer, constants.LabelPrimary),
+	)
+
+	cycleDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    constants.WVAOptimizationCycleDurationSeconds,
+			Help:    "Duration of the saturation engine's optimization cycles in seconds",
+			Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 20, 30, 60, 120},
+		},
+		withInstance(constants.LabelAnalyzer),
+	)
+	queryDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    constants.WVAMetricsQueryDurationSeconds,
+			Help:    "Latency of metrics queries in seconds, including retries",
+			Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		withInstance(constants.LabelSource, constants.LabelQuery),
+	)
+
+	limiterCuts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVALimiterCutsTotal,
+			Help: "Total number of decisions whose target the limiter reduced",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace, constants.LabelLimitedBy),
+	)
+	safetyOverrides = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVASafetyOverridesTotal,
+			Help: "Total number of decisions overridden for safety",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace, constants.LabelReason),
+	)
+	scaleToZeroTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVAScaleToZeroTotal,
+			Help: "Total number of decisions scaling a variant to zero replicas",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace),
+	)
+	scaleFromZero = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVAScaleFromZeroTotal,
+			Help: "Total number of scale-from-zero actuations",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace),
+	)
+	modelSkips = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: constants.WVAModelSkipsTotal,
+			Help: "Total number of models skipped in an optimization cycle",
+		},
+		withInstance(constants.LabelModelName, constants.LabelNamespace, constants.LabelReason),
+	)
+
+	for _, c := range []struct {
+		name      string
+		collector prometheus.Collector
+	}{
+		{"analyzerSupply", analyzerSupply},
+		{"analyzerDemand", analyzerDemand},
+		{"analyzerUtilization", analyzerUtilization},
+		{"analyzerRequiredCapacity", analyzerRequiredCapacity},
+		{"analyzerSpareCapacity", analyzerSpareCapacity},
+		{"analyzerRecommendedReplicas", analyzerRecommendedReplicas},
+		{"analyzerReplicaDivergence", analyzerReplicaDivergence},
+		{"cycleDuration", cycleDuration},
+		{"queryDuration", queryDuration},
+		{"limiterCuts", limiterCuts},
+		{"safetyOverrides", safetyOverrides},
+		{"scaleToZero", scaleToZeroTotal},
+		{"scaleFromZero", scaleFromZero},
+		{"modelSkips", modelSkips},
+	} {
+		if err := registry.Register(c.collector); err != nil {
+			return fmt.Errorf("failed to register %s metric: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// EmitAnalyzerResult sets the analyzer gauges of a model from an analyzer
+// result: the model totals, and each role of a P/D disaggregated model.
+func (m *MetricsEmitter) EmitAnalyzerResult(result *interfaces.AnalyzerResult) error {
+	if analyzerSupply == nil {
+		return errors.New("analyzer metrics not initialized")
+	}
+	set := func(role string, supply, demand, required, spare float64) {
+		labels := instanceLabels(prometheus.Labels{
+			constants.LabelModelName: result.ModelID,
+			constants.LabelNamespace: result.Namespace,
+			constants.LabelAnalyzer:  result.AnalyzerName,
+			constants.LabelRole:      role,
+		})
+		analyzerSupply.With(labels).Set(supply)
+		analyzerDemand.With(labels).Set(demand)
+		utilization := 0.0
+		if supply > 0 {
+			utilization = demand / supply
+		}
+		analyzerUtilization.With(labels).Set(utilization)
+		analyzerRequiredCapacity.With(labels).Set(required)
+		analyzerSpareCapacity.With(labels).Set(spare)
+	}
+
+	set(RoleAll, result.TotalSupply, result.TotalDemand, result.RequiredCapacity, result.SpareCapacity)
+	roles := map[string]bool{RoleAll: true}
+	for role, rc := range result.RoleCapacities {
+		set(role, rc.TotalSupply, rc.TotalDemand, rc.RequiredCapacity, rc.SpareCapacity)
+		roles[role] = true
+	}
+
+	// Delete the roles the model no longer has, e.g. when the VA of a role
+	// of a P/D disaggregated model was deleted
+	modelSeriesMu.Lock()
+	defer modelSeriesMu.Unlock()
+	analyzers := trackModel(result.ModelID, result.Namespace)
+	for role := range analyzers[result.AnalyzerName] {
+		if !roles[role] {
+			deleteAnalyzerSeries(prometheus.Labels{
+				constants.LabelModelName: result.ModelID,
+				constants.LabelNamespace: result.Namespace,
+				constants.LabelAnalyzer:  result.AnalyzerName,
+				constants.LabelRole:      role,
+			})
+		}
+	}
+	analyzers[result.AnalyzerName] = roles
+	return nil
+}
+
+// RetainModels deletes the analyzer, recommendation and divergence series of
+// the models that are not in keys, which are namespace/model ID, so that a
+// model whose VAs were deleted does not keep reporting its last values.
+func (m *MetricsEmitter) RetainModels(keys map[string]bool) {
+	modelSeriesMu.Lock()
+	defer modelSeriesMu.Unlock()
+	for key := range modelSeries {
+		if keys[key] {
+			continue
+		}
+		namespace, modelID, _ := strings.Cut(key, "/")
+		labels := prometheus.Labels{
+			constants.LabelModelName: modelID,
+			constants.LabelNamespace: namespace,
+		}
+		deleteAnalyzerSeries(labels)
+		if analyzerRecommendedReplicas != nil {
+			analyzerRecommendedReplicas.DeletePartialMatch(labels)
+		}
+		if analyzerReplicaDivergence != nil {
+			analyzerReplicaDivergence.DeletePartialMatch(labels)
+		}
+		delete(modelSeries, key)
+	}
+}
+
+// trackModel records that a model has series, and returns its roles by
+// analyzer. modelSeriesMu must be held.
+func trackModel(modelID, namespace string) map[string]map[string]bool {
+	key := namespace + "/" + modelID
+	if modelSeries[key] == nil {
+		modelSeries[key] = make(map[string]map[string]bool)
+	}
+	return modelSeries[key]
+}
+
+// deleteAnalyzerSeries deletes the analyzer gauge series matching labels.
+func deleteAnalyzerSeries(labels prometheus.Labels) {
+	for _, gauge := range []*prometheus.GaugeVec{
+		analyzerSupply, analyzerDemand, analyzerUtilization, analyzerRequiredCapacity, analyzerSpareCapacity,
+	} {
+		if gauge != nil {
+			gauge.DeletePartialMatch(labels)
+		}
+	}
+}
+
+// EmitAnalyzerRecommendation sets the replicas an analyzer recommends for a model.
+func (m *MetricsEmitter) EmitAnalyzerRecommendation(modelID, namespace, analyzer string, replicas int) error {
+	if analyzerRecommendedReplicas == nil {
+		return errors.New("analyzerRecommendedReplicas metric not initialized")
+	}
+	analyzerRecommendedReplicas.With(instanceLabels(prometheus.Labels{
+		constants.LabelModelName: modelID,
+		constants.LabelNamespace: namespace,
+		constants.LabelAnalyzer:  analyzer,
+	})).Set(float64(replicas))
+	modelSeriesMu.Lock()
+	trackModel(modelID, namespace)
+	modelSeriesMu.Unlock()
+	return nil
+}
+
+// EmitAnalyzerDivergence sets the difference between the replicas a comparison
+// analyzer and the primary analyzer recommend for a model.
+func (m *MetricsEmitter) EmitAnalyzerDivergence(modelID, namespace, analyzer, primary string, divergence int) error {
+	if analyzerReplicaDivergence == nil {
+		return errors.New("analyzerReplicaDivergence metric not initialized")
+	}
+	analyzerReplicaDivergence.With(instanceLabels(prometheus.Labels{
+		constants.LabelModelName: modelID,
+		constants.LabelNamespace: namespace,
+		constants.LabelAnalyzer:  analyzer,
+		constants.LabelPrimary:   primary,
+	})).Set(float64(divergence))
+	modelSeriesMu.Lock()
+	trackModel(modelID, namespace)
+	modelSeriesMu.Unlock()
+	return nil
+}
+
+// ObserveCycleDuration records the duration of an optimization cycle.
+func (m *MetricsEmitter) ObserveCycleDuration(analyzer string, duration time.Duration) error {
+	if cycleDuration == nil {
+		return errors.New("cycleDuration metric not initialized")
+	}
+	cycleDuration.With(instanceLabels(prometheus.Labels{constants.LabelAnalyzer: analyzer})).Observe(duration.Seconds())
+	return nil
+}
+
+// ObserveQueryDuration records the latency of a metrics query of a source.
+func (m *MetricsEmitter) ObserveQueryDuration(source, query string, duration time.Duration) error {
+	if queryDuration == nil {
+		return errors.New("queryDuration metric not initialized")
+	}
+	queryDuration.With(instanceLabels(prometheus.Labels{
+		constants.LabelSource: source,
+		constants.LabelQuery:  query,
+	})).Observe(duration.Seconds())
+	return nil
+}
+
+// EmitDecision counts the limiter cuts, saturation vetoes and scale-to-zero
+// of a decision.
+func (m *MetricsEmitter) EmitDecision(d interfaces.VariantDecision) error {
+	if limiterCuts == nil || safetyOverrides == nil || scaleToZeroTotal == nil {
+		return errors.New("decision metrics not initialized")
+	}
+	if d.WasLimited {
+		limiterCuts.With(instanceLabels(prometheus.Labels{
+			constants.LabelVariantName: d.VariantName,
+			constants.LabelNamespace:   d.Namespace,
+			constants.LabelLimitedBy:   d.LimitedBy,
+		})).Inc()
+	}
+	if d.SafetyOverride {
+		safetyOverrides.With(instanceLabels(prometheus.Labels{
+			constants.LabelVariantName: d.VariantName,
+			constants.LabelNamespace:   d.Namespace,
+			constants.LabelReason:      SafetyOverrideSaturationVeto,
+		})).Inc()
+	}
+	if d.TargetReplicas == 0 && d.CurrentReplicas > 0 {
+		scaleToZeroTotal.With(instanceLabels(prometheus.Labels{
+			constants.LabelVariantName: d.VariantName,
+			constants.LabelNamespace:   d.Namespace,
+		})).Inc()
+	}
+	return nil
+}
+
+// EmitSafetyNet counts a variant whose current replicas were kept because its
+// model could not be analyzed.
+func (m *MetricsEmitter) EmitSafetyNet(variantName, namespace string) error {
+	if safetyOverrides == nil {
+		return errors.New("safetyOverrides metric not initialized")
+	}
+	safetyOverrides.With(instanceLabels(prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+		constants.LabelReason:      SafetyOverrideSafetyNet,
+	})).Inc()
+	return nil
+}
+
+// EmitScaleFromZero counts a scale-from-zero actuation of a variant.
+func (m *MetricsEmitter) EmitScaleFromZero(variantName, namespace string) error {
+	if scaleFromZero == nil {
+		return errors.New("scaleFromZero metric not initialized")
+	}
+	scaleFromZero.With(instanceLabels(prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+	})).Inc()
+	return nil
+}
+
+// EmitModelSkip counts a model skipped in an optimization cycle.
+func (m *MetricsEmitter) EmitModelSkip(modelID, namespace, reason string) error {
+	if modelSkips == nil {
+		return errors.New("modelSkips metric not initialized")
+	}
+	modelSkips.With(instanceLabels(prometheus.Labels{
+		constants.LabelModelName: modelID,
+		constants.LabelNamespace: namespace,
+		constants.LabelReason:    reason,
+	})).Inc()
+	return nil
+}
@@ -0,0 +1,231 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+// initTestMetrics registers the metrics in a new registry, with the given
+// controller instance.
+func initTestMetrics(t *testing.T, instance string) *prometheus.Registry {
+	t.Helper()
+	t.Setenv(ControllerInstanceEnvVar, instance)
+	registry := prometheus.NewRegistry()
+	if err := InitMetrics(registry); err != nil {
+		t.Fatalf("InitMetrics() error = %v", err)
+	}
+	return registry
+}
+
+func TestInitPipelineMetrics_ControllerInstance(t *testing.T) {
+	tests := []struct {
+		name     string
+		instance string
+		want     string
+	}{
+		{
+			name: "without controller instance",
+			want: `
+# HELP wva_model_skips_total Total number of models skipped in an optimization cycle
+# TYPE wva_model_skips_total counter
+wva_model_skips_total{model_name="meta/llama",namespace="llm",reason="no_metrics"} 1
+`,
+		},
+		{
+			name:     "with controller instance",
+			instance: "wva-a",
+			want: `
+# HELP wva_model_skips_total Total number of models skipped in an optimization cycle
+# TYPE wva_model_skips_total counter
+wva_model_skips_total{controller_instance="wva-a",model_name="meta/llama",namespace="llm",reason="no_metrics"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := initTestMetrics(t, tt.instance)
+			if err := NewMetricsEmitter().EmitModelSkip("meta/llama", "llm", SkipNoMetrics); err != nil {
+				t.Fatal(err)
+			}
+			if err := testutil.GatherAndCompare(registry, strings.NewReader(tt.want), constants.WVAModelSkipsTotal); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestEmitAnalyzerResult(t *testing.T) {
+	initTestMetrics(t, "")
+	result := &interfaces.AnalyzerResult{
+		ModelID:          "meta/llama",
+		Namespace:        "llm",
+		AnalyzerName:     "saturation",
+		TotalSupply:      100,
+		TotalDemand:      80,
+		RequiredCapacity: 10,
+		SpareCapacity:    5,
+		RoleCapacities: map[string]interfaces.RoleCapacity{
+			"prefill": {Role: "prefill", TotalSupply: 40, TotalDemand: 10},
+			"decode":  {Role: "decode", TotalSupply: 0, TotalDemand: 70, RequiredCapacity: 10},
+		},
+	}
+	if err := NewMetricsEmitter().EmitAnalyzerResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(analyzerSupply); n != 3 {
+		t.Errorf("Supply series = %d, want one for role all and one per role", n)
+	}
+	tests := []struct {
+		role        string
+		supply      float64
+		demand      float64
+		utilization float64
+		required    float64
+		spare       float64
+	}{
+		{role: RoleAll, supply: 100, demand: 80, utilization: 0.8, required: 10, spare: 5},
+		{role: "prefill", supply: 40, demand: 10, utilization: 0.25},
+		// a role without supply has no utilization rather than an infinite one
+		{role: "decode", supply: 0, demand: 70, utilization: 0, required: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.role, func(t *testing.T) {
+			gauges := []struct {
+				name  string
+				gauge *prometheus.GaugeVec
+				want  float64
+			}{
+				{constants.WVAAnalyzerSupply, analyzerSupply, tt.supply},
+				{constants.WVAAnalyzerDemand, analyzerDemand, tt.demand},
+				{constants.WVAAnalyzerUtilization, analyzerUtilization, tt.utilization},
+				{constants.WVAAnalyzerRequiredCapacity, analyzerRequiredCapacity, tt.required},
+				{constants.WVAAnalyzerSpareCapacity, analyzerSpareCapacity, tt.spare},
+			}
+			for _, g := range gauges {
+				got := testutil.ToFloat64(g.gauge.WithLabelValues("meta/llama", "llm", "saturation", tt.role))
+				if got != g.want {
+					t.Errorf("%s = %v, want %v", g.name, got, g.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetainModels(t *testing.T) {
+	initTestMetrics(t, "")
+	emitter := NewMetricsEmitter()
+	for _, modelID := range []string{"meta/llama", "ibm/granite"} {
+		if err := emitter.EmitAnalyzerResult(&interfaces.AnalyzerResult{ModelID: modelID, Namespace: "llm", AnalyzerName: "saturation"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := emitter.EmitAnalyzerRecommendation(modelID, "llm", "saturation", 2); err != nil {
+			t.Fatal(err)
+		}
+		if err := emitter.EmitAnalyzerDivergence(modelID, "llm", "queueing-model", "saturation", 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	emitter.RetainModels(map[string]bool{"llm/ibm/granite": true})
+
+	for _, g := range []struct {
+		name  string
+		gauge *prometheus.GaugeVec
+	}{
+		{constants.WVAAnalyzerSupply, analyzerSupply},
+		{constants.WVAAnalyzerUtilization, analyzerUtilization},
+		{constants.WVAAnalyzerRecommendedReplicas, analyzerRecommendedReplicas},
+		{constants.WVAAnalyzerReplicaDivergence, analyzerReplicaDivergence},
+	} {
+		if n := testutil.CollectAndCount(g.gauge); n != 1 {
+			t.Errorf("%s series = %d, want only those of the retained model", g.name, n)
+		}
+	}
+	if got := testutil.ToFloat64(analyzerRecommendedReplicas.WithLabelValues("ibm/granite", "llm", "saturation")); got != 2 {
+		t.Errorf("Recommended replicas of the retained model = %v, want 2", got)
+	}
+
+	emitter.RetainModels(nil)
+	if n := testutil.CollectAndCount(analyzerSupply); n != 0 {
+		t.Errorf("Supply series = %d, want none without models", n)
+	}
+}
+
+func TestEmitAnalyzerResult_DeletesRemovedRoles(t *testing.T) {
+	initTestMetrics(t, "")
+	emitter := NewMetricsEmitter()
+	result := &interfaces.AnalyzerResult{
+		ModelID:      "meta/llama",
+		Namespace:    "llm",
+		AnalyzerName: "saturation",
+		RoleCapacities: map[string]interfaces.RoleCapacity{
+			"prefill": {Role: "prefill", TotalSupply: 40},
+			"decode":  {Role: "decode", TotalSupply: 60},
+		},
+	}
+	if err := emitter.EmitAnalyzerResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	// the prefill variant was deleted
+	delete(result.RoleCapacities, "prefill")
+	if err := emitter.EmitAnalyzerResult(result); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := testutil.CollectAndCount(analyzerSupply); n != 2 {
+		t.Errorf("Supply series = %d, want role all and decode", n)
+	}
+	if got := testutil.ToFloat64(analyzerSupply.WithLabelValues("meta/llama", "llm", "saturation", "decode")); got != 60 {
+		t.Errorf("Decode supply = %v, want 60", got)
+	}
+}
+
+func TestEmitDecisionAndModelSkip(t *testing.T) {
+	initTestMetrics(t, "")
+	emitter := NewMetricsEmitter()
+	decisions := []interfaces.VariantDecision{
+		{VariantName: "llama-a100", Namespace: "llm", CurrentReplicas: 2, TargetReplicas: 3, WasLimited: true, LimitedBy: "gpu-limiter"},
+		{VariantName: "llama-a100", Namespace: "llm", CurrentReplicas: 2, TargetReplicas: 3, WasLimited: true, LimitedBy: "gpu-limiter"},
+		{VariantName: "llama-h100", Namespace: "llm", CurrentReplicas: 1, TargetReplicas: 2, WasLimited: true, LimitedBy: "namespace-quota"},
+		{VariantName: "llama-l4", Namespace: "llm", CurrentReplicas: 1, TargetReplicas: 1},
+	}
+	for _, d := range decisions {
+		if err := emitter.EmitDecision(d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, reason := range []string{SkipNoMetrics, SkipAnalysisFailed, SkipAnalysisFailed, SkipStaleMetrics} {
+		if err := emitter.EmitModelSkip("meta/llama", "llm", reason); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if n := testutil.CollectAndCount(limiterCuts); n != 2 {
+		t.Errorf("Limiter cut series = %d, want one per limited variant", n)
+	}
+	counters := []struct {
+		name string
+		got  float64
+		want float64
+	}{
+		{"gpu-limiter cuts", testutil.ToFloat64(limiterCuts.WithLabelValues("llama-a100", "llm", "gpu-limiter")), 2},
+		{"namespace-quota cuts", testutil.ToFloat64(limiterCuts.WithLabelValues("llama-h100", "llm", "namespace-quota")), 1},
+		{"no_metrics skips", testutil.ToFloat64(modelSkips.WithLabelValues("meta/llama", "llm", SkipNoMetrics)), 1},
+		{"analysis_failed skips", testutil.ToFloat64(modelSkips.WithLabelValues("meta/llama", "llm", SkipAnalysisFailed)), 2},
+		{"stale_metrics skips", testutil.ToFloat64(modelSkips.WithLabelValues("meta/llama", "llm", SkipStaleMetrics)), 1},
+		{"config_missing skips", testutil.ToFloat64(modelSkips.WithLabelValues("meta/llama", "llm", SkipConfigMissing)), 0},
+	}
+	for _, c := range counters {
+		if c.got != c.want {
+			t.Errorf("%s = %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+// Values of the baseline label of the shadow replica difference.
+const (
+	ShadowBaselineHPA     = "hpa"
+	ShadowBaselineCurrent = "current"
+)
+
+var (
+	shadowDesiredReplicas   *prometheus.GaugeVec
+	shadowReplicaDifference *prometheus.GaugeVec
+)
+
+// initShadowMetrics creates and registers the metrics of the variants in
+// shadow mode. Called by InitMetrics after the controller instance is read.
+func initShadowMetrics(registry prometheus.Registerer) error {
+	shadowDesiredReplicas = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAShadowDesiredReplicas,
+			Help: "Replicas recommended by WVA for a variant in shadow mode",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace, constants.LabelAcceleratorType),
+	)
+	shadowReplicaDifference = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: constants.WVAShadowReplicaDifference,
+			Help: "Replicas recommended by WVA for a variant in shadow mode minus the replicas of the baseline (the HPA's desired replicas, or the current replicas)",
+		},
+		withInstance(constants.LabelVariantName, constants.LabelNamespace, constants.LabelBaseline),
+	)
+
+	if err := registry.Register(shadowDesiredReplicas); err != nil {
+		return fmt.Errorf("failed to register shadowDesiredReplicas metric: %w", err)
+	}
+	if err := registry.Register(shadowReplicaDifference); err != nil {
+		return fmt.Errorf("failed to register shadowReplicaDifference metric: %w", err)
+	}
+	return nil
+}
+
+// EmitShadowReplicas sets the recommendation of a variant in shadow mode and
+// its difference to the replicas of the baseline.
+func (m *MetricsEmitter) EmitShadowReplicas(variantName, namespace, acceleratorType string, recommended int32, baseline string, baselineReplicas int32) error {
+	if shadowDesiredReplicas == nil || shadowReplicaDifference == nil {
+		return errors.New("shadow metrics not initialized")
+	}
+	// Drop the series of a previous accelerator or baseline, e.g. when the HPA was deleted
+	m.ClearShadowReplicas(variantName, namespace)
+	shadowDesiredReplicas.With(instanceLabels(prometheus.Labels{
+		constants.LabelVariantName:     variantName,
+		constants.LabelNamespace:       namespace,
+		constants.LabelAcceleratorType: acceleratorType,
+	})).Set(float64(recommended))
+	shadowReplicaDifference.With(instanceLabels(prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+		constants.LabelBaseline:    baseline,
+	})).Set(float64(recommended - baselineReplicas))
+	return nil
+}
+
+// ClearShadowReplicas removes the shadow mode metrics of a variant, so that a
+// variant leaving shadow mode does not keep reporting its last recommendation.
+func (m *MetricsEmitter) ClearShadowReplicas(variantName, namespace string) {
+	if shadowDesiredReplicas == nil || shadowReplicaDifference == nil {
+		return
+	}
+	labels := prometheus.Labels{
+		constants.LabelVariantName: variantName,
+		constants.LabelNamespace:   namespace,
+	}
+	shadowDesiredReplicas.DeletePartialMatch(labels)
+	shadowReplicaDifference.DeletePartialMatch(labels)
+}
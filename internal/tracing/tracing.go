@@ -0,0 +1,111 @@
+// Package tracing provides the OpenTelemetry spans of the optimization cycle
+// and the reconciler. Spans are no-ops until Setup installs an exporter.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+const (
+	// TracerName is the instrumentation scope of the controller's spans.
+	TracerName = "github.com/llm-d/llm-d-workload-variant-autoscaler"
+	// ServiceName is the service.name of the exported spans, unless
+	// OTEL_SERVICE_NAME is set.
+	ServiceName = "workload-variant-autoscaler"
+)
+
+// Span attributes.
+const (
+	ModelIDKey   = attribute.Key("wva.model_id")
+	NamespaceKey = attribute.Key("wva.namespace")
+	VariantKey   = attribute.Key("wva.variant")
+	AnalyzerKey  = attribute.Key("wva.analyzer")
+)
+
+// Model returns the attributes of a model.
+func Model(namespace, modelID string) []attribute.KeyValue {
+	return []attribute.KeyValue{NamespaceKey.String(namespace), ModelIDKey.String(modelID)}
+}
+
+// Variant returns the attributes of a variant, i.e. a VariantAutoscaling.
+func Variant(namespace, variantName string) []attribute.KeyValue {
+	return []attribute.KeyValue{NamespaceKey.String(namespace), VariantKey.String(variantName)}
+}
+
+// Start starts a span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends a span, marking it failed with err if not nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Setup installs the global tracer provider for the configured exporter. It
+// returns a function that flushes and stops the exporter, or nil when tracing
+// is disabled.
+func Setup(ctx context.Context, cfg config.TracingConfig) (func(context.Context) error, error) {
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "":
+		return nil, nil
+	case config.TracingExporterStdout:
+		exporter, err = stdouttrace.New()
+	case config.TracingExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if cfg.OTLPEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint))
+		}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("creating %s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		ctrl.Log.WithName("tracing").Error(err, "Failed to export spans")
+	}))
+	return provider.Shutdown, nil
+}
@@ -0,0 +1,75 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+func TestStartEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	ctx, parent := Start(context.Background(), "parent", Model("llm", "meta/llama")...)
+	_, child := Start(ctx, "child", Variant("llm", "llama-a100")...)
+	End(child, errors.New("scale target not found"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+	childSpan, parentSpan := spans[0], spans[1]
+	if childSpan.Parent().SpanID() != parentSpan.SpanContext().SpanID() {
+		t.Error("Expected child span to be a child of the parent span")
+	}
+	if childSpan.Status().Code != codes.Error || len(childSpan.Events()) != 1 {
+		t.Errorf("Expected failed child span with the error recorded, got %v, %d events",
+			childSpan.Status(), len(childSpan.Events()))
+	}
+	if parentSpan.Status().Code != codes.Unset {
+		t.Errorf("Expected parent span status unset, got %v", parentSpan.Status())
+	}
+
+	attrs := make(map[string]string)
+	for _, kv := range parentSpan.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.AsString()
+	}
+	if attrs[string(NamespaceKey)] != "llm" || attrs[string(ModelIDKey)] != "meta/llama" {
+		t.Errorf("Expected model attributes, got %v", attrs)
+	}
+}
+
+func TestSetup(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{})
+	if err != nil || shutdown != nil {
+		t.Errorf("Expected tracing disabled without an exporter, got %v, %v", shutdown != nil, err)
+	}
+
+	shutdown, err = Setup(context.Background(), config.TracingConfig{Exporter: config.TracingExporterStdout, SampleRatio: 1})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+	if _, ok := otel.GetTracerProvider().(*sdktrace.TracerProvider); !ok {
+		t.Errorf("Expected the SDK tracer provider to be installed, got %T", otel.GetTracerProvider())
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown() error = %v", err)
+	}
+
+	if _, err := Setup(context.Background(), config.TracingConfig{Exporter: "zipkin"}); err == nil {
+		t.Error("Expected an error for an unknown exporter")
+	}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"math"
+	"strconv"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+// VariantCost is the resolved per-replica cost of a variant.
+type VariantCost struct {
+	// Cost is the per-replica cost used by the optimizer.
+	Cost float64
+	// Source is one of the wvav1alpha1.CostSource* constants.
+	Source string
+	// Accelerator is the catalog key the price was looked up for (catalog source only).
+	Accelerator string
+	// PricingTier is the catalog tier the price was taken from (catalog source only).
+	PricingTier string
+	// GPUsPerReplica is the GPU count the catalog price was multiplied by (catalog source only).
+	GPUsPerReplica int
+	// LegacyDefaultIgnored is set when spec.variantCost is the legacy CRD default
+	// and the catalog price was used instead.
+	LegacyDefaultIgnored bool
+}
+
+// legacyDefaultVariantCost is the value the CRD used to default spec.variantCost
+// to. VAs created before the price catalog still have it persisted, so it is not
+// treated as an override of a catalog price, and the VA controller warns about
+// it. Deprecated: this exception will be removed in a future release; set "10"
+// to pin the cost explicitly.
+const legacyDefaultVariantCost = "10.0"
+
+// ResolveVariantCost determines the per-replica cost of a VA.
+//
+// Resolution order:
+//  1. spec.variantCost, when set and valid, unless it is the legacy CRD default
+//     "10.0" and the catalog prices the variant's accelerator
+//  2. GPUs per replica × the catalog's per-GPU price for the variant's accelerator and tier
+//  3. saturation.DefaultVariantCost
+//
+// scaleTarget may be nil, in which case only the VA accelerator label is used and
+// the catalog path assumes one GPU per replica.
+func ResolveVariantCost(
+	va *wvav1alpha1.VariantAutoscaling,
+	scaleTarget scaletarget.ScaleTargetAccessor,
+	catalog config.PriceCatalog,
+) VariantCost {
+	if va.Spec.VariantCost != "" && va.Spec.VariantCost != legacyDefaultVariantCost {
+		if cost, err := strconv.ParseFloat(va.Spec.VariantCost, 64); err == nil {
+			return VariantCost{Cost: cost, Source: wvav1alpha1.CostSourceVariantCost}
+		}
+	}
+
+	if accelerator := GetAcceleratorNameFromScaleTarget(va, scaleTarget); accelerator != "" {
+		if price, ok := catalog.Lookup(accelerator); ok {
+			gpus := 1
+			if scaleTarget != nil {
+				if n := scaleTarget.GetTotalGPUsPerReplica(); n > 0 {
+					gpus = n
+				}
+			}
+			tier := va.Spec.PricingTier
+			if tier == "" {
+				tier = config.PricingTierOnDemand
+			}
+			return VariantCost{
+				Cost:                 float64(gpus) * price.PriceForTier(tier),
+				Source:               wvav1alpha1.CostSourcePriceCatalog,
+				Accelerator:          config.PriceCatalogKey(accelerator),
+				PricingTier:          tier,
+				GPUsPerReplica:       gpus,
+				LegacyDefaultIgnored: va.Spec.VariantCost == legacyDefaultVariantCost,
+			}
+		}
+	}
+
+	if va.Spec.VariantCost == legacyDefaultVariantCost {
+		return VariantCost{Cost: saturation.DefaultVariantCost, Source: wvav1alpha1.CostSourceVariantCost}
+	}
+	return VariantCost{Cost: saturation.DefaultVariantCost, Source: wvav1alpha1.CostSourceDefault}
+}
+
+// ToStatus converts the resolved cost into its status representation.
+// The cost is rounded to cents so that tier price × GPU count products
+// do not surface floating point noise.
+func (c VariantCost) ToStatus() *wvav1alpha1.EffectiveCost {
+	return &wvav1alpha1.EffectiveCost{
+		Cost:           strconv.FormatFloat(math.Round(c.Cost*100)/100, 'f', -1, 64),
+		Source:         c.Source,
+		Accelerator:    c.Accelerator,
+		PricingTier:    c.PricingTier,
+		GPUsPerReplica: int32(c.GPUsPerReplica),
+	}
+}
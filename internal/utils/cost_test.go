@@ -0,0 +1,177 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+func gpuDeployment(product string, gpus int64) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"nvidia.com/gpu.product": product},
+					Containers: []corev1.Container{{
+						Name: "vllm",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								"nvidia.com/gpu": *resource.NewQuantity(gpus, resource.DecimalSI),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestResolveVariantCost(t *testing.T) {
+	t.Parallel()
+
+	spot := 1.5
+	catalog := config.PriceCatalog{
+		"H100": {OnDemand: 4.0, Spot: &spot},
+	}
+
+	cases := []struct {
+		name        string
+		spec        llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec
+		labels      map[string]string
+		deployment  *appsv1.Deployment
+		wantCost    float64
+		wantSource  string
+		wantGPUs    int
+		wantTier    string
+		wantAccKey  string
+		wantStatus  string
+		wantLegacy  bool
+		skipCatalog bool
+	}{
+		{
+			name:       "explicit variantCost overrides catalog",
+			spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{VariantCost: "7.5"},
+			deployment: gpuDeployment("NVIDIA-H100-80GB-HBM3", 2),
+			wantCost:   7.5,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourceVariantCost,
+			wantStatus: "7.5",
+		},
+		{
+			name:       "legacy CRD default variantCost yields to catalog",
+			spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{VariantCost: "10.0"},
+			deployment: gpuDeployment("NVIDIA-H100-80GB-HBM3", 2),
+			wantCost:   8.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog,
+			wantGPUs:   2,
+			wantTier:   config.PricingTierOnDemand,
+			wantAccKey: "H100",
+			wantStatus: "8",
+			wantLegacy: true,
+		},
+		{
+			name:       "legacy CRD default variantCost applies to unpriced accelerator",
+			spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{VariantCost: "10.0"},
+			deployment: gpuDeployment("NVIDIA-L4", 1),
+			wantCost:   10.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourceVariantCost,
+			wantStatus: "10",
+		},
+		{
+			name:       "explicit variantCost of 10 overrides catalog",
+			spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{VariantCost: "10"},
+			deployment: gpuDeployment("NVIDIA-H100-80GB-HBM3", 2),
+			wantCost:   10.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourceVariantCost,
+			wantStatus: "10",
+		},
+		{
+			name:       "catalog price times GPUs per replica",
+			deployment: gpuDeployment("NVIDIA-H100-80GB-HBM3", 2),
+			wantCost:   8.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog,
+			wantGPUs:   2,
+			wantTier:   config.PricingTierOnDemand,
+			wantAccKey: "H100",
+			wantStatus: "8",
+		},
+		{
+			name:       "spot tier",
+			spec:       llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{PricingTier: config.PricingTierSpot},
+			deployment: gpuDeployment("NVIDIA-H100-80GB-HBM3", 4),
+			wantCost:   6.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog,
+			wantGPUs:   4,
+			wantTier:   config.PricingTierSpot,
+			wantAccKey: "H100",
+			wantStatus: "6",
+		},
+		{
+			name:       "label accelerator without scale target assumes one GPU",
+			labels:     map[string]string{AcceleratorNameLabel: "h100"},
+			wantCost:   4.0,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourcePriceCatalog,
+			wantGPUs:   1,
+			wantTier:   config.PricingTierOnDemand,
+			wantAccKey: "H100",
+			wantStatus: "4",
+		},
+		{
+			name:       "unpriced accelerator uses default",
+			deployment: gpuDeployment("NVIDIA-L4", 1),
+			wantCost:   saturation.DefaultVariantCost,
+			wantSource: llmdVariantAutoscalingV1alpha1.CostSourceDefault,
+			wantStatus: "10",
+		},
+		{
+			name:        "invalid variantCost is ignored",
+			spec:        llmdVariantAutoscalingV1alpha1.VariantAutoscalingConfigSpec{VariantCost: "abc"},
+			deployment:  gpuDeployment("NVIDIA-H100-80GB-HBM3", 1),
+			wantCost:    saturation.DefaultVariantCost,
+			wantSource:  llmdVariantAutoscalingV1alpha1.CostSourceDefault,
+			wantStatus:  "10",
+			skipCatalog: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+				ObjectMeta: metav1.ObjectMeta{Labels: tc.labels},
+				Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+					VariantAutoscalingConfigSpec: tc.spec,
+				},
+			}
+			var scaleTarget scaletarget.ScaleTargetAccessor
+			if tc.deployment != nil {
+				scaleTarget = scaletarget.NewDeploymentAccessor(tc.deployment)
+			}
+			c := catalog
+			if tc.skipCatalog {
+				c = nil
+			}
+
+			got := ResolveVariantCost(va, scaleTarget, c)
+			assert.InDelta(t, tc.wantCost, got.Cost, 1e-9)
+			assert.Equal(t, tc.wantSource, got.Source)
+			assert.Equal(t, tc.wantGPUs, got.GPUsPerReplica)
+			assert.Equal(t, tc.wantTier, got.PricingTier)
+			assert.Equal(t, tc.wantAccKey, got.Accelerator)
+			assert.Equal(t, tc.wantLegacy, got.LegacyDefaultIgnored)
+
+			status := got.ToStatus()
+			assert.Equal(t, tc.wantStatus, status.Cost)
+			assert.Equal(t, tc.wantSource, status.Source)
+		})
+	}
+}
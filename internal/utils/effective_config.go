@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+// effectiveConfigHashLength is the number of hex characters kept from the hash
+// of an effective configuration.
+const effectiveConfigHashLength = 16
+
+// EffectiveConfig converts the effective configuration of a VA's model into its
+// status representation. previous is the VA's current status.effectiveConfig:
+// its LastChangeTime is kept while the hash is unchanged.
+func EffectiveConfig(cfg config.ModelConfig, previous *wvav1alpha1.EffectiveConfig, now time.Time) *wvav1alpha1.EffectiveConfig {
+	sat := cfg.Saturation
+	effective := &wvav1alpha1.EffectiveConfig{
+		Saturation: wvav1alpha1.EffectiveSaturationConfig{
+			KvCacheThreshold:     formatDecimal(sat.KvCacheThreshold),
+			QueueLengthThreshold: formatDecimal(sat.QueueLengthThreshold),
+			KvSpareTrigger:       formatDecimal(sat.KvSpareTrigger),
+			QueueSpareTrigger:    formatDecimal(sat.QueueSpareTrigger),
+			Priority:             formatDecimal(sat.Priority),
+			ColdStartLookahead:   sat.ColdStartLookahead,
+			CompareAnalyzers:     slices.Clone(sat.CompareAnalyzers),
+			Sources:              configSources(cfg.SaturationSources),
+		},
+		ScaleToZero: wvav1alpha1.EffectiveScaleToZeroConfig{
+			Enabled: cfg.ScaleToZero.EnableScaleToZero != nil && *cfg.ScaleToZero.EnableScaleToZero,
+			Sources: configSources(cfg.ScaleToZeroSources),
+		},
+	}
+	if sat.IsV2() {
+		effective.Saturation.ScaleUpThreshold = formatDecimal(sat.ScaleUpThreshold)
+		effective.Saturation.ScaleDownBoundary = formatDecimal(sat.ScaleDownBoundary)
+	}
+	if sat.MinOnDemandFraction > 0 {
+		effective.Saturation.MinOnDemandFraction = formatDecimal(sat.MinOnDemandFraction)
+	}
+
+	if qm := cfg.QueueingModel; qm != nil {
+		status := &wvav1alpha1.EffectiveQueueingModelConfig{
+			SLOMultiplier: formatDecimal(config.DefaultSLOMultiplier),
+			TuningEnabled: qm.TuningEnabled == nil || *qm.TuningEnabled,
+			Sources:       configSources(cfg.QueueingModelSources),
+		}
+		if qm.SLOMultiplier > 1.0 {
+			status.SLOMultiplier = formatDecimal(qm.SLOMultiplier)
+		}
+		if qm.TargetTTFT > 0 && qm.TargetITL > 0 {
+			status.TargetTTFT = strconv.FormatFloat(float64(qm.TargetTTFT), 'f', -1, 32)
+			status.TargetITL = strconv.FormatFloat(float64(qm.TargetITL), 'f', -1, 32)
+		}
+		if qm.ColdStartLookahead != nil {
+			status.ColdStartLookahead = *qm.ColdStartLookahead
+		}
+		if qm.MinOnDemandFraction != nil && *qm.MinOnDemandFraction > 0 {
+			status.MinOnDemandFraction = formatDecimal(*qm.MinOnDemandFraction)
+		}
+		if qm.RequestClasses != nil {
+			status.RequestClasses = int32(*qm.RequestClasses)
+		}
+		effective.QueueingModel = status
+	}
+
+	if retention, err := time.ParseDuration(cfg.ScaleToZero.RetentionPeriod); err == nil {
+		effective.ScaleToZero.RetentionPeriod = metav1.Duration{Duration: retention}
+	}
+	for _, s := range cfg.ScaleToZero.Schedules {
+		effective.ScaleToZero.Schedules = append(effective.ScaleToZero.Schedules, s.Name)
+	}
+
+	effective.Hash = effectiveConfigHash(effective)
+	effective.LastChangeTime = metav1.NewTime(now)
+	if previous != nil && previous.Hash == effective.Hash {
+		effective.LastChangeTime = previous.LastChangeTime
+	}
+	return effective
+}
+
+// effectiveConfigHash returns a short hash of the values and sources of an
+// effective configuration.
+func effectiveConfigHash(effective *wvav1alpha1.EffectiveConfig) string {
+	// Marshaling a struct of plain fields cannot fail
+	data, _ := json.Marshal([]any{effective.Saturation, effective.QueueingModel, effective.ScaleToZero})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:effectiveConfigHashLength]
+}
+
+func configSources(sources []config.ConfigSource) []wvav1alpha1.ConfigSource {
+	result := make([]wvav1alpha1.ConfigSource, 0, len(sources))
+	for _, s := range sources {
+		result = append(result, wvav1alpha1.ConfigSource{Layer: s.Layer, Entry: s.Entry, Name: s.Name})
+	}
+	return result
+}
+
+func formatDecimal(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	t.Parallel()
+
+	sat := config.SaturationScalingConfig{KvCacheThreshold: 0.8, QueueLengthThreshold: 5, KvSpareTrigger: 0.1, QueueSpareTrigger: 3}
+	sat.ApplyDefaults()
+	mc := config.ModelConfig{
+		Saturation: sat,
+		SaturationSources: []config.ConfigSource{
+			{Layer: config.ConfigLayerBuiltIn},
+			{Layer: config.ConfigLayerGlobalConfigMap, Entry: config.ConfigEntryDefault},
+		},
+		QueueingModel: &interfaces.QueueingModelScalingConfig{TargetTTFT: 500, TargetITL: 25},
+		ScaleToZero: config.ModelScaleToZeroConfig{
+			EnableScaleToZero: ptr.To(true),
+			RetentionPeriod:   "15m0s",
+			Schedules:         []config.ScheduleConfig{{Name: "nights"}},
+		},
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	effective := EffectiveConfig(mc, nil, now)
+	assert.Equal(t, "0.8", effective.Saturation.KvCacheThreshold)
+	assert.Equal(t, "1", effective.Saturation.Priority)
+	assert.Empty(t, effective.Saturation.ScaleUpThreshold, "V2 thresholds are reported for the V2 analyzer only")
+	assert.Equal(t, []llmdVariantAutoscalingV1alpha1.ConfigSource{
+		{Layer: "BuiltIn"},
+		{Layer: "GlobalConfigMap", Entry: "Default"},
+	}, effective.Saturation.Sources)
+	require.NotNil(t, effective.QueueingModel)
+	assert.Equal(t, "3", effective.QueueingModel.SLOMultiplier, "the analyzer's default")
+	assert.True(t, effective.QueueingModel.TuningEnabled)
+	assert.Equal(t, "500", effective.QueueingModel.TargetTTFT)
+	assert.True(t, effective.ScaleToZero.Enabled)
+	assert.Equal(t, 15*time.Minute, effective.ScaleToZero.RetentionPeriod.Duration)
+	assert.Equal(t, []string{"nights"}, effective.ScaleToZero.Schedules)
+	assert.Len(t, effective.Hash, effectiveConfigHashLength)
+	assert.Equal(t, now, effective.LastChangeTime.Time)
+
+	later := now.Add(time.Minute)
+	unchanged := EffectiveConfig(mc, effective, later)
+	assert.Equal(t, effective.Hash, unchanged.Hash)
+	assert.Equal(t, now, unchanged.LastChangeTime.Time, "kept while the hash is unchanged")
+
+	mc.Saturation.KvCacheThreshold = 0.9
+	changed := EffectiveConfig(mc, effective, later)
+	assert.NotEqual(t, effective.Hash, changed.Hash)
+	assert.Equal(t, later, changed.LastChangeTime.Time)
+}
@@ -40,6 +40,13 @@ type ScaleTargetAccessor interface {
 	// Use this for: GPU resource extraction when workers differ from leader.
 	GetWorkerPodTemplateSpec() *corev1.PodTemplateSpec
 
+	// GetPodSelector returns the selector of the scale target's (leader) pods.
+	// For Deployment: spec.selector, which keeps selecting pods whose other
+	// labels were changed.
+	// For LWS: the labels of the leader pod template.
+	// Returns nil when the pods cannot be selected.
+	GetPodSelector() *v1.LabelSelector
+
 	// GetGroupSize returns the number of pods per replica.
 	// For Deployment: always 1.
 	// For LWS: spec.leaderWorkerTemplate.size (1 leader + N-1 workers).
@@ -62,6 +62,11 @@ func (r *deploymentAccessor) GetWorkerPodTemplateSpec() *corev1.PodTemplateSpec
 	return r.GetLeaderPodTemplateSpec()
 }
 
+func (r *deploymentAccessor) GetPodSelector() *v1.LabelSelector {
+	// r.deployment is always not nil
+	return r.deployment.Spec.Selector
+}
+
 func (r *deploymentAccessor) GetGroupSize() int32 {
 	return 1
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/attribute"
 	appsv1 "k8s.io/api/apps/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -13,9 +14,14 @@ import (
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
 	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/resources"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/tracing"
 )
 
-func FetchScaleTarget(ctx context.Context, c client.Client, vaName, kind, name, namespace string) (ScaleTargetAccessor, error) {
+func FetchScaleTarget(ctx context.Context, c client.Client, vaName, kind, name, namespace string) (_ ScaleTargetAccessor, err error) {
+	ctx, span := tracing.Start(ctx, "FetchScaleTarget",
+		append(tracing.Variant(namespace, vaName), attribute.String("wva.scale_target_kind", kind))...)
+	defer func() { tracing.End(span, err) }()
+
 	switch kind {
 	case constants.DeploymentKind, "": // matching "" for backward compatibility
 		var deployment appsv1.Deployment
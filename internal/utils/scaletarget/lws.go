@@ -74,6 +74,14 @@ func (r *lwsAccessor) GetWorkerPodTemplateSpec() *corev1.PodTemplateSpec {
 	return &r.lws.Spec.LeaderWorkerTemplate.WorkerTemplate
 }
 
+func (r *lwsAccessor) GetPodSelector() *v1.LabelSelector {
+	labels := r.GetLeaderPodTemplateSpec().Labels
+	if len(labels) == 0 {
+		return nil
+	}
+	return &v1.LabelSelector{MatchLabels: labels}
+}
+
 func (r *lwsAccessor) GetGroupSize() int32 {
 	// r.lws is always not nil
 	if r.lws.Spec.LeaderWorkerTemplate.Size == nil {
@@ -0,0 +1,196 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed standard five-field cron expression
+// (minute hour day-of-month month day-of-week).
+type Cron struct {
+	minute, hour, dom, month, dow uint64
+	// Standard cron semantics: when both day fields are restricted, a day
+	// matches if either matches; otherwise both must match.
+	domStar, dowStar bool
+}
+
+// cronMacros are the supported shorthand expressions.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	domField    = cronField{name: "day-of-month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day-of-week accepts 7 as an alias for Sunday.
+	dowField = cronField{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// ParseCron parses a standard five-field cron expression, or one of the
+// macros @yearly, @annually, @monthly, @weekly, @daily, @midnight, @hourly.
+// Fields accept *, values, ranges (a-b), steps (*/n, a-b/n, a/n), comma
+// lists, and three-letter month and day-of-week names.
+func ParseCron(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &Cron{
+		domStar: strings.HasPrefix(fields[2], "*"),
+		dowStar: strings.HasPrefix(fields[4], "*"),
+	}
+	var err error
+	if c.minute, err = minuteField.parse(fields[0]); err != nil {
+		return nil, err
+	}
+	if c.hour, err = hourField.parse(fields[1]); err != nil {
+		return nil, err
+	}
+	if c.dom, err = domField.parse(fields[2]); err != nil {
+		return nil, err
+	}
+	if c.month, err = monthField.parse(fields[3]); err != nil {
+		return nil, err
+	}
+	if c.dow, err = dowField.parse(fields[4]); err != nil {
+		return nil, err
+	}
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1 // 7 is Sunday
+	}
+	return c, nil
+}
+
+// parse returns the bitset of values selected by a field.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+			step = n
+		}
+
+		var lo, hi int
+		switch {
+		case rangePart == "*":
+			lo, hi = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			loStr, hiStr, _ := strings.Cut(rangePart, "-")
+			var err error
+			if lo, err = f.value(loStr); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(hiStr); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+			}
+		default:
+			v, err := f.value(rangePart)
+			if err != nil {
+				return 0, err
+			}
+			lo, hi = v, v
+			if hasStep {
+				hi = f.max
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name within the field's bounds.
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d] in %s field", v, f.min, f.max, f.name)
+	}
+	return v, nil
+}
+
+// dayMatches reports whether the day of t matches the day-of-month,
+// month and day-of-week fields.
+func (c *Cron) dayMatches(t time.Time) bool {
+	if c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	if c.domStar || c.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// Prev returns the latest activation time at or before t that is after
+// t-lookback, evaluated in t's location. Returns false if there is none.
+func (c *Cron) Prev(t time.Time, lookback time.Duration) (time.Time, bool) {
+	earliest := t.Add(-lookback)
+	cand := t.Truncate(time.Minute)
+	if cand.After(t) {
+		cand = cand.Add(-time.Minute)
+	}
+	loc := t.Location()
+
+	for cand.After(earliest) {
+		if !c.dayMatches(cand) {
+			// Jump to the last minute of the previous day.
+			y, m, d := cand.Date()
+			cand = time.Date(y, m, d, 0, 0, 0, 0, loc).Add(-time.Minute)
+			continue
+		}
+		if c.hour&(1<<uint(cand.Hour())) == 0 {
+			// Jump to the last minute of the previous hour.
+			cand = cand.Add(-time.Duration(cand.Minute()+1) * time.Minute)
+			continue
+		}
+		if c.minute&(1<<uint(cand.Minute())) == 0 {
+			cand = cand.Add(-time.Minute)
+			continue
+		}
+		return cand, true
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,68 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "weekday business hours", expr: "0 8 * * mon-fri"},
+		{name: "steps and lists", expr: "*/15 0,12 1-15/2 jan,jul *"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "macro", expr: "@daily"},
+		{name: "too few fields", expr: "0 8 * *", wantErr: true},
+		{name: "out of range", expr: "60 * * * *", wantErr: true},
+		{name: "inverted range", expr: "0 10-8 * * *", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "unknown name", expr: "0 0 * * funday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCron(tt.expr)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCron(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronPrev(t *testing.T) {
+	// 2025-06-04 is a Wednesday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, time.June, day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		expr     string
+		t        time.Time
+		lookback time.Duration
+		want     time.Time
+		wantOK   bool
+	}{
+		{name: "same day", expr: "0 8 * * *", t: at(4, 12, 30), lookback: 10 * time.Hour, want: at(4, 8, 0), wantOK: true},
+		{name: "exact start", expr: "0 8 * * *", t: at(4, 8, 0), lookback: time.Hour, want: at(4, 8, 0), wantOK: true},
+		{name: "previous day", expr: "0 22 * * *", t: at(4, 2, 0), lookback: 8 * time.Hour, want: at(3, 22, 0), wantOK: true},
+		{name: "outside lookback", expr: "0 8 * * *", t: at(4, 19, 0), lookback: 10 * time.Hour},
+		{name: "weekday only on weekend", expr: "0 8 * * mon-fri", t: at(7, 9, 0), lookback: 2 * time.Hour},
+		{name: "weekly window", expr: "0 18 * * fri", t: at(9, 6, 0), lookback: 63 * time.Hour, want: at(6, 18, 0), wantOK: true},
+		{name: "day-of-month or day-of-week", expr: "0 0 1 * mon", t: at(2, 1, 0), lookback: 2 * time.Hour, want: at(2, 0, 0), wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+			}
+			got, ok := c.Prev(tt.t, tt.lookback)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Prev(%s, %s) = %s, %v; want %s, %v", tt.t, tt.lookback, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
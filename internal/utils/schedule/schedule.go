@@ -0,0 +1,169 @@
+// Package schedule evaluates time-based scaling schedules: recurring windows,
+// defined by a cron expression, a time zone and a duration, during which
+// replica bounds and scale-to-zero eligibility are overridden.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxDuration is the longest supported schedule window.
+const MaxDuration = 7 * 24 * time.Hour
+
+// Rule sources, reported in VariantAutoscaling status.
+const (
+	// SourceVariantAutoscaling marks rules from a VA's spec.schedules.
+	SourceVariantAutoscaling = "VariantAutoscaling"
+	// SourceConfigMap marks per-model rules from the scale-to-zero ConfigMap.
+	SourceConfigMap = "ConfigMap"
+	// SourceAutoscalingPolicy marks per-model rules from an autoscaling policy.
+	SourceAutoscalingPolicy = "AutoscalingPolicy"
+)
+
+// Rule is a validated scaling schedule.
+type Rule struct {
+	Name     string
+	Source   string
+	Duration time.Duration
+
+	// MinReplicas overrides the variant's minimum replicas while active (nil = no override).
+	MinReplicas *int
+	// MaxReplicas overrides the variant's maximum replicas while active (nil = no override).
+	// Zero forces the variant to zero replicas.
+	MaxReplicas *int
+	// ScaleToZero overrides the model's scale-to-zero eligibility while active (nil = no override).
+	ScaleToZero *bool
+
+	cron     *Cron
+	location *time.Location
+}
+
+// RuleSpec holds the raw fields of a schedule, as written in a VA or ConfigMap.
+type RuleSpec struct {
+	Name        string
+	Schedule    string // cron expression
+	TimeZone    string // IANA name, "" = UTC
+	Duration    time.Duration
+	MinReplicas *int
+	MaxReplicas *int
+	ScaleToZero *bool
+}
+
+// NewRule validates spec and returns the corresponding Rule.
+func NewRule(spec RuleSpec, source string) (Rule, error) {
+	if spec.Name == "" {
+		return Rule{}, errors.New("schedule name must not be empty")
+	}
+	cron, err := ParseCron(spec.Schedule)
+	if err != nil {
+		return Rule{}, fmt.Errorf("schedule %q: %w", spec.Name, err)
+	}
+	location := time.UTC
+	if spec.TimeZone != "" {
+		if location, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return Rule{}, fmt.Errorf("schedule %q: invalid time zone %q: %w", spec.Name, spec.TimeZone, err)
+		}
+	}
+	if spec.Duration <= 0 || spec.Duration > MaxDuration {
+		return Rule{}, fmt.Errorf("schedule %q: duration must be in (0, %s], got %s", spec.Name, MaxDuration, spec.Duration)
+	}
+	if spec.MinReplicas != nil && *spec.MinReplicas < 0 {
+		return Rule{}, fmt.Errorf("schedule %q: minReplicas must be >= 0, got %d", spec.Name, *spec.MinReplicas)
+	}
+	if spec.MaxReplicas != nil && *spec.MaxReplicas < 0 {
+		return Rule{}, fmt.Errorf("schedule %q: maxReplicas must be >= 0, got %d", spec.Name, *spec.MaxReplicas)
+	}
+	if spec.MinReplicas != nil && spec.MaxReplicas != nil && *spec.MinReplicas > *spec.MaxReplicas {
+		return Rule{}, fmt.Errorf("schedule %q: minReplicas (%d) must be <= maxReplicas (%d)",
+			spec.Name, *spec.MinReplicas, *spec.MaxReplicas)
+	}
+	if spec.MinReplicas == nil && spec.MaxReplicas == nil && spec.ScaleToZero == nil {
+		return Rule{}, fmt.Errorf("schedule %q: must override at least one of minReplicas, maxReplicas or scaleToZero", spec.Name)
+	}
+
+	return Rule{
+		Name:        spec.Name,
+		Source:      source,
+		Duration:    spec.Duration,
+		MinReplicas: spec.MinReplicas,
+		MaxReplicas: spec.MaxReplicas,
+		ScaleToZero: spec.ScaleToZero,
+		cron:        cron,
+		location:    location,
+	}, nil
+}
+
+// ActiveUntil returns the end of the rule's window containing now, if any.
+func (r Rule) ActiveUntil(now time.Time) (time.Time, bool) {
+	if r.cron == nil {
+		return time.Time{}, false
+	}
+	start, ok := r.cron.Prev(now.In(r.location), r.Duration)
+	if !ok {
+		return time.Time{}, false
+	}
+	return start.Add(r.Duration), true
+}
+
+// ActiveRule is a rule whose window contains the evaluation time.
+type ActiveRule struct {
+	Name   string
+	Source string
+	Until  time.Time
+}
+
+// Overrides is the combined effect of the rules active at a point in time.
+// A nil field means no active rule overrides it.
+type Overrides struct {
+	MinReplicas *int
+	MaxReplicas *int
+	ScaleToZero *bool
+	Active      []ActiveRule
+}
+
+// Resolve combines the rules active at now. When several active rules set the
+// same field, the one that keeps more capacity wins: the highest minReplicas,
+// the highest maxReplicas, and scaleToZero false over true.
+func Resolve(rules []Rule, now time.Time) Overrides {
+	var o Overrides
+	for _, r := range rules {
+		until, ok := r.ActiveUntil(now)
+		if !ok {
+			continue
+		}
+		o.Active = append(o.Active, ActiveRule{Name: r.Name, Source: r.Source, Until: until})
+		if r.MinReplicas != nil && (o.MinReplicas == nil || *r.MinReplicas > *o.MinReplicas) {
+			o.MinReplicas = r.MinReplicas
+		}
+		if r.MaxReplicas != nil && (o.MaxReplicas == nil || *r.MaxReplicas > *o.MaxReplicas) {
+			o.MaxReplicas = r.MaxReplicas
+		}
+		if r.ScaleToZero != nil && (o.ScaleToZero == nil || !*r.ScaleToZero) {
+			o.ScaleToZero = r.ScaleToZero
+		}
+	}
+	return o
+}
+
+// Merge returns the overrides of o with each field set in variant taking
+// precedence. Used to layer VA-level schedules over model-level schedules.
+func (o Overrides) Merge(variant Overrides) Overrides {
+	merged := Overrides{
+		MinReplicas: o.MinReplicas,
+		MaxReplicas: o.MaxReplicas,
+		ScaleToZero: o.ScaleToZero,
+		Active:      append(append([]ActiveRule{}, o.Active...), variant.Active...),
+	}
+	if variant.MinReplicas != nil {
+		merged.MinReplicas = variant.MinReplicas
+	}
+	if variant.MaxReplicas != nil {
+		merged.MaxReplicas = variant.MaxReplicas
+	}
+	if variant.ScaleToZero != nil {
+		merged.ScaleToZero = variant.ScaleToZero
+	}
+	return merged
+}
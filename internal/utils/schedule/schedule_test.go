@@ -0,0 +1,91 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func intPtr(i int) *int { return &i }
+
+func boolPtr(b bool) *bool { return &b }
+
+func mustRule(t *testing.T, spec RuleSpec) Rule {
+	t.Helper()
+	r, err := NewRule(spec, SourceVariantAutoscaling)
+	if err != nil {
+		t.Fatalf("NewRule(%+v): %v", spec, err)
+	}
+	return r
+}
+
+func TestNewRule(t *testing.T) {
+	valid := RuleSpec{Name: "business-hours", Schedule: "0 8 * * mon-fri", Duration: 10 * time.Hour, MinReplicas: intPtr(2)}
+	tests := []struct {
+		name    string
+		modify  func(*RuleSpec)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(*RuleSpec) {}},
+		{name: "time zone", modify: func(s *RuleSpec) { s.TimeZone = "Europe/Berlin" }},
+		{name: "missing name", modify: func(s *RuleSpec) { s.Name = "" }, wantErr: true},
+		{name: "invalid cron", modify: func(s *RuleSpec) { s.Schedule = "daily" }, wantErr: true},
+		{name: "invalid time zone", modify: func(s *RuleSpec) { s.TimeZone = "Mars/Olympus" }, wantErr: true},
+		{name: "zero duration", modify: func(s *RuleSpec) { s.Duration = 0 }, wantErr: true},
+		{name: "duration too long", modify: func(s *RuleSpec) { s.Duration = MaxDuration + time.Minute }, wantErr: true},
+		{name: "negative min", modify: func(s *RuleSpec) { s.MinReplicas = intPtr(-1) }, wantErr: true},
+		{name: "min above max", modify: func(s *RuleSpec) { s.MaxReplicas = intPtr(1) }, wantErr: true},
+		{name: "no override", modify: func(s *RuleSpec) { s.MinReplicas = nil }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := valid
+			tt.modify(&spec)
+			_, err := NewRule(spec, SourceConfigMap)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleActiveUntilTimeZone(t *testing.T) {
+	r := mustRule(t, RuleSpec{Name: "mornings", Schedule: "0 8 * * *", TimeZone: "America/New_York", Duration: 4 * time.Hour, MinReplicas: intPtr(1)})
+
+	// 13:00 UTC is 09:00 in New York (EDT) on 2025-06-04.
+	now := time.Date(2025, time.June, 4, 13, 0, 0, 0, time.UTC)
+	until, ok := r.ActiveUntil(now)
+	if !ok {
+		t.Fatal("Expected rule to be active")
+	}
+	if want := time.Date(2025, time.June, 4, 16, 0, 0, 0, time.UTC); !until.Equal(want) {
+		t.Errorf("ActiveUntil = %s, want %s", until, want)
+	}
+
+	if _, ok := r.ActiveUntil(now.Add(-2 * time.Hour)); ok {
+		t.Error("Expected rule to be inactive before the window")
+	}
+}
+
+func TestResolveAndMerge(t *testing.T) {
+	now := time.Date(2025, time.June, 4, 12, 0, 0, 0, time.UTC)
+	daytime := mustRule(t, RuleSpec{Name: "daytime", Schedule: "0 8 * * *", Duration: 10 * time.Hour, MinReplicas: intPtr(2), ScaleToZero: boolPtr(true)})
+	launch := mustRule(t, RuleSpec{Name: "launch", Schedule: "0 10 * * *", Duration: 4 * time.Hour, MinReplicas: intPtr(4), MaxReplicas: intPtr(8), ScaleToZero: boolPtr(false)})
+	night := mustRule(t, RuleSpec{Name: "night", Schedule: "0 22 * * *", Duration: 8 * time.Hour, MaxReplicas: intPtr(0)})
+
+	o := Resolve([]Rule{daytime, launch, night}, now)
+	if len(o.Active) != 2 {
+		t.Fatalf("Expected 2 active rules, got %+v", o.Active)
+	}
+	if *o.MinReplicas != 4 || *o.MaxReplicas != 8 || *o.ScaleToZero {
+		t.Errorf("Expected min 4, max 8, scaleToZero false; got %d, %d, %v", *o.MinReplicas, *o.MaxReplicas, *o.ScaleToZero)
+	}
+
+	merged := o.Merge(Overrides{MaxReplicas: intPtr(5), Active: []ActiveRule{{Name: "va"}}})
+	if *merged.MinReplicas != 4 || *merged.MaxReplicas != 5 || len(merged.Active) != 3 {
+		t.Errorf("Expected variant max to take precedence, got %+v", merged)
+	}
+
+	if o := Resolve([]Rule{night}, now); len(o.Active) != 0 || o.MaxReplicas != nil {
+		t.Errorf("Expected no overrides outside the window, got %+v", o)
+	}
+}
@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"errors"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/schedule"
+)
+
+// VariantScheduleRules converts a VA's spec.schedules into schedule rules.
+// Invalid schedules are skipped; their errors are joined into the returned error
+// so the caller can report them without dropping the valid ones.
+func VariantScheduleRules(va *wvav1alpha1.VariantAutoscaling) ([]schedule.Rule, error) {
+	if len(va.Spec.Schedules) == 0 {
+		return nil, nil
+	}
+
+	rules := make([]schedule.Rule, 0, len(va.Spec.Schedules))
+	var errs []error
+	for _, s := range va.Spec.Schedules {
+		rule, err := schedule.NewRule(schedule.RuleSpec{
+			Name:        s.Name,
+			Schedule:    s.Schedule,
+			TimeZone:    s.TimeZone,
+			Duration:    s.Duration.Duration,
+			MinReplicas: int32PtrToIntPtr(s.MinReplicas),
+			MaxReplicas: int32PtrToIntPtr(s.MaxReplicas),
+			ScaleToZero: s.ScaleToZero,
+		}, schedule.SourceVariantAutoscaling)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	return rules, errors.Join(errs...)
+}
+
+// ActiveSchedules returns the scaling schedules that apply to a VA at now, for
+// its status: the model's ConfigMap schedules and the VA's valid spec.schedules.
+func ActiveSchedules(va *wvav1alpha1.VariantAutoscaling, modelRules []schedule.Rule, now time.Time) []wvav1alpha1.ActiveSchedule {
+	variantRules, _ := VariantScheduleRules(va)
+	overrides := schedule.Resolve(modelRules, now).Merge(schedule.Resolve(variantRules, now))
+	if len(overrides.Active) == 0 {
+		return nil
+	}
+	active := make([]wvav1alpha1.ActiveSchedule, 0, len(overrides.Active))
+	for _, a := range overrides.Active {
+		active = append(active, wvav1alpha1.ActiveSchedule{
+			Name:   a.Name,
+			Source: a.Source,
+			Until:  metav1.NewTime(a.Until),
+		})
+	}
+	return active
+}
+
+func int32PtrToIntPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	n := int(*v)
+	return &n
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/logging"
+)
+
+// IsShadowMode reports whether a VA runs in shadow mode, i.e. whether the
+// wva.llmd.ai/shadow annotation is "true" on the VA or, if the VA does not set
+// it, on its namespace. If the namespace cannot be read the VA is not shadowed,
+// like the namespace exclusion check. Without a client, e.g. in replay, only
+// the VA annotation is checked.
+func IsShadowMode(ctx context.Context, c client.Client, va *wvav1alpha1.VariantAutoscaling) bool {
+	if value, ok := va.Annotations[constants.ShadowModeAnnotationKey]; ok {
+		return value == constants.AnnotationValueTrue
+	}
+
+	if c == nil {
+		return false
+	}
+	var ns corev1.Namespace
+	if err := c.Get(ctx, client.ObjectKey{Name: va.Namespace}, &ns); err != nil {
+		ctrl.LoggerFrom(ctx).V(logging.DEBUG).Info("Failed to get namespace for shadow mode check",
+			"namespace", va.Namespace, "error", err)
+		return false
+	}
+	return ns.Annotations[constants.ShadowModeAnnotationKey] == constants.AnnotationValueTrue
+}
+
+// HPADesiredReplicas returns the replicas chosen by the HorizontalPodAutoscaler
+// that scales the VA's scale target, and the name of that HPA. found is false
+// if no HPA in the VA's namespace targets it.
+func HPADesiredReplicas(ctx context.Context, c client.Client, va *wvav1alpha1.VariantAutoscaling) (replicas int32, name string, found bool, err error) {
+	var hpas autoscalingv2.HorizontalPodAutoscalerList
+	if err := c.List(ctx, &hpas, client.InNamespace(va.Namespace)); err != nil {
+		return 0, "", false, err
+	}
+	for i := range hpas.Items {
+		ref := hpas.Items[i].Spec.ScaleTargetRef
+		if ref.Kind == va.GetScaleTargetKind() && ref.Name == va.GetScaleTargetName() {
+			return hpas.Items[i].Status.DesiredReplicas, hpas.Items[i].Name, true, nil
+		}
+	}
+	return 0, "", false, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025 The llm-d Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	wvav1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/constants"
+)
+
+func newShadowTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := autoscalingv2.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func newShadowTestVA(namespace string, annotations map[string]string) *wvav1alpha1.VariantAutoscaling {
+	return &wvav1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama-a100", Namespace: namespace, Annotations: annotations},
+		Spec: wvav1alpha1.VariantAutoscalingSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "llama-a100",
+			},
+		},
+	}
+}
+
+func TestIsShadowMode(t *testing.T) {
+	shadowed := map[string]string{constants.ShadowModeAnnotationKey: constants.AnnotationValueTrue}
+	c := newShadowTestClient(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shadow", Annotations: shadowed}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	)
+
+	tests := []struct {
+		name string
+		va   *wvav1alpha1.VariantAutoscaling
+		want bool
+	}{
+		{"not annotated", newShadowTestVA("default", nil), false},
+		{"VA annotated", newShadowTestVA("default", shadowed), true},
+		{"namespace annotated", newShadowTestVA("shadow", nil), true},
+		{"VA opts out of shadowed namespace", newShadowTestVA("shadow", map[string]string{constants.ShadowModeAnnotationKey: "false"}), false},
+		{"namespace not found", newShadowTestVA("missing", nil), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsShadowMode(context.Background(), c, tt.va); got != tt.want {
+				t.Errorf("IsShadowMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHPADesiredReplicas(t *testing.T) {
+	hpa := func(name, namespace, target string, desired int32) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Name: target},
+				MaxReplicas:    10,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{DesiredReplicas: desired},
+		}
+	}
+	c := newShadowTestClient(t,
+		hpa("other", "default", "mistral-a100", 2),
+		hpa("llama", "default", "llama-a100", 4),
+		hpa("llama", "prod", "llama-a100", 7),
+	)
+
+	replicas, name, found, err := HPADesiredReplicas(context.Background(), c, newShadowTestVA("default", nil))
+	if err != nil || !found || name != "llama" || replicas != 4 {
+		t.Errorf("Expected HPA llama with 4 replicas, got %q, %d, found=%v, err=%v", name, replicas, found, err)
+	}
+
+	_, _, found, err = HPADesiredReplicas(context.Background(), c, newShadowTestVA("staging", nil))
+	if err != nil || found {
+		t.Errorf("Expected no HPA in namespace staging, got found=%v, err=%v", found, err)
+	}
+}
@@ -0,0 +1,281 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// request class: a share of the requests with a given average size
+type RequestClass struct {
+	Weight      float32      // share of the requests in the class (relative to the other classes >0)
+	RequestSize *RequestSize // number of input and output tokens per request of the class
+	TargetPerf  *TargetPerf  // performance targets of the class (nil if none)
+}
+
+// Analyzer of inference server queue serving several request classes.
+//
+// The classes share the queue (FCFS) and the batch. Requests in service are mixed in proportion
+// to the arrival share of their class times the number of iterations they stay in the batch
+// (outputTokens + 1), which sets the iteration time of the batch. The service rate of the model
+// is averaged over the classes, weighted by their arrival share. The metrics of a class add its
+// own prefill and decode work to the iteration time of the mixed batch.
+type MultiClassAnalyzer struct {
+	MaxBatchSize   int                     // maximum batch size
+	MaxNumTokens   int                     // maximum number of tokens per batch
+	MaxQueueSize   int                     // maximum queue size
+	KVCacheTokens  int                     // KV cache capacity in tokens (0 if unknown)
+	MaxConcurrency int                     // maximum number of requests concurrently in service, given the mix of requests
+	ServiceParms   *ServiceParms           // request processing parameters
+	Classes        []*RequestClass         // request classes (weights normalized to a sum of 1)
+	Model          *MM1ModelStateDependent // queueing model
+	RateRange      *RateRange              // range of request rates for model stability (all classes)
+
+	serviceShares      []float32 // share of the requests in service of each class
+	tokensPerIteration float32   // average number of tokens a request in service computes per iteration
+	kvCacheTokens      float32   // average number of tokens a request in service holds in the KV cache
+}
+
+// analysis solution metrics data of several request classes
+type MultiClassMetrics struct {
+	Total   *AnalysisMetrics   // metrics over all requests
+	Classes []*AnalysisMetrics // metrics of the requests of each class, in the order of the classes
+}
+
+// create a new multi-class queue analyzer from config
+func NewMultiClassAnalyzer(qConfig *Configuration, classes []*RequestClass) (*MultiClassAnalyzer, error) {
+	if err := qConfig.check(); err != nil {
+		return nil, err
+	}
+	if len(classes) == 0 {
+		return nil, errors.New("no request classes")
+	}
+	var totalWeight float32
+	for _, class := range classes {
+		if err := class.check(); err != nil {
+			return nil, err
+		}
+		totalWeight += class.Weight
+	}
+
+	// normalize weights
+	normalized := make([]*RequestClass, len(classes))
+	for i, class := range classes {
+		normalized[i] = &RequestClass{
+			Weight:      class.Weight / totalWeight,
+			RequestSize: class.RequestSize,
+			TargetPerf:  class.TargetPerf,
+		}
+	}
+	return buildMultiClassModel(qConfig, normalized), nil
+}
+
+// build queueing model of classes with normalized weights, leaving arrival rate as parameter
+func buildMultiClassModel(c *Configuration, classes []*RequestClass) *MultiClassAnalyzer {
+	parms := c.ServiceParms
+
+	// mix of requests in service
+	var iterations, tokens, kvCacheTokens float32
+	for _, class := range classes {
+		r := class.RequestSize
+		iterations += class.Weight * (r.AvgOutputTokens + 1)
+		tokens += class.Weight * (r.ComputedInputTokens() + r.AvgOutputTokens)
+		kvCacheTokens += class.Weight * (r.AvgOutputTokens + 1) * r.KVCacheTokens()
+	}
+	tokensPerIteration := tokens / iterations
+	kvCacheTokens /= iterations
+	serviceShares := make([]float32, len(classes))
+	for i, class := range classes {
+		serviceShares[i] = class.Weight * (class.RequestSize.AvgOutputTokens + 1) / iterations
+	}
+
+	// calculate state-dependent service rate, averaging the service time of the classes
+	maxConcurrency := c.maxConcurrency(tokensPerIteration, kvCacheTokens)
+	servRate := make([]float32, maxConcurrency)
+	for n := 1; n <= maxConcurrency; n++ {
+		iterationTime := parms.iterationTime(tokensPerIteration, kvCacheTokens, float32(n))
+		var servTime float32
+		for _, class := range classes {
+			servTime += class.Weight * parms.serviceTime(class.RequestSize, iterationTime)
+		}
+		servRate[n-1] = float32(n) / servTime
+	}
+
+	// set and check limits
+	lambdaMin := servRate[0] * Epsilon
+	lambdaMax := servRate[maxConcurrency-1] * (1 - Epsilon)
+	rateRange := &RateRange{Min: lambdaMin * 1000, Max: lambdaMax * 1000}
+
+	// create model
+	occupancyUpperBound := c.MaxQueueSize + c.MaxBatchSize
+	model := NewMM1ModelStateDependent(occupancyUpperBound, servRate)
+
+	return &MultiClassAnalyzer{
+		MaxBatchSize:       c.MaxBatchSize,
+		MaxNumTokens:       c.MaxNumTokens,
+		MaxQueueSize:       c.MaxQueueSize,
+		KVCacheTokens:      c.KVCacheTokens(),
+		MaxConcurrency:     maxConcurrency,
+		ServiceParms:       parms,
+		Classes:            classes,
+		Model:              model,
+		RateRange:          rateRange,
+		serviceShares:      serviceShares,
+		tokensPerIteration: tokensPerIteration,
+		kvCacheTokens:      kvCacheTokens,
+	}
+}
+
+// evaluate performance metrics given request rate (all classes)
+func (qa *MultiClassAnalyzer) Analyze(requestRate float32) (metrics *MultiClassMetrics, err error) {
+	if requestRate <= 0 {
+		return nil, fmt.Errorf("invalid request rate %v", requestRate)
+	}
+	if requestRate > qa.RateRange.Max {
+		return nil, fmt.Errorf("rate=%v, max allowed rate=%v", requestRate, qa.RateRange.Max)
+	}
+	return qa.analyze(requestRate / 1000)
+}
+
+// evaluate performance metrics given lambda (req/msec)
+func (qa *MultiClassAnalyzer) analyze(lambda float32) (*MultiClassMetrics, error) {
+	model := qa.Model
+	model.Solve(lambda, 1)
+	if !model.IsValid() {
+		return nil, fmt.Errorf("invalid model %s", model)
+	}
+
+	// get statistics
+	avgNumInServ := model.GetAvgNumInServers()
+	iterationTime := qa.ServiceParms.iterationTime(qa.tokensPerIteration, qa.kvCacheTokens, avgNumInServ)
+	avgWaitTime := model.GetAvgWaitTime()
+	throughput := model.GetThroughput() * 1000
+
+	rho := avgNumInServ / float32(qa.MaxConcurrency)
+	rho = min(max(rho, 0), 1)
+
+	metrics := &MultiClassMetrics{
+		Total: &AnalysisMetrics{
+			Throughput:   throughput,
+			AvgRespTime:  model.GetAvgRespTime(),
+			AvgWaitTime:  avgWaitTime,
+			AvgNumInServ: avgNumInServ,
+			MaxRate:      qa.RateRange.Max,
+			Rho:          rho,
+		},
+		Classes: make([]*AnalysisMetrics, len(qa.Classes)),
+	}
+	for i, class := range qa.Classes {
+		r := class.RequestSize
+		avgPrefillTime := qa.ServiceParms.prefillTime(r, iterationTime)
+		avgDecodeTime := qa.ServiceParms.decodeTime(r, iterationTime)
+		avgTTFT := avgWaitTime + avgPrefillTime + avgDecodeTime
+		metrics.Classes[i] = &AnalysisMetrics{
+			Throughput:     throughput * class.Weight,
+			AvgRespTime:    avgWaitTime + avgPrefillTime + r.AvgOutputTokens*avgDecodeTime,
+			AvgWaitTime:    avgWaitTime,
+			AvgNumInServ:   avgNumInServ * qa.serviceShares[i],
+			AvgPrefillTime: avgPrefillTime,
+			AvgTokenTime:   avgDecodeTime,
+			AvgTTFT:        avgTTFT,
+			MaxRate:        qa.RateRange.Max * class.Weight,
+			Rho:            rho,
+		}
+		metrics.Total.AvgPrefillTime += class.Weight * avgPrefillTime
+		metrics.Total.AvgTokenTime += class.Weight * avgDecodeTime
+		metrics.Total.AvgTTFT += class.Weight * avgTTFT
+	}
+	return metrics, nil
+}
+
+// evaluate max request rate (all classes) to achieve the target performance of every class, returns
+//   - max request rate
+//   - performance metrics at max request rate
+func (qa *MultiClassAnalyzer) Size() (maxRate float32, metrics *MultiClassMetrics, err error) {
+	lambdaMin := qa.RateRange.Min / 1000
+	lambdaMax := qa.RateRange.Max / 1000
+
+	var latencyTargets, tpsTargets bool
+	for _, class := range qa.Classes {
+		if tp := class.TargetPerf; tp != nil {
+			latencyTargets = latencyTargets || tp.TargetTTFT > 0 || tp.TargetITL > 0
+			tpsTargets = tpsTargets || tp.TargetTPS > 0
+		}
+	}
+
+	// find max rate at which the latency of every class is within its targets
+	lambdaStar := lambdaMax
+	if latencyTargets {
+		var ind int
+		lambdaStar, ind, err = BinarySearch(lambdaMin, lambdaMax, 1, qa.evalTargetRatio())
+		if ind < 0 {
+			err = errors.New("target is below the bounded region")
+		}
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to calculate lambdaStar, range=%s, ind=%d, err=%v",
+				qa.RateRange, ind, err)
+		}
+	}
+	if tpsTargets {
+		lambdaStar = min(lambdaStar, lambdaMax*(1-StabilitySafetyFraction))
+	}
+
+	if metrics, err = qa.analyze(lambdaStar); err != nil {
+		return 0, nil, err
+	}
+	return lambdaStar * 1000, metrics, nil
+}
+
+// Function used in binary search (targets of all classes): the largest ratio of a latency to its target
+//   - x is lambda req/msec
+func (qa *MultiClassAnalyzer) evalTargetRatio() func(x float32) (float32, error) {
+	return func(x float32) (float32, error) {
+		metrics, err := qa.analyze(x)
+		if err != nil {
+			return 0, err
+		}
+		var ratio float32
+		for i, class := range qa.Classes {
+			tp := class.TargetPerf
+			if tp == nil {
+				continue
+			}
+			if tp.TargetTTFT > 0 {
+				ratio = max(ratio, metrics.Classes[i].AvgTTFT/tp.TargetTTFT)
+			}
+			if tp.TargetITL > 0 {
+				ratio = max(ratio, metrics.Classes[i].AvgTokenTime/tp.TargetITL)
+			}
+		}
+		return ratio, nil
+	}
+}
+
+// Average service time of a request, given the iteration time of its batch
+func (p *ServiceParms) serviceTime(r *RequestSize, iterationTime float32) float32 {
+	return p.prefillTime(r, iterationTime) + r.AvgOutputTokens*p.decodeTime(r, iterationTime)
+}
+
+// check validity of request class
+func (rc *RequestClass) check() error {
+	if rc.Weight <= 0 || math.IsInf(float64(rc.Weight), 0) || math.IsNaN(float64(rc.Weight)) ||
+		rc.RequestSize == nil {
+		return fmt.Errorf("invalid request class %s", rc)
+	}
+	if err := rc.RequestSize.check(); err != nil {
+		return err
+	}
+	if rc.TargetPerf != nil {
+		return rc.TargetPerf.check()
+	}
+	return nil
+}
+
+func (rc *RequestClass) String() string {
+	return fmt.Sprintf("{weight=%.3f, reqSize:%s, targets:%s}", rc.Weight, rc.RequestSize, rc.TargetPerf)
+}
+
+func (qa *MultiClassAnalyzer) String() string {
+	return fmt.Sprintf("{maxBatch=%d, maxNumTokens=%d, maxQueue=%d, kvCacheTokens=%d, maxConc=%d, servParms:%s, classes:%v, model:%s, rates:%s}",
+		qa.MaxBatchSize, qa.MaxNumTokens, qa.MaxQueueSize, qa.KVCacheTokens, qa.MaxConcurrency, qa.ServiceParms, qa.Classes, qa.Model, qa.RateRange)
+}
@@ -0,0 +1,225 @@
+package analyzer_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+var multiClassConfig = &analyzer.Configuration{
+	MaxBatchSize: 64,
+	MaxQueueSize: 128,
+	ServiceParms: &analyzer.ServiceParms{
+		Alpha: 5.0,
+		Beta:  0.01,
+		Gamma: 0.0005,
+	},
+}
+
+func TestNewMultiClassAnalyzer(t *testing.T) {
+	chat := &analyzer.RequestSize{AvgInputTokens: 200, AvgOutputTokens: 100}
+	tests := []struct {
+		name    string
+		classes []*analyzer.RequestClass
+		wantErr bool
+	}{
+		{
+			name:    "single class",
+			classes: []*analyzer.RequestClass{{Weight: 1, RequestSize: chat}},
+		},
+		{
+			name: "two classes",
+			classes: []*analyzer.RequestClass{
+				{Weight: 3, RequestSize: chat},
+				{Weight: 1, RequestSize: &analyzer.RequestSize{AvgInputTokens: 8000, AvgOutputTokens: 200}},
+			},
+		},
+		{
+			name:    "no classes",
+			wantErr: true,
+		},
+		{
+			name:    "zero weight",
+			classes: []*analyzer.RequestClass{{Weight: 0, RequestSize: chat}},
+			wantErr: true,
+		},
+		{
+			name:    "nil request size",
+			classes: []*analyzer.RequestClass{{Weight: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid request size",
+			classes: []*analyzer.RequestClass{{Weight: 1, RequestSize: &analyzer.RequestSize{AvgInputTokens: 100}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid target",
+			classes: []*analyzer.RequestClass{{Weight: 1, RequestSize: chat, TargetPerf: &analyzer.TargetPerf{TargetTTFT: -1}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qa, err := analyzer.NewMultiClassAnalyzer(multiClassConfig, tt.classes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMultiClassAnalyzer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			var sum float32
+			for _, class := range qa.Classes {
+				sum += class.Weight
+			}
+			if math.Abs(float64(sum-1)) > 1e-6 {
+				t.Errorf("Sum of normalized weights = %v, expected 1", sum)
+			}
+		})
+	}
+}
+
+// A single class is the single-class queue model
+func TestMultiClassAnalyzer_SingleClass(t *testing.T) {
+	requestSize := &analyzer.RequestSize{AvgInputTokens: 500, AvgOutputTokens: 100}
+	single, err := analyzer.NewQueueAnalyzer(multiClassConfig, requestSize)
+	if err != nil {
+		t.Fatalf("NewQueueAnalyzer() failed: %v", err)
+	}
+	multi, err := analyzer.NewMultiClassAnalyzer(multiClassConfig, []*analyzer.RequestClass{{Weight: 1, RequestSize: requestSize}})
+	if err != nil {
+		t.Fatalf("NewMultiClassAnalyzer() failed: %v", err)
+	}
+
+	if multi.MaxConcurrency != single.MaxConcurrency {
+		t.Errorf("MaxConcurrency = %v, expected %v", multi.MaxConcurrency, single.MaxConcurrency)
+	}
+	if !analyzer.WithinTolerance(multi.RateRange.Max, single.RateRange.Max, 1e-4) {
+		t.Errorf("RateRange.Max = %v, expected %v", multi.RateRange.Max, single.RateRange.Max)
+	}
+
+	rate := single.RateRange.Max / 2
+	singleMetrics, err := single.Analyze(rate)
+	if err != nil {
+		t.Fatalf("Analyze() failed: %v", err)
+	}
+	multiMetrics, err := multi.Analyze(rate)
+	if err != nil {
+		t.Fatalf("Analyze() failed: %v", err)
+	}
+	if !analyzer.WithinTolerance(multiMetrics.Total.Throughput, singleMetrics.Throughput, 1e-4) ||
+		!analyzer.WithinTolerance(multiMetrics.Total.AvgWaitTime, singleMetrics.AvgWaitTime, 1e-3) ||
+		!analyzer.WithinTolerance(multiMetrics.Classes[0].AvgPrefillTime, singleMetrics.AvgPrefillTime, 1e-4) {
+		t.Errorf("Metrics = %s, expected %s", multiMetrics.Total, singleMetrics)
+	}
+}
+
+func TestMultiClassAnalyzer_Analyze(t *testing.T) {
+	classes := []*analyzer.RequestClass{
+		{Weight: 0.9, RequestSize: &analyzer.RequestSize{AvgInputTokens: 200, AvgOutputTokens: 100}},
+		{Weight: 0.1, RequestSize: &analyzer.RequestSize{AvgInputTokens: 30000, AvgOutputTokens: 300}},
+	}
+	qa, err := analyzer.NewMultiClassAnalyzer(multiClassConfig, classes)
+	if err != nil {
+		t.Fatalf("NewMultiClassAnalyzer() failed: %v", err)
+	}
+
+	if _, err := qa.Analyze(0); err == nil {
+		t.Error("Expected error for zero request rate")
+	}
+	if _, err := qa.Analyze(qa.RateRange.Max * 2); err == nil {
+		t.Error("Expected error for request rate above max")
+	}
+
+	metrics, err := qa.Analyze(qa.RateRange.Max / 2)
+	if err != nil {
+		t.Fatalf("Analyze() failed: %v", err)
+	}
+	chat, rag := metrics.Classes[0], metrics.Classes[1]
+	if !analyzer.WithinTolerance(chat.Throughput+rag.Throughput, metrics.Total.Throughput, 1e-4) {
+		t.Errorf("Class throughputs %v + %v, expected %v", chat.Throughput, rag.Throughput, metrics.Total.Throughput)
+	}
+	if chat.AvgWaitTime != rag.AvgWaitTime {
+		t.Errorf("Classes share the queue, wait times %v and %v", chat.AvgWaitTime, rag.AvgWaitTime)
+	}
+	if rag.AvgTTFT <= chat.AvgTTFT {
+		t.Errorf("Long prompts TTFT %v should exceed short prompts TTFT %v", rag.AvgTTFT, chat.AvgTTFT)
+	}
+	if metrics.Total.Rho <= 0 || metrics.Total.Rho > 1 {
+		t.Errorf("Rho = %v, expected in (0, 1]", metrics.Total.Rho)
+	}
+}
+
+// The average request size overestimates the capacity of a mix of short and long requests
+func TestMultiClassAnalyzer_Size(t *testing.T) {
+	short := &analyzer.RequestSize{AvgInputTokens: 200, AvgOutputTokens: 100}
+	long := &analyzer.RequestSize{AvgInputTokens: 30000, AvgOutputTokens: 300}
+	targets := &analyzer.TargetPerf{TargetTTFT: 2000, TargetITL: 60}
+	classes := []*analyzer.RequestClass{
+		{Weight: 0.9, RequestSize: short, TargetPerf: targets},
+		{Weight: 0.1, RequestSize: long, TargetPerf: targets},
+	}
+	qa, err := analyzer.NewMultiClassAnalyzer(multiClassConfig, classes)
+	if err != nil {
+		t.Fatalf("NewMultiClassAnalyzer() failed: %v", err)
+	}
+	maxRate, metrics, err := qa.Size()
+	if err != nil {
+		t.Fatalf("Size() failed: %v", err)
+	}
+	if maxRate <= 0 || maxRate > qa.RateRange.Max {
+		t.Fatalf("Max rate = %v, expected in (0, %v]", maxRate, qa.RateRange.Max)
+	}
+
+	// every class meets its targets, and one of them is binding
+	var worst float32
+	for i, class := range metrics.Classes {
+		ratio := max(class.AvgTTFT/targets.TargetTTFT, class.AvgTokenTime/targets.TargetITL)
+		if ratio > 1.01 {
+			t.Errorf("Class %d misses its targets: %s", i, class)
+		}
+		worst = max(worst, ratio)
+	}
+	if !analyzer.WithinTolerance(worst, 1, 0.01) {
+		t.Errorf("Largest ratio to target = %v, expected 1", worst)
+	}
+
+	average := &analyzer.RequestSize{
+		AvgInputTokens:  0.9*short.AvgInputTokens + 0.1*long.AvgInputTokens,
+		AvgOutputTokens: 0.9*short.AvgOutputTokens + 0.1*long.AvgOutputTokens,
+	}
+	single, err := analyzer.NewQueueAnalyzer(multiClassConfig, average)
+	if err != nil {
+		t.Fatalf("NewQueueAnalyzer() failed: %v", err)
+	}
+	_, singleMetrics, _, err := single.Size(targets)
+	if err != nil {
+		t.Fatalf("Size() failed: %v", err)
+	}
+	if singleMetrics.Throughput <= metrics.Total.Throughput {
+		t.Errorf("Average request size rate %v, expected above multi-class rate %v",
+			singleMetrics.Throughput, metrics.Total.Throughput)
+	}
+}
+
+func TestMultiClassAnalyzer_SizeWithoutTargets(t *testing.T) {
+	classes := []*analyzer.RequestClass{
+		{Weight: 1, RequestSize: &analyzer.RequestSize{AvgInputTokens: 200, AvgOutputTokens: 100}},
+		{Weight: 1, RequestSize: &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 100},
+			TargetPerf: &analyzer.TargetPerf{TargetTPS: 100}},
+	}
+	qa, err := analyzer.NewMultiClassAnalyzer(multiClassConfig, classes)
+	if err != nil {
+		t.Fatalf("NewMultiClassAnalyzer() failed: %v", err)
+	}
+	maxRate, _, err := qa.Size()
+	if err != nil {
+		t.Fatalf("Size() failed: %v", err)
+	}
+	expected := qa.RateRange.Max * (1 - analyzer.StabilitySafetyFraction)
+	if !analyzer.WithinTolerance(maxRate, expected, 1e-4) {
+		t.Errorf("Max rate = %v, expected %v", maxRate, expected)
+	}
+}
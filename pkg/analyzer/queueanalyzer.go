@@ -16,13 +16,15 @@ const DefaultMaxNumTokens = 8192
 
 // Analyzer of inference server queue
 type QueueAnalyzer struct {
-	MaxBatchSize int                     // maximum batch size
-	MaxNumTokens int                     // maximum number of tokens per batch
-	MaxQueueSize int                     // maximum queue size
-	ServiceParms *ServiceParms           // request processing parameters
-	RequestSize  *RequestSize            // number of input and output tokens per request
-	Model        *MM1ModelStateDependent // queueing model
-	RateRange    *RateRange              // range of request rates for model stability
+	MaxBatchSize   int                     // maximum batch size
+	MaxNumTokens   int                     // maximum number of tokens per batch
+	MaxQueueSize   int                     // maximum queue size
+	KVCacheTokens  int                     // KV cache capacity in tokens (0 if unknown)
+	MaxConcurrency int                     // maximum number of requests concurrently in service, given the request size
+	ServiceParms   *ServiceParms           // request processing parameters
+	RequestSize    *RequestSize            // number of input and output tokens per request
+	Model          *MM1ModelStateDependent // queueing model
+	RateRange      *RateRange              // range of request rates for model stability
 }
 
 // queue configuration parameters
@@ -30,6 +32,8 @@ type Configuration struct {
 	MaxBatchSize int           // maximum batch size (limit on the number of requests concurrently receiving service >0)
 	MaxNumTokens int           // maximum number of tokens per batch (limit on the number of tokens per batch >0)
 	MaxQueueSize int           // maximum queue size (limit on the number of requests queued for servive >=0)
+	NumGPUBlocks int           // number of KV cache blocks (num_gpu_blocks >=0, 0 if unknown)
+	BlockSize    int           // number of tokens per KV cache block (block_size >=0, 0 if unknown)
 	ServiceParms *ServiceParms // request processing parameters
 }
 
@@ -45,6 +49,8 @@ type ServiceParms struct {
 type RequestSize struct {
 	AvgInputTokens  float32 // average number of input tokens per request
 	AvgOutputTokens float32 // average number of output tokens per request
+
+	PrefixCacheHitRate float32 // share of the input tokens served from the prefix cache [0, 1] (0 if unknown)
 }
 
 // range of request rates (requests/sec)
@@ -96,9 +102,11 @@ func NewQueueAnalyzer(qConfig *Configuration, requestSize *RequestSize) (*QueueA
 func BuildModel(c *Configuration, r *RequestSize) (modelData *QueueAnalyzer) {
 	parms := c.ServiceParms
 
-	// calculate state-dependent service rate
-	servRate := make([]float32, c.MaxBatchSize)
-	for n := 1; n <= c.MaxBatchSize; n++ {
+	// calculate state-dependent service rate, up to the number of requests
+	// which fit in the batch, the token budget and the KV cache
+	maxConcurrency := c.MaxConcurrency(r)
+	servRate := make([]float32, maxConcurrency)
+	for n := 1; n <= maxConcurrency; n++ {
 		prefillTime := parms.PrefillTime(r, float32(n))
 		decodeTime := r.AvgOutputTokens * parms.DecodeTime(r, float32(n))
 		servRate[n-1] = float32(n) / (prefillTime + decodeTime)
@@ -106,22 +114,55 @@ func BuildModel(c *Configuration, r *RequestSize) (modelData *QueueAnalyzer) {
 
 	// set and check limits
 	lambdaMin := servRate[0] * Epsilon
-	lambdaMax := servRate[c.MaxBatchSize-1] * (1 - Epsilon)
+	lambdaMax := servRate[maxConcurrency-1] * (1 - Epsilon)
 	rateRange := &RateRange{Min: lambdaMin * 1000, Max: lambdaMax * 1000}
 
-	// create and solve model
+	// create and solve model, requests beyond the concurrency limit wait in queue
 	occupancyUpperBound := c.MaxQueueSize + c.MaxBatchSize
 	model := NewMM1ModelStateDependent(occupancyUpperBound, servRate)
 
 	return &QueueAnalyzer{
-		MaxBatchSize: c.MaxBatchSize,
-		MaxNumTokens: c.MaxNumTokens,
-		MaxQueueSize: c.MaxQueueSize,
-		ServiceParms: parms,
-		RequestSize:  r,
-		Model:        model,
-		RateRange:    rateRange,
+		MaxBatchSize:   c.MaxBatchSize,
+		MaxNumTokens:   c.MaxNumTokens,
+		MaxQueueSize:   c.MaxQueueSize,
+		KVCacheTokens:  c.KVCacheTokens(),
+		MaxConcurrency: maxConcurrency,
+		ServiceParms:   parms,
+		RequestSize:    r,
+		Model:          model,
+		RateRange:      rateRange,
+	}
+}
+
+// KV cache capacity in tokens (0 if unknown)
+func (c *Configuration) KVCacheTokens() int {
+	if c.NumGPUBlocks <= 0 || c.BlockSize <= 0 {
+		return 0
+	}
+	return c.NumGPUBlocks * c.BlockSize
+}
+
+// Maximum number of requests concurrently in service, limited by
+//   - the max batch size
+//   - the token budget per batch, given the average number of tokens a request contributes to an iteration
+//   - the KV cache capacity, given the average number of tokens a request holds in the KV cache
+//
+// The limit is at least one request.
+func (c *Configuration) MaxConcurrency(r *RequestSize) int {
+	return c.maxConcurrency(r.TokensPerIteration(), r.KVCacheTokens())
+}
+
+// maximum number of requests concurrently in service, given the average number of tokens
+// a request computes per iteration and holds in the KV cache
+func (c *Configuration) maxConcurrency(tokensPerIteration, kvCacheTokens float32) int {
+	maxConcurrency := c.MaxBatchSize
+	if c.MaxNumTokens > 0 && tokensPerIteration > 0 {
+		maxConcurrency = min(maxConcurrency, int(float32(c.MaxNumTokens)/tokensPerIteration))
+	}
+	if capacity := c.KVCacheTokens(); capacity > 0 && kvCacheTokens > 0 {
+		maxConcurrency = min(maxConcurrency, int(float32(capacity)/kvCacheTokens))
 	}
+	return max(maxConcurrency, 1)
 }
 
 // evaluate performance metrics given request rate
@@ -149,7 +190,7 @@ func (qa *QueueAnalyzer) Analyze(requestRate float32) (metrics *AnalysisMetrics,
 	avgDecodeTime := (model.GetAvgServTime() - avgPrefillTime) / qa.RequestSize.AvgOutputTokens
 	avgTTFT := model.GetAvgWaitTime() + avgPrefillTime + avgDecodeTime
 
-	rho := avgNumInServ / float32(qa.MaxBatchSize)
+	rho := avgNumInServ / float32(qa.MaxConcurrency)
 	rho = min(max(rho, 0), 1)
 
 	// return solution
@@ -172,7 +213,7 @@ type EvalFuncData struct {
 	model        *MM1ModelStateDependent // queueing model
 	requestSize  *RequestSize            // number of input and output tokens per request
 	serviceParms *ServiceParms           // request processing parameters for prefill and decode stages
-	maxBatchSize int                     // max batch size
+	maxBatchSize int                     // max batch size (effective max concurrency)
 }
 
 // evaluate max request rates to achieve a given target performance, returns
@@ -200,7 +241,7 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 			model:        qa.Model,
 			requestSize:  qa.RequestSize,
 			serviceParms: qa.ServiceParms,
-			maxBatchSize: qa.MaxBatchSize,
+			maxBatchSize: qa.MaxConcurrency,
 		})
 		lambdaStarTTFT, ind, err = BinarySearch(lambdaMin, lambdaMax, targetTTFT, evalTTF)
 		if ind < 0 {
@@ -219,7 +260,7 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 			model:        qa.Model,
 			requestSize:  qa.RequestSize,
 			serviceParms: qa.ServiceParms,
-			maxBatchSize: qa.MaxBatchSize,
+			maxBatchSize: qa.MaxConcurrency,
 		})
 		lambdaStarITL, ind, err = BinarySearch(lambdaMin, lambdaMax, targetITL, evalITL)
 		if ind < 0 {
@@ -258,25 +299,52 @@ func (qa *QueueAnalyzer) Size(targetPerf *TargetPerf) (targetRate *TargetRate, m
 	return targetRate, metrics, achieved, nil
 }
 
+// Average number of input tokens a request computes in prefill (those not served from the prefix cache)
+func (r *RequestSize) ComputedInputTokens() float32 {
+	return r.AvgInputTokens * (1 - r.PrefixCacheHitRate)
+}
+
+// Average number of tokens a request computes per iteration (prefill tokens spread over the iterations of the request)
+func (r *RequestSize) TokensPerIteration() float32 {
+	return (r.ComputedInputTokens() + r.AvgOutputTokens) / (r.AvgOutputTokens + 1)
+}
+
+// Average number of tokens a request holds in the KV cache during its service (cached prefixes included)
+func (r *RequestSize) KVCacheTokens() float32 {
+	return r.AvgInputTokens + r.AvgOutputTokens/2
+}
+
 // Average iteration time as a function of the batch size T(n)
 func (p *ServiceParms) IterationTime(r *RequestSize, batchSize float32) float32 {
-	tokensCompute := (r.AvgInputTokens + r.AvgOutputTokens) / (r.AvgOutputTokens + 1)
-	tokensMemory := r.AvgInputTokens + r.AvgOutputTokens/2
-	return p.Alpha + batchSize*(p.Beta*tokensCompute+p.Gamma*tokensMemory)
+	return p.iterationTime(r.TokensPerIteration(), r.KVCacheTokens(), batchSize)
+}
+
+// Average iteration time of a batch whose requests compute and hold the given average numbers of tokens
+func (p *ServiceParms) iterationTime(tokensPerIteration, kvCacheTokens, batchSize float32) float32 {
+	return p.Alpha + batchSize*(p.Beta*tokensPerIteration+p.Gamma*kvCacheTokens)
 }
 
 // Average prefill time as a function of the batch size
 func (p *ServiceParms) PrefillTime(r *RequestSize, batchSize float32) float32 {
+	return p.prefillTime(r, p.IterationTime(r, batchSize))
+}
+
+// Average prefill time of a request, given the iteration time of its batch
+func (p *ServiceParms) prefillTime(r *RequestSize, iterationTime float32) float32 {
 	if r.AvgInputTokens == 0 {
 		return 0
 	}
-	return p.IterationTime(r, batchSize) + (p.Beta+p.Gamma)*r.AvgInputTokens
+	return iterationTime + p.Beta*r.ComputedInputTokens() + p.Gamma*r.AvgInputTokens
 }
 
 // Average decode time (generation of ne token) as a function of the batch size
 func (p *ServiceParms) DecodeTime(r *RequestSize, batchSize float32) float32 {
-	return p.IterationTime(r, batchSize) +
-		p.Beta + p.Gamma*(r.AvgInputTokens+r.AvgOutputTokens/2)
+	return p.decodeTime(r, p.IterationTime(r, batchSize))
+}
+
+// Average decode time of a request, given the iteration time of its batch
+func (p *ServiceParms) decodeTime(r *RequestSize, iterationTime float32) float32 {
+	return iterationTime + p.Beta + p.Gamma*r.KVCacheTokens()
 }
 
 // Function used in binary search (target TTFT)
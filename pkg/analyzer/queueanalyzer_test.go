@@ -131,6 +131,39 @@ func TestConfiguration_Check(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "negative number of GPU blocks",
+			config: &analyzer.Configuration{
+				MaxBatchSize: 8,
+				MaxQueueSize: 16,
+				NumGPUBlocks: -1,
+				BlockSize:    16,
+				ServiceParms: testConfig.ServiceParms,
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative block size",
+			config: &analyzer.Configuration{
+				MaxBatchSize: 8,
+				MaxQueueSize: 16,
+				NumGPUBlocks: 1000,
+				BlockSize:    -1,
+				ServiceParms: testConfig.ServiceParms,
+			},
+			wantErr: true,
+		},
+		{
+			name: "KV cache capacity",
+			config: &analyzer.Configuration{
+				MaxBatchSize: 8,
+				MaxQueueSize: 16,
+				NumGPUBlocks: 1000,
+				BlockSize:    16,
+				ServiceParms: testConfig.ServiceParms,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,6 +215,21 @@ func TestRequestSize_Check(t *testing.T) {
 			requestSize: &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: -1},
 			wantErr:     true,
 		},
+		{
+			name:        "all input tokens cached",
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10, PrefixCacheHitRate: 1},
+			wantErr:     false,
+		},
+		{
+			name:        "negative prefix cache hit rate",
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10, PrefixCacheHitRate: -0.1},
+			wantErr:     true,
+		},
+		{
+			name:        "prefix cache hit rate above one",
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10, PrefixCacheHitRate: 1.1},
+			wantErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -204,6 +252,7 @@ func TestPrefillParms_PrefillTime(t *testing.T) {
 	tests := []struct {
 		name           string
 		avgInputTokens int
+		hitRate        float32
 		batchSize      float32
 		expected       float32
 	}{
@@ -231,13 +280,21 @@ func TestPrefillParms_PrefillTime(t *testing.T) {
 			batchSize:      2.5,
 			expected:       29.25,
 		},
+		{
+			name:           "half of the input tokens cached",
+			avgInputTokens: 1000,
+			hitRate:        0.5,
+			batchSize:      1.0,
+			expected:       22.0,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			result := parms.PrefillTime(&analyzer.RequestSize{
-				AvgInputTokens:  float32(tt.avgInputTokens),
-				AvgOutputTokens: 0,
+				AvgInputTokens:     float32(tt.avgInputTokens),
+				AvgOutputTokens:    0,
+				PrefixCacheHitRate: tt.hitRate,
 			}, tt.batchSize)
 			if math.Abs(float64(result-tt.expected)) > 1e-6 {
 				t.Errorf("PrefillTime() = %v, expected %v", result, tt.expected)
@@ -334,6 +391,127 @@ func TestBuildModel(t *testing.T) {
 	}
 }
 
+func TestConfiguration_MaxConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *analyzer.Configuration
+		requestSize *analyzer.RequestSize
+		expected    int
+	}{
+		{
+			name:        "limited by batch size",
+			config:      &analyzer.Configuration{MaxBatchSize: 8, MaxNumTokens: 8192},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10},
+			expected:    8,
+		},
+		{
+			name:        "no token budget",
+			config:      &analyzer.Configuration{MaxBatchSize: 256},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 8000, AvgOutputTokens: 1},
+			expected:    256,
+		},
+		{
+			// (8000 + 1) / 2 tokens per iteration
+			name:        "limited by token budget",
+			config:      &analyzer.Configuration{MaxBatchSize: 256, MaxNumTokens: 8192},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 8000, AvgOutputTokens: 1},
+			expected:    2,
+		},
+		{
+			// 1000 blocks * 16 tokens / (2000 + 200/2) tokens per request
+			name:        "limited by KV cache capacity",
+			config:      &analyzer.Configuration{MaxBatchSize: 256, MaxNumTokens: 8192, NumGPUBlocks: 1000, BlockSize: 16},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200},
+			expected:    7,
+		},
+		{
+			name:        "unknown block size",
+			config:      &analyzer.Configuration{MaxBatchSize: 256, MaxNumTokens: 8192, NumGPUBlocks: 1000},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200},
+			expected:    256,
+		},
+		{
+			name:        "at least one request",
+			config:      &analyzer.Configuration{MaxBatchSize: 256, MaxNumTokens: 8192, NumGPUBlocks: 10, BlockSize: 16},
+			requestSize: &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200},
+			expected:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.MaxConcurrency(tt.requestSize); got != tt.expected {
+				t.Errorf("MaxConcurrency() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildModel_KVCacheCapacity(t *testing.T) {
+	requestSize := &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200}
+	config := &analyzer.Configuration{
+		MaxBatchSize: 64,
+		MaxNumTokens: 8192,
+		MaxQueueSize: 128,
+		ServiceParms: testConfig.ServiceParms,
+	}
+	unbounded := analyzer.BuildModel(config, requestSize)
+
+	bounded := *config
+	bounded.NumGPUBlocks = 1000
+	bounded.BlockSize = 16
+	qa := analyzer.BuildModel(&bounded, requestSize)
+
+	if qa.KVCacheTokens != 16000 {
+		t.Errorf("KVCacheTokens = %v, expected 16000", qa.KVCacheTokens)
+	}
+	if qa.MaxConcurrency != 7 {
+		t.Errorf("MaxConcurrency = %v, expected 7", qa.MaxConcurrency)
+	}
+	if qa.RateRange.Max >= unbounded.RateRange.Max {
+		t.Errorf("Max rate with KV cache limit (%v) should be less than without (%v)",
+			qa.RateRange.Max, unbounded.RateRange.Max)
+	}
+
+	// utilization is relative to the concurrency limit
+	metrics, err := qa.Analyze(qa.RateRange.Max * 0.99)
+	if err != nil {
+		t.Fatalf("Analyze() failed: %v", err)
+	}
+	if metrics.AvgNumInServ > float32(qa.MaxConcurrency) {
+		t.Errorf("AvgNumInServ = %v, should not exceed %v", metrics.AvgNumInServ, qa.MaxConcurrency)
+	}
+	if metrics.Rho < 0.9 {
+		t.Errorf("Rho = %v near max rate, expected close to 1", metrics.Rho)
+	}
+}
+
+// Cached prefixes save prefill compute, but still hold KV cache memory
+func TestBuildModel_PrefixCacheHitRate(t *testing.T) {
+	config := &analyzer.Configuration{
+		MaxBatchSize: 64,
+		MaxNumTokens: 8192,
+		MaxQueueSize: 128,
+		NumGPUBlocks: 1000,
+		BlockSize:    16,
+		ServiceParms: testConfig.ServiceParms,
+	}
+	uncached := analyzer.BuildModel(config, &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200})
+	cached := analyzer.BuildModel(config, &analyzer.RequestSize{AvgInputTokens: 2000, AvgOutputTokens: 200, PrefixCacheHitRate: 0.8})
+
+	if cached.MaxConcurrency != uncached.MaxConcurrency {
+		t.Errorf("MaxConcurrency = %v, expected %v as cached prefixes hold KV cache",
+			cached.MaxConcurrency, uncached.MaxConcurrency)
+	}
+	if cached.RateRange.Max <= uncached.RateRange.Max {
+		t.Errorf("Max rate with prefix cache hits (%v) should be greater than without (%v)",
+			cached.RateRange.Max, uncached.RateRange.Max)
+	}
+	if got := cached.RequestSize.ComputedInputTokens(); math.Abs(float64(got-400)) > 1e-3 {
+		t.Errorf("ComputedInputTokens() = %v, expected 400", got)
+	}
+}
+
 func TestQueueAnalyzer_Analyze(t *testing.T) {
 	requestSize := &analyzer.RequestSize{AvgInputTokens: 100, AvgOutputTokens: 10}
 	qa, err := analyzer.NewQueueAnalyzer(testConfig, requestSize)
@@ -94,7 +94,7 @@ func EvalWaitingTime(model *MM1ModelStateDependent) func(x float32) (float32, er
 // check validity of configuration parameters
 func (c *Configuration) check() error {
 	if c.MaxBatchSize <= 0 || c.MaxQueueSize < 0 || c.MaxNumTokens < 0 ||
-		c.ServiceParms == nil {
+		c.NumGPUBlocks < 0 || c.BlockSize < 0 || c.ServiceParms == nil {
 		return fmt.Errorf("invalid configuration %s", c)
 	}
 	if c.MaxNumTokens == 0 {
@@ -105,7 +105,8 @@ func (c *Configuration) check() error {
 
 // check validity of request size
 func (rq *RequestSize) check() error {
-	if rq.AvgInputTokens < 0 || rq.AvgOutputTokens < 1 {
+	if rq.AvgInputTokens < 0 || rq.AvgOutputTokens < 1 ||
+		rq.PrefixCacheHitRate < 0 || rq.PrefixCacheHitRate > 1 {
 		return fmt.Errorf("invalid request size %s", rq)
 	}
 	return nil
@@ -126,13 +127,13 @@ func (targetPerf *TargetPerf) check() error {
  */
 
 func (c *Configuration) String() string {
-	return fmt.Sprintf("{maxBatch=%d, maxNumTokens=%d, maxQueue=%d, servParms:%s}",
-		c.MaxBatchSize, c.MaxNumTokens, c.MaxQueueSize, c.ServiceParms)
+	return fmt.Sprintf("{maxBatch=%d, maxNumTokens=%d, maxQueue=%d, numGPUBlocks=%d, blockSize=%d, servParms:%s}",
+		c.MaxBatchSize, c.MaxNumTokens, c.MaxQueueSize, c.NumGPUBlocks, c.BlockSize, c.ServiceParms)
 }
 
 func (qa *QueueAnalyzer) String() string {
-	return fmt.Sprintf("{maxBatch=%d, maxNumTokens=%d, maxQueue=%d, servParms:%s, reqSize:%s, model:%s, rates:%s}",
-		qa.MaxBatchSize, qa.MaxNumTokens, qa.MaxQueueSize, qa.ServiceParms, qa.RequestSize, qa.Model, qa.RateRange)
+	return fmt.Sprintf("{maxBatch=%d, maxNumTokens=%d, maxQueue=%d, kvCacheTokens=%d, maxConc=%d, servParms:%s, reqSize:%s, model:%s, rates:%s}",
+		qa.MaxBatchSize, qa.MaxNumTokens, qa.MaxQueueSize, qa.KVCacheTokens, qa.MaxConcurrency, qa.ServiceParms, qa.RequestSize, qa.Model, qa.RateRange)
 }
 
 func (sp *ServiceParms) String() string {
@@ -140,7 +141,7 @@ func (sp *ServiceParms) String() string {
 }
 
 func (rq *RequestSize) String() string {
-	return fmt.Sprintf("{inTokens=%.1f, outTokens=%.1f}", rq.AvgInputTokens, rq.AvgOutputTokens)
+	return fmt.Sprintf("{inTokens=%.1f, outTokens=%.1f, prefixHitRate=%.3f}", rq.AvgInputTokens, rq.AvgOutputTokens, rq.PrefixCacheHitRate)
 }
 
 func (rr *RateRange) String() string {
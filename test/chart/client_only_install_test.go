@@ -305,3 +305,82 @@ func TestScaleTargetKindWithDefaultName(t *testing.T) {
 		t.Error("should use default name pattern {modelName}-decode when scaleTargetName is not specified")
 	}
 }
+
+// TestExternalMetrics verifies that wva.externalMetrics.enabled registers the
+// controller as the external.metrics.k8s.io server, and that it is off by default.
+func TestExternalMetrics(t *testing.T) {
+	output := helmTemplate(t, "wva-default", map[string]string{})
+	if strings.Contains(output, "kind: APIService") {
+		t.Error("should not register an APIService by default")
+	}
+
+	output = helmTemplate(t, "wva", map[string]string{
+		"wva.externalMetrics.enabled": "true",
+	})
+	mustContain := []string{
+		"kind: APIService",
+		"name: v1beta1.external.metrics.k8s.io",
+		"name: wva-workload-variant-autoscaler-external-metrics",
+		"name: extension-apiserver-authentication-reader",
+		"namespace: kube-system",
+		`EXTERNAL_METRICS_BIND_ADDRESS: ":6443"`,
+		"containerPort: 6443",
+	}
+	for _, marker := range mustContain {
+		if !strings.Contains(output, marker) {
+			t.Errorf("external metrics install should contain %q", marker)
+		}
+	}
+
+	output = helmTemplate(t, "wva-client-only", map[string]string{
+		"controller.enabled":          "false",
+		"wva.externalMetrics.enabled": "true",
+	})
+	if strings.Contains(output, "kind: APIService") {
+		t.Error("client-only install should not register an APIService")
+	}
+}
+
+func TestKedaScaler(t *testing.T) {
+	output := helmTemplate(t, "wva-default", map[string]string{})
+	if strings.Contains(output, "KEDA_SCALER_BIND_ADDRESS") {
+		t.Error("should not serve the KEDA scaler by default")
+	}
+
+	output = helmTemplate(t, "wva", map[string]string{
+		"wva.kedaScaler.enabled":        "true",
+		"wva.kedaScaler.certSecret":     "keda-scaler-tls",
+		"wva.kedaScaler.clientCASecret": "keda-client-ca",
+	})
+	mustContain := []string{
+		"name: wva-workload-variant-autoscaler-keda-scaler",
+		`KEDA_SCALER_BIND_ADDRESS: ":9000"`,
+		`KEDA_SCALER_CERT_PATH: "/etc/wva/keda-scaler/tls"`,
+		`KEDA_SCALER_CLIENT_CA_CERT_PATH: "/etc/wva/keda-scaler/ca/ca.crt"`,
+		"secretName: keda-scaler-tls",
+		"secretName: keda-client-ca",
+		"containerPort: 9000",
+	}
+	for _, marker := range mustContain {
+		if !strings.Contains(output, marker) {
+			t.Errorf("KEDA scaler install should contain %q", marker)
+		}
+	}
+
+	output = helmTemplate(t, "wva-insecure", map[string]string{
+		"wva.kedaScaler.enabled":  "true",
+		"wva.kedaScaler.insecure": "true",
+	})
+	if !strings.Contains(output, `KEDA_SCALER_INSECURE: "true"`) || strings.Contains(output, "KEDA_SCALER_CERT_PATH") {
+		t.Error("insecure KEDA scaler install should serve plaintext gRPC")
+	}
+
+	output = helmTemplate(t, "wva-client-only", map[string]string{
+		"controller.enabled":      "false",
+		"wva.kedaScaler.enabled":  "true",
+		"wva.kedaScaler.insecure": "true",
+	})
+	if strings.Contains(output, "keda-scaler") {
+		t.Error("client-only install should not create the KEDA scaler Service")
+	}
+}
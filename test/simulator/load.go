@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"math"
+	"time"
+)
+
+// LoadPattern is the arrival rate of requests to a model over simulated time.
+type LoadPattern interface {
+	// Rate returns the arrival rate (requests/sec) at elapsed time t since the
+	// start of the simulation.
+	Rate(t time.Duration) float64
+}
+
+// Constant is a fixed arrival rate (requests/sec).
+type Constant float64
+
+// Rate implements LoadPattern.
+func (c Constant) Rate(time.Duration) float64 {
+	return float64(c)
+}
+
+// Diurnal is a daily cycle between a minimum and a peak arrival rate, shaped
+// as a cosine with its peak at PeakAt into the day.
+type Diurnal struct {
+	Min    float64
+	Peak   float64
+	PeakAt time.Duration
+}
+
+// Rate implements LoadPattern.
+func (d Diurnal) Rate(t time.Duration) float64 {
+	const day = 24 * time.Hour
+	phase := 2 * math.Pi * float64((t-d.PeakAt)%day) / float64(day)
+	return d.Min + (d.Peak-d.Min)*(1+math.Cos(phase))/2
+}
+
+// Step is a piecewise constant arrival rate.
+type Step struct {
+	Steps []RateStep
+}
+
+// RateStep is an arrival rate that applies from At until the next step.
+type RateStep struct {
+	At   time.Duration
+	Rate float64
+}
+
+// Rate implements LoadPattern. The rate is zero before the first step.
+func (s Step) Rate(t time.Duration) float64 {
+	rate := 0.0
+	for _, step := range s.Steps {
+		if t < step.At {
+			break
+		}
+		rate = step.Rate
+	}
+	return rate
+}
+
+// Spike adds a burst of Extra requests/sec to a base pattern between At and
+// At+Duration.
+type Spike struct {
+	Base     LoadPattern
+	At       time.Duration
+	Duration time.Duration
+	Extra    float64
+}
+
+// Rate implements LoadPattern.
+func (s Spike) Rate(t time.Duration) float64 {
+	rate := s.Base.Rate(t)
+	if t >= s.At && t < s.At+s.Duration {
+		rate += s.Extra
+	}
+	return rate
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/registration"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector/source"
+)
+
+// metricsSource is a MetricsSource serving the metrics vLLM and the inference
+// scheduler would report for the simulated replicas, as Prometheus query
+// results labeled by pod.
+type metricsSource struct {
+	sim       *Simulator
+	queryList *source.QueryList
+}
+
+var _ source.MetricsSource = &metricsSource{}
+
+func newMetricsSource(sim *Simulator) *metricsSource {
+	return &metricsSource{
+		sim:       sim,
+		queryList: source.NewQueryList(),
+	}
+}
+
+// QueryList returns an empty query list: queries are answered by name.
+func (s *metricsSource) QueryList() *source.QueryList {
+	return s.queryList
+}
+
+// Refresh returns the current metrics of the ready replicas of the model in
+// spec.Params. Queries without a simulated metric return no values, as an
+// absent Prometheus series does.
+func (s *metricsSource) Refresh(_ context.Context, spec source.RefreshSpec) (map[string]*source.MetricResult, error) {
+	modelID := spec.Params[source.ParamModelID]
+	namespace := spec.Params[source.ParamNamespace]
+	now := s.sim.now
+
+	results := make(map[string]*source.MetricResult, len(spec.Queries))
+	for _, query := range spec.Queries {
+		result := &source.MetricResult{QueryName: query, CollectedAt: now}
+		for _, m := range s.sim.models {
+			if m.spec.ModelID != modelID || (namespace != "" && m.spec.Namespace != namespace) {
+				continue
+			}
+			for _, v := range m.variants {
+				for _, r := range v.replicas {
+					if !r.ready {
+						continue
+					}
+					if value, ok := replicaMetric(query, m, v, r); ok {
+						value.Timestamp = now
+						result.Values = append(result.Values, value)
+					}
+				}
+			}
+		}
+		results[query] = result
+	}
+	return results, nil
+}
+
+// Get returns nil: results are not cached.
+func (s *metricsSource) Get(string, map[string]string) *source.CachedValue {
+	return nil
+}
+
+// replicaMetric returns the value of a query for one replica.
+func replicaMetric(query string, m *model, v *variant, r *replica) (source.MetricValue, bool) {
+	labels := map[string]string{"pod": r.name, "namespace": m.spec.Namespace}
+	var value float64
+	switch query {
+	case registration.QueryKvCacheUsage:
+		value = r.load.kvUsage
+	case registration.QueryQueueLength:
+		value = r.load.queueLength
+	case registration.QueryCacheConfigInfo:
+		labels["num_gpu_blocks"] = strconv.FormatInt(v.spec.Server.NumGpuBlocks, 10)
+		labels["block_size"] = strconv.FormatInt(v.spec.Server.BlockSize, 10)
+		value = 1
+	case registration.QueryAvgInputTokens:
+		value = m.spec.AvgInputTokens
+	case registration.QueryAvgOutputTokens:
+		value = m.spec.AvgOutputTokens
+	case registration.QuerySchedulerDispatchRate:
+		value = r.load.arrivalRate
+	case registration.QueryAvgTTFT:
+		value = r.load.ttft.Seconds()
+	case registration.QueryAvgITL:
+		value = r.load.itl.Seconds()
+	default:
+		return source.MetricValue{}, false
+	}
+	return source.MetricValue{Value: value, Labels: labels}, true
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/pkg/analyzer"
+)
+
+// serverModel is the steady-state behavior of one replica of a variant serving
+// a model, given by the queueing model of pkg/analyzer.
+type serverModel struct {
+	analyzer *analyzer.QueueAnalyzer
+	// maxRate is the highest arrival rate (requests/sec) the model is stable at.
+	maxRate float64
+	// tokensPerRequest is the average KV cache footprint of a request in service.
+	tokensPerRequest float64
+	// kvCapacityTokens is the KV cache capacity of a replica in tokens.
+	kvCapacityTokens float64
+}
+
+// replicaLoad is the steady state of a replica over one simulation step.
+type replicaLoad struct {
+	arrivalRate float64 // requests/sec routed to the replica
+	served      float64 // requests/sec served
+	ttft        time.Duration
+	itl         time.Duration
+	kvUsage     float64 // fraction of the KV cache in use
+	queueLength float64 // requests waiting in the server queue
+}
+
+// newServerModel builds the queueing model of a replica. The batch size is
+// bounded by both --max-num-seqs and the number of average requests that fit
+// in the KV cache.
+func newServerModel(spec ServerSpec, inputTokens, outputTokens float64) (*serverModel, error) {
+	kvCapacityTokens := float64(spec.NumGpuBlocks * spec.BlockSize)
+	// Input tokens and, on average, half of the output tokens are in the KV
+	// cache while a request is in service.
+	tokensPerRequest := inputTokens + outputTokens/2
+	maxBatchSize := spec.MaxBatchSize
+	if kvBound := int(kvCapacityTokens / (inputTokens + outputTokens)); kvBound < maxBatchSize {
+		maxBatchSize = kvBound
+	}
+	if maxBatchSize < 1 {
+		return nil, fmt.Errorf("KV cache of %v tokens cannot hold a request of %v tokens",
+			kvCapacityTokens, inputTokens+outputTokens)
+	}
+
+	qa, err := analyzer.NewQueueAnalyzer(&analyzer.Configuration{
+		MaxBatchSize: maxBatchSize,
+		MaxQueueSize: spec.MaxQueueSize,
+		ServiceParms: &analyzer.ServiceParms{
+			Alpha: spec.Alpha,
+			Beta:  spec.Beta,
+			Gamma: spec.Gamma,
+		},
+	}, &analyzer.RequestSize{
+		AvgInputTokens:  float32(inputTokens),
+		AvgOutputTokens: float32(outputTokens),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &serverModel{
+		analyzer:         qa,
+		maxRate:          float64(qa.RateRange.Max),
+		tokensPerRequest: tokensPerRequest,
+		kvCapacityTokens: kvCapacityTokens,
+	}, nil
+}
+
+// serve returns the steady state of a replica receiving rate requests/sec.
+// Requests beyond the rate the server can sustain, and requests the model
+// rejects with a full queue, are not served.
+func (m *serverModel) serve(rate float64) replicaLoad {
+	load := replicaLoad{arrivalRate: rate}
+	if rate <= 0 {
+		return load
+	}
+
+	metrics, err := m.analyzer.Analyze(float32(math.Min(rate, m.maxRate)))
+	if err != nil {
+		// Below the lowest rate the model resolves: an idle replica.
+		return load
+	}
+	load.served = math.Min(float64(metrics.Throughput), rate)
+	load.ttft = msec(metrics.AvgTTFT)
+	load.itl = msec(metrics.AvgTokenTime)
+	load.kvUsage = math.Min(float64(metrics.AvgNumInServ)*m.tokensPerRequest/m.kvCapacityTokens, 1)
+	// Little's law: waiting requests = throughput × waiting time.
+	load.queueLength = float64(metrics.Throughput) * float64(metrics.AvgWaitTime) / 1000
+	return load
+}
+
+func msec(ms float32) time.Duration {
+	return time.Duration(float64(ms) * float64(time.Millisecond))
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+// Result is the outcome of a simulation.
+type Result struct {
+	// Models holds the outcome per model, keyed by namespace/modelID.
+	Models map[string]*ModelResult
+}
+
+// ModelResult is the outcome of a simulation for one model.
+type ModelResult struct {
+	// Requests is the number of requests that arrived.
+	Requests float64
+	// Served is the number of requests served. Requests beyond what the ready
+	// replicas sustain are not served.
+	Served float64
+	// WithinSLO is the number of requests served within the SLO.
+	WithinSLO float64
+	// Cost is the cost of all replicas, ready or starting, over the simulation.
+	Cost     float64
+	GPUHours float64
+	// ScaleUps and ScaleDowns count the cycles that changed a variant's replicas.
+	ScaleUps    int
+	ScaleDowns  int
+	MaxReplicas int
+}
+
+// SLOAttainment returns the fraction of arrived requests served within the SLO.
+func (r *ModelResult) SLOAttainment() float64 {
+	if r.Requests == 0 {
+		return 1
+	}
+	return r.WithinSLO / r.Requests
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultStep is the default resolution of load and replica state.
+	DefaultStep = 10 * time.Second
+	// DefaultInterval is the default period of the autoscaler's optimization cycle.
+	DefaultInterval = 30 * time.Second
+	// DefaultScaleDownStabilization is the HPA's default scale-down
+	// stabilization window.
+	DefaultScaleDownStabilization = 5 * time.Minute
+)
+
+// Scenario describes a simulation: the models, their variants and load, and
+// how long to run.
+type Scenario struct {
+	// Start is the virtual wall-clock time the simulation starts at. Scaling
+	// schedules are evaluated against it.
+	Start time.Time
+	// Duration is the simulated time to run for.
+	Duration time.Duration
+	// Step is the resolution at which load is routed to replicas and SLO
+	// attainment and cost are accounted. Defaults to DefaultStep.
+	Step time.Duration
+	// Interval is the period of the autoscaler's optimization cycle.
+	// Defaults to DefaultInterval.
+	Interval time.Duration
+	// ScaleDownStabilization is the scale-down stabilization window of the
+	// HPA applying the decisions. Defaults to DefaultScaleDownStabilization;
+	// a negative value applies scale-down decisions at once.
+	ScaleDownStabilization time.Duration
+	// GPULimits is the GPU capacity per accelerator type available to the GPU
+	// limiter. Only used when enableLimiter is set.
+	GPULimits map[string]int
+	Models    []ModelSpec
+}
+
+// ModelSpec is a model served by one or more variants.
+type ModelSpec struct {
+	ModelID   string
+	Namespace string
+	// Load is the arrival rate of requests to the model.
+	Load            LoadPattern
+	AvgInputTokens  float64
+	AvgOutputTokens float64
+	// SLO is the latency a request must be served within to count towards
+	// SLO attainment.
+	SLO      SLO
+	Variants []VariantSpec
+}
+
+// SLO is a latency objective. A zero target is not considered.
+type SLO struct {
+	TTFT time.Duration
+	ITL  time.Duration
+}
+
+// VariantSpec is a variant of a model: a Deployment of replicas on one
+// accelerator type, scaled by a VariantAutoscaling.
+type VariantSpec struct {
+	Name        string
+	Accelerator string
+	// Cost is the cost of one replica per hour.
+	Cost            float64
+	GPUsPerReplica  int
+	InitialReplicas int
+	MinReplicas     int
+	MaxReplicas     int
+	// StartupDelay is the time from creating a replica until it serves traffic.
+	StartupDelay time.Duration
+	Server       ServerSpec
+}
+
+// ServerSpec is the vLLM server configuration and performance of a replica.
+type ServerSpec struct {
+	// MaxBatchSize is the maximum number of requests in a batch (--max-num-seqs).
+	MaxBatchSize int
+	// MaxQueueSize is the maximum number of requests waiting in the server.
+	MaxQueueSize int
+	NumGpuBlocks int64
+	BlockSize    int64
+	// Alpha, Beta and Gamma are the parameters of the iteration time of the
+	// queueing model (msec), see pkg/analyzer.
+	Alpha float32
+	Beta  float32
+	Gamma float32
+}
+
+// validate checks the scenario and applies defaults.
+func (s *Scenario) validate() error {
+	if s.Duration <= 0 {
+		return errors.New("duration must be positive")
+	}
+	if s.Step == 0 {
+		s.Step = DefaultStep
+	}
+	if s.Interval == 0 {
+		s.Interval = DefaultInterval
+	}
+	if s.ScaleDownStabilization == 0 {
+		s.ScaleDownStabilization = DefaultScaleDownStabilization
+	}
+	if s.Step < 0 || s.Interval < 0 {
+		return errors.New("step and interval must be positive")
+	}
+	if len(s.Models) == 0 {
+		return errors.New("no models")
+	}
+	for _, m := range s.Models {
+		if m.ModelID == "" || m.Namespace == "" {
+			return errors.New("models need a modelID and namespace")
+		}
+		if m.Load == nil {
+			return fmt.Errorf("model %s has no load pattern", m.ModelID)
+		}
+		if len(m.Variants) == 0 {
+			return fmt.Errorf("model %s has no variants", m.ModelID)
+		}
+		for _, v := range m.Variants {
+			if v.Name == "" {
+				return fmt.Errorf("model %s has a variant without a name", m.ModelID)
+			}
+			if v.InitialReplicas < v.MinReplicas || (v.MaxReplicas > 0 && v.InitialReplicas > v.MaxReplicas) {
+				return fmt.Errorf("variant %s: initial replicas %d outside [%d, %d]",
+					v.Name, v.InitialReplicas, v.MinReplicas, v.MaxReplicas)
+			}
+		}
+	}
+	return nil
+}
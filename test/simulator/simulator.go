@@ -0,0 +1,514 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator is a discrete-event simulator of vLLM replicas scaled by
+// the autoscaler, running on virtual time. Replicas are modeled with the
+// queueing model of pkg/analyzer, their metrics are collected through the
+// replica metrics collector from a fake MetricsSource, and the decisions of
+// the analyzers, optimizer, GPU limiter and enforcer are applied to them after
+// a startup delay. A day of traffic runs in seconds under go test, reporting
+// SLO attainment and cost.
+package simulator
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	llmdVariantAutoscalingV1alpha1 "github.com/llm-d/llm-d-workload-variant-autoscaler/api/v1alpha1"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/collector"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/controller/indexers"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/engines/saturation"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/interfaces"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils"
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/utils/scaletarget"
+)
+
+// Simulator runs a Scenario against the autoscaler's decision pipeline.
+type Simulator struct {
+	scenario  Scenario
+	cfg       *config.Config
+	now       time.Time
+	events    eventQueue
+	seq       int
+	models    []*model
+	client    client.Client
+	collector *collector.ReplicaMetricsCollector
+	replayer  *saturation.Replayer
+	result    *Result
+}
+
+// model is the simulated state of a ModelSpec.
+type model struct {
+	spec     ModelSpec
+	variants []*variant
+	// arrivals are the requests that arrived in each past step, for the
+	// scale-to-zero request count.
+	arrivals []stepArrivals
+	result   *ModelResult
+}
+
+type stepArrivals struct {
+	at       time.Time
+	requests float64
+}
+
+// variant is the simulated state of a VariantSpec.
+type variant struct {
+	spec        VariantSpec
+	server      *serverModel
+	replicas    []*replica
+	desired     int
+	nextOrdinal int
+	va          *llmdVariantAutoscalingV1alpha1.VariantAutoscaling
+	deployment  *appsv1.Deployment
+	replicaSet  *appsv1.ReplicaSet
+	// recommendations are the targets decided within the scale-down
+	// stabilization window.
+	recommendations []recommendation
+}
+
+type recommendation struct {
+	at       time.Time
+	replicas int
+}
+
+// replica is one pod of a variant.
+type replica struct {
+	name  string
+	ready bool
+	load  replicaLoad
+}
+
+// New creates a Simulator for the scenario, deciding with the saturation,
+// queueing model and scale-to-zero configuration in cfg.
+func New(scenario Scenario, cfg *config.Config) (*Simulator, error) {
+	if err := scenario.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := llmdVariantAutoscalingV1alpha1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+
+	s := &Simulator{
+		scenario: scenario,
+		cfg:      cfg,
+		now:      scenario.Start,
+		replayer: saturation.NewReplayer(cfg),
+		result:   &Result{Models: make(map[string]*ModelResult, len(scenario.Models))},
+	}
+
+	var objects []client.Object
+	for _, spec := range scenario.Models {
+		m := &model{spec: spec, result: &ModelResult{}}
+		s.result.Models[utils.GetNamespacedKey(spec.Namespace, spec.ModelID)] = m.result
+		for _, vspec := range spec.Variants {
+			server, err := newServerModel(vspec.Server, spec.AvgInputTokens, spec.AvgOutputTokens)
+			if err != nil {
+				return nil, fmt.Errorf("variant %s: %w", vspec.Name, err)
+			}
+			v := &variant{spec: vspec, server: server}
+			v.va, v.deployment, v.replicaSet = variantObjects(spec, vspec)
+			objects = append(objects, v.va, v.deployment, v.replicaSet)
+			m.variants = append(m.variants, v)
+		}
+		s.models = append(s.models, m)
+	}
+
+	s.client = fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(&llmdVariantAutoscalingV1alpha1.VariantAutoscaling{}, indexers.VAScaleTargetKey, indexers.VAScaleTargetIndexFunc).
+		Build()
+	s.collector = collector.NewReplicaMetricsCollector(newMetricsSource(s), s.client, config.DefaultFreshnessThresholds())
+	return s, nil
+}
+
+// Run simulates the scenario and returns SLO attainment and cost per model.
+// Initial replicas are ready at the start. Load and replica state advance in
+// steps; the optimization cycle runs every interval, and replicas it adds
+// serve traffic after their variant's startup delay.
+func (s *Simulator) Run(ctx context.Context) (*Result, error) {
+	for _, m := range s.models {
+		for _, v := range m.variants {
+			if err := s.scale(ctx, v, v.spec.InitialReplicas); err != nil {
+				return nil, err
+			}
+			for _, r := range v.replicas {
+				r.ready = true
+			}
+		}
+	}
+
+	end := s.scenario.Start.Add(s.scenario.Duration)
+	s.schedule(s.scenario.Start, priorityStep, s.step)
+	s.schedule(s.scenario.Start.Add(s.scenario.Interval), priorityCycle, s.cycle)
+
+	for s.events.Len() > 0 {
+		ev := heap.Pop(&s.events).(*event)
+		if !ev.at.Before(end) {
+			break
+		}
+		s.now = ev.at
+		if err := ev.run(s.logger(ctx)); err != nil {
+			return nil, fmt.Errorf("at %s: %w", s.now.Sub(s.scenario.Start), err)
+		}
+	}
+	return s.result, nil
+}
+
+// step routes the load of the coming step to the ready replicas, and accounts
+// SLO attainment and cost over it.
+func (s *Simulator) step(context.Context) error {
+	stepSeconds := s.scenario.Step.Seconds()
+	for _, m := range s.models {
+		rate := m.spec.Load.Rate(s.now.Sub(s.scenario.Start))
+		m.arrivals = append(m.arrivals, stepArrivals{at: s.now, requests: rate * stepSeconds})
+		m.result.Requests += rate * stepSeconds
+
+		// The inference scheduler balances load in proportion to the rate
+		// each replica sustains.
+		capacity := 0.0
+		for _, v := range m.variants {
+			for _, r := range v.replicas {
+				if r.ready {
+					capacity += v.server.maxRate
+				}
+			}
+		}
+
+		replicas := 0
+		for _, v := range m.variants {
+			replicas += len(v.replicas)
+			m.result.Cost += float64(len(v.replicas)) * v.spec.Cost * s.scenario.Step.Hours()
+			m.result.GPUHours += float64(len(v.replicas)*gpusPerReplica(v.spec)) * s.scenario.Step.Hours()
+			for _, r := range v.replicas {
+				r.load = replicaLoad{}
+				if !r.ready || capacity == 0 {
+					continue
+				}
+				r.load = v.server.serve(rate * v.server.maxRate / capacity)
+				m.result.Served += r.load.served * stepSeconds
+				if m.spec.SLO.met(r.load) {
+					m.result.WithinSLO += r.load.served * stepSeconds
+				}
+			}
+		}
+		m.result.MaxReplicas = max(m.result.MaxReplicas, replicas)
+	}
+	s.schedule(s.now.Add(s.scenario.Step), priorityStep, s.step)
+	return nil
+}
+
+// cycle runs one optimization cycle: it collects the metrics of every model
+// from the metrics source, runs them through the decision pipeline, and
+// scales the variants to the decided targets.
+func (s *Simulator) cycle(ctx context.Context) error {
+	replayCycle := saturation.ReplayCycle{Time: s.now, GPULimits: s.scenario.GPULimits}
+	for _, m := range s.models {
+		recorded, err := s.collect(ctx, m)
+		if err != nil {
+			return err
+		}
+		replayCycle.Models = append(replayCycle.Models, *recorded)
+	}
+
+	results := s.replayer.Replay(ctx, &saturation.ReplayTrace{Cycles: []saturation.ReplayCycle{replayCycle}})
+	for _, d := range results[0].Decisions {
+		m, v := s.findVariant(d)
+		if v == nil {
+			continue
+		}
+		target := v.stabilize(s.now, d.TargetReplicas, s.scenario.ScaleDownStabilization)
+		if target > len(v.replicas) {
+			m.result.ScaleUps++
+		} else if target < len(v.replicas) {
+			m.result.ScaleDowns++
+		}
+		if err := s.scale(ctx, v, target); err != nil {
+			return err
+		}
+	}
+
+	s.schedule(s.now.Add(s.scenario.Interval), priorityCycle, s.cycle)
+	return nil
+}
+
+// collect gathers the cycle input of a model the way the engine does.
+func (s *Simulator) collect(ctx context.Context, m *model) (*saturation.ReplayModel, error) {
+	scaleTargets := make(map[string]scaletarget.ScaleTargetAccessor, len(m.variants))
+	vas := make(map[string]*llmdVariantAutoscalingV1alpha1.VariantAutoscaling, len(m.variants))
+	costs := make(map[string]float64, len(m.variants))
+	states := make([]interfaces.VariantReplicaState, 0, len(m.variants))
+	for _, v := range m.variants {
+		scaleTargets[utils.GetNamespacedKey(m.spec.Namespace, v.deployment.Name)] = scaletarget.NewDeploymentAccessor(v.deployment)
+		vaKey := utils.GetNamespacedKey(m.spec.Namespace, v.va.Name)
+		vas[vaKey] = v.va
+		costs[vaKey] = v.spec.Cost
+
+		pending := 0
+		for _, r := range v.replicas {
+			if !r.ready {
+				pending++
+			}
+		}
+		states = append(states, interfaces.VariantReplicaState{
+			VariantName:     v.spec.Name,
+			CurrentReplicas: len(v.replicas),
+			DesiredReplicas: v.desired,
+			PendingReplicas: pending,
+			GPUsPerReplica:  gpusPerReplica(v.spec),
+			MinReplicas:     ptr.To(v.spec.MinReplicas),
+			MaxReplicas:     ptr.To(v.spec.MaxReplicas),
+		})
+	}
+
+	replicaMetrics, err := s.collector.CollectReplicaMetrics(ctx, m.spec.ModelID, m.spec.Namespace, scaleTargets, vas, costs)
+	if err != nil {
+		return nil, fmt.Errorf("collecting metrics of model %s: %w", m.spec.ModelID, err)
+	}
+
+	retention := config.ScaleToZeroRetentionPeriod(s.cfg.ScaleToZeroConfigForNamespace(m.spec.Namespace), m.spec.ModelID)
+	for len(m.arrivals) > 0 && s.now.Sub(m.arrivals[0].at) > retention {
+		m.arrivals = m.arrivals[1:]
+	}
+	requestCount := 0.0
+	for _, a := range m.arrivals {
+		requestCount += a.requests
+	}
+
+	return &saturation.ReplayModel{
+		ModelID:        m.spec.ModelID,
+		Namespace:      m.spec.Namespace,
+		ReplicaMetrics: replicaMetrics,
+		VariantStates:  states,
+		SchedulerQueue: s.collector.CollectSchedulerQueueMetrics(ctx, m.spec.ModelID),
+		RequestCount:   requestCount,
+	}, nil
+}
+
+// stabilize returns the replicas to scale a variant to for a decided target,
+// as the HPA does with its default behavior: scale-up applies at once, and
+// scale-down to the highest target decided within the stabilization window.
+func (v *variant) stabilize(now time.Time, target int, window time.Duration) int {
+	v.recommendations = append(v.recommendations, recommendation{at: now, replicas: target})
+	for len(v.recommendations) > 0 && now.Sub(v.recommendations[0].at) > window {
+		v.recommendations = v.recommendations[1:]
+	}
+	if target >= len(v.replicas) {
+		return target
+	}
+	stabilized := target
+	for _, r := range v.recommendations {
+		stabilized = max(stabilized, r.replicas)
+	}
+	return min(stabilized, len(v.replicas))
+}
+
+// scale sets the number of replicas of a variant. New replicas become ready
+// after the startup delay; scale-down removes starting replicas first.
+func (s *Simulator) scale(ctx context.Context, v *variant, target int) error {
+	v.desired = target
+	for len(v.replicas) < target {
+		r := &replica{name: fmt.Sprintf("%s-%d", v.spec.Name, v.nextOrdinal)}
+		v.nextOrdinal++
+		if err := s.client.Create(ctx, podObject(v, r.name)); err != nil {
+			return fmt.Errorf("creating pod %s: %w", r.name, err)
+		}
+		v.replicas = append(v.replicas, r)
+		s.schedule(s.now.Add(v.spec.StartupDelay), priorityReady, func(context.Context) error {
+			r.ready = true
+			return nil
+		})
+	}
+	for len(v.replicas) > target {
+		victim := len(v.replicas) - 1
+		for i := len(v.replicas) - 1; i >= 0; i-- {
+			if !v.replicas[i].ready {
+				victim = i
+				break
+			}
+		}
+		if err := s.client.Delete(ctx, podObject(v, v.replicas[victim].name)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("deleting pod %s: %w", v.replicas[victim].name, err)
+		}
+		v.replicas = append(v.replicas[:victim], v.replicas[victim+1:]...)
+	}
+	v.deployment.Spec.Replicas = ptr.To(int32(target))
+	return nil
+}
+
+func (s *Simulator) findVariant(d interfaces.VariantDecision) (*model, *variant) {
+	for _, m := range s.models {
+		if m.spec.ModelID != d.ModelID || m.spec.Namespace != d.Namespace {
+			continue
+		}
+		for _, v := range m.variants {
+			if v.spec.Name == d.VariantName {
+				return m, v
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (slo SLO) met(load replicaLoad) bool {
+	return (slo.TTFT == 0 || load.ttft <= slo.TTFT) && (slo.ITL == 0 || load.itl <= slo.ITL)
+}
+
+func gpusPerReplica(v VariantSpec) int {
+	if v.GPUsPerReplica > 0 {
+		return v.GPUsPerReplica
+	}
+	return 1
+}
+
+// variantObjects returns the VariantAutoscaling, Deployment and ReplicaSet
+// of a variant. The collector maps pods to variants through them.
+func variantObjects(m ModelSpec, v VariantSpec) (
+	*llmdVariantAutoscalingV1alpha1.VariantAutoscaling, *appsv1.Deployment, *appsv1.ReplicaSet) {
+	va := &llmdVariantAutoscalingV1alpha1.VariantAutoscaling{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v.Name,
+			Namespace: m.Namespace,
+			Labels:    map[string]string{utils.AcceleratorNameLabel: v.Accelerator},
+		},
+		Spec: llmdVariantAutoscalingV1alpha1.VariantAutoscalingSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       v.Name,
+			},
+			ModelID:     m.ModelID,
+			MinReplicas: ptr.To(int32(v.MinReplicas)),
+			MaxReplicas: int32(v.MaxReplicas),
+		},
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: v.Name, Namespace: m.Namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(v.InitialReplicas)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "vllm",
+						Args: []string{"--max-num-seqs", fmt.Sprint(v.Server.MaxBatchSize)},
+					}},
+				},
+			},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      v.Name + "-rs",
+			Namespace: m.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       v.Name,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	return va, deployment, replicaSet
+}
+
+func podObject(v *variant, name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: v.deployment.Namespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       v.replicaSet.Name,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+}
+
+// Event priorities order events at the same time: replicas become ready
+// before load is routed, and load is routed before the cycle reads metrics.
+const (
+	priorityReady = iota
+	priorityStep
+	priorityCycle
+)
+
+type event struct {
+	at       time.Time
+	priority int
+	seq      int
+	run      func(context.Context) error
+}
+
+// schedule adds an event. Events at the same time and priority run in the
+// order they were scheduled.
+func (s *Simulator) schedule(at time.Time, priority int, run func(context.Context) error) {
+	s.seq++
+	heap.Push(&s.events, &event{at: at, priority: priority, seq: s.seq, run: run})
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x any) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() any {
+	old := *q
+	ev := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ev
+}
+
+// logger returns the logger of ctx with the simulated time.
+func (s *Simulator) logger(ctx context.Context) context.Context {
+	return ctrl.LoggerInto(ctx, ctrl.LoggerFrom(ctx).WithValues("simTime", s.now.Sub(s.scenario.Start)))
+}
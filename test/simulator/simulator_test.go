@@ -0,0 +1,181 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/llm-d/llm-d-workload-variant-autoscaler/internal/config"
+)
+
+const (
+	testModelKey = "llm/meta/llama"
+	replicaCost  = 10.0
+)
+
+func testSaturationConfig(analyzerName string) config.SaturationScalingConfig {
+	return config.SaturationScalingConfig{
+		KvCacheThreshold:     0.70,
+		QueueLengthThreshold: 5,
+		KvSpareTrigger:       0.2,
+		QueueSpareTrigger:    3,
+		AnalyzerName:         analyzerName,
+	}
+}
+
+func testConfig(satConfig config.SaturationScalingConfig) *config.Config {
+	satConfig.ApplyDefaults()
+	cfg := &config.Config{}
+	cfg.UpdateSaturationConfig(config.SaturationScalingConfigPerModel{"default": satConfig})
+	return cfg
+}
+
+func testScenario(load LoadPattern, duration, startupDelay time.Duration) Scenario {
+	return Scenario{
+		Start:    time.Date(2025, 6, 4, 0, 0, 0, 0, time.UTC),
+		Duration: duration,
+		Models: []ModelSpec{{
+			ModelID:         "meta/llama",
+			Namespace:       "llm",
+			Load:            load,
+			AvgInputTokens:  512,
+			AvgOutputTokens: 256,
+			SLO:             SLO{TTFT: time.Second, ITL: 50 * time.Millisecond},
+			Variants: []VariantSpec{{
+				Name:            "llama-a100",
+				Accelerator:     "A100",
+				Cost:            replicaCost,
+				GPUsPerReplica:  1,
+				InitialReplicas: 1,
+				MinReplicas:     1,
+				MaxReplicas:     16,
+				StartupDelay:    startupDelay,
+				Server: ServerSpec{
+					MaxBatchSize: 64,
+					MaxQueueSize: 128,
+					NumGpuBlocks: 3072,
+					BlockSize:    16,
+					Alpha:        8,
+					Beta:         0.02,
+					Gamma:        0.0002,
+				},
+			}},
+		}},
+	}
+}
+
+func run(t *testing.T, scenario Scenario, cfg *config.Config) *ModelResult {
+	t.Helper()
+	sim, err := New(scenario, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	ctx := ctrl.LoggerInto(context.Background(), logr.Discard())
+	result, err := sim.Run(ctx)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	return result.Models[testModelKey]
+}
+
+func TestDiurnalDay(t *testing.T) {
+	load := Diurnal{Min: 5, Peak: 60, PeakAt: 14 * time.Hour}
+
+	for _, analyzerName := range []string{"", "saturation"} {
+		t.Run("analyzer="+analyzerName, func(t *testing.T) {
+			res := run(t, testScenario(load, 24*time.Hour, 2*time.Minute), testConfig(testSaturationConfig(analyzerName)))
+
+			if attainment := res.SLOAttainment(); attainment < 0.95 {
+				t.Errorf("Expected SLO attainment of at least 95%%, got %.3f (%+v)", attainment, res)
+			}
+			if res.ScaleUps == 0 || res.ScaleDowns == 0 {
+				t.Errorf("Expected scale-ups and scale-downs following the daily cycle, got %+v", res)
+			}
+			// Static provisioning for the peak runs the most replicas all day.
+			if peakCost := float64(res.MaxReplicas) * replicaCost * 24; res.Cost >= 0.8*peakCost {
+				t.Errorf("Expected cost well below static peak provisioning (%.0f), got %.0f", peakCost, res.Cost)
+			}
+			t.Logf("SLO attainment %.3f, cost %.0f, GPU hours %.1f, max replicas %d, scale-ups %d, scale-downs %d",
+				res.SLOAttainment(), res.Cost, res.GPUHours, res.MaxReplicas, res.ScaleUps, res.ScaleDowns)
+		})
+	}
+}
+
+func TestKvThresholdTradeOff(t *testing.T) {
+	scenario := testScenario(Diurnal{Min: 5, Peak: 60, PeakAt: 14 * time.Hour}, 24*time.Hour, 2*time.Minute)
+
+	tight := testSaturationConfig("")
+	tight.KvCacheThreshold, tight.KvSpareTrigger = 0.8, 0.1
+	loose := testSaturationConfig("")
+	loose.KvCacheThreshold, loose.KvSpareTrigger = 0.5, 0.2
+
+	high := run(t, scenario, testConfig(tight))
+	low := run(t, scenario, testConfig(loose))
+
+	// Scaling out at lower KV cache usage buys SLO attainment with replicas.
+	if low.SLOAttainment() <= high.SLOAttainment() || low.Cost <= high.Cost {
+		t.Errorf("Expected a lower KV cache threshold to raise both SLO attainment and cost, got %.3f/%.0f (0.8) and %.3f/%.0f (0.5)",
+			high.SLOAttainment(), high.Cost, low.SLOAttainment(), low.Cost)
+	}
+}
+
+func TestStartupDelay(t *testing.T) {
+	load := Step{Steps: []RateStep{{At: 0, Rate: 4}, {At: 30 * time.Minute, Rate: 40}}}
+
+	fast := run(t, testScenario(load, 2*time.Hour, 30*time.Second), testConfig(testSaturationConfig("")))
+	slow := run(t, testScenario(load, 2*time.Hour, 10*time.Minute), testConfig(testSaturationConfig("")))
+
+	if fast.Requests != slow.Requests {
+		t.Fatalf("Expected the same load, got %.0f and %.0f requests", fast.Requests, slow.Requests)
+	}
+	if slow.SLOAttainment() >= fast.SLOAttainment() {
+		t.Errorf("Expected slower startup to lose SLO attainment on a load step, got %.3f (30s) and %.3f (10m)",
+			fast.SLOAttainment(), slow.SLOAttainment())
+	}
+	if fast.MaxReplicas < 4 {
+		t.Errorf("Expected scale-up to absorb a 10x load step, got at most %d replicas", fast.MaxReplicas)
+	}
+}
+
+func TestGPULimit(t *testing.T) {
+	scenario := testScenario(Constant(40), time.Hour, time.Minute)
+	scenario.GPULimits = map[string]int{"A100": 3}
+	satConfig := testSaturationConfig("")
+	satConfig.EnableLimiter = true
+
+	res := run(t, scenario, testConfig(satConfig))
+	if res.MaxReplicas != 3 {
+		t.Errorf("Expected replicas to be limited to the 3 available GPUs, got %d", res.MaxReplicas)
+	}
+	if res.Served >= res.Requests {
+		t.Errorf("Expected requests beyond the capacity of 3 replicas to be dropped, got %+v", res)
+	}
+}
+
+func TestLoadPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		load LoadPattern
+		at   time.Duration
+		want float64
+	}{
+		{name: "constant", load: Constant(3), at: time.Hour, want: 3},
+		{name: "diurnal peak", load: Diurnal{Min: 2, Peak: 10, PeakAt: 12 * time.Hour}, at: 12 * time.Hour, want: 10},
+		{name: "diurnal trough", load: Diurnal{Min: 2, Peak: 10, PeakAt: 12 * time.Hour}, at: 0, want: 2},
+		{name: "diurnal next day", load: Diurnal{Min: 2, Peak: 10, PeakAt: 12 * time.Hour}, at: 36 * time.Hour, want: 10},
+		{name: "before first step", load: Step{Steps: []RateStep{{At: time.Hour, Rate: 5}}}, at: 0, want: 0},
+		{name: "step", load: Step{Steps: []RateStep{{At: 0, Rate: 1}, {At: time.Hour, Rate: 5}}}, at: 2 * time.Hour, want: 5},
+		{name: "spike", load: Spike{Base: Constant(1), At: time.Hour, Duration: time.Minute, Extra: 9}, at: time.Hour, want: 10},
+		{name: "after spike", load: Spike{Base: Constant(1), At: time.Hour, Duration: time.Minute, Extra: 9}, at: 2 * time.Hour, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.load.Rate(tt.at); got < tt.want-1e-9 || got > tt.want+1e-9 {
+				t.Errorf("Rate(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}